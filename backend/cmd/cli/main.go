@@ -17,5 +17,5 @@ func init() {
 func main() {
 	conf := config.MustLoad(configPath)
 
-	cli.Run(conf)
+	cli.Run(conf, flag.Args()...)
 }
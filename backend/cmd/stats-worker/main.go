@@ -0,0 +1,21 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/hahaclassic/orpheon/backend/internal/config"
+	"github.com/hahaclassic/orpheon/backend/internal/worker"
+)
+
+var configPath = ".env"
+
+func init() {
+	flag.StringVar(&configPath, "config", ".env", "path to config file")
+	flag.Parse()
+}
+
+func main() {
+	conf := config.MustLoad(configPath)
+
+	worker.Run(conf)
+}
@@ -3,12 +3,13 @@ package main
 // Запуск тестов для исследования производительности FS и MinIO
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"flag"
 	"fmt"
+	"io"
 	"log"
-	"math"
 	"os"
 	"runtime"
 	"sync"
@@ -16,9 +17,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/config"
-	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
-	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/audio"
 	inframinio "github.com/hahaclassic/orpheon/backend/internal/infrastructure/minio"
+	audio_cas "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/content-addressed"
 	audio_fs "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/fs"
 	audio_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/minio"
 	minio "github.com/minio/minio-go/v7"
@@ -58,12 +58,12 @@ func main() {
 		log.Fatalln("Failed to create MinIO client:", err)
 	}
 
-	minioRepo, err := audio_minio.NewAudioFileRepository(ctx, minioClient, conf.MinIO.BucketAudio)
+	minioRepo, err := audio_minio.NewAudioStorage(ctx, minioClient, conf.MinIO.BucketAudio)
 	if err != nil {
 		log.Fatalln("Failed to create MinIO repository:", err)
 	}
 
-	fsRepo, err := audio_fs.NewAudioFileRepository(conf.AudioStorage.BasePath)
+	fsRepo, err := audio_fs.NewAudioStorage(conf.AudioStorage.BasePath)
 	if err != nil {
 		log.Fatalln("Failed to create FS repository:", err)
 	}
@@ -118,7 +118,7 @@ func main() {
 
 	for _, n := range fileCounts {
 		var totalFSUpload, totalMinIOUpload, totalFSRead, totalMinIORead time.Duration
-		var trackIDs1, trackIDs3 []uuid.UUID
+		var hashes1, hashes3 []string
 
 		for i := range testIterations {
 			runtime.GC()
@@ -134,22 +134,22 @@ func main() {
 			fmt.Println("Testing FS upload...")
 			t1, c1 := testWriteParallel(ctx, fsRepo, n, srcData)
 			totalFSUpload += t1
-			trackIDs1 = c1
+			hashes1 = c1
 			fmt.Printf("FS upload time: %v\n", t1)
 
 			fmt.Println("Testing FS read...")
-			t2 := testReadParallel(ctx, fsRepo, trackIDs1)
+			t2 := testReadParallel(ctx, fsRepo, hashes1)
 			totalFSRead += t2
 			fmt.Printf("FS read time: %v\n", t2)
 
 			fmt.Println("Testing MinIO upload...")
 			t3, c3 := testWriteParallel(ctx, minioRepo, n, srcData)
 			totalMinIOUpload += t3
-			trackIDs3 = c3
+			hashes3 = c3
 			fmt.Printf("MinIO upload time: %v\n", t3)
 
 			fmt.Println("Testing MinIO read...")
-			t4 := testReadParallel(ctx, minioRepo, trackIDs3)
+			t4 := testReadParallel(ctx, minioRepo, hashes3)
 			totalMinIORead += t4
 			fmt.Printf("MinIO read time: %v\n", t4)
 		}
@@ -178,40 +178,41 @@ func main() {
 	fmt.Printf("\nResults have been saved to results/result_%s.txt\n", timestamp)
 }
 
-func testReadParallel(ctx context.Context, repo audio.AudioFileRepository, trackIDs []uuid.UUID) time.Duration {
+func testReadParallel(ctx context.Context, repo audio_cas.BlobStore, hashes []string) time.Duration {
 	start := time.Now()
 
 	numWorkers := numGoroutines
-	trackIDChan := make(chan uuid.UUID, len(trackIDs))
+	hashChan := make(chan string, len(hashes))
 	wg := sync.WaitGroup{}
 	wg.Add(numWorkers)
 
 	for range numWorkers {
 		go func() {
 			defer wg.Done()
-			for trackID := range trackIDChan {
-				_, err := repo.GetAudioChunk(ctx, &entity.AudioChunk{
-					TrackID: trackID,
-					Start:   0,
-					End:     math.MaxInt64,
-				})
+			for hash := range hashChan {
+				_, content, err := repo.OpenBlob(ctx, hash)
 				if err != nil {
-					log.Fatalf("Failed to get audio chunk: %v\n", err)
+					log.Fatalf("Failed to open audio file: %v\n", err)
+				}
+				if _, err := io.Copy(io.Discard, content); err != nil {
+					log.Fatalf("Failed to read audio file: %v\n", err)
 				}
+				content.Close()
 			}
 		}()
 	}
 
-	for _, trackID := range trackIDs {
-		trackIDChan <- trackID
+	for _, hash := range hashes {
+		hashChan <- hash
 	}
-	close(trackIDChan)
+	close(hashChan)
 
 	wg.Wait()
 	return time.Since(start)
 }
 
-func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n int, fileData []byte) (time.Duration, []uuid.UUID) {
+// testWriteParallel prefixes every file with its own ID, so the blobs are not deduplicated.
+func testWriteParallel(ctx context.Context, repo audio_cas.BlobStore, n int, fileData []byte) (time.Duration, []string) {
 	trackIDs := make([]uuid.UUID, n)
 	for i := range trackIDs {
 		trackIDs[i] = uuid.New()
@@ -221,6 +222,8 @@ func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n in
 
 	numWorkers := numGoroutines
 	trackIDChan := make(chan uuid.UUID, len(trackIDs))
+	hashes := make([]string, 0, n)
+	mu := sync.Mutex{}
 	wg := sync.WaitGroup{}
 	wg.Add(numWorkers)
 
@@ -228,15 +231,14 @@ func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n in
 		go func() {
 			defer wg.Done()
 			for trackID := range trackIDChan {
-				err := repo.UploadAudioFile(ctx, &entity.AudioChunk{
-					TrackID: trackID,
-					Data:    fileData,
-					Start:   0,
-					End:     math.MaxInt64,
-				})
+				content := io.MultiReader(bytes.NewReader(trackID[:]), bytes.NewReader(fileData))
+				blob, err := repo.WriteBlob(ctx, content, int64(len(trackID)+len(fileData)))
 				if err != nil {
 					log.Fatalf("Failed to upload audio file: %v\n", err)
 				}
+				mu.Lock()
+				hashes = append(hashes, blob.Hash)
+				mu.Unlock()
 			}
 		}()
 	}
@@ -248,7 +250,7 @@ func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n in
 
 	wg.Wait()
 
-	return time.Since(start), trackIDs
+	return time.Since(start), hashes
 }
 
 func cleanupFS() error {
@@ -1,12 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"flag"
 	"fmt"
+	"io"
 	"log"
-	"math"
 	"os"
 	"runtime"
 	"sync"
@@ -14,9 +15,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/config"
-	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
-	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/audio"
 	inframinio "github.com/hahaclassic/orpheon/backend/internal/infrastructure/minio"
+	audio_cas "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/content-addressed"
 	audio_fs "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/fs"
 	audio_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/minio"
 	minio "github.com/minio/minio-go/v7"
@@ -46,12 +46,12 @@ func main() {
 		log.Fatalln("Failed to create MinIO client:", err)
 	}
 
-	minioRepo, err := audio_minio.NewAudioFileRepository(ctx, minioClient, conf.MinIO.BucketAudio)
+	minioRepo, err := audio_minio.NewAudioStorage(ctx, minioClient, conf.MinIO.BucketAudio)
 	if err != nil {
 		log.Fatalln("Failed to create MinIO repository:", err)
 	}
 
-	fsRepo, err := audio_fs.NewAudioFileRepository(conf.AudioStorage.BasePath)
+	fsRepo, err := audio_fs.NewAudioStorage(conf.AudioStorage.BasePath)
 	if err != nil {
 		log.Fatalln("Failed to create FS repository:", err)
 	}
@@ -97,7 +97,7 @@ func main() {
 		}
 
 		var totalFSUpload, totalMinIOUpload, totalFSRead, totalMinIORead time.Duration
-		var trackIDsFS, trackIDsMinIO []uuid.UUID
+		var hashesFS, hashesMinIO []string
 
 		for iter := 0; iter < testIterations; iter++ {
 			runtime.GC()
@@ -112,19 +112,19 @@ func main() {
 
 			tFSUp, idsFS := testWriteParallel(ctx, fsRepo, numFiles, srcData)
 			totalFSUpload += tFSUp
-			trackIDsFS = idsFS
+			hashesFS = idsFS
 			fmt.Printf("FS upload: %v\n", tFSUp)
 
-			tFSRead := testReadParallel(ctx, fsRepo, trackIDsFS)
+			tFSRead := testReadParallel(ctx, fsRepo, hashesFS)
 			totalFSRead += tFSRead
 			fmt.Printf("FS read: %v\n", tFSRead)
 
 			tMinIOUp, idsMinIO := testWriteParallel(ctx, minioRepo, numFiles, srcData)
 			totalMinIOUpload += tMinIOUp
-			trackIDsMinIO = idsMinIO
+			hashesMinIO = idsMinIO
 			fmt.Printf("MinIO upload: %v\n", tMinIOUp)
 
-			tMinIORead := testReadParallel(ctx, minioRepo, trackIDsMinIO)
+			tMinIORead := testReadParallel(ctx, minioRepo, hashesMinIO)
 			totalMinIORead += tMinIORead
 			fmt.Printf("MinIO read: %v\n", tMinIORead)
 		}
@@ -153,39 +153,40 @@ func main() {
 	fmt.Printf("Results saved to %s/result_size_%s.txt\n", resultsDir, timestamp)
 }
 
-func testReadParallel(ctx context.Context, repo audio.AudioFileRepository, trackIDs []uuid.UUID) time.Duration {
+func testReadParallel(ctx context.Context, repo audio_cas.BlobStore, hashes []string) time.Duration {
 	start := time.Now()
 	numWorkers := numGoroutines
-	trackIDChan := make(chan uuid.UUID, len(trackIDs))
+	hashChan := make(chan string, len(hashes))
 	wg := sync.WaitGroup{}
 	wg.Add(numWorkers)
 
 	for i := 0; i < numWorkers; i++ {
 		go func() {
 			defer wg.Done()
-			for trackID := range trackIDChan {
-				_, err := repo.GetAudioChunk(ctx, &entity.AudioChunk{
-					TrackID: trackID,
-					Start:   0,
-					End:     math.MaxInt64,
-				})
+			for hash := range hashChan {
+				_, content, err := repo.OpenBlob(ctx, hash)
 				if err != nil {
-					log.Fatalf("Failed to get audio chunk: %v\n", err)
+					log.Fatalf("Failed to open audio file: %v\n", err)
+				}
+				if _, err := io.Copy(io.Discard, content); err != nil {
+					log.Fatalf("Failed to read audio file: %v\n", err)
 				}
+				content.Close()
 			}
 		}()
 	}
 
-	for _, trackID := range trackIDs {
-		trackIDChan <- trackID
+	for _, hash := range hashes {
+		hashChan <- hash
 	}
-	close(trackIDChan)
+	close(hashChan)
 
 	wg.Wait()
 	return time.Since(start)
 }
 
-func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n int, fileData []byte) (time.Duration, []uuid.UUID) {
+// testWriteParallel prefixes every file with its own ID, so the blobs are not deduplicated.
+func testWriteParallel(ctx context.Context, repo audio_cas.BlobStore, n int, fileData []byte) (time.Duration, []string) {
 	trackIDs := make([]uuid.UUID, n)
 	for i := range trackIDs {
 		trackIDs[i] = uuid.New()
@@ -194,6 +195,8 @@ func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n in
 	start := time.Now()
 	numWorkers := numGoroutines
 	trackIDChan := make(chan uuid.UUID, len(trackIDs))
+	hashes := make([]string, 0, n)
+	mu := sync.Mutex{}
 	wg := sync.WaitGroup{}
 	wg.Add(numWorkers)
 
@@ -201,15 +204,14 @@ func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n in
 		go func() {
 			defer wg.Done()
 			for trackID := range trackIDChan {
-				err := repo.UploadAudioFile(ctx, &entity.AudioChunk{
-					TrackID: trackID,
-					Data:    fileData,
-					Start:   0,
-					End:     math.MaxInt64,
-				})
+				content := io.MultiReader(bytes.NewReader(trackID[:]), bytes.NewReader(fileData))
+				blob, err := repo.WriteBlob(ctx, content, int64(len(trackID)+len(fileData)))
 				if err != nil {
 					log.Fatalf("Failed to upload audio file: %v\n", err)
 				}
+				mu.Lock()
+				hashes = append(hashes, blob.Hash)
+				mu.Unlock()
 			}
 		}()
 	}
@@ -220,7 +222,7 @@ func testWriteParallel(ctx context.Context, repo audio.AudioFileRepository, n in
 	close(trackIDChan)
 
 	wg.Wait()
-	return time.Since(start), trackIDs
+	return time.Since(start), hashes
 }
 
 func cleanupFS() error {
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tracks
+    ADD COLUMN format TEXT NOT NULL DEFAULT 'mp3'
+    CHECK (format IN ('mp3', 'flac', 'ogg', 'wav')); -- формат оригинального аудиофайла
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tracks DROP COLUMN format;
+-- +goose StatementEnd
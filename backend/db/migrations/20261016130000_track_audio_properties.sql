@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tracks
+    ADD COLUMN bitrate INT NOT NULL DEFAULT 0 CHECK (bitrate >= 0),         -- средний битрейт оригинала, бит/с
+    ADD COLUMN sample_rate INT NOT NULL DEFAULT 0 CHECK (sample_rate >= 0); -- частота дискретизации, Гц
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tracks
+    DROP COLUMN bitrate,
+    DROP COLUMN sample_rate;
+-- +goose StatementEnd
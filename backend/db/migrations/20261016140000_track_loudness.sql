@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tracks
+    ADD COLUMN loudness DOUBLE PRECISION,        -- интегральная громкость по EBU R128, LUFS; NULL пока трек не проанализирован
+    ADD COLUMN true_peak DOUBLE PRECISION,       -- истинный пик, dBTP
+    ADD COLUMN album_loudness DOUBLE PRECISION,  -- громкость всего альбома, общая для его треков
+    ADD COLUMN album_true_peak DOUBLE PRECISION; -- истинный пик альбома, dBTP
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tracks
+    DROP COLUMN loudness,
+    DROP COLUMN true_peak,
+    DROP COLUMN album_loudness,
+    DROP COLUMN album_true_peak;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE track_audio_files (
+    track_id UUID NOT NULL REFERENCES tracks(id) ON DELETE CASCADE,
+    quality TEXT NOT NULL,                                -- original или одна из транскодированных версий
+    hash CHAR(64) NOT NULL,                               -- SHA-256 содержимого, имя блоба в хранилище
+    format TEXT NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (track_id, quality)
+);
+
+-- одинаковые загрузки ссылаются на один блоб, по индексу считаются ссылки на него
+CREATE INDEX track_audio_files_hash_idx ON track_audio_files (hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE track_audio_files;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- таблица зависит только от содержимого файла, поэтому хранится по хэшу блоба
+CREATE TABLE audio_seek_tables (
+    hash CHAR(64) PRIMARY KEY,
+    interval_ms INT NOT NULL CHECK (interval_ms > 0),
+    offsets BIGINT[] NOT NULL,                            -- смещение кадра, звучащего в момент i * interval_ms
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audio_seek_tables;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- событие может прийти повторно (ретраи клиента, редоставка шины), поэтому ключ - его ID
+CREATE TABLE listening_history (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    track_id UUID NOT NULL REFERENCES tracks(id) ON DELETE CASCADE,
+    listened_at TIMESTAMPTZ NOT NULL,
+    seconds INT NOT NULL CHECK (seconds >= 0),                 -- сколько секунд трека прослушано
+    context_type TEXT CHECK (context_type IN ('playlist', 'album', 'artist')),
+    context_id UUID,                                            -- откуда запущен трек, без внешнего ключа: контекст может быть удален
+    PRIMARY KEY (user_id, event_id),
+    CHECK ((context_type IS NULL) = (context_id IS NULL))
+);
+
+CREATE INDEX listening_history_recent_idx ON listening_history (user_id, listened_at DESC);
+
+-- пока запись на паузе, новые прослушивания не сохраняются
+CREATE TABLE listening_history_pauses (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    paused_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE listening_history_pauses;
+DROP TABLE listening_history;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- прослушивания по часам (для чарта за сутки) и по дням (за неделю и месяц), границы в UTC
+CREATE TABLE track_plays_hourly (
+    track_id UUID NOT NULL REFERENCES tracks(id) ON DELETE CASCADE,
+    hour TIMESTAMPTZ NOT NULL,
+    plays INT NOT NULL CHECK (plays >= 0),
+    PRIMARY KEY (track_id, hour)
+);
+
+CREATE INDEX track_plays_hourly_hour_idx ON track_plays_hourly (hour);
+
+CREATE TABLE track_plays_daily (
+    track_id UUID NOT NULL REFERENCES tracks(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    plays INT NOT NULL CHECK (plays >= 0),
+    PRIMARY KEY (track_id, day)
+);
+
+CREATE INDEX track_plays_daily_day_idx ON track_plays_daily (day);
+
+-- общий чарт завершенной недели, week - ее понедельник
+CREATE TABLE weekly_chart_snapshots (
+    week DATE NOT NULL CHECK (EXTRACT(ISODOW FROM week) = 1),
+    position INT NOT NULL CHECK (position >= 1),
+    track_id UUID NOT NULL REFERENCES tracks(id) ON DELETE CASCADE,
+    plays INT NOT NULL CHECK (plays >= 0),
+    previous_position INT CHECK (previous_position >= 1), -- NULL, если трека не было в чарте прошлой недели
+    PRIMARY KEY (week, position),
+    UNIQUE (week, track_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE weekly_chart_snapshots;
+DROP TABLE track_plays_daily;
+DROP TABLE track_plays_hourly;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- события, уже учтенные в статистике; запись добавляется в той же транзакции, что и счетчики,
+-- поэтому событие учитывается ровно один раз, даже если обработка упала на полпути.
+-- ID события задает клиент, поэтому он уникален в пределах пользователя
+CREATE TABLE processed_listening_events (
+    user_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, event_id)
+);
+
+-- для удаления событий старше окна дедупликации
+CREATE INDEX processed_listening_events_processed_at_idx ON processed_listening_events (processed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE processed_listening_events;
+-- +goose StatementEnd
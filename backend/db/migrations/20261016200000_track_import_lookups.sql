@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- у разных артистов могут быть альбомы с одинаковым названием,
+-- импорт сопоставляет альбом по паре (артист, название)
+ALTER TABLE albums DROP CONSTRAINT albums_title_key;
+
+-- импорт ищет записи по названию без учета регистра
+CREATE INDEX genres_lower_title_idx ON genres (LOWER(title));
+CREATE INDEX artists_lower_name_idx ON artists (LOWER(name));
+CREATE INDEX albums_lower_title_idx ON albums (LOWER(title));
+CREATE INDEX tracks_album_lower_name_idx ON tracks (album_id, LOWER(name));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX tracks_album_lower_name_idx;
+DROP INDEX albums_lower_title_idx;
+DROP INDEX artists_lower_name_idx;
+DROP INDEX genres_lower_title_idx;
+
+ALTER TABLE albums ADD CONSTRAINT albums_title_key UNIQUE (title);
+-- +goose StatementEnd
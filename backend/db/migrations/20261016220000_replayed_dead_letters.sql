@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- письма из dead-letter топика, события которых уже опубликованы повторно;
+-- в Kafka письмо не удалить, поэтому повтор отмечается здесь, чтобы не опубликовать событие дважды
+CREATE TABLE replayed_dead_letters (
+    topic TEXT NOT NULL,
+    partition INT NOT NULL,
+    "offset" BIGINT NOT NULL,
+    replayed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (topic, partition, "offset")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE replayed_dead_letters;
+-- +goose StatementEnd
@@ -1,17 +1,147 @@
 package audioconverter
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
 
+	"github.com/hahaclassic/orpheon/backend/internal/config"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 )
 
-type AudioConverter struct{}
+const (
+	defaultFFmpegPath = "ffmpeg"
+	// PCMSampleRate is the sample rate of decoded audio, it is only used
+	// for analysis, so a low rate keeps the stream small.
+	PCMSampleRate = 8000
+)
+
+var (
+	ErrUnsupportedQuality = errors.New("unsupported target quality")
+	ErrConversion         = errors.New("audio conversion error")
+)
+
+type AudioConverterConfig = config.AudioConverterConfig
+
+// AudioConverter transcodes and decodes audio by piping it through ffmpeg.
+type AudioConverter struct {
+	ffmpegPath string
+}
+
+func New(cfg AudioConverterConfig) *AudioConverter {
+	ffmpegPath := cfg.FFmpegPath
+	if ffmpegPath == "" {
+		ffmpegPath = defaultFFmpegPath
+	}
+
+	return &AudioConverter{
+		ffmpegPath: ffmpegPath,
+	}
+}
+
+// ChangeBitrate starts the transcoding of src and returns its output as a stream.
+// Conversion errors are reported by the final Read of the stream.
+func (a *AudioConverter) ChangeBitrate(ctx context.Context, src io.Reader, quality entity.AudioQuality) (io.ReadCloser, error) {
+	bitrate := quality.Bitrate()
+	if bitrate <= 0 {
+		return nil, ErrUnsupportedQuality
+	}
+
+	return a.run(ctx, src,
+		"-vn",
+		"-codec:a", "libmp3lame",
+		"-b:a", fmt.Sprintf("%dk", bitrate),
+		"-f", "mp3",
+	)
+}
 
-func New() *AudioConverter {
-	return &AudioConverter{}
+// DecodePCM starts the decoding of src to mono signed 16-bit little-endian
+// samples at PCMSampleRate and returns them as a stream.
+func (a *AudioConverter) DecodePCM(ctx context.Context, src io.Reader) (io.ReadCloser, error) {
+	return a.run(ctx, src,
+		"-vn",
+		"-ac", "1",
+		"-ar", strconv.Itoa(PCMSampleRate),
+		"-f", "s16le",
+	)
 }
 
-func (a AudioConverter) ChangeBitrate(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error) {
-	return chunk, nil
+// run pipes src through ffmpeg with the given output options.
+func (a *AudioConverter) run(ctx context.Context, src io.Reader, output ...string) (io.ReadCloser, error) {
+	return a.runWithInput(ctx, src, nil, output...)
+}
+
+// runWithInput is run with options applied to the input, placed before -i.
+func (a *AudioConverter) runWithInput(ctx context.Context, src io.Reader, input []string, output ...string) (io.ReadCloser, error) {
+	args := append([]string{"-hide_banner", "-loglevel", "error"}, input...)
+	args = append(append(args, "-i", "pipe:0"), output...)
+	cmd := exec.CommandContext(ctx, a.ffmpegPath, append(args, "pipe:1")...)
+
+	stderr := &bytes.Buffer{}
+	cmd.Stdin = src
+	cmd.Stderr = stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConversion, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConversion, err)
+	}
+
+	return &conversion{
+		stdout: stdout,
+		cmd:    cmd,
+		stderr: stderr,
+	}, nil
+}
+
+type conversion struct {
+	stdout   io.ReadCloser
+	cmd      *exec.Cmd
+	stderr   *bytes.Buffer
+	finished bool
+	err      error
+}
+
+func (c *conversion) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if errors.Is(err, io.EOF) {
+		if err := c.wait(); err != nil {
+			return n, err
+		}
+	}
+
+	return n, err
+}
+
+// Close stops ffmpeg if the output has not been read till the end.
+func (c *conversion) Close() error {
+	if c.finished {
+		return c.err
+	}
+
+	_ = c.cmd.Process.Kill()
+	_ = c.wait()
+
+	return nil
+}
+
+func (c *conversion) wait() error {
+	if c.finished {
+		return c.err
+	}
+	c.finished = true
+
+	if err := c.cmd.Wait(); err != nil {
+		c.err = fmt.Errorf("%w: %w: %s", ErrConversion, err, strings.TrimSpace(c.stderr.String()))
+	}
+
+	return c.err
 }
@@ -0,0 +1,72 @@
+package audioconverter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"testing"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeBitrateUnsupportedQuality(t *testing.T) {
+	_, err := New(AudioConverterConfig{}).ChangeBitrate(context.Background(), bytes.NewReader(nil), entity.QualityOriginal)
+	assert.ErrorIs(t, err, ErrUnsupportedQuality)
+}
+
+func TestChangeBitrateInvalidInput(t *testing.T) {
+	if _, err := exec.LookPath(defaultFFmpegPath); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+
+	src := bytes.NewReader([]byte("definitely not an audio file"))
+
+	res, err := New(AudioConverterConfig{}).ChangeBitrate(context.Background(), src, entity.QualityLow)
+	require.NoError(t, err)
+	defer res.Close()
+
+	_, err = io.ReadAll(res)
+	assert.ErrorIs(t, err, ErrConversion)
+}
+
+func TestChangeBitrate(t *testing.T) {
+	if _, err := exec.LookPath(defaultFFmpegPath); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+
+	// one second of silence generated by ffmpeg itself
+	src, err := exec.Command(defaultFFmpegPath, "-hide_banner", "-loglevel", "error",
+		"-f", "lavfi", "-i", "anullsrc=r=44100:cl=stereo", "-t", "1",
+		"-f", "wav", "pipe:1").Output()
+	require.NoError(t, err)
+
+	res, err := New(AudioConverterConfig{}).ChangeBitrate(context.Background(), bytes.NewReader(src), entity.QualityLow)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(res)
+	require.NoError(t, err)
+	assert.NoError(t, res.Close())
+	assert.NotEmpty(t, data)
+}
+
+func TestDecodePCM(t *testing.T) {
+	if _, err := exec.LookPath(defaultFFmpegPath); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+
+	src, err := exec.Command(defaultFFmpegPath, "-hide_banner", "-loglevel", "error",
+		"-f", "lavfi", "-i", "sine=frequency=440:sample_rate=44100", "-t", "1",
+		"-f", "wav", "pipe:1").Output()
+	require.NoError(t, err)
+
+	res, err := New(AudioConverterConfig{}).DecodePCM(context.Background(), bytes.NewReader(src))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(res)
+	require.NoError(t, err)
+	assert.NoError(t, res.Close())
+	assert.InDelta(t, 2*PCMSampleRate, len(data), 2*PCMSampleRate/100) // one second of 16-bit mono samples
+}
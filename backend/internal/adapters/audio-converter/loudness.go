@@ -0,0 +1,93 @@
+package audioconverter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+// silenceLevel replaces the -inf ffmpeg reports for digital silence,
+// it is the absolute gate of EBU R128.
+const silenceLevel = -70.0
+
+var ErrNoLoudnessSummary = errors.New("no loudness summary in ffmpeg output")
+
+var (
+	integratedRegexp = regexp.MustCompile(`I:\s+(-?[\d.]+|-inf) LUFS`)
+	truePeakRegexp   = regexp.MustCompile(`Peak:\s+(-?[\d.]+|-inf) dBFS`)
+)
+
+// MeasureLoudness measures the integrated loudness and the true peak of src
+// with the ebur128 filter of ffmpeg.
+func (a *AudioConverter) MeasureLoudness(ctx context.Context, src io.Reader) (*entity.Loudness, error) {
+	cmd := exec.CommandContext(ctx, a.ffmpegPath,
+		"-hide_banner", "-nostats",
+		"-i", "pipe:0",
+		"-vn",
+		"-af", "ebur128=peak=true",
+		"-f", "null", "-",
+	)
+
+	stderr := &bytes.Buffer{}
+	cmd.Stdin = src
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %w: %s", ErrConversion, err, lastLine(stderr.String()))
+	}
+
+	return parseLoudness(stderr.String())
+}
+
+// parseLoudness reads the summary ebur128 prints when the stream ends.
+func parseLoudness(output string) (*entity.Loudness, error) {
+	idx := strings.LastIndex(output, "Summary:")
+	if idx < 0 {
+		return nil, ErrNoLoudnessSummary
+	}
+	summary := output[idx:]
+
+	integrated, err := parseLevel(integratedRegexp, summary)
+	if err != nil {
+		return nil, err
+	}
+	truePeak, err := parseLevel(truePeakRegexp, summary)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Loudness{
+		Integrated: integrated,
+		TruePeak:   truePeak,
+	}, nil
+}
+
+func parseLevel(re *regexp.Regexp, summary string) (float64, error) {
+	match := re.FindStringSubmatch(summary)
+	if match == nil {
+		return 0, ErrNoLoudnessSummary
+	}
+	if match[1] == "-inf" {
+		return silenceLevel, nil
+	}
+
+	level, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrNoLoudnessSummary, err)
+	}
+
+	return max(level, silenceLevel), nil
+}
+
+func lastLine(output string) string {
+	output = strings.TrimSpace(output)
+	return output[strings.LastIndex(output, "\n")+1:]
+}
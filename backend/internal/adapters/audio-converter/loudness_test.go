@@ -0,0 +1,78 @@
+package audioconverter
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ebur128Output = `[Parsed_ebur128_0 @ 0x5581] t: 2.9       TARGET:-23 LUFS    M: -20.1 S:-120.7     I: -19.8 LUFS       LRA:   0.0 LU  FTPK: -2.1 dBFS  TPK: -2.1 dBFS
+[Parsed_ebur128_0 @ 0x5581] Summary:
+
+  Integrated loudness:
+    I:         -16.3 LUFS
+    Threshold: -26.5 LUFS
+
+  Loudness range:
+    LRA:         5.2 LU
+    Threshold: -36.6 LUFS
+    LRA low:   -20.1 LUFS
+    LRA high:  -14.9 LUFS
+
+  True peak:
+    Peak:       -0.6 dBFS
+`
+
+func TestParseLoudness(t *testing.T) {
+	res, err := parseLoudness(ebur128Output)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Loudness{Integrated: -16.3, TruePeak: -0.6}, res)
+}
+
+func TestParseLoudnessSilence(t *testing.T) {
+	res, err := parseLoudness(`Summary:
+  Integrated loudness:
+    I:         -70.0 LUFS
+  True peak:
+    Peak:       -inf dBFS
+`)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Loudness{Integrated: -70, TruePeak: -70}, res)
+}
+
+func TestParseLoudnessNoSummary(t *testing.T) {
+	_, err := parseLoudness("pipe:0: Invalid data found when processing input")
+	assert.ErrorIs(t, err, ErrNoLoudnessSummary)
+}
+
+func TestMeasureLoudness(t *testing.T) {
+	if _, err := exec.LookPath(defaultFFmpegPath); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+
+	// full scale sine, its loudness is about -3 LUFS
+	src, err := exec.Command(defaultFFmpegPath, "-hide_banner", "-loglevel", "error",
+		"-f", "lavfi", "-i", "sine=frequency=1000:sample_rate=48000", "-t", "3",
+		"-f", "wav", "pipe:1").Output()
+	require.NoError(t, err)
+
+	res, err := New(AudioConverterConfig{}).MeasureLoudness(context.Background(), bytes.NewReader(src))
+	require.NoError(t, err)
+	assert.Less(t, res.Integrated, 0.0)
+	assert.Greater(t, res.Integrated, -30.0)
+	assert.LessOrEqual(t, res.TruePeak, 1.0)
+}
+
+func TestMeasureLoudnessInvalidInput(t *testing.T) {
+	if _, err := exec.LookPath(defaultFFmpegPath); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+
+	_, err := New(AudioConverterConfig{}).MeasureLoudness(context.Background(), bytes.NewReader([]byte("definitely not an audio file")))
+	assert.ErrorIs(t, err, ErrConversion)
+}
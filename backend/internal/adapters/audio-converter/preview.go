@@ -0,0 +1,43 @@
+package audioconverter
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+// previewQuality is the bitrate previews are encoded with.
+const previewQuality = entity.QualityMedium
+
+// ClipPreview cuts the clip out of src, fades it in and out and encodes it as MP3.
+// The result is streamed like the output of ChangeBitrate.
+func (a *AudioConverter) ClipPreview(ctx context.Context, src io.Reader, clip *entity.PreviewClip) (io.ReadCloser, error) {
+	return a.runWithInput(ctx, src, previewInput(clip),
+		"-vn",
+		"-af", previewFilter(clip),
+		"-codec:a", "libmp3lame",
+		"-b:a", fmt.Sprintf("%dk", previewQuality.Bitrate()),
+		"-f", "mp3",
+	)
+}
+
+// previewInput seeks the input to the clip, so ffmpeg skips the audio before it
+// without decoding and stops reading after it.
+func previewInput(clip *entity.PreviewClip) []string {
+	return []string{
+		"-ss", fmt.Sprintf("%.3f", clip.Start.Seconds()),
+		"-t", fmt.Sprintf("%.3f", clip.Duration.Seconds()),
+	}
+}
+
+// previewFilter fades the clip in and out. The timestamps of an input seeked
+// with -ss start at zero, so the fades are placed relative to the start of the clip.
+func previewFilter(clip *entity.PreviewClip) string {
+	duration := clip.Duration.Seconds()
+	fade := clip.Fade.Seconds()
+
+	return fmt.Sprintf("afade=t=in:st=0:d=%.3f,afade=t=out:st=%.3f:d=%.3f",
+		fade, max(duration-fade, 0), fade)
+}
@@ -0,0 +1,47 @@
+package audioconverter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewFilter(t *testing.T) {
+	clip := &entity.PreviewClip{
+		Start:    75 * time.Second,
+		Duration: 30 * time.Second,
+		Fade:     2 * time.Second,
+	}
+
+	assert.Equal(t, []string{"-ss", "75.000", "-t", "30.000"}, previewInput(clip))
+	assert.Equal(t, "afade=t=in:st=0:d=2.000,afade=t=out:st=28.000:d=2.000", previewFilter(clip))
+}
+
+func TestClipPreview(t *testing.T) {
+	if _, err := exec.LookPath(defaultFFmpegPath); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+
+	src, err := exec.Command(defaultFFmpegPath, "-hide_banner", "-loglevel", "error",
+		"-f", "lavfi", "-i", "sine=frequency=440:sample_rate=44100", "-t", "5",
+		"-f", "wav", "pipe:1").Output()
+	require.NoError(t, err)
+
+	clip := &entity.PreviewClip{Start: time.Second, Duration: 2 * time.Second, Fade: 500 * time.Millisecond}
+
+	res, err := New(AudioConverterConfig{}).ClipPreview(context.Background(), bytes.NewReader(src), clip)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(res)
+	require.NoError(t, err)
+	assert.NoError(t, res.Close())
+	// 2 seconds at 160 kbps
+	assert.InDelta(t, 40000, len(data), 8000)
+}
@@ -2,39 +2,109 @@ package inmemory
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"sync"
 )
 
-type EventHandler[T any] func(ctx context.Context, event T) error
+const DefaultQueueSize = 1024
 
+var (
+	ErrClosed       = errors.New("event bus is closed")
+	ErrHandlerPanic = errors.New("event handler panicked")
+)
+
+// InMemoryEventBus queues the published events and hands them to the subscribers
+// in the background, so publishers don't wait on the handlers.
+// Every event is delivered to one of the subscribers.
 type InMemoryEventBus[T any] struct {
-	mu       sync.RWMutex
-	handlers []EventHandler[T]
+	events    chan T
+	closed    chan struct{}
+	closeOnce sync.Once
 }
 
-func NewInMemoryEventBus[T any]() *InMemoryEventBus[T] {
+func NewInMemoryEventBus[T any](queueSize int) *InMemoryEventBus[T] {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+
 	return &InMemoryEventBus[T]{
-		handlers: make([]EventHandler[T], 0),
+		events: make(chan T, queueSize),
+		closed: make(chan struct{}),
 	}
 }
 
-func (e *InMemoryEventBus[T]) Subscribe(ctx context.Context, handler EventHandler[T]) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-	e.handlers = append(e.handlers, handler)
+// Subscribe delivers the events to the handler until the context is done. Once the
+// bus is closed, it delivers the events left in the queue and returns nil.
+// Handler errors and panics are logged, the event is not redelivered.
+func (e *InMemoryEventBus[T]) Subscribe(ctx context.Context, handler func(ctx context.Context, event T) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-e.events:
+			e.handle(ctx, handler, event)
+		case <-e.closed:
+			return e.drain(ctx, handler)
+		}
+	}
+}
 
-	return nil
+func (e *InMemoryEventBus[T]) drain(ctx context.Context, handler func(ctx context.Context, event T) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-e.events:
+			e.handle(ctx, handler, event)
+		default:
+			return nil
+		}
+	}
 }
 
-func (e *InMemoryEventBus[T]) Publish(ctx context.Context, event T) error {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+func (e *InMemoryEventBus[T]) handle(ctx context.Context, handler func(ctx context.Context, event T) error, event T) {
+	if err := safeHandle(ctx, handler, event); err != nil {
+		slog.Error("failed to handle event", "err", err)
+	}
+}
 
-	for _, handler := range e.handlers {
-		if err := handler(ctx, event); err != nil {
-			return err
+// safeHandle turns a panic of the handler into an error, so one event can't stop the subscriber.
+func safeHandle[T any](ctx context.Context, handler func(ctx context.Context, event T) error, event T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
 		}
+	}()
+
+	return handler(ctx, event)
+}
+
+// Publish blocks only while the queue is full.
+func (e *InMemoryEventBus[T]) Publish(ctx context.Context, event T) error {
+	select {
+	case <-e.closed:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-e.closed:
+		return ErrClosed
+	case e.events <- event:
+		return nil
 	}
+}
+
+// Close stops accepting events, the subscribers return once the queue is drained.
+// It is called after the publishers are stopped, an event published concurrently may be lost.
+func (e *InMemoryEventBus[T]) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closed)
+	})
 
 	return nil
 }
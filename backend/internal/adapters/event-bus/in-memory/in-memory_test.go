@@ -0,0 +1,102 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishDoesNotWaitForHandler(t *testing.T) {
+	bus := NewInMemoryEventBus[int](2)
+
+	require.NoError(t, bus.Publish(context.Background(), 1))
+	require.NoError(t, bus.Publish(context.Background(), 2))
+
+	// the queue is full and nobody consumes it
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Publish(ctx, 3), context.DeadlineExceeded)
+}
+
+func TestSubscribeDeliversInOrder(t *testing.T) {
+	bus := NewInMemoryEventBus[int](0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan int, 3)
+	done := make(chan error)
+	go func() {
+		done <- bus.Subscribe(ctx, func(ctx context.Context, event int) error {
+			received <- event
+			if event == 2 {
+				return errors.New("handler failed")
+			}
+			return nil
+		})
+	}()
+
+	for event := range 3 {
+		require.NoError(t, bus.Publish(context.Background(), event+1))
+	}
+
+	// a failed event doesn't stop the delivery
+	for want := range 3 {
+		select {
+		case got := <-received:
+			assert.Equal(t, want+1, got)
+		case <-time.After(time.Second):
+			t.Fatal("event was not delivered")
+		}
+	}
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestCloseDrainsQueue(t *testing.T) {
+	bus := NewInMemoryEventBus[int](3)
+	for event := range 3 {
+		require.NoError(t, bus.Publish(context.Background(), event+1))
+	}
+	require.NoError(t, bus.Close())
+	assert.ErrorIs(t, bus.Publish(context.Background(), 4), ErrClosed)
+
+	var received []int
+	err := bus.Subscribe(context.Background(), func(ctx context.Context, event int) error {
+		received = append(received, event)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, received)
+}
+
+func TestHandlerPanicDoesNotStopSubscriber(t *testing.T) {
+	bus := NewInMemoryEventBus[int](3)
+	for event := range 3 {
+		require.NoError(t, bus.Publish(context.Background(), event+1))
+	}
+	require.NoError(t, bus.Close())
+
+	var received []int
+	err := bus.Subscribe(context.Background(), func(ctx context.Context, event int) error {
+		if event == 2 {
+			panic("index out of range")
+		}
+		received = append(received, event)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, received)
+}
+
+func TestSafeHandleReturnsPanicAsError(t *testing.T) {
+	err := safeHandle(context.Background(), func(ctx context.Context, event int) error {
+		panic("boom")
+	}, 1)
+
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+}
@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	headerError             = "error"
+	headerAttempts          = "attempts"
+	headerFailedAt          = "failed_at"
+	headerOriginalPartition = "original_partition"
+	headerOriginalOffset    = "original_offset"
+)
+
+var ErrKafkaReadDeadLetters = errors.New("kafka read dead letters error")
+
+// deadLetter keeps the original key and payload, the failure is described in the headers.
+func deadLetter(m kafka.Message, err error, attempts int, failedAt time.Time) kafka.Message {
+	return kafka.Message{
+		Key:   m.Key,
+		Value: m.Value,
+		Headers: []kafka.Header{
+			{Key: headerError, Value: []byte(err.Error())},
+			{Key: headerAttempts, Value: []byte(strconv.Itoa(attempts))},
+			{Key: headerFailedAt, Value: []byte(failedAt.UTC().Format(time.RFC3339))},
+			{Key: headerOriginalPartition, Value: []byte(strconv.Itoa(m.Partition))},
+			{Key: headerOriginalOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+		},
+	}
+}
+
+func parseDeadLetter(m kafka.Message) *entity.DeadLetter {
+	letter := &entity.DeadLetter{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Payload:   m.Value,
+	}
+
+	event := &entity.ListeningEvent{}
+	if err := json.Unmarshal(m.Value, event); err == nil {
+		letter.Event = event
+	}
+
+	for _, h := range m.Headers {
+		switch h.Key {
+		case headerError:
+			letter.Error = string(h.Value)
+		case headerAttempts:
+			letter.Attempts, _ = strconv.Atoi(string(h.Value))
+		case headerFailedAt:
+			letter.FailedAt, _ = time.Parse(time.RFC3339, string(h.Value))
+		}
+	}
+
+	return letter
+}
+
+// DeadLetterReader reads the dead-letter topic without a consumer group,
+// so listing the letters doesn't remove them.
+type DeadLetterReader struct {
+	brokers []string
+	topic   string
+}
+
+func NewDeadLetterReader(brokers []string, deadLetterTopic string) *DeadLetterReader {
+	if deadLetterTopic == "" {
+		deadLetterTopic = DefaultDeadLetterTopic
+	}
+
+	return &DeadLetterReader{brokers: brokers, topic: deadLetterTopic}
+}
+
+// ListDeadLetters reads the letters present when it is called, partition by partition.
+func (r *DeadLetterReader) ListDeadLetters(ctx context.Context, limit int) (_ []*entity.DeadLetter, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("%w: %v", ErrKafkaReadDeadLetters, err)
+		}
+	}()
+
+	conn, err := kafka.DialContext(ctx, "tcp", r.brokers[0])
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := conn.ReadPartitions(r.topic)
+	_ = conn.Close()
+	if errors.Is(err, kafka.UnknownTopicOrPartition) {
+		// nothing has failed yet
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var letters []*entity.DeadLetter
+	for _, p := range partitions {
+		left := 0
+		if limit > 0 {
+			if left = limit - len(letters); left <= 0 {
+				break
+			}
+		}
+
+		read, err := r.readPartition(ctx, p.ID, left)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, read...)
+	}
+
+	return letters, nil
+}
+
+func (r *DeadLetterReader) readPartition(ctx context.Context, partition int, limit int) ([]*entity.DeadLetter, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", r.brokers[0], r.topic, partition)
+	if err != nil {
+		return nil, err
+	}
+	first, last, err := conn.ReadOffsets()
+	_ = conn.Close()
+	if err != nil {
+		return nil, err
+	}
+	if first >= last {
+		return nil, nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   r.brokers,
+		Topic:     r.topic,
+		Partition: partition,
+		MaxBytes:  10e6, // 10MB
+	})
+	defer func() { _ = reader.Close() }()
+	if err := reader.SetOffset(first); err != nil {
+		return nil, err
+	}
+
+	var letters []*entity.DeadLetter
+	for limit <= 0 || len(letters) < limit {
+		m, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, parseDeadLetter(m))
+		if m.Offset >= last-1 {
+			break
+		}
+	}
+
+	return letters, nil
+}
@@ -5,41 +5,102 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
 	"github.com/segmentio/kafka-go"
 )
 
-const topic = "listening_events"
+const (
+	topic                  = "listening_events"
+	DefaultDeadLetterTopic = topic + ".dlq"
+
+	defaultMaxRetries    = 3
+	defaultRetryBackoff  = 500 * time.Millisecond
+	defaultMaxRetryDelay = 30 * time.Second
+)
 
 var (
-	ErrKafkaPublish   = errors.New("kafka publish error")
-	ErrKafkaSubscribe = errors.New("kafka subscribe error")
+	ErrKafkaPublish    = errors.New("kafka publish error")
+	ErrKafkaSubscribe  = errors.New("kafka subscribe error")
+	ErrKafkaDeadLetter = errors.New("kafka dead letter error")
+	ErrMalformedEvent  = errors.New("malformed listening event")
+	ErrHandlerPanic    = errors.New("listening event handler panicked")
 )
 
+// KafkaEventBus joins the consumer group only in Subscribe,
+// so processes that just publish don't take partitions from the workers.
 type KafkaEventBus struct {
-	writer *kafka.Writer
-	reader *kafka.Reader
+	brokers []string
+	groupID string
+	writer  *kafka.Writer
+	dlq     *kafka.Writer
+	retry   retryPolicy
+}
+
+type OptionFunc func(*KafkaEventBus)
+
+// WithRetries sets how many times a failed event is retried before it is dead-lettered.
+// The delay starts at backoff and doubles up to maxDelay.
+func WithRetries(maxRetries int, backoff, maxDelay time.Duration) OptionFunc {
+	return func(k *KafkaEventBus) {
+		if maxRetries >= 0 {
+			k.retry.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			k.retry.backoff = backoff
+		}
+		if maxDelay > 0 {
+			k.retry.maxDelay = maxDelay
+		}
+	}
+}
+
+// WithPermanentErrors adds a check for the errors a retry won't fix, e.g. a constraint
+// violation of the storage. Such events are dead-lettered after the first attempt.
+func WithPermanentErrors(isPermanent func(err error) bool) OptionFunc {
+	return func(k *KafkaEventBus) {
+		k.retry.isPermanent = isPermanent
+	}
 }
 
-func NewKafkaEventBus(brokers []string, groupID string) *KafkaEventBus {
-	return &KafkaEventBus{
+func WithDeadLetterTopic(deadLetterTopic string) OptionFunc {
+	return func(k *KafkaEventBus) {
+		if deadLetterTopic != "" {
+			k.dlq.Topic = deadLetterTopic
+		}
+	}
+}
+
+func NewKafkaEventBus(brokers []string, groupID string, opts ...OptionFunc) *KafkaEventBus {
+	k := &KafkaEventBus{
+		brokers: brokers,
+		groupID: groupID,
 		writer: &kafka.Writer{
 			Addr:     kafka.TCP(brokers...),
 			Topic:    topic,
 			Balancer: &kafka.Hash{},
 		},
-		reader: kafka.NewReader(kafka.ReaderConfig{
-			Brokers:        brokers,
-			GroupID:        groupID,
-			Topic:          topic,
-			MinBytes:       10e3, // 10KB
-			MaxBytes:       10e6, // 10MB
-			CommitInterval: time.Second,
-		}),
+		dlq: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  DefaultDeadLetterTopic,
+			Balancer:               &kafka.Hash{},
+			AllowAutoTopicCreation: true,
+		},
+		retry: retryPolicy{
+			maxRetries: defaultMaxRetries,
+			backoff:    defaultRetryBackoff,
+			maxDelay:   defaultMaxRetryDelay,
+		},
 	}
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
 }
 
 func (k *KafkaEventBus) Publish(ctx context.Context, event *entity.ListeningEvent) error {
@@ -60,37 +121,116 @@ func (k *KafkaEventBus) Publish(ctx context.Context, event *entity.ListeningEven
 	return nil
 }
 
+// Subscribe commits an event only after it is handled or moved to the dead-letter topic,
+// so the events of a stopped worker are redelivered.
 func (k *KafkaEventBus) Subscribe(ctx context.Context, handler func(ctx context.Context, event *entity.ListeningEvent) error) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        k.brokers,
+		GroupID:        k.groupID,
+		Topic:          topic,
+		MinBytes:       10e3, // 10KB
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: time.Second,
+	})
+	defer func() {
+		if err := reader.Close(); err != nil {
+			slog.Error("failed to close kafka reader", "err", err)
+		}
+	}()
+
 	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			m, err := k.reader.ReadMessage(ctx)
-			if err != nil {
-				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-					return err
-				}
-				return errwrap.Wrap(ErrKafkaSubscribe, err)
+		m, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
 			}
+			return errwrap.Wrap(ErrKafkaSubscribe, err)
+		}
 
-			event := &entity.ListeningEvent{}
-			if err := json.Unmarshal(m.Value, event); err != nil {
-				fmt.Printf("unmarshal error: %v\n", err)
-				continue
+		attempts, err := k.retry.handle(ctx, m.Value, handler)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
 
-			if err := handler(ctx, event); err != nil {
-				fmt.Printf("handler error: %v\n", err)
-				continue
+			slog.Error("listening event moved to the dead-letter topic", "err", err, "attempts", attempts,
+				"partition", m.Partition, "offset", m.Offset)
+			if err := k.dlq.WriteMessages(ctx, deadLetter(m, err, attempts, time.Now())); err != nil {
+				return errwrap.Wrap(ErrKafkaDeadLetter, err)
 			}
 		}
+
+		if err := reader.CommitMessages(ctx, m); err != nil {
+			return errwrap.Wrap(ErrKafkaSubscribe, err)
+		}
 	}
 }
 
 func (k *KafkaEventBus) Close() error {
-	if err := k.reader.Close(); err != nil {
-		return err
+	return errors.Join(k.writer.Close(), k.dlq.Close())
+}
+
+type retryPolicy struct {
+	maxRetries  int
+	backoff     time.Duration
+	maxDelay    time.Duration
+	isPermanent func(err error) bool // optional
+}
+
+// delay returns the pause before the retry following the given attempt, counting from 1.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	d := p.backoff << (attempt - 1)
+	if d > p.maxDelay || d <= 0 {
+		return p.maxDelay
+	}
+	return d
+}
+
+// permanent reports whether the error would repeat on a retry: a panic of the handler,
+// a missing or forbidden object, or an error recognized by isPermanent.
+func (p retryPolicy) permanent(err error) bool {
+	switch {
+	case errors.Is(err, ErrHandlerPanic),
+		errors.Is(err, commonerr.ErrNotFound),
+		errors.Is(err, commonerr.ErrForbidden):
+		return true
+	default:
+		return p.isPermanent != nil && p.isPermanent(err)
+	}
+}
+
+// handle returns the number of attempts made. Malformed events and permanent errors are not retried.
+func (p retryPolicy) handle(ctx context.Context, data []byte,
+	handler func(ctx context.Context, event *entity.ListeningEvent) error) (int, error) {
+	event := &entity.ListeningEvent{}
+	if err := json.Unmarshal(data, event); err != nil {
+		return 1, fmt.Errorf("%w: %v", ErrMalformedEvent, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := safeHandle(ctx, handler, event)
+		if err == nil || attempt > p.maxRetries || p.permanent(err) {
+			return attempt, err
+		}
+
+		delay := p.delay(attempt)
+		slog.Warn("failed to handle listening event, retrying", "err", err, "attempt", attempt, "delay", delay)
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(delay):
+		}
 	}
-	return k.writer.Close()
+}
+
+// safeHandle turns a panic of the handler into an error, so one event can't stop the worker.
+func safeHandle(ctx context.Context, handler func(ctx context.Context, event *entity.ListeningEvent) error,
+	event *entity.ListeningEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+		}
+	}()
+
+	return handler(ctx, event)
 }
@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPolicy(maxRetries int) retryPolicy {
+	return retryPolicy{maxRetries: maxRetries, backoff: time.Millisecond, maxDelay: 4 * time.Millisecond}
+}
+
+func encodeEvent(t *testing.T) ([]byte, *entity.ListeningEvent) {
+	event := &entity.ListeningEvent{TrackID: uuid.New(), UserID: uuid.New(), Ranges: []*entity.Range{{Start: 0, End: 40}}}
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	return data, event
+}
+
+func TestRetryDelayDoublesUpToMax(t *testing.T) {
+	p := retryPolicy{backoff: 100 * time.Millisecond, maxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, p.delay(1))
+	assert.Equal(t, 200*time.Millisecond, p.delay(2))
+	assert.Equal(t, 800*time.Millisecond, p.delay(4))
+	assert.Equal(t, time.Second, p.delay(5))
+	assert.Equal(t, time.Second, p.delay(80))
+}
+
+func TestHandleRetriesUntilSuccess(t *testing.T) {
+	data, want := encodeEvent(t)
+	calls := 0
+
+	attempts, err := testPolicy(3).handle(context.Background(), data, func(ctx context.Context, event *entity.ListeningEvent) error {
+		calls++
+		assert.Equal(t, want, event)
+		if calls < 3 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestHandleGivesUpAfterRetries(t *testing.T) {
+	data, _ := encodeEvent(t)
+	handlerErr := errors.New("segments not found")
+
+	attempts, err := testPolicy(2).handle(context.Background(), data, func(ctx context.Context, event *entity.ListeningEvent) error {
+		return handlerErr
+	})
+
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestHandleDoesNotRetryPermanentErrors(t *testing.T) {
+	data, _ := encodeEvent(t)
+	constraintErr := errors.New("violates foreign key constraint")
+	p := testPolicy(3)
+	p.isPermanent = func(err error) bool { return errors.Is(err, constraintErr) }
+
+	for _, handlerErr := range []error{commonerr.ErrNotFound, constraintErr} {
+		calls := 0
+		attempts, err := p.handle(context.Background(), data, func(ctx context.Context, event *entity.ListeningEvent) error {
+			calls++
+			return fmt.Errorf("update stat: %w", handlerErr)
+		})
+
+		assert.ErrorIs(t, err, handlerErr)
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, 1, calls)
+	}
+}
+
+func TestHandleDoesNotRetryMalformedEvent(t *testing.T) {
+	attempts, err := testPolicy(3).handle(context.Background(), []byte("{"), func(ctx context.Context, event *entity.ListeningEvent) error {
+		t.Fatal("handler called for a malformed event")
+		return nil
+	})
+
+	assert.ErrorIs(t, err, ErrMalformedEvent)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestHandleRecoversPanic(t *testing.T) {
+	data, _ := encodeEvent(t)
+
+	_, err := testPolicy(0).handle(context.Background(), data, func(ctx context.Context, event *entity.ListeningEvent) error {
+		var segments []*entity.Segment
+		_ = segments[0]
+		return nil
+	})
+
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+}
+
+func TestHandleStopsOnCancel(t *testing.T) {
+	data, _ := encodeEvent(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	p := retryPolicy{maxRetries: 3, backoff: time.Hour, maxDelay: time.Hour}
+
+	attempts, err := p.handle(ctx, data, func(ctx context.Context, event *entity.ListeningEvent) error {
+		cancel()
+		return errors.New("database unavailable")
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDeadLetterRoundTrip(t *testing.T) {
+	data, event := encodeEvent(t)
+	failedAt := time.Date(2025, 6, 1, 10, 30, 0, 0, time.UTC)
+	original := kafka.Message{Partition: 2, Offset: 41, Key: []byte(event.TrackID.String()), Value: data}
+
+	m := deadLetter(original, errors.New("segments not found"), 4, failedAt)
+	assert.Equal(t, original.Key, m.Key)
+	assert.Equal(t, original.Value, m.Value)
+
+	// as read back from the dead-letter topic
+	m.Partition, m.Offset = 0, 7
+	letter := parseDeadLetter(m)
+
+	assert.Equal(t, "0:7", letter.ID())
+	assert.Equal(t, event, letter.Event)
+	assert.Equal(t, data, letter.Payload)
+	assert.Equal(t, "segments not found", letter.Error)
+	assert.Equal(t, 4, letter.Attempts)
+	assert.True(t, failedAt.Equal(letter.FailedAt))
+}
+
+func TestParseDeadLetterWithInvalidPayload(t *testing.T) {
+	letter := parseDeadLetter(deadLetter(kafka.Message{Value: []byte("{")}, ErrMalformedEvent, 1, time.Now()))
+
+	assert.Nil(t, letter.Event)
+	assert.Equal(t, []byte("{"), letter.Payload)
+}
@@ -0,0 +1,84 @@
+package formatdetector
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported audio format")
+
+var (
+	id3Magic    = []byte("ID3")
+	flacMagic   = []byte("fLaC")
+	oggMagic    = []byte("OggS")
+	vorbisMagic = []byte("\x01vorbis")
+	riffMagic   = []byte("RIFF")
+	waveMagic   = []byte("WAVE")
+)
+
+const (
+	id3HeaderSize = 10
+	// first page of an Ogg stream: 27 bytes of page header and a single segment table entry
+	oggFirstPacketOffset = 28
+)
+
+// Detector sniffs the audio format from the magic bytes at the beginning of a file.
+type Detector struct{}
+
+func New() *Detector {
+	return &Detector{}
+}
+
+func (d *Detector) DetectFormat(header []byte) (entity.AudioFormat, error) {
+	switch {
+	case bytes.HasPrefix(header, riffMagic) && len(header) >= 12 && bytes.Equal(header[8:12], waveMagic):
+		return entity.FormatWAV, nil
+	case bytes.HasPrefix(header, oggMagic):
+		if len(header) >= oggFirstPacketOffset+len(vorbisMagic) &&
+			bytes.Equal(header[oggFirstPacketOffset:oggFirstPacketOffset+len(vorbisMagic)], vorbisMagic) {
+			return entity.FormatOGG, nil
+		}
+		return "", ErrUnsupportedFormat
+	case bytes.HasPrefix(header, flacMagic):
+		return entity.FormatFLAC, nil
+	case bytes.HasPrefix(header, id3Magic):
+		// ID3v2 is mostly used by MP3, but some encoders prepend it to FLAC as well
+		if body := skipID3v2(header); body != nil && bytes.HasPrefix(body, flacMagic) {
+			return entity.FormatFLAC, nil
+		}
+		return entity.FormatMP3, nil
+	case isMP3FrameSync(header):
+		return entity.FormatMP3, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// skipID3v2 returns the data following the ID3v2 tag or nil if the header is too short to contain it.
+func skipID3v2(header []byte) []byte {
+	if len(header) < id3HeaderSize {
+		return nil
+	}
+
+	size := int(header[6]&0x7F)<<21 | int(header[7]&0x7F)<<14 | int(header[8]&0x7F)<<7 | int(header[9]&0x7F)
+	if header[5]&0x10 != 0 { // footer present
+		size += id3HeaderSize
+	}
+
+	if len(header) < id3HeaderSize+size {
+		return nil
+	}
+
+	return header[id3HeaderSize+size:]
+}
+
+// isMP3FrameSync reports whether the header starts with an MPEG audio Layer III frame.
+func isMP3FrameSync(header []byte) bool {
+	if len(header) < 2 {
+		return false
+	}
+
+	return header[0] == 0xFF && header[1]&0xE0 == 0xE0 && header[1]&0x06 == 0x02
+}
@@ -0,0 +1,63 @@
+package formatdetector
+
+import (
+	"testing"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func oggPage(codecMagic string) []byte {
+	page := append([]byte("OggS"), make([]byte, 24)...)
+	return append(page, []byte(codecMagic)...)
+}
+
+func id3v2Tag(payloadSize int) []byte {
+	tag := []byte{'I', 'D', '3', 0x04, 0x00, 0x00,
+		byte(payloadSize >> 21 & 0x7F), byte(payloadSize >> 14 & 0x7F),
+		byte(payloadSize >> 7 & 0x7F), byte(payloadSize & 0x7F)}
+	return append(tag, make([]byte, payloadSize)...)
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+		want   entity.AudioFormat
+	}{
+		{"mp3 frame sync", []byte{0xFF, 0xFB, 0x90, 0x00}, entity.FormatMP3},
+		{"mp3 with id3v2", append(id3v2Tag(20), 0xFF, 0xFB, 0x90, 0x00), entity.FormatMP3},
+		{"flac", []byte("fLaC\x00\x00\x00\x22"), entity.FormatFLAC},
+		{"flac with id3v2", append(id3v2Tag(20), []byte("fLaC")...), entity.FormatFLAC},
+		{"ogg vorbis", oggPage("\x01vorbis"), entity.FormatOGG},
+		{"wav", []byte("RIFF\x24\x00\x00\x00WAVEfmt "), entity.FormatWAV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().DetectFormat(tt.header)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDetectFormatUnsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+	}{
+		{"empty", nil},
+		{"text", []byte("definitely not an audio file")},
+		{"ogg opus", oggPage("OpusHead")},
+		{"riff avi", []byte("RIFF\x24\x00\x00\x00AVI LIST")},
+		{"aac adts", []byte{0xFF, 0xF1, 0x50, 0x80}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New().DetectFormat(tt.header)
+			assert.ErrorIs(t, err, ErrUnsupportedFormat)
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package id3reader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrNoTag     = errors.New("no id3v2 tag found")
+	ErrTruncated = errors.New("id3v2 tag is truncated")
+)
+
+const (
+	headerSize = 10
+
+	flagUnsynchronisation = 0x80
+	flagExtendedHeader    = 0x40
+
+	pictureTypeFrontCover = 3
+)
+
+const (
+	encodingLatin1 = iota
+	encodingUTF16
+	encodingUTF16BE
+	encodingUTF8
+)
+
+// frame ids of ID3v2.2 and ID3v2.3/2.4
+var frameIDs = map[string]string{
+	"TT2": "TIT2", "TP1": "TPE1", "TAL": "TALB", "TRK": "TRCK",
+	"TYE": "TYER", "TCO": "TCON", "PIC": "APIC",
+}
+
+// Reader reads ID3v2.2, ID3v2.3 and ID3v2.4 tags from the beginning of a file.
+type Reader struct{}
+
+func New() *Reader {
+	return &Reader{}
+}
+
+// TagSize returns the size of the tag at the beginning of header including
+// its header and footer, or 0 if there is none.
+func (r *Reader) TagSize(header []byte) int {
+	if len(header) < headerSize || string(header[:3]) != "ID3" {
+		return 0
+	}
+
+	size := headerSize + syncsafe(header[6:10])
+	if header[5]&0x10 != 0 { // footer present
+		size += headerSize
+	}
+	return size
+}
+
+// ReadTags parses the tag at the beginning of header, which must hold the whole tag.
+func (r *Reader) ReadTags(header []byte) (*entity.AudioTags, error) {
+	if len(header) < headerSize || string(header[:3]) != "ID3" {
+		return nil, ErrNoTag
+	}
+
+	version := header[3]
+	flags := header[5]
+	size := syncsafe(header[6:10])
+	if len(header) < headerSize+size {
+		return nil, ErrTruncated
+	}
+
+	body := header[headerSize : headerSize+size]
+	if flags&flagUnsynchronisation != 0 {
+		body = bytes.ReplaceAll(body, []byte{0xFF, 0x00}, []byte{0xFF})
+	}
+
+	if flags&flagExtendedHeader != 0 && version >= 3 {
+		skip := 0
+		if len(body) >= 4 {
+			skip = int(binary.BigEndian.Uint32(body)) + 4
+			if version == 4 {
+				skip = syncsafe(body[:4])
+			}
+		}
+		if skip > len(body) {
+			return nil, ErrTruncated
+		}
+		body = body[skip:]
+	}
+
+	tags := &entity.AudioTags{}
+	var cover, anyPicture []byte
+
+	for _, frame := range readFrames(body, version) {
+		switch frame.id {
+		case "TIT2":
+			tags.Title = decodeText(frame.data)
+		case "TPE1":
+			tags.Artist = decodeText(frame.data)
+		case "TALB":
+			tags.Album = decodeText(frame.data)
+		case "TRCK":
+			tags.TrackNumber = leadingNumber(decodeText(frame.data))
+		case "TYER", "TDRC":
+			tags.Year = leadingNumber(decodeText(frame.data))
+		case "TCON":
+			tags.Genre = parseGenre(decodeText(frame.data))
+		case "APIC":
+			pictureType, data := decodePicture(frame.data, version)
+			if pictureType == pictureTypeFrontCover && cover == nil {
+				cover = data
+			}
+			if anyPicture == nil {
+				anyPicture = data
+			}
+		}
+	}
+
+	tags.Cover = cover
+	if tags.Cover == nil {
+		tags.Cover = anyPicture
+	}
+
+	return tags, nil
+}
+
+type frame struct {
+	id   string
+	data []byte
+}
+
+func readFrames(body []byte, version byte) []frame {
+	idSize, frameheaderSize := 4, 10
+	if version == 2 {
+		idSize, frameheaderSize = 3, 6
+	}
+
+	frames := make([]frame, 0)
+	for len(body) >= frameheaderSize && body[0] != 0 { // the rest is padding
+		id := string(body[:idSize])
+
+		var size int
+		var flags uint16
+		switch version {
+		case 2:
+			size = int(body[3])<<16 | int(body[4])<<8 | int(body[5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(body[4:8]))
+			flags = binary.BigEndian.Uint16(body[8:10])
+		default:
+			size = syncsafe(body[4:8])
+			flags = binary.BigEndian.Uint16(body[8:10])
+		}
+
+		if size < 0 || frameheaderSize+size > len(body) {
+			break
+		}
+		data := body[frameheaderSize : frameheaderSize+size]
+		body = body[frameheaderSize+size:]
+
+		if version == 2 {
+			id = frameIDs[id]
+		}
+
+		data, ok := frameData(data, flags, version)
+		if ok && id != "" {
+			frames = append(frames, frame{id: id, data: data})
+		}
+	}
+
+	return frames
+}
+
+// frameData strips the extra bytes announced by the frame flags,
+// compressed and encrypted frames are skipped.
+func frameData(data []byte, flags uint16, version byte) ([]byte, bool) {
+	switch version {
+	case 3:
+		if flags&0x00C0 != 0 {
+			return nil, false
+		}
+		if flags&0x0020 != 0 && len(data) > 0 { // group identifier
+			data = data[1:]
+		}
+	case 4:
+		if flags&0x000C != 0 {
+			return nil, false
+		}
+		if flags&0x0040 != 0 && len(data) > 0 { // group identifier
+			data = data[1:]
+		}
+		if flags&0x0001 != 0 && len(data) >= 4 { // data length indicator
+			data = data[4:]
+		}
+		if flags&0x0002 != 0 {
+			data = bytes.ReplaceAll(data, []byte{0xFF, 0x00}, []byte{0xFF})
+		}
+	}
+
+	return data, true
+}
+
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7F)<<21 | int(b[1]&0x7F)<<14 | int(b[2]&0x7F)<<7 | int(b[3]&0x7F)
+}
+
+// decodeText returns the first value of a text frame.
+func decodeText(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	text, _ := decodeString(data[0], data[1:])
+	return strings.TrimSpace(text)
+}
+
+// decodeString decodes a string terminated according to its encoding
+// and returns it together with the rest of the data.
+func decodeString(encoding byte, data []byte) (string, []byte) {
+	if encoding == encodingUTF16 || encoding == encodingUTF16BE {
+		end := len(data) &^ 1
+		rest := []byte(nil)
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				end, rest = i, data[i+2:]
+				break
+			}
+		}
+		return decodeUTF16(data[:end], encoding == encodingUTF16BE), rest
+	}
+
+	end, rest := len(data), []byte(nil)
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		end, rest = i, data[i+1:]
+	}
+
+	if encoding == encodingUTF8 {
+		return string(data[:end]), rest
+	}
+
+	runes := make([]rune, end)
+	for i, b := range data[:end] {
+		runes[i] = rune(b)
+	}
+	return string(runes), rest
+}
+
+func decodeUTF16(data []byte, bigEndian bool) string {
+	if len(data) >= 2 {
+		switch {
+		case data[0] == 0xFF && data[1] == 0xFE:
+			bigEndian, data = false, data[2:]
+		case data[0] == 0xFE && data[1] == 0xFF:
+			bigEndian, data = true, data[2:]
+		}
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = binary.BigEndian.Uint16(data[2*i:])
+		} else {
+			units[i] = binary.LittleEndian.Uint16(data[2*i:])
+		}
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// decodePicture returns the picture type and the image data of an APIC (PIC in ID3v2.2) frame.
+func decodePicture(data []byte, version byte) (byte, []byte) {
+	if len(data) < 2 {
+		return 0, nil
+	}
+	encoding, data := data[0], data[1:]
+
+	if version == 2 {
+		if len(data) < 3 {
+			return 0, nil
+		}
+		data = data[3:] // image format
+	} else {
+		i := bytes.IndexByte(data, 0)
+		if i < 0 {
+			return 0, nil
+		}
+		data = data[i+1:] // MIME type
+	}
+
+	if len(data) < 1 {
+		return 0, nil
+	}
+	pictureType := data[0]
+
+	_, image := decodeString(encoding, data[1:]) // description
+	if len(image) == 0 {
+		return 0, nil
+	}
+
+	return pictureType, image
+}
+
+func leadingNumber(s string) int {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+
+	n, _ := strconv.Atoi(s[:end])
+	return n
+}
+
+// parseGenre resolves ID3v1 genre references such as "(17)", "17" or "(17)Rock".
+func parseGenre(s string) string {
+	if strings.HasPrefix(s, "(") {
+		if end := strings.IndexByte(s, ')'); end > 0 {
+			if refined := strings.TrimSpace(s[end+1:]); refined != "" {
+				return refined
+			}
+			s = s[1:end]
+		}
+	}
+
+	switch s {
+	case "RX":
+		return "Remix"
+	case "CR":
+		return "Cover"
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n >= 0 && n < len(genres) {
+			return genres[n]
+		}
+		return ""
+	}
+
+	return s
+}
+
+// genres are the standard ID3v1 genres
+var genres = []string{
+	"Blues", "Classic Rock", "Country", "Dance", "Disco", "Funk", "Grunge", "Hip-Hop",
+	"Jazz", "Metal", "New Age", "Oldies", "Other", "Pop", "R&B", "Rap",
+	"Reggae", "Rock", "Techno", "Industrial", "Alternative", "Ska", "Death Metal", "Pranks",
+	"Soundtrack", "Euro-Techno", "Ambient", "Trip-Hop", "Vocal", "Jazz+Funk", "Fusion", "Trance",
+	"Classical", "Instrumental", "Acid", "House", "Game", "Sound Clip", "Gospel", "Noise",
+	"AlternRock", "Bass", "Soul", "Punk", "Space", "Meditative", "Instrumental Pop", "Instrumental Rock",
+	"Ethnic", "Gothic", "Darkwave", "Techno-Industrial", "Electronic", "Pop-Folk", "Eurodance", "Dream",
+	"Southern Rock", "Comedy", "Cult", "Gangsta", "Top 40", "Christian Rap", "Pop/Funk", "Jungle",
+	"Native American", "Cabaret", "New Wave", "Psychadelic", "Rave", "Showtunes", "Trailer", "Lo-Fi",
+	"Tribal", "Acid Punk", "Acid Jazz", "Polka", "Retro", "Musical", "Rock & Roll", "Hard Rock",
+}
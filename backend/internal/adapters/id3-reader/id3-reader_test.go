@@ -0,0 +1,160 @@
+package id3reader
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func syncsafeBytes(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}
+}
+
+func tag(version byte, frames ...[]byte) []byte {
+	var body []byte
+	for _, f := range frames {
+		body = append(body, f...)
+	}
+	body = append(body, make([]byte, 16)...) // padding
+
+	header := append([]byte{'I', 'D', '3', version, 0x00, 0x00}, syncsafeBytes(len(body))...)
+	return append(header, body...)
+}
+
+func rawFrame(version byte, id string, data []byte) []byte {
+	switch version {
+	case 2:
+		return append([]byte{id[0], id[1], id[2], byte(len(data) >> 16), byte(len(data) >> 8), byte(len(data))}, data...)
+	case 3:
+		f := append([]byte(id), binary.BigEndian.AppendUint32(nil, uint32(len(data)))...)
+		return append(append(f, 0x00, 0x00), data...)
+	default:
+		f := append([]byte(id), syncsafeBytes(len(data))...)
+		return append(append(f, 0x00, 0x00), data...)
+	}
+}
+
+func latin1(s string) []byte {
+	return append([]byte{encodingLatin1}, s...)
+}
+
+func utf16LE(s string) []byte {
+	data := []byte{encodingUTF16, 0xFF, 0xFE}
+	for _, r := range s {
+		data = append(data, byte(r), byte(r>>8))
+	}
+	return append(data, 0x00, 0x00)
+}
+
+func TestReadTags(t *testing.T) {
+	cover := []byte{0x89, 'P', 'N', 'G', 0x01, 0x02}
+	apic := append([]byte{encodingLatin1}, "image/png\x00"...)
+	apic = append(append(apic, pictureTypeFrontCover), "front\x00"...)
+	apic = append(apic, cover...)
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   *entity.AudioTags
+	}{
+		{
+			name: "id3v2.3",
+			header: tag(3,
+				rawFrame(3, "TIT2", latin1("Song")),
+				rawFrame(3, "TPE1", latin1("Artist")),
+				rawFrame(3, "TALB", latin1("Album")),
+				rawFrame(3, "TRCK", latin1("3/12")),
+				rawFrame(3, "TYER", latin1("2001")),
+				rawFrame(3, "TCON", latin1("(17)")),
+				rawFrame(3, "APIC", apic),
+			),
+			want: &entity.AudioTags{Title: "Song", Artist: "Artist", Album: "Album",
+				TrackNumber: 3, Year: 2001, Genre: "Rock", Cover: cover},
+		},
+		{
+			name: "id3v2.4 utf-8 and utf-16",
+			header: tag(4,
+				rawFrame(4, "TIT2", append([]byte{encodingUTF8}, "Песня"...)),
+				rawFrame(4, "TPE1", utf16LE("Исполнитель")),
+				rawFrame(4, "TALB", latin1("Album")),
+				rawFrame(4, "TDRC", latin1("1999-05-01")),
+				rawFrame(4, "TCON", latin1("Synthwave")),
+			),
+			want: &entity.AudioTags{Title: "Песня", Artist: "Исполнитель", Album: "Album",
+				Year: 1999, Genre: "Synthwave"},
+		},
+		{
+			name: "id3v2.2",
+			header: tag(2,
+				rawFrame(2, "TT2", latin1("Song")),
+				rawFrame(2, "TP1", latin1("Artist")),
+				rawFrame(2, "TAL", latin1("Album")),
+				rawFrame(2, "TRK", latin1("7")),
+				rawFrame(2, "TCO", latin1("8")),
+			),
+			want: &entity.AudioTags{Title: "Song", Artist: "Artist", Album: "Album",
+				TrackNumber: 7, Genre: "Jazz"},
+		},
+		{
+			name:   "empty tag",
+			header: tag(3),
+			want:   &entity.AudioTags{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().ReadTags(tt.header)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadTagsErrors(t *testing.T) {
+	full := tag(3, rawFrame(3, "TIT2", latin1("Song")))
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   error
+	}{
+		{"empty", nil, ErrNoTag},
+		{"mp3 frame sync", []byte{0xFF, 0xFB, 0x90, 0x00, 0, 0, 0, 0, 0, 0}, ErrNoTag},
+		{"truncated", full[:len(full)-4], ErrTruncated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New().ReadTags(tt.header)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestParseGenre(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"(17)", "Rock"},
+		{"(17)Indie Rock", "Indie Rock"},
+		{"0", "Blues"},
+		{"(RX)", "Remix"},
+		{"(255)", ""},
+		{"Darkwave", "Darkwave"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseGenre(tt.in))
+		})
+	}
+}
+
+func TestTagSize(t *testing.T) {
+	assert.Equal(t, 10+17, New().TagSize(tag(3, []byte{'x'})))
+	assert.Equal(t, 0, New().TagSize([]byte("fLaC")))
+}
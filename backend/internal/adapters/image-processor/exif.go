@@ -0,0 +1,143 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const (
+	jpegTEM           = 0x01
+	jpegRST0          = 0xd0
+	jpegSOI           = 0xd8
+	jpegEOI           = 0xd9
+	jpegSOS           = 0xda
+	jpegAPP1          = 0xe1
+	orientationTag    = 0x0112
+	ifdEntrySize      = 12
+	tiffHeaderSize    = 8
+	normalOrientation = 1
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifOrientation returns the EXIF orientation of a JPEG, 1 if there is none.
+// Cameras store rotated photos as shot and only tag the rotation.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xff || data[1] != jpegSOI {
+		return normalOrientation
+	}
+
+	for i := 2; i+2 <= len(data) && data[i] == 0xff; {
+		marker := data[i+1]
+		if standaloneMarker(marker) {
+			i += 2
+			continue
+		}
+		if marker == jpegSOS || i+4 > len(data) {
+			break
+		}
+
+		// the length counts its own two bytes
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			break
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == jpegAPP1 && bytes.HasPrefix(segment, exifHeader) {
+			return tiffOrientation(segment[len(exifHeader):])
+		}
+		i += 2 + length
+	}
+
+	return normalOrientation
+}
+
+// standaloneMarker reports whether the marker has no length and segment after it.
+// A 0xff marker is a fill byte before the next marker.
+func standaloneMarker(marker byte) bool {
+	return marker == jpegTEM || marker == 0xff || marker == jpegSOI || marker == jpegEOI ||
+		(marker >= jpegRST0 && marker < jpegRST0+8)
+}
+
+// tiffOrientation looks the orientation up in the first IFD of the TIFF structure inside EXIF.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < tiffHeaderSize {
+		return normalOrientation
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return normalOrientation
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return normalOrientation
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := range entries {
+		entry := ifd + 2 + i*ifdEntrySize
+		if entry+ifdEntrySize > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == orientationTag {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			break
+		}
+	}
+
+	return normalOrientation
+}
+
+// orient turns the image upright according to the EXIF orientation.
+func orient(img image.Image, orientation int) image.Image {
+	if orientation == normalOrientation {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	// orientations 5 to 8 swap the width and the height
+	transposed := orientation >= 5
+
+	dw, dh := w, h
+	if transposed {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := range h {
+		for x := range w {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored
+				dx, dy = w-1-x, y
+			case 3: // rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // rotated 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotated 90 counterclockwise
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+
+	return dst
+}
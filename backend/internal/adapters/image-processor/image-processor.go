@@ -0,0 +1,199 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+const (
+	// MinSide keeps thumbnails from being blown up out of tiny uploads.
+	MinSide     = 64
+	MaxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+// Processor decodes uploaded covers and re-encodes them into CoverSizes. Only
+// the pixels are encoded again, so EXIF and any other metadata are dropped.
+type Processor struct{}
+
+func New() *Processor {
+	return &Processor{}
+}
+
+// ProcessCover returns a cover for each of CoverSizes, cropped to a square around the center.
+// Opaque images are encoded as JPEG, images with transparency as PNG.
+func (p *Processor) ProcessCover(data []byte) ([]*entity.Cover, error) {
+	img, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeSizes(cropSquare(img))
+}
+
+// MakeCollage tiles the images into a 2x2 grid, or returns the first image
+// alone if there are fewer than four, in each of CoverSizes. The images are
+// never scaled up, so the collage is at most as sharp as its smallest image.
+func (p *Processor) MakeCollage(images [][]byte) ([]*entity.Cover, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("%w: no images for a collage", commonerr.ErrInvalidImage)
+	}
+
+	grid := 1
+	if len(images) >= 4 {
+		grid = 2
+	}
+
+	tiles := make([]*image.RGBA, grid*grid)
+	side := entity.LargestCoverSize / grid
+	for i, data := range images[:grid*grid] {
+		img, err := decode(data)
+		if err != nil {
+			return nil, err
+		}
+		tiles[i] = cropSquare(img)
+		side = min(side, tiles[i].Bounds().Dx())
+	}
+
+	collage := image.NewRGBA(image.Rect(0, 0, side*grid, side*grid))
+	for i, tile := range tiles {
+		origin := image.Pt(i%grid*side, i/grid*side)
+		draw.Draw(collage, image.Rectangle{Min: origin, Max: origin.Add(image.Pt(side, side))},
+			downscale(tile, side), image.Point{}, draw.Src)
+	}
+
+	return encodeSizes(collage)
+}
+
+// decode checks the dimensions before decoding, so a small file claiming huge
+// dimensions is never decoded, and turns JPEGs upright.
+func decode(data []byte) (image.Image, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", commonerr.ErrInvalidImage, err)
+	}
+	if config.Width < MinSide || config.Height < MinSide {
+		return nil, fmt.Errorf("%w: %dx%d is smaller than %dx%d", commonerr.ErrInvalidImage,
+			config.Width, config.Height, MinSide, MinSide)
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d is over %d pixels", commonerr.ErrInvalidImage,
+			config.Width, config.Height, MaxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", commonerr.ErrInvalidImage, err)
+	}
+	if format == "jpeg" {
+		img = orient(img, exifOrientation(data))
+	}
+
+	return img, nil
+}
+
+func encodeSizes(square *image.RGBA) ([]*entity.Cover, error) {
+	opaque := isOpaque(square)
+
+	covers := make([]*entity.Cover, 0, len(entity.CoverSizes))
+	for _, size := range entity.CoverSizes {
+		side := min(size, square.Bounds().Dx())
+
+		cover, err := encode(downscale(square, side), opaque)
+		if err != nil {
+			return nil, err
+		}
+		cover.Size = size
+		covers = append(covers, cover)
+	}
+
+	return covers, nil
+}
+
+func encode(img image.Image, opaque bool) (*entity.Cover, error) {
+	var (
+		buf         bytes.Buffer
+		contentType string
+		err         error
+	)
+
+	if opaque {
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		contentType = "image/png"
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cover: %w", err)
+	}
+
+	return &entity.Cover{
+		ContentType: contentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// cropSquare cuts the largest centered square out of the image.
+func cropSquare(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	origin := image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2)
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), img, origin, draw.Src)
+
+	return square
+}
+
+func isOpaque(img *image.RGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0xff {
+			return false
+		}
+	}
+	return true
+}
+
+// downscale averages the source pixels covered by each target pixel. The
+// pixels are premultiplied, so transparent ones do not darken the edges.
+func downscale(src *image.RGBA, side int) *image.RGBA {
+	srcSide := src.Bounds().Dx()
+	if side == srcSide {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	for y := range side {
+		y0, y1 := y*srcSide/side, max((y+1)*srcSide/side, y*srcSide/side+1)
+		for x := range side {
+			x0, x1 := x*srcSide/side, max((x+1)*srcSide/side, x*srcSide/side+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := range sum {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			i := dst.PixOffset(x, y)
+			for c := range sum {
+				dst.Pix[i+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+
+	return dst
+}
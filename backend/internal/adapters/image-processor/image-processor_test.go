@@ -0,0 +1,247 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// halves paints the left half of the image red and the right half blue.
+func halves(w, h int, alpha uint8) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.NRGBA{R: 0xff, A: alpha}
+			if x >= w/2 {
+				c = color.NRGBA{B: 0xff, A: alpha}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// withOrientation inserts an APP1 segment tagging the orientation right after SOI.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append(append([]byte{}, exifHeader...), tiff...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xff, jpegAPP1}, uint16(len(segment)+2))
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+// withDimensions rewrites the IHDR chunk of a PNG to claim other dimensions.
+func withDimensions(data []byte, w, h uint32) []byte {
+	out := append([]byte{}, data...)
+	ihdr := out[12:29] // chunk type and data
+	binary.BigEndian.PutUint32(ihdr[4:], w)
+	binary.BigEndian.PutUint32(ihdr[8:], h)
+	binary.BigEndian.PutUint32(out[29:], crc32.ChecksumIEEE(ihdr))
+	return out
+}
+
+func decodeCover(t *testing.T, cover *entity.Cover) image.Image {
+	img, _, err := image.Decode(bytes.NewReader(cover.Data))
+	require.NoError(t, err)
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > 0xc000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return b > 0xc000 && r < 0x4000
+}
+
+func TestProcessCoverSizes(t *testing.T) {
+	covers, err := New().ProcessCover(encodeJPEG(t, halves(800, 700, 0xff)))
+
+	require.NoError(t, err)
+	require.Len(t, covers, len(entity.CoverSizes))
+	for i, cover := range covers {
+		assert.Equal(t, entity.CoverSizes[i], cover.Size)
+		assert.Equal(t, "image/jpeg", cover.ContentType)
+
+		// the 1200 cover is not scaled up from the 700 square
+		side := min(cover.Size, 700)
+		assert.Equal(t, image.Rect(0, 0, side, side), decodeCover(t, cover).Bounds())
+	}
+}
+
+func TestProcessCoverCropsCenter(t *testing.T) {
+	covers, err := New().ProcessCover(encodeJPEG(t, halves(400, 100, 0xff)))
+
+	require.NoError(t, err)
+	img := decodeCover(t, covers[0])
+	assert.Equal(t, image.Rect(0, 0, 64, 64), img.Bounds())
+	assert.True(t, isRed(img.At(10, 32)))
+	assert.True(t, isBlue(img.At(54, 32)))
+}
+
+func TestProcessCoverKeepsTransparency(t *testing.T) {
+	covers, err := New().ProcessCover(encodePNG(t, halves(128, 128, 0x80)))
+
+	require.NoError(t, err)
+	for _, cover := range covers {
+		assert.Equal(t, "image/png", cover.ContentType)
+		_, _, _, a := decodeCover(t, cover).At(0, 0).RGBA()
+		assert.Less(t, a, uint32(0xffff))
+	}
+}
+
+func TestProcessCoverAppliesOrientation(t *testing.T) {
+	data := withOrientation(encodeJPEG(t, halves(80, 64, 0xff)), 6)
+	assert.Equal(t, 6, exifOrientation(data))
+
+	covers, err := New().ProcessCover(data)
+
+	require.NoError(t, err)
+	// rotated clockwise, the red left half becomes the top
+	img := decodeCover(t, covers[0])
+	assert.True(t, isRed(img.At(32, 5)))
+	assert.True(t, isBlue(img.At(32, 58)))
+	assert.NotContains(t, string(covers[0].Data), "Exif")
+}
+
+func TestProcessCoverInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"garbage", []byte("definitely not an image")},
+		{"truncated", encodePNG(t, halves(128, 128, 0xff))[:100]},
+		{"too small", encodePNG(t, halves(200, 32, 0xff))},
+		{"too many pixels", withDimensions(encodePNG(t, halves(64, 64, 0xff)), 10_000, 10_000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New().ProcessCover(tt.data)
+			assert.ErrorIs(t, err, commonerr.ErrInvalidImage)
+		})
+	}
+}
+
+func TestExifOrientationWithoutExif(t *testing.T) {
+	assert.Equal(t, normalOrientation, exifOrientation(encodeJPEG(t, halves(64, 64, 0xff))))
+	assert.Equal(t, normalOrientation, exifOrientation([]byte{0xff, jpegSOI}))
+}
+
+func TestExifOrientationMalformedSegments(t *testing.T) {
+	body := encodeJPEG(t, halves(64, 64, 0xff))
+	oriented := withOrientation(body, 6)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"standalone marker before the body", append([]byte{0xff, jpegSOI, 0xff, jpegRST0, 0, 0}, body[2:]...), normalOrientation},
+		{"standalone markers before exif", append([]byte{0xff, jpegSOI, 0xff, jpegTEM, 0xff, jpegRST0 + 7}, oriented[2:]...), 6},
+		{"fill bytes before exif", append([]byte{0xff, jpegSOI, 0xff, 0xff}, oriented[2:]...), 6},
+		{"zero length", []byte{0xff, jpegSOI, 0xff, jpegAPP1, 0, 0}, normalOrientation},
+		{"length without its own bytes", []byte{0xff, jpegSOI, 0xff, jpegAPP1, 0, 1, 0}, normalOrientation},
+		{"length past the end", []byte{0xff, jpegSOI, 0xff, jpegAPP1, 0, 10, 'E'}, normalOrientation},
+		{"truncated length", []byte{0xff, jpegSOI, 0xff, jpegAPP1, 0}, normalOrientation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exifOrientation(tt.data))
+		})
+	}
+}
+
+func solid(c color.NRGBA, side int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, side, side))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func TestMakeCollageGrid(t *testing.T) {
+	red, blue := solid(color.NRGBA{R: 0xff, A: 0xff}, 300), solid(color.NRGBA{B: 0xff, A: 0xff}, 300)
+
+	covers, err := New().MakeCollage([][]byte{red, blue, blue, red, blue})
+
+	require.NoError(t, err)
+	require.Len(t, covers, len(entity.CoverSizes))
+	img := decodeCover(t, covers[1])
+	assert.Equal(t, image.Rect(0, 0, 300, 300), img.Bounds())
+	assert.Equal(t, "image/jpeg", covers[1].ContentType)
+	assert.True(t, isRed(img.At(50, 50)))
+	assert.True(t, isBlue(img.At(250, 50)))
+	assert.True(t, isBlue(img.At(50, 250)))
+	assert.True(t, isRed(img.At(250, 250)))
+}
+
+func TestMakeCollageSingle(t *testing.T) {
+	red, blue := solid(color.NRGBA{R: 0xff, A: 0xff}, 100), solid(color.NRGBA{B: 0xff, A: 0xff}, 100)
+
+	covers, err := New().MakeCollage([][]byte{red, blue})
+
+	require.NoError(t, err)
+	// the 100x100 cover is not scaled up for the larger sizes
+	img := decodeCover(t, covers[len(covers)-1])
+	assert.Equal(t, image.Rect(0, 0, 100, 100), img.Bounds())
+	assert.True(t, isRed(img.At(90, 90)))
+}
+
+func TestMakeCollageGridOfSmallCovers(t *testing.T) {
+	red, blue := solid(color.NRGBA{R: 0xff, A: 0xff}, 100), solid(color.NRGBA{B: 0xff, A: 0xff}, 100)
+
+	covers, err := New().MakeCollage([][]byte{red, blue, blue, red})
+
+	require.NoError(t, err)
+	// the tiles keep the 100x100 of the covers
+	img := decodeCover(t, covers[len(covers)-1])
+	assert.Equal(t, image.Rect(0, 0, 200, 200), img.Bounds())
+	assert.True(t, isRed(img.At(50, 50)))
+	assert.True(t, isBlue(img.At(150, 50)))
+}
+
+func TestMakeCollageInvalid(t *testing.T) {
+	_, err := New().MakeCollage(nil)
+	assert.ErrorIs(t, err, commonerr.ErrInvalidImage)
+
+	_, err = New().MakeCollage([][]byte{[]byte("not an image")})
+	assert.ErrorIs(t, err, commonerr.ErrInvalidImage)
+}
@@ -0,0 +1,109 @@
+package mp3parser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+const (
+	// maxGarbageRatio is the share of unparsable bytes between frames
+	// above which the stream is considered corrupt.
+	maxGarbageRatio = 0.1
+	// minDeclaredFramesRatio is the share of frames declared by a VBR header
+	// that must be present, otherwise the stream is considered truncated.
+	minDeclaredFramesRatio = 0.9
+
+	vbriOffset = headerSize + 32
+)
+
+var ErrCorrupt = errors.New("corrupt mpeg audio stream")
+
+// Analyze measures the duration, the average bitrate and the sample rate of an
+// MPEG audio stream and validates its integrity. A Xing, Info or VBRI header in
+// the first frame is used to detect truncated files, the frame itself carries
+// no audio and is not counted.
+func (p *Parser) Analyze(r io.Reader) (*entity.AudioInfo, error) {
+	info := &entity.AudioInfo{Format: entity.FormatMP3}
+
+	var frames, declared, size int64
+	first := true
+
+	skipped, err := scan(r, func(_ int64, header frameHeader, data []byte) {
+		if first {
+			first = false
+			info.SampleRate = header.sampleRate
+
+			if n, ok := vbrFrames(header, data); ok {
+				declared = n
+				return
+			}
+		}
+
+		frames++
+		size += header.size()
+		info.Duration += header.duration()
+	})
+	if errors.Is(err, ErrNoFrames) {
+		return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case frames == 0:
+		return nil, fmt.Errorf("%w: %w", ErrCorrupt, ErrNoFrames)
+	case float64(skipped) > maxGarbageRatio*float64(size):
+		return nil, fmt.Errorf("%w: %d bytes of garbage between frames", ErrCorrupt, skipped)
+	case float64(frames) < minDeclaredFramesRatio*float64(declared):
+		return nil, fmt.Errorf("%w: truncated, %d of %d frames found", ErrCorrupt, frames, declared)
+	}
+
+	info.Bitrate = int(math.Round(float64(size*8) / info.Duration.Seconds()))
+
+	return info, nil
+}
+
+// vbrFrames reports whether the frame holds a Xing, Info or VBRI header
+// and returns the number of audio frames it declares, 0 if unknown.
+func vbrFrames(header frameHeader, data []byte) (int64, bool) {
+	if header.layer != layer3 {
+		return 0, false
+	}
+
+	if off := headerSize + header.sideInfoSize(); len(data) >= off+8 {
+		if tag := string(data[off : off+4]); tag == "Xing" || tag == "Info" {
+			flags := binary.BigEndian.Uint32(data[off+4:])
+			if flags&0x01 == 0 || len(data) < off+12 {
+				return 0, true
+			}
+			return int64(binary.BigEndian.Uint32(data[off+8:])), true
+		}
+	}
+
+	if len(data) >= vbriOffset+18 && string(data[vbriOffset:vbriOffset+4]) == "VBRI" {
+		return int64(binary.BigEndian.Uint32(data[vbriOffset+14:])), true
+	}
+
+	return 0, false
+}
+
+// sideInfoSize returns the size of the layer III side information,
+// which precedes a Xing header.
+func (h frameHeader) sideInfoSize() int {
+	switch {
+	case h.version == mpeg1 && h.channels == 1:
+		return 17
+	case h.version == mpeg1:
+		return 32
+	case h.channels == 1:
+		return 9
+	default:
+		return 17
+	}
+}
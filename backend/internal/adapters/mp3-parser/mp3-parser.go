@@ -0,0 +1,242 @@
+package mp3parser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+const (
+	headerSize    = 4
+	id3HeaderSize = 10
+	readerSize    = 16 * 1024
+)
+
+var (
+	ErrNoFrames = errors.New("no mpeg audio frames found")
+	ErrRead     = errors.New("failed to read audio stream")
+)
+
+const (
+	mpeg25 = 0
+	mpeg2  = 2
+	mpeg1  = 3
+
+	layer3 = 1
+	layer2 = 2
+	layer1 = 3
+)
+
+// bitrates in kbps, indexed by [table][bitrate index]
+var bitrates = [5][16]int{
+	{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0}, // MPEG1 Layer1
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},    // MPEG1 Layer2
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},     // MPEG1 Layer3
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},    // MPEG2/2.5 Layer1
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},         // MPEG2/2.5 Layer2/3
+}
+
+var sampleRates = [4][3]int{
+	mpeg25: {11025, 12000, 8000},
+	mpeg2:  {22050, 24000, 16000},
+	mpeg1:  {44100, 48000, 32000},
+}
+
+type frameHeader struct {
+	version    int
+	layer      int
+	bitrate    int // bits per second
+	sampleRate int
+	padding    int
+	channels   int
+}
+
+func (h frameHeader) samples() int {
+	switch {
+	case h.layer == layer1:
+		return 384
+	case h.layer == layer3 && h.version != mpeg1:
+		return 576
+	default:
+		return 1152
+	}
+}
+
+func (h frameHeader) size() int64 {
+	if h.layer == layer1 {
+		return int64((12*h.bitrate/h.sampleRate + h.padding) * 4)
+	}
+
+	return int64(h.samples()/8*h.bitrate/h.sampleRate + h.padding)
+}
+
+func (h frameHeader) duration() time.Duration {
+	return time.Duration(h.samples()) * time.Second / time.Duration(h.sampleRate)
+}
+
+func parseHeader(b []byte) (frameHeader, bool) {
+	if len(b) < headerSize || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return frameHeader{}, false
+	}
+
+	h := frameHeader{
+		version: int(b[1]>>3) & 0x03,
+		layer:   int(b[1]>>1) & 0x03,
+		padding: int(b[2]>>1) & 0x01,
+	}
+	bitrateIdx := int(b[2] >> 4)
+	sampleRateIdx := int(b[2]>>2) & 0x03
+
+	if h.version == 1 || h.layer == 0 || bitrateIdx == 0 || bitrateIdx == 15 || sampleRateIdx == 3 {
+		return frameHeader{}, false
+	}
+
+	var table int
+	switch {
+	case h.version == mpeg1:
+		table = 3 - h.layer // layer1 -> 0, layer2 -> 1, layer3 -> 2
+	case h.layer == layer1:
+		table = 3
+	default:
+		table = 4
+	}
+
+	h.bitrate = bitrates[table][bitrateIdx] * 1000
+	h.sampleRate = sampleRates[h.version][sampleRateIdx]
+	h.channels = 2
+	if b[3]>>6 == 0x03 {
+		h.channels = 1
+	}
+
+	return h, true
+}
+
+type Parser struct{}
+
+func New() *Parser {
+	return &Parser{}
+}
+
+// ParseFrames scans an MPEG audio stream and returns all frames found in it.
+// ID3v2 tags and garbage between frames are skipped.
+func (p *Parser) ParseFrames(r io.Reader) ([]*entity.AudioFrame, error) {
+	frames := make([]*entity.AudioFrame, 0, 1024)
+
+	_, err := scan(r, func(offset int64, header frameHeader, _ []byte) {
+		frames = append(frames, &entity.AudioFrame{
+			Offset:   offset,
+			Size:     header.size(),
+			Duration: header.duration(),
+			Bitrate:  header.bitrate,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return frames, nil
+}
+
+// scan walks the frames of an MPEG audio stream and calls fn with the offset,
+// the header and the data of every frame, data is valid only during the call.
+// It returns the number of garbage bytes found between frames.
+func scan(r io.Reader, fn func(offset int64, header frameHeader, data []byte)) (int64, error) {
+	br := bufio.NewReaderSize(r, readerSize)
+
+	offset, err := skipID3v2(br)
+	if err != nil {
+		return 0, err
+	}
+
+	var found bool
+	var skipped, pending int64
+
+	for {
+		buf, err := br.Peek(headerSize)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, fmt.Errorf("%w: %w", ErrRead, err)
+		}
+
+		header, ok := parseHeader(buf)
+		if !ok || !frameIsComplete(br, header) {
+			if _, err := br.Discard(1); err != nil {
+				return 0, fmt.Errorf("%w: %w", ErrRead, err)
+			}
+			offset++
+			pending++
+			continue
+		}
+
+		// garbage before the first frame and trailing tags are not counted
+		if found {
+			skipped += pending
+		}
+		found = true
+		pending = 0
+
+		size := header.size()
+		data, err := br.Peek(int(size))
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrRead, err)
+		}
+		fn(offset, header, data)
+
+		if _, err := br.Discard(int(size)); err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrRead, err)
+		}
+		offset += size
+	}
+
+	if !found {
+		return 0, ErrNoFrames
+	}
+
+	return skipped, nil
+}
+
+// frameIsComplete checks that the whole frame is available and that it is
+// followed either by the end of stream or by another valid frame header,
+// which filters out false sync words inside audio data.
+func frameIsComplete(br *bufio.Reader, header frameHeader) bool {
+	size := int(header.size())
+
+	buf, err := br.Peek(size + headerSize)
+	if err != nil {
+		return len(buf) == size || (len(buf) > size && !isFrameSync(buf[size:]))
+	}
+
+	next, ok := parseHeader(buf[size:])
+
+	return ok && next.version == header.version && next.layer == header.layer
+}
+
+func isFrameSync(b []byte) bool {
+	return len(b) >= 2 && b[0] == 0xFF && b[1]&0xE0 == 0xE0
+}
+
+func skipID3v2(br *bufio.Reader) (int64, error) {
+	buf, err := br.Peek(id3HeaderSize)
+	if err != nil || string(buf[:3]) != "ID3" {
+		return 0, nil
+	}
+
+	size := int64(buf[6]&0x7F)<<21 | int64(buf[7]&0x7F)<<14 | int64(buf[8]&0x7F)<<7 | int64(buf[9]&0x7F)
+	size += id3HeaderSize
+	if buf[5]&0x10 != 0 { // footer present
+		size += id3HeaderSize
+	}
+
+	n, err := br.Discard(int(size))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("%w: %w", ErrRead, err)
+	}
+
+	return int64(n), nil
+}
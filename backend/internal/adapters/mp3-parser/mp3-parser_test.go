@@ -0,0 +1,130 @@
+package mp3parser
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MPEG1 Layer3, 128 kbps, 44100 Hz, no padding -> 417 bytes per frame
+var mpeg1Layer3Header = []byte{0xFF, 0xFB, 0x90, 0x00}
+
+const mpeg1Layer3FrameSize = 417
+
+func testFrames(count int) []byte {
+	buf := bytes.NewBuffer(nil)
+	for range count {
+		frame := make([]byte, mpeg1Layer3FrameSize)
+		copy(frame, mpeg1Layer3Header)
+		buf.Write(frame)
+	}
+	return buf.Bytes()
+}
+
+func testID3v2Tag(payloadSize int) []byte {
+	tag := []byte{'I', 'D', '3', 0x04, 0x00, 0x00,
+		byte(payloadSize >> 21 & 0x7F), byte(payloadSize >> 14 & 0x7F),
+		byte(payloadSize >> 7 & 0x7F), byte(payloadSize & 0x7F)}
+	return append(tag, make([]byte, payloadSize)...)
+}
+
+func TestParseFrames(t *testing.T) {
+	frames, err := New().ParseFrames(bytes.NewReader(testFrames(10)))
+	require.NoError(t, err)
+	require.Len(t, frames, 10)
+
+	for i, frame := range frames {
+		assert.Equal(t, int64(i*mpeg1Layer3FrameSize), frame.Offset)
+		assert.Equal(t, int64(mpeg1Layer3FrameSize), frame.Size)
+		assert.Equal(t, 128000, frame.Bitrate)
+		assert.Equal(t, 1152*time.Second/44100, frame.Duration)
+	}
+}
+
+func TestParseFramesSkipsID3v2(t *testing.T) {
+	tag := testID3v2Tag(300)
+	data := append(tag, testFrames(3)...)
+
+	frames, err := New().ParseFrames(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, frames, 3)
+	assert.Equal(t, int64(len(tag)), frames[0].Offset)
+}
+
+func TestParseFramesSkipsGarbage(t *testing.T) {
+	garbage := []byte{0x00, 0xFF, 0x01, 0x02, 0xFF, 0xFB}
+	data := append(garbage, testFrames(2)...)
+	data = append(data, []byte("TAG")...)
+
+	frames, err := New().ParseFrames(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.Equal(t, int64(len(garbage)), frames[0].Offset)
+}
+
+func TestParseFramesNoFrames(t *testing.T) {
+	_, err := New().ParseFrames(bytes.NewReader([]byte("definitely not an mp3 file")))
+	assert.ErrorIs(t, err, ErrNoFrames)
+}
+
+func TestParseFramesTruncated(t *testing.T) {
+	data := testFrames(3)
+	data = data[:len(data)-100]
+
+	frames, err := New().ParseFrames(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Len(t, frames, 2)
+}
+
+func testXingFrame(frames int) []byte {
+	frame := make([]byte, mpeg1Layer3FrameSize)
+	copy(frame, mpeg1Layer3Header)
+	off := headerSize + 32 // stereo MPEG1 side info
+	copy(frame[off:], "Xing")
+	frame[off+7] = 0x01 // frames field present
+	frame[off+8], frame[off+9], frame[off+10], frame[off+11] =
+		byte(frames>>24), byte(frames>>16), byte(frames>>8), byte(frames)
+	return frame
+}
+
+func TestAnalyze(t *testing.T) {
+	info, err := New().Analyze(bytes.NewReader(testFrames(100)))
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.FormatMP3, info.Format)
+	assert.Equal(t, 100*(1152*time.Second/44100), info.Duration)
+	assert.Equal(t, 44100, info.SampleRate)
+	assert.InDelta(t, 128000, info.Bitrate, 1000)
+}
+
+func TestAnalyzeSkipsXingFrame(t *testing.T) {
+	data := append(testXingFrame(10), testFrames(10)...)
+
+	info, err := New().Analyze(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 10*(1152*time.Second/44100), info.Duration)
+}
+
+func TestAnalyzeTruncated(t *testing.T) {
+	data := append(testXingFrame(100), testFrames(10)...)
+
+	_, err := New().Analyze(bytes.NewReader(data))
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
+
+func TestAnalyzeGarbage(t *testing.T) {
+	data := append(testFrames(5), make([]byte, 1000)...)
+	data = append(data, testFrames(5)...)
+
+	_, err := New().Analyze(bytes.NewReader(data))
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
+
+func TestAnalyzeNoFrames(t *testing.T) {
+	_, err := New().Analyze(bytes.NewReader([]byte("definitely not an mp3 file")))
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
@@ -0,0 +1,87 @@
+package streamtokens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGrant() *entity.StreamGrant {
+	return &entity.StreamGrant{
+		Resource:   entity.StreamAudio,
+		ResourceID: uuid.New(),
+		Quality:    entity.QualityHigh,
+		UserID:     uuid.New(),
+		ExpiresAt:  time.Unix(time.Now().Add(time.Minute).Unix(), 0),
+	}
+}
+
+func TestSignAndParse(t *testing.T) {
+	signer := New([]byte("supersecretkey"))
+	grant := testGrant()
+
+	token, err := signer.Sign(grant)
+	require.NoError(t, err)
+	assert.NotContains(t, token, "=")
+	assert.NotContains(t, token, "/")
+
+	parsed, err := signer.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, grant.Resource, parsed.Resource)
+	assert.Equal(t, grant.ResourceID, parsed.ResourceID)
+	assert.Equal(t, grant.Quality, parsed.Quality)
+	assert.Equal(t, grant.UserID, parsed.UserID)
+	assert.True(t, grant.ExpiresAt.Equal(parsed.ExpiresAt))
+}
+
+func TestSignAnonymous(t *testing.T) {
+	signer := New([]byte("supersecretkey"))
+	grant := testGrant()
+	grant.UserID = uuid.Nil
+	grant.Quality = ""
+
+	token, err := signer.Sign(grant)
+	require.NoError(t, err)
+
+	parsed, err := signer.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, parsed.UserID)
+	assert.Empty(t, parsed.Quality)
+}
+
+func TestParseWrongKey(t *testing.T) {
+	token, err := New([]byte("supersecretkey")).Sign(testGrant())
+	require.NoError(t, err)
+
+	_, err = New([]byte("anotherkey")).Parse(token)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParseTamperedPayload(t *testing.T) {
+	signer := New([]byte("supersecretkey"))
+
+	token, err := signer.Sign(testGrant())
+	require.NoError(t, err)
+	other, err := signer.Sign(testGrant())
+	require.NoError(t, err)
+
+	payload, _, _ := strings.Cut(other, ".")
+	_, signature, _ := strings.Cut(token, ".")
+
+	_, err = signer.Parse(payload + "." + signature)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParseMalformed(t *testing.T) {
+	signer := New([]byte("supersecretkey"))
+
+	for _, token := range []string{"", "no-dot", "abc.!!!"} {
+		_, err := signer.Parse(token)
+		assert.ErrorIs(t, err, ErrMalformedToken, token)
+	}
+}
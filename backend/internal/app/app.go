@@ -1,8 +1,9 @@
 package app
 
 import (
+	"bytes"
 	"context"
-	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
@@ -12,8 +13,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	audioconverter "github.com/hahaclassic/orpheon/backend/internal/adapters/audio-converter"
+	formatdetector "github.com/hahaclassic/orpheon/backend/internal/adapters/format-detector"
+	id3reader "github.com/hahaclassic/orpheon/backend/internal/adapters/id3-reader"
+	imageprocessor "github.com/hahaclassic/orpheon/backend/internal/adapters/image-processor"
+	mp3parser "github.com/hahaclassic/orpheon/backend/internal/adapters/mp3-parser"
 	bcrypt_hasher "github.com/hahaclassic/orpheon/backend/internal/adapters/password-hasher/bcrypt-hasher"
 	jwttokens "github.com/hahaclassic/orpheon/backend/internal/adapters/tokens/jwt"
+	streamtokens "github.com/hahaclassic/orpheon/backend/internal/adapters/tokens/stream"
 	"github.com/hahaclassic/orpheon/backend/internal/config"
 	auth_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/auth"
 	album_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/content/album"
@@ -22,6 +28,7 @@ import (
 	license_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/content/license"
 	playlist_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/content/playlist"
 	search_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/content/search"
+	stream_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/content/stream"
 	track_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/content/track"
 	stats_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/stat"
 	user_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/user"
@@ -33,6 +40,7 @@ import (
 	track_router "github.com/hahaclassic/orpheon/backend/internal/controller/http/router/router-registrators/track"
 	user_me_router "github.com/hahaclassic/orpheon/backend/internal/controller/http/router/router-registrators/user-me"
 	"github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/cookie"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/auth"
 	content_aggregator "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/aggregator"
 	album_cover_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/album/cover"
@@ -53,21 +61,31 @@ import (
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/playlist/privacy"
 	playlist_tracks_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/playlist/tracks"
 	search_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/search"
+	stream_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/stream"
 	audio_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/audio"
+	hls_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/hls"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/importer"
+	loudness_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/loudness"
 	track_meta_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/meta"
+	preview_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/preview"
+	seek_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/seek"
 	tracksegment "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/segment"
+	upload_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/upload"
+	waveform_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/waveform"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/charts"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/consumer"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/history"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/processor"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/publisher"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/user"
 	minio_client "github.com/hahaclassic/orpheon/backend/internal/infrastructure/minio"
 	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/postgres"
 	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/redis"
 	auth_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/auth/auth-repo/postgres"
 	refresh_redis "github.com/hahaclassic/orpheon/backend/internal/repository/auth/refresh-token/redis"
-	album_cover_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/album/cover/minio"
 	album_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/album/meta/postgres"
 	album_tracks_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/album/tracks/postgres"
 	assign_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/artist/assign/postgres"
-	avatar_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/artist/avatar/minio"
 	artist_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/artist/meta/postgres"
 	genre_assign_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/genre/assign/postgres"
 	genre_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/genre/meta/postgres"
@@ -76,16 +94,23 @@ import (
 	access_cache_redis "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/access-cache/redis"
 	access_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/access-meta/default/postgres"
 	access_meta "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/access-meta/with-cache"
-	playlist_cover_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/cover/minio"
 	favorites_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/favorites/postgres"
 	playlist_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/meta/postgres"
 	playlist_tracks_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/tracks/postgres"
 	search_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/search/postgres"
-	audio_fs "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/fs"
-	audio_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/minio"
+	audio_cas "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/content-addressed"
+	audio_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/postgres"
+	charts_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/charts/postgres"
+	history_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/history/postgres"
+	import_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/import/postgres"
 	track_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/meta/postgres"
+	seek_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/seek/postgres"
 	segment_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/segment/postgres"
+	stat_dedup_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/stat-dedup/postgres"
 	user_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/user/postgres"
+	"github.com/hahaclassic/orpheon/backend/internal/storages"
+	"github.com/hahaclassic/orpheon/backend/internal/worker"
+	"github.com/hahaclassic/orpheon/backend/pkg/jobs"
 	"github.com/minio/minio-go/v7"
 )
 
@@ -102,10 +127,14 @@ func Run(conf *config.Config) {
 	}
 	defer redisClient.Close()
 
-	minioClient, err := minio_client.NewMinioClient(conf.MinIO)
-	if err != nil {
-		slog.Error("failed to create minio client", "err", err)
-		return
+	// MinIO is left out of deployments keeping everything on the filesystem
+	var minioClient *minio.Client
+	if storages.UsesMinIO(conf) {
+		minioClient, err = minio_client.NewMinioClient(conf.MinIO)
+		if err != nil {
+			slog.Error("failed to create minio client", "err", err)
+			return
+		}
 	}
 
 	// Initialize repositories
@@ -125,37 +154,41 @@ func Run(conf *config.Config) {
 	playlistTrackRepo := playlist_tracks_postgres.NewPlaylistTracksRepository(pgxpool)
 	playlistFavoriteRepo := favorites_postgres.NewPlaylistFavoriteRepository(pgxpool)
 	segmentRepo := segment_postgres.NewTrackSegmentRepository(pgxpool)
+	seekTableRepo := seek_postgres.NewSeekTableRepository(pgxpool)
 
-	albumCoverRepo, err := album_cover_minio.NewAlbumCoverRepository(ctx, minioClient, conf.MinIO.BucketAlbum)
+	covers, err := storages.NewCoverStorages(ctx, conf, minioClient, conf.CoverStorage.Type)
 	if err != nil {
-		slog.Error("failed to create album cover repository", "err", err)
+		slog.Error("failed to create cover repositories", "err", err)
 		return
 	}
+	albumCoverRepo := covers.Album
+	artistAvatarRepo := covers.Avatar
+	playlistCoverRepo := covers.Playlist
+	coverProcessor := imageprocessor.New()
 
-	artistAvatarRepo, err := avatar_minio.NewArtistAvatarRepository(ctx, minioClient, conf.MinIO.BucketArtistAvatar)
+	audioStorage, err := storages.NewAudioStorage(ctx, conf, minioClient, conf.AudioStorage.Type)
 	if err != nil {
-		slog.Error("failed to create artist avatar repository", "err", err)
+		slog.Error("failed to create audio file repository", "err", err)
 		return
 	}
-
-	audioRepo, err := setupAudioStorage(ctx, conf, minioClient)
+	audioIndex := audio_postgres.NewAudioFileIndex(pgxpool)
+	audioCache, err := storages.NewAudioCache(ctx, conf, audioStorage, audioIndex)
 	if err != nil {
-		slog.Error("failed to create audio file repository", "err", err)
+		slog.Error("failed to create audio cache", "err", err)
 		return
 	}
+	var audioBlobs audio_cas.BlobStore = audioStorage
+	if audioCache != nil {
+		audioBlobs = audioCache
+	}
+	audioRepo := audio_cas.New(audioBlobs, audioIndex, audio_cas.WithLegacyFiles(audioStorage))
 
-	// audioRepo, err := audio_minio.NewAudioFileRepository(ctx, minioClient, conf.MinIO.BucketAudio)
+	// audioStorage, err := audio_minio.NewAudioStorage(ctx, minioClient, conf.MinIO.BucketAudio)
 	// if err != nil {
 	// 	slog.Error("failed to create audio file repository", "err", err)
 	// 	return
 	// }
 
-	playlistCoverRepo, err := playlist_cover_minio.NewPlaylistCoverRepository(ctx, minioClient, conf.MinIO.BucketPlaylist)
-	if err != nil {
-		slog.Error("failed to create playlist cover repository", "err", err)
-		return
-	}
-
 	playlistAccessRepo := access_meta_postgres.NewPlaylistAccessRepository(pgxpool)
 	accessCacheLocal, err := access_cache_local.NewAccessCache(conf.LocalAccessMetaCache.Size)
 	if err != nil {
@@ -174,15 +207,42 @@ func Run(conf *config.Config) {
 	authService := auth.NewAuthService(authRepo, refreshRepo, userService, hasher, tokenService)
 	playlistPolicyService := policy.New(playlistAccessRepoWithCache)
 
+	// the stream key may be shared with a CDN, so it must not be able to sign access tokens
+	if len(conf.StreamToken.SecretKey) == 0 || bytes.Equal(conf.StreamToken.SecretKey, conf.AccessToken.SecretKey) {
+		slog.Error("STREAM_TOKEN_SECRET_KEY must be set and differ from the access token key")
+		return
+	}
+	streamTokenService := stream_service.New(streamtokens.New(conf.StreamToken.SecretKey), conf.StreamToken.TTL, conf.StreamToken.Required)
+
 	// Initialize content services
+	// the jobs enqueued by the last requests are finished before the connections are closed
+	backgroundJobs := jobs.New(conf.BackgroundJobs.Workers, conf.BackgroundJobs.QueueSize)
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := backgroundJobs.Close(closeCtx); err != nil {
+			slog.Error("background jobs left unfinished", "err", err)
+		}
+	}()
 	segmentService := tracksegment.NewTrackSegmentService(segmentRepo)
-	trackService := track_meta_service.NewTrackMetaService(trackRepo, segmentService)
-	trackAudioService := audio_service.New(audioRepo, audioconverter.New())
+	audioConverter := audioconverter.New(conf.AudioConverter)
+	albumTrackService := album_tracks_service.NewAlbumTrackService(albumTrackRepo)
+	trackLoudnessService := loudness_service.New(audioRepo, audioConverter, trackRepo, albumTrackService)
+	trackWaveformService := waveform_service.New(audioRepo, audioConverter, audioStorage)
+	trackSeekService := seek_service.New(audioRepo, audioIndex, mp3parser.New(), seekTableRepo)
+	trackAudioService := audio_service.New(audioRepo, audioConverter, formatdetector.New(), mp3parser.New(),
+		trackRepo, segmentService, trackWaveformService, trackLoudnessService, trackSeekService, backgroundJobs)
+	trackService := track_meta_service.NewTrackMetaService(trackRepo, segmentService, trackLoudnessService, trackAudioService)
+	trackHLSService := hls_service.New(audioRepo, mp3parser.New())
+	trackPreviewService := preview_service.New(audioRepo, audioConverter, trackRepo, segmentService)
+	trackUploadService := upload_service.New(audioStorage, trackAudioService)
 	artistMetaService := artist_meta_service.New(artistMetaRepo)
 	playlistMetaService := playlist_meta_service.NewPlaylistMetaService(playlistRepo, playlistPolicyService, playlistAccessRepo)
 	playlistTrackService := playlist_tracks_service.NewPlaylistTrackService(playlistTrackRepo, playlistPolicyService)
 	playlistFavoriteService := playlist_favorites_service.NewPlaylistFavoriteService(playlistFavoriteRepo, playlistPolicyService)
-	playlistCoverService := playlist_cover_service.New(playlistCoverRepo, playlistPolicyService)
+	albumCoverService := album_cover_service.New(albumCoverRepo, coverProcessor)
+	playlistCoverService := playlist_cover_service.New(playlistCoverRepo, playlistPolicyService, coverProcessor,
+		playlist_cover_service.WithCollages(playlistMetaService, playlistTrackService, albumCoverService, coverProcessor))
 	playlistDeletionService := playlist_deletion_service.New(
 		playlist_deletion_service.WithMetaDeletion(playlistMetaService),
 		playlist_deletion_service.WithCoverDeletion(playlistCoverService),
@@ -198,12 +258,41 @@ func Run(conf *config.Config) {
 	genreAssignService := genre_assign.NewGenreAssignService(genreAssignRepo)
 	licenseService := license_service.NewLicenseService(licenseRepo)
 	albumMetaService := album_meta_service.New(albumMetaRepo)
-	albumCoverService := album_cover_service.New(albumCoverRepo)
-	albumTrackService := album_tracks_service.NewAlbumTrackService(albumTrackRepo)
 	artistAssignService := assign.NewArtistAssignService(artistAssignRepo)
-	artistAvatarService := avatar.NewArtistCoverService(artistAvatarRepo)
+	artistAvatarService := avatar.NewArtistCoverService(artistAvatarRepo, coverProcessor)
 	searchService := search_service.NewSearchService(searchRepo)
-	listeningStatService := processor.NewListeningStatService(trackRepo, segmentRepo)
+	listeningEventDedupRepo := stat_dedup_postgres.NewListeningEventDedupRepository(pgxpool, conf.EventBus.DedupWindow)
+	chartRepo := charts_postgres.NewChartRepository(pgxpool)
+	listeningStatService := processor.NewListeningStatService(trackRepo, segmentRepo, listeningEventDedupRepo, chartRepo,
+		postgres.NewTransactor(pgxpool))
+
+	listeningEventBus, err := worker.NewEventBus(conf.EventBus)
+	if err != nil {
+		slog.Error("failed to create event bus", "err", err)
+		return
+	}
+	if closer, ok := listeningEventBus.(io.Closer); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				slog.Error("failed to close event bus", "err", err)
+			}
+		}()
+	}
+	listeningEventPublisher := publisher.New(listeningEventBus)
+	listeningHistoryService := history.New(history_postgres.NewListeningHistoryRepository(pgxpool))
+
+	trackImportService := importer.New(
+		import_postgres.NewImportRepository(pgxpool),
+		artistMetaService,
+		artistAssignService,
+		albumMetaService,
+		albumCoverService,
+		genreService,
+		genreAssignService,
+		trackService,
+		trackAudioService,
+		id3reader.New(),
+	)
 
 	contentAggregator := content_aggregator.NewContentAggregator(
 		trackService,
@@ -212,6 +301,7 @@ func Run(conf *config.Config) {
 		licenseService,
 		genreService,
 	)
+	chartService := charts.New(chartRepo, contentAggregator)
 
 	playlistAggregator := playlist_aggregator.NewPlaylistAggregator(
 		playlistMetaService,
@@ -225,6 +315,7 @@ func Run(conf *config.Config) {
 	authMiddleware := middleware.NewAuthMiddleware(authService, cookieTokensSetter)
 	authMiddlewareRequired := authMiddleware.Optional() //authMiddleware.Required()
 	authMiddlewareOptional := authMiddleware.Optional()
+	streamTokenMiddleware := middleware.NewStreamTokenMiddleware(streamTokenService, conf.StreamToken.Required, authMiddleware)
 
 	authController := auth_ctrl.NewAuthController(authService, cookieTokensSetter, authMiddlewareRequired)
 
@@ -238,8 +329,14 @@ func Run(conf *config.Config) {
 	albumTrackController := album_ctrl.NewAlbumTrackController(albumTrackService, contentAggregator)
 	albumCoverController := album_ctrl.NewAlbumCoverController(albumCoverService)
 	trackMetaController := track_ctrl.NewTrackMetaController(trackService, contentAggregator)
-	trackAudioController := track_ctrl.NewTrackAudioController(trackAudioService)
+	trackAudioController := track_ctrl.NewTrackAudioController(trackAudioService, trackSeekService)
+	trackHLSController := track_ctrl.NewTrackHLSController(trackHLSService)
+	trackUploadController := track_ctrl.NewTrackAudioUploadController(trackUploadService)
+	trackImportController := track_ctrl.NewTrackImportController(trackImportService, conf.TrackImport.MaxFileSizeMB<<20)
+	trackWaveformController := track_ctrl.NewTrackWaveformController(trackWaveformService)
+	trackPreviewController := track_ctrl.NewTrackPreviewController(trackPreviewService)
 	searchController := search_ctrl.NewSearchController(searchService, contentAggregator, playlistAggregator, authMiddlewareOptional)
+	streamController := stream_ctrl.NewStreamController(streamTokenService, conf.StreamToken.BaseURL, authMiddlewareOptional)
 	userController := user_ctrl.NewUserController(userService)
 	playlistMetaController := playlist_ctrl.NewPlaylistMetaController(playlistMetaService,
 		playlistDeletionService,
@@ -250,24 +347,28 @@ func Run(conf *config.Config) {
 	playlistTrackController := playlist_ctrl.NewPlaylistTrackController(playlistTrackService, contentAggregator)
 	playlistFavoriteController := playlist_ctrl.NewPlaylistFavoritesController(playlistFavoriteService, playlistAggregator)
 	trackSegmentController := track_ctrl.NewTrackSegmentController(segmentService)
-	statController := stats_ctrl.NewStatController(listeningStatService)
+	statController := stats_ctrl.NewStatController(listeningEventPublisher)
+	historyController := stats_ctrl.NewHistoryController(listeningHistoryService, contentAggregator)
+	chartController := stats_ctrl.NewChartController(chartService)
 
 	albumRouter := album_router.NewAlbumRouter(
 		albumMetaController, albumCoverController,
-		albumTrackController, genreAssignController, authMiddlewareRequired)
+		albumTrackController, genreAssignController,
+		streamTokenMiddleware.Image(entity.StreamAlbumCover), authMiddlewareRequired)
 
 	artistRouter := artist_router.NewArtistRouter(
 		artistMetaController, artistAvatarController,
-		artistAssignController, authMiddlewareRequired)
+		artistAssignController, streamTokenMiddleware.Image(entity.StreamArtistAvatar), authMiddlewareRequired)
 
 	playlistRouter := playlist_router.NewPlaylistRouter(
-		playlistMetaController, playlistTrackController, playlistCoverController, authMiddlewareRequired)
+		playlistMetaController, playlistTrackController, playlistCoverController,
+		streamTokenMiddleware.Image(entity.StreamPlaylistCover), authMiddlewareRequired)
 
 	trackRouter := track_router.NewTrackRouter(trackMetaController,
-		trackSegmentController, trackAudioController, statController, artistAssignController, authMiddlewareRequired)
+		trackSegmentController, trackAudioController, trackUploadController, trackHLSController, trackImportController, trackWaveformController, trackPreviewController, statController, artistAssignController, streamTokenMiddleware, authMiddlewareRequired)
 
 	meRouter := user_me_router.NewMeRouter(playlistMetaController, userController,
-		playlistFavoriteController, authMiddlewareRequired)
+		playlistFavoriteController, historyController, authMiddlewareRequired)
 
 	loggerMiddleware, err := middleware.SetupLoggerMiddleware(conf.Logger.Path, conf.Logger.Level)
 	if err != nil {
@@ -282,6 +383,8 @@ func Run(conf *config.Config) {
 			genreController,
 			licenseController,
 			searchController,
+			streamController,
+			chartController,
 
 			albumRouter,
 			artistRouter,
@@ -307,6 +410,30 @@ func Run(conf *config.Config) {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if audioCache != nil && conf.AudioStorage.CacheStatsInterval > 0 {
+		go audioCache.ReportStats(ctx, conf.AudioStorage.CacheStatsInterval)
+	}
+	if audioCache != nil && conf.AudioStorage.CacheResyncInterval > 0 {
+		go audioCache.RunResync(ctx, conf.AudioStorage.CacheResyncInterval)
+	}
+
+	// with Kafka the events are processed by cmd/stats-worker. The consumers outlive
+	// the signal, they stop once the server is shut down and the queue is drained.
+	consumersCtx, stopConsumers := context.WithCancel(context.Background())
+	defer stopConsumers()
+	consumersDone := make(chan struct{})
+	if conf.EventBus.Type != worker.KafkaBus {
+		go func() {
+			defer close(consumersDone)
+			worker.RunConsumers(consumersCtx, consumer.New(listeningEventBus, listeningStatService, listeningHistoryService),
+				conf.EventBus.Workers)
+		}()
+		go listeningStatService.RunDedupCleanup(ctx, processor.DefaultDedupCleanupInterval)
+		go chartService.RunMaintenance(ctx, conf.Charts.MaintenanceInterval)
+	} else {
+		close(consumersDone)
+	}
+
 	go func() {
 		slog.Info("starting server", "addr", addr)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -325,26 +452,21 @@ func Run(conf *config.Config) {
 	} else {
 		slog.Info("server exited properly")
 	}
-}
-
-func setupAudioStorage(ctx context.Context, conf *config.Config, minioClient *minio.Client) (audio_service.AudioFileRepository, error) {
-	var (
-		err       error
-		audioRepo audio_service.AudioFileRepository
-	)
 
-	switch conf.AudioStorage.Type {
-	case "fs":
-		audioRepo, err = audio_fs.NewAudioFileRepository(conf.AudioStorage.BasePath)
-	case "minio":
-		audioRepo, err = audio_minio.NewAudioFileRepository(ctx, minioClient, conf.MinIO.BucketAudio)
-	default:
-		return nil, fmt.Errorf("audio file repository implementation are not specified")
+	// no more events are published, the consumers process the queued ones
+	if closer, ok := listeningEventBus.(io.Closer); ok && conf.EventBus.Type != worker.KafkaBus {
+		if err := closer.Close(); err != nil {
+			slog.Error("failed to close event bus", "err", err)
+		}
 	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create audio file repository: %w", err)
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelDrain()
+
+	select {
+	case <-consumersDone:
+	case <-drainCtx.Done():
+		slog.Error("listening events left unprocessed, draining timed out")
+		stopConsumers()
+		<-consumersDone
 	}
-
-	return audioRepo, nil
 }
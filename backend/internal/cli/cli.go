@@ -4,10 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"os/signal"
 	"syscall"
 
 	audioconverter "github.com/hahaclassic/orpheon/backend/internal/adapters/audio-converter"
+	"github.com/hahaclassic/orpheon/backend/internal/adapters/event-bus/kafka"
+	formatdetector "github.com/hahaclassic/orpheon/backend/internal/adapters/format-detector"
+	id3reader "github.com/hahaclassic/orpheon/backend/internal/adapters/id3-reader"
+	imageprocessor "github.com/hahaclassic/orpheon/backend/internal/adapters/image-processor"
+	mp3parser "github.com/hahaclassic/orpheon/backend/internal/adapters/mp3-parser"
 	bcrypt_hasher "github.com/hahaclassic/orpheon/backend/internal/adapters/password-hasher/bcrypt-hasher"
 	jwttokens "github.com/hahaclassic/orpheon/backend/internal/adapters/tokens/jwt"
 	"github.com/hahaclassic/orpheon/backend/internal/config"
@@ -21,6 +27,7 @@ import (
 	track_cli_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/cli/api/content/track"
 	player_cli_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/cli/api/player"
 	user_cli_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/cli/api/user"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/library"
 	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/player"
 	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/session"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/auth"
@@ -30,6 +37,7 @@ import (
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/artist/assign"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/artist/avatar"
 	artist_meta_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/artist/meta"
+	genre_assign "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/genre/assign"
 	genre_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/genre/meta"
 	license_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/license"
 	playlist_cover_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/playlist/cover"
@@ -40,41 +48,66 @@ import (
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/playlist/privacy"
 	playlist_tracks_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/playlist/tracks"
 	search_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/search"
+	migration_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/storage/migration"
+	resize_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/storage/resize"
 	audio_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/audio"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/importer"
+	loudness_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/loudness"
 	track_meta_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/meta"
+	prewarm_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/prewarm"
+	scrub_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/scrub"
+	seek_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/seek"
 	tracksegment "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/segment"
+	waveform_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/waveform"
+	deadletters "github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/dead-letters"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/user"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/storage"
 	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/minio"
 	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/postgres"
 	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/redis"
 	auth_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/auth/auth-repo/postgres"
 	refresh_redis "github.com/hahaclassic/orpheon/backend/internal/repository/auth/refresh-token/redis"
-	album_cover_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/album/cover/minio"
 	album_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/album/meta/postgres"
 	album_tracks_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/album/tracks/postgres"
 	assign_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/artist/assign/postgres"
-	avatar_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/artist/avatar/minio"
 	artist_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/artist/meta/postgres"
+	genre_assign_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/genre/assign/postgres"
 	genre_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/genre/meta/postgres"
 	license_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/license/postgres"
 	access_cache_local "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/access-cache/local"
 	access_cache_redis "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/access-cache/redis"
 	access_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/access-meta/default/postgres"
 	access_meta "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/access-meta/with-cache"
-	playlist_cover_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/cover/minio"
 	favorites_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/favorites/postgres"
 	playlist_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/meta/postgres"
 	playlist_tracks_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/playlist/tracks/postgres"
 	search_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/search/postgres"
-	audio_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/minio"
+	audio_cas "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/content-addressed"
+	audio_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/postgres"
+	dead_letters_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/dead-letters/postgres"
+	import_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/import/postgres"
 	track_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/meta/postgres"
+	seek_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/seek/postgres"
 	segment_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/segment/postgres"
 	user_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/user/postgres"
+	"github.com/hahaclassic/orpheon/backend/internal/storages"
+	"github.com/hahaclassic/orpheon/backend/internal/worker"
 	"github.com/hahaclassic/orpheon/backend/pkg/cmdrouter"
+	"github.com/hahaclassic/orpheon/backend/pkg/jobs"
 	tableoutput "github.com/hahaclassic/orpheon/backend/pkg/table"
+	minio_go "github.com/minio/minio-go/v7"
 )
 
-func Run(conf *config.Config) {
+// Run starts the interactive CLI, or runs the command given in args.
+func Run(conf *config.Config, args ...string) {
+	// deferred first, so the connections are closed before a failed command exits
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
 	ctx := context.Background()
 	_ = session.Instance()
 
@@ -88,10 +121,13 @@ func Run(conf *config.Config) {
 	}
 	defer redisClient.Close()
 
-	minioClient, err := minio.NewMinioClient(conf.MinIO)
-	if err != nil {
-		slog.Error("failed to create minio client", "err", err)
-		return
+	var minioClient *minio_go.Client
+	if storages.UsesMinIO(conf) {
+		minioClient, err = minio.NewMinioClient(conf.MinIO)
+		if err != nil {
+			slog.Error("failed to create minio client", "err", err)
+			return
+		}
 	}
 
 	// Initialize repositories
@@ -104,36 +140,41 @@ func Run(conf *config.Config) {
 	artistMetaRepo := artist_meta_postgres.NewArtistMetaRepository(pgxpool)
 	artistAssignRepo := assign_postgres.NewArtistAssignRepository(pgxpool)
 	genreRepo := genre_postgres.NewGenreRepository(pgxpool)
+	genreAssignRepo := genre_assign_postgres.NewGenreAssignRepository(pgxpool)
 	licenseRepo := license_postgres.NewLicenseRepository(pgxpool)
 	searchRepo := search_postgres.NewSearchRepository(pgxpool)
 	playlistRepo := playlist_meta_postgres.NewPlaylistMetaRepository(pgxpool)
 	playlistTrackRepo := playlist_tracks_postgres.NewPlaylistTracksRepository(pgxpool)
 	playlistFavoriteRepo := favorites_postgres.NewPlaylistFavoriteRepository(pgxpool)
 	segmentRepo := segment_postgres.NewTrackSegmentRepository(pgxpool)
+	seekTableRepo := seek_postgres.NewSeekTableRepository(pgxpool)
 
-	albumCoverRepo, err := album_cover_minio.NewAlbumCoverRepository(ctx, minioClient, conf.MinIO.BucketAlbum)
-	if err != nil {
-		slog.Error("failed to create album cover repository", "err", err)
-		return
-	}
-
-	artistAvatarRepo, err := avatar_minio.NewArtistAvatarRepository(ctx, minioClient, conf.MinIO.BucketArtistAvatar)
+	covers, err := storages.NewCoverStorages(ctx, conf, minioClient, conf.CoverStorage.Type)
 	if err != nil {
-		slog.Error("failed to create artist avatar repository", "err", err)
+		slog.Error("failed to create cover repositories", "err", err)
 		return
 	}
+	albumCoverRepo := covers.Album
+	artistAvatarRepo := covers.Avatar
+	playlistCoverRepo := covers.Playlist
+	coverProcessor := imageprocessor.New()
 
-	audioRepo, err := audio_minio.NewAudioFileRepository(ctx, minioClient, conf.MinIO.BucketAudio)
+	audioStorage, err := storages.NewAudioStorage(ctx, conf, minioClient, conf.AudioStorage.Type)
 	if err != nil {
 		slog.Error("failed to create audio file repository", "err", err)
 		return
 	}
-
-	playlistCoverRepo, err := playlist_cover_minio.NewPlaylistCoverRepository(ctx, minioClient, conf.MinIO.BucketPlaylist)
+	audioIndex := audio_postgres.NewAudioFileIndex(pgxpool)
+	audioCache, err := storages.NewAudioCache(ctx, conf, audioStorage, audioIndex)
 	if err != nil {
-		slog.Error("failed to create playlist cover repository", "err", err)
+		slog.Error("failed to create audio cache", "err", err)
 		return
 	}
+	var audioBlobs audio_cas.BlobStore = audioStorage
+	if audioCache != nil {
+		audioBlobs = audioCache
+	}
+	audioRepo := audio_cas.New(audioBlobs, audioIndex, audio_cas.WithLegacyFiles(audioStorage))
 
 	playlistAccessRepo := access_meta_postgres.NewPlaylistAccessRepository(pgxpool)
 	accessCacheLocal, err := access_cache_local.NewAccessCache(conf.LocalAccessMetaCache.Size)
@@ -154,14 +195,29 @@ func Run(conf *config.Config) {
 	playlistPolicyService := policy.New(playlistAccessRepoWithCache)
 
 	// Initialize content services
+	// a command exits once the jobs it enqueued are finished
+	backgroundJobs := jobs.New(conf.BackgroundJobs.Workers, conf.BackgroundJobs.QueueSize)
+	defer func() {
+		if err := backgroundJobs.Close(context.Background()); err != nil {
+			slog.Error("background jobs left unfinished", "err", err)
+		}
+	}()
 	segmentService := tracksegment.NewTrackSegmentService(segmentRepo)
-	trackService := track_meta_service.NewTrackMetaService(trackRepo, segmentService)
-	trackAudioService := audio_service.New(audioRepo, audioconverter.New())
+	audioConverter := audioconverter.New(conf.AudioConverter)
+	albumTrackService := album_tracks_service.NewAlbumTrackService(albumTrackRepo)
+	trackLoudnessService := loudness_service.New(audioRepo, audioConverter, trackRepo, albumTrackService)
+	trackWaveformService := waveform_service.New(audioRepo, audioConverter, audioStorage)
+	trackSeekService := seek_service.New(audioRepo, audioIndex, mp3parser.New(), seekTableRepo)
+	trackAudioService := audio_service.New(audioRepo, audioConverter, formatdetector.New(), mp3parser.New(),
+		trackRepo, segmentService, trackWaveformService, trackLoudnessService, trackSeekService, backgroundJobs)
+	trackService := track_meta_service.NewTrackMetaService(trackRepo, segmentService, trackLoudnessService, trackAudioService)
 	artistMetaService := artist_meta_service.New(artistMetaRepo)
 	playlistMetaService := playlist_meta_service.NewPlaylistMetaService(playlistRepo, playlistPolicyService, playlistAccessRepo)
 	playlistTrackService := playlist_tracks_service.NewPlaylistTrackService(playlistTrackRepo, playlistPolicyService)
 	playlistFavoriteService := playlist_favorites_service.NewPlaylistFavoriteService(playlistFavoriteRepo, playlistPolicyService)
-	playlistCoverService := playlist_cover_service.New(playlistCoverRepo, playlistPolicyService)
+	albumCoverService := album_cover_service.New(albumCoverRepo, coverProcessor)
+	playlistCoverService := playlist_cover_service.New(playlistCoverRepo, playlistPolicyService, coverProcessor,
+		playlist_cover_service.WithCollages(playlistMetaService, playlistTrackService, albumCoverService, coverProcessor))
 	playlistDeletionService := playlist_deletion_service.New(
 		playlist_deletion_service.WithMetaDeletion(playlistMetaService),
 		playlist_deletion_service.WithCoverDeletion(playlistCoverService),
@@ -174,13 +230,24 @@ func Run(conf *config.Config) {
 		playlistAccessRepo,
 	)
 	genreService := genre_service.NewGenreService(genreRepo)
+	genreAssignService := genre_assign.NewGenreAssignService(genreAssignRepo)
 	licenseService := license_service.NewLicenseService(licenseRepo)
 	albumMetaService := album_meta_service.New(albumMetaRepo)
-	albumCoverService := album_cover_service.New(albumCoverRepo)
-	albumTrackService := album_tracks_service.NewAlbumTrackService(albumTrackRepo)
 	artistAssignService := assign.NewArtistAssignService(artistAssignRepo)
-	artistAvatarService := avatar.NewArtistCoverService(artistAvatarRepo)
+	artistAvatarService := avatar.NewArtistCoverService(artistAvatarRepo, coverProcessor)
 	searchService := search_service.NewSearchService(searchRepo)
+	trackImportService := importer.New(
+		import_postgres.NewImportRepository(pgxpool),
+		artistMetaService,
+		artistAssignService,
+		albumMetaService,
+		albumCoverService,
+		genreService,
+		genreAssignService,
+		trackService,
+		trackAudioService,
+		id3reader.New(),
+	)
 	//listeningStatService := processor.NewListeningStatService(trackRepo, segmentRepo)
 
 	authController := auth_cli_ctrl.NewAuthController(authService)
@@ -194,6 +261,7 @@ func Run(conf *config.Config) {
 	albumCoverController := album_cli_ctrl.NewAlbumCoverController(albumCoverService)
 	trackMetaController := track_cli_ctrl.NewTrackMetaController(trackService)
 	trackAudioController := track_cli_ctrl.NewTrackAudioController(trackAudioService)
+	trackImportController := track_cli_ctrl.NewTrackImportController(trackImportService)
 	searchController := search_cli_ctrl.NewSearchController(searchService)
 	userController := user_cli_ctrl.NewUserController(userService)
 	playlistMetaController := playlist_cli_ctrl.NewPlaylistMetaController(playlistMetaService,
@@ -203,8 +271,9 @@ func Run(conf *config.Config) {
 	playlistTrackController := playlist_cli_ctrl.NewPlaylistTrackController(playlistTrackService)
 	playlistFavoriteController := playlist_cli_ctrl.NewPlaylistFavoriteController(playlistFavoriteService)
 	trackSegmentController := track_cli_ctrl.NewTrackSegmentController(segmentService)
+	trackWaveformController := track_cli_ctrl.NewTrackWaveformController(trackWaveformService, segmentService)
 
-	player := player.NewPlayer(trackAudioService)
+	player := player.NewPlayer(trackAudioService, trackSeekService)
 	playerController := player_cli_ctrl.NewPlayerController(player, albumTrackService,
 		playlistTrackService, trackService)
 
@@ -222,7 +291,9 @@ func Run(conf *config.Config) {
 
 	trackGroup.Group("Meta", trackMetaController.Menu()...)
 	trackGroup.Group("Audio", trackAudioController.Menu()...)
+	trackGroup.Group("Import", trackImportController.Menu()...)
 	trackGroup.SetOptionHandlers(trackSegmentController.Menu()...)
+	trackGroup.SetOptionHandlers(trackWaveformController.Menu()...)
 
 	albumGroup.Group("Meta", albumMetaController.Menu()...)
 	albumGroup.Group("Covers", albumCoverController.Menu()...)
@@ -247,6 +318,58 @@ func Run(conf *config.Config) {
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if len(args) > 0 {
+		if err := authController.RefreshToken(ctx); err != nil {
+			slog.Error("failed to restore session", "err", err)
+		}
+		commands := &commands{
+			importer:    library.NewImporter(trackImportService),
+			scrubber:    scrub_service.New(audioStorage, audioIndex),
+			legacyAudio: audioRepo,
+			resizer: resize_service.New(&resize_service.Covers{
+				PlaylistCovers: playlistCoverRepo,
+				AlbumCovers:    albumCoverRepo,
+				ArtistAvatars:  artistAvatarRepo,
+			}, coverProcessor),
+		}
+		if audioCache != nil {
+			commands.scrubber = scrub_service.New(audioStorage, audioIndex, scrub_service.WithCache(audioCache))
+			commands.prewarmer = prewarm_service.New(audioIndex, audioCache)
+		}
+		commands.migrations = func(ctx context.Context, from, to string) (storage.StorageMigrationService, error) {
+			source, err := setupStorageBackend(ctx, conf, minioClient, from)
+			if err != nil {
+				return nil, err
+			}
+			sink, err := setupStorageBackend(ctx, conf, minioClient, to)
+			if err != nil {
+				return nil, err
+			}
+			return migration_service.New(source, sink, audioIndex), nil
+		}
+		if conf.EventBus.Type == worker.KafkaBus {
+			if len(conf.EventBus.KafkaBrokers) == 0 {
+				slog.Error("no kafka brokers configured")
+				exitCode = 1
+				return
+			}
+			bus := worker.NewKafkaEventBus(conf.EventBus)
+			defer func() {
+				if err := bus.Close(); err != nil {
+					slog.Error("failed to close event bus", "err", err)
+				}
+			}()
+			deadLetterReader := kafka.NewDeadLetterReader(conf.EventBus.KafkaBrokers, conf.EventBus.KafkaDeadLetterTopic)
+			replayedRepo := dead_letters_postgres.NewReplayedDeadLetterRepository(pgxpool)
+			commands.deadLetters = deadletters.New(deadLetterReader, replayedRepo, bus)
+		}
+		if err := commands.run(ctx, args); err != nil {
+			slog.Error("command failed", "command", args[0], "err", err)
+			exitCode = 1
+		}
+		return
+	}
+
 	fmt.Println("Orpheon. CLI")
 	if err := authController.RefreshToken(ctx); err == nil {
 		userInfo, err := userService.GetUser(ctx, session.Claims().UserID)
@@ -263,3 +386,33 @@ func Run(conf *config.Config) {
 	<-ctx.Done()
 	slog.Info("Orpheon. CLI exited")
 }
+
+// setupStorageBackend opens every storage of the backend type for a storage migration.
+// MinIO is connected to on demand, the configured storages may not use it.
+func setupStorageBackend(ctx context.Context, conf *config.Config, minioClient *minio_go.Client,
+	storageType string) (*migration_service.Backend, error) {
+	if storageType == storages.MinIO && minioClient == nil {
+		var err error
+		if minioClient, err = minio.NewMinioClient(conf.MinIO); err != nil {
+			return nil, err
+		}
+	}
+
+	audio, err := storages.NewAudioStorage(ctx, conf, minioClient, storageType)
+	if err != nil {
+		return nil, err
+	}
+
+	covers, err := storages.NewCoverStorages(ctx, conf, minioClient, storageType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &migration_service.Backend{
+		Audio:          audio,
+		Waveforms:      audio,
+		PlaylistCovers: covers.Playlist,
+		AlbumCovers:    covers.Album,
+		ArtistAvatars:  covers.Avatar,
+	}, nil
+}
@@ -0,0 +1,237 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/library"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/output"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/session"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/storage"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	stats "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+)
+
+const importStateFile = ".import-state.json"
+
+// commands are the non-interactive commands given on the command line.
+type commands struct {
+	importer  *library.Importer
+	scrubber  track.AudioScrubService
+	prewarmer track.AudioCachePrewarmService // nil if the audio cache is not configured
+	// migrations opens the backends of the given types for a storage migration
+	migrations  func(ctx context.Context, from, to string) (storage.StorageMigrationService, error)
+	resizer     storage.CoverResizeService
+	deadLetters stats.DeadLetterService // nil unless the Kafka event bus is configured
+	legacyAudio legacyAudioAdopter
+}
+
+type legacyAudioAdopter interface {
+	AdoptLegacyFiles(ctx context.Context) (int, error)
+}
+
+func (c *commands) run(ctx context.Context, args []string) error {
+	switch args[0] {
+	case "import":
+		return c.runImport(ctx, args[1:])
+	case "scrub":
+		return c.runScrub(ctx, args[1:])
+	case "prewarm":
+		return c.runPrewarm(ctx, args[1:])
+	case "migrate-storage":
+		return c.runMigrateStorage(ctx, args[1:])
+	case "resize-covers":
+		return c.runResizeCovers(ctx)
+	case "dead-letters":
+		return c.runDeadLetters(ctx, args[1:])
+	case "adopt-legacy-audio":
+		return c.runAdoptLegacyAudio(ctx)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// runImport imports a library laid out as Artist/Album/NN - Title.ext:
+//
+//	import -license <id> [-genre <id>] [-state <file>] <dir>
+func (c *commands) runImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	licenseID := flags.String("license", "", "license ID of the imported albums and tracks")
+	genreID := flags.String("genre", "", "genre ID of the files without a genre tag")
+	statePath := flags.String("state", importStateFile, "path to the state file used to resume an interrupted import")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import -license <id> [-genre <id>] [-state <file>] <dir>")
+	}
+
+	var (
+		params entity.TrackImportParams
+		err    error
+	)
+	if params.LicenseID, err = uuid.Parse(*licenseID); err != nil {
+		return fmt.Errorf("failed to parse license ID: %w", err)
+	}
+	if *genreID != "" {
+		if params.GenreID, err = uuid.Parse(*genreID); err != nil {
+			return fmt.Errorf("failed to parse genre ID: %w", err)
+		}
+	}
+
+	if session.Claims().AccessLvl != entity.Admin {
+		return errors.New("import requires an admin session, log in with the interactive CLI first")
+	}
+
+	state, err := library.LoadState(*statePath)
+	if err != nil {
+		return fmt.Errorf("failed to load import state: %w", err)
+	}
+
+	return c.importer.Import(ctx, flags.Arg(0), params, state)
+}
+
+// runScrub verifies the stored audio blobs against their hashes:
+//
+//	scrub [-quarantine]
+func (c *commands) runScrub(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("scrub", flag.ContinueOnError)
+	quarantine := flags.Bool("quarantine", false, "move corrupted and orphaned blobs aside and unlink broken files from their tracks")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := c.scrubber.ScrubAudio(ctx, session.Claims(), *quarantine)
+	if report != nil {
+		output.PrintBlobScrubReport(report)
+	}
+
+	return err
+}
+
+// runPrewarm copies the audio of the most streamed tracks to the local audio cache:
+//
+//	prewarm [-tracks <n>]
+func (c *commands) runPrewarm(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("prewarm", flag.ContinueOnError)
+	tracks := flags.Int("tracks", 100, "number of the most streamed tracks to cache")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if c.prewarmer == nil {
+		return errors.New("audio cache is not configured, set AUDIO_CACHE_DIR and AUDIO_CACHE_SIZE_MB")
+	}
+
+	stats, err := c.prewarmer.PrewarmAudioCache(ctx, session.Claims(), *tracks)
+	if stats != nil {
+		output.PrintAudioCacheStats(stats)
+	}
+
+	return err
+}
+
+// runMigrateStorage copies audio, waveforms, covers and avatars between storage backends.
+// Running it again resumes an interrupted migration:
+//
+//	migrate-storage -from fs -to minio [-concurrency <n>] [-dry-run]
+func (c *commands) runMigrateStorage(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("migrate-storage", flag.ContinueOnError)
+	from := flags.String("from", "", "storage type to copy from: fs or minio")
+	to := flags.String("to", "", "storage type to copy to: fs or minio")
+	concurrency := flags.Int("concurrency", 4, "number of items copied at once")
+	dryRun := flags.Bool("dry-run", false, "only report what would be copied")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" || *to == "" || *from == *to {
+		return errors.New("usage: migrate-storage -from <fs|minio> -to <fs|minio> [-concurrency <n>] [-dry-run]")
+	}
+
+	migrator, err := c.migrations(ctx, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	report, err := migrator.MigrateStorage(ctx, session.Claims(), &entity.StorageMigrationOptions{
+		Concurrency: *concurrency,
+		DryRun:      *dryRun,
+	})
+	if report != nil {
+		output.PrintStorageMigrationReport(report)
+	}
+
+	return err
+}
+
+// runResizeCovers stores the covers and avatars uploaded before resizing in every size.
+// Running it again resumes an interrupted backfill:
+//
+//	resize-covers
+func (c *commands) runResizeCovers(ctx context.Context) error {
+	report, err := c.resizer.ResizeCovers(ctx, session.Claims())
+	if report != nil {
+		output.PrintCoverResizeReport(report)
+	}
+
+	return err
+}
+
+// runAdoptLegacyAudio moves the audio files stored per track before the blobs
+// into blobs. Files left behind are moved when they are first streamed:
+//
+//	adopt-legacy-audio
+func (c *commands) runAdoptLegacyAudio(ctx context.Context) error {
+	if session.Claims().AccessLvl != entity.Admin {
+		return errors.New("adopt-legacy-audio requires an admin session, log in with the interactive CLI first")
+	}
+
+	adopted, err := c.legacyAudio.AdoptLegacyFiles(ctx)
+	fmt.Printf("Legacy audio files moved into blobs: %d\n", adopted)
+
+	return err
+}
+
+// runDeadLetters lists the listening events the stats worker gave up on, or publishes them again:
+//
+//	dead-letters list [-limit <n>]
+//	dead-letters replay [<partition>:<offset>...]
+func (c *commands) runDeadLetters(ctx context.Context, args []string) error {
+	const usage = "usage: dead-letters list [-limit <n>] | dead-letters replay [<partition>:<offset>...]"
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	if c.deadLetters == nil {
+		return errors.New("dead letters are kept only by the kafka event bus, set EVENT_BUS_TYPE=kafka")
+	}
+
+	switch args[0] {
+	case "list":
+		flags := flag.NewFlagSet("dead-letters list", flag.ContinueOnError)
+		limit := flags.Int("limit", 100, "maximum number of dead letters to show, all if 0")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		letters, err := c.deadLetters.ListDeadLetters(ctx, session.Claims(), *limit)
+		if err != nil {
+			return err
+		}
+		output.PrintDeadLetters(letters)
+
+		return nil
+	case "replay":
+		report, err := c.deadLetters.ReplayDeadLetters(ctx, session.Claims(), args[1:])
+		if report != nil {
+			output.PrintDeadLetterReplayReport(report)
+		}
+
+		return err
+	default:
+		return errors.New(usage)
+	}
+}
@@ -73,9 +73,65 @@ type CookieConfig struct {
 	AccessTTL  time.Duration `env:"COOKIE_ACCESS_TTL"`
 }
 
+type StreamTokenConfig struct {
+	SecretKey []byte        `env:"STREAM_TOKEN_SECRET_KEY"`
+	TTL       time.Duration `env:"STREAM_TOKEN_TTL"`
+	Required  bool          `env:"STREAM_TOKEN_REQUIRED"`
+	BaseURL   string        `env:"STREAM_BASE_URL"`
+}
+
 type AudioStorageConfig struct {
 	Type     string `env:"AUDIO_STORAGE_TYPE"`
 	BasePath string `env:"AUDIO_STORAGE_BASE_PATH"`
+	// the local cache is disabled unless both the dir and the size are set
+	CacheDir           string        `env:"AUDIO_CACHE_DIR"`
+	CacheSizeMB        int64         `env:"AUDIO_CACHE_SIZE_MB"`
+	CacheEviction      string        `env:"AUDIO_CACHE_EVICTION"`
+	CacheStatsInterval time.Duration `env:"AUDIO_CACHE_STATS_INTERVAL"`
+	// picks up the blobs cached by the other processes, e.g. the CLI prewarm
+	CacheResyncInterval time.Duration `env:"AUDIO_CACHE_RESYNC_INTERVAL" env-default:"5m"`
+}
+
+type CoverStorageConfig struct {
+	Type     string `env:"COVER_STORAGE_TYPE"`
+	BasePath string `env:"COVER_STORAGE_BASE_PATH"`
+}
+
+type AudioConverterConfig struct {
+	FFmpegPath string `env:"FFMPEG_PATH"`
+}
+
+// EventBusConfig selects how listening events reach the stats processing:
+// "memory" processes them in the API process, "kafka" leaves them to cmd/stats-worker.
+type EventBusConfig struct {
+	Type      string `env:"EVENT_BUS_TYPE"`
+	QueueSize int    `env:"EVENT_BUS_QUEUE_SIZE"`
+	Workers   int    `env:"STATS_WORKERS"`
+	// a repeated event ID is recognized within the window
+	DedupWindow  time.Duration `env:"STATS_DEDUP_WINDOW"`
+	KafkaBrokers []string      `env:"KAFKA_BROKERS" env-separator:","`
+	KafkaGroupID string        `env:"KAFKA_GROUP_ID"`
+	// failed events are retried with exponential backoff, then moved to the dead-letter topic
+	KafkaMaxRetries      int           `env:"KAFKA_MAX_RETRIES" env-default:"3"`
+	KafkaRetryBackoff    time.Duration `env:"KAFKA_RETRY_BACKOFF"`
+	KafkaRetryMaxDelay   time.Duration `env:"KAFKA_RETRY_MAX_DELAY"`
+	KafkaDeadLetterTopic string        `env:"KAFKA_DEAD_LETTER_TOPIC"`
+}
+
+type ChartsConfig struct {
+	// how often the stats processing snapshots the last completed week and deletes old hourly plays
+	MaintenanceInterval time.Duration `env:"CHART_MAINTENANCE_INTERVAL" env-default:"1h"`
+}
+
+type TrackImportConfig struct {
+	MaxFileSizeMB int64 `env:"TRACK_IMPORT_MAX_FILE_SIZE_MB" env-default:"30"`
+}
+
+// BackgroundJobsConfig configures the queue of the work done after a request,
+// e.g. the analysis of an uploaded track.
+type BackgroundJobsConfig struct {
+	Workers   int `env:"JOBS_WORKERS" env-default:"2"`
+	QueueSize int `env:"JOBS_QUEUE_SIZE" env-default:"100"`
 }
 
 type LoggerConfig struct {
@@ -94,7 +150,14 @@ type Config struct {
 	RedisAccessMetaCache RedisAccessMetaConfig
 	LocalAccessMetaCache LocalAccessMetaConfig
 	Cookie               CookieConfig
+	StreamToken          StreamTokenConfig
 	AudioStorage         AudioStorageConfig
+	CoverStorage         CoverStorageConfig
+	AudioConverter       AudioConverterConfig
+	EventBus             EventBusConfig
+	Charts               ChartsConfig
+	TrackImport          TrackImportConfig
+	BackgroundJobs       BackgroundJobsConfig
 	Logger               LoggerConfig
 }
 
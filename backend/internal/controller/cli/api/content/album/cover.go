@@ -56,7 +56,7 @@ func (c *AlbumCoverController) getCover(ctx context.Context) error {
 	scanner.Scan()
 	savePath := scanner.Text()
 
-	cover, err := c.albumCoverService.GetCover(ctx, albumID)
+	cover, err := c.albumCoverService.GetCover(ctx, albumID, entity.LargestCoverSize)
 	if err != nil {
 		return fmt.Errorf("failed to get cover: %w", err)
 	}
@@ -102,7 +102,7 @@ func (c *ArtistAvatarController) getAvatar(ctx context.Context) error {
 	}
 	defer file.Close()
 
-	cover, err := c.artistAvatarService.GetCover(ctx, artistID)
+	cover, err := c.artistAvatarService.GetCover(ctx, artistID, entity.LargestCoverSize)
 	if err != nil {
 		return fmt.Errorf("failed to get avatar: %w", err)
 	}
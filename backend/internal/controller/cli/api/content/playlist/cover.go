@@ -94,7 +94,7 @@ func (c *PlaylistCoverController) getCover(ctx context.Context) error {
 		return fmt.Errorf("failed to parse playlist ID: %w", err)
 	}
 
-	cover, err := c.playlistCoverService.GetCover(ctx, session.Claims(), playlistID)
+	cover, err := c.playlistCoverService.GetCover(ctx, session.Claims(), playlistID, entity.LargestCoverSize)
 	if err != nil {
 		return fmt.Errorf("failed to get playlist cover: %w", err)
 	}
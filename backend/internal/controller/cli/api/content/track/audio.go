@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 	"os"
 
 	"github.com/google/uuid"
@@ -63,19 +62,17 @@ func (c *TrackAudioController) uploadAudio(ctx context.Context) error {
 	}
 	defer file.Close()
 
-	data, err := io.ReadAll(file)
+	info, err := file.Stat()
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return fmt.Errorf("failed to stat file: %w", err)
 	}
 
-	chunk := &entity.AudioChunk{
+	audioFile := &entity.AudioFile{
 		TrackID: trackID,
-		Start:   0,
-		End:     int64(len(data)),
-		Data:    data,
+		Size:    info.Size(),
 	}
 
-	err = c.audioFileService.UploadAudioFile(ctx, session.Claims(), chunk)
+	err = c.audioFileService.UploadAudioFile(ctx, session.Claims(), audioFile, file)
 	if err != nil {
 		return fmt.Errorf("failed to upload audio: %w", err)
 	}
@@ -95,7 +92,7 @@ func (c *TrackAudioController) getAudio(ctx context.Context) error {
 		return fmt.Errorf("failed to parse track ID: %w", err)
 	}
 
-	fmt.Print("Enter path to save audio file (.mp3): ")
+	fmt.Print("Enter path to save audio file: ")
 	scanner.Scan()
 	filePath := scanner.Text()
 
@@ -105,21 +102,18 @@ func (c *TrackAudioController) getAudio(ctx context.Context) error {
 	}
 	defer file.Close()
 
-	chunk, err := c.audioFileService.GetAudioChunk(ctx, &entity.AudioChunk{
-		TrackID: trackID,
-		Start:   0,
-		End:     int64(math.MaxInt64),
-	})
+	audioFile, content, err := c.audioFileService.GetAudioFile(ctx, trackID, entity.QualityOriginal)
 	if err != nil {
 		return fmt.Errorf("failed to download audio: %w", err)
 	}
+	defer content.Close()
 
-	_, err = file.Write(chunk.Data)
+	_, err = io.Copy(file, content)
 	if err != nil {
 		return fmt.Errorf("failed to write audio to file: %w", err)
 	}
 
-	fmt.Println("Audio downloaded successfully")
+	fmt.Printf("Audio downloaded successfully (format: %s)\n", audioFile.Format)
 	return nil
 }
 
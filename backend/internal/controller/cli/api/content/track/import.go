@@ -0,0 +1,79 @@
+package track_cli_ctrl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/output"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/session"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	"github.com/hahaclassic/orpheon/backend/pkg/cmdrouter"
+)
+
+type TrackImportController struct {
+	importService track.TrackImportService
+}
+
+func NewTrackImportController(importService track.TrackImportService) *TrackImportController {
+	return &TrackImportController{
+		importService: importService,
+	}
+}
+
+func (c *TrackImportController) Menu() []cmdrouter.OptionHandler {
+	return []cmdrouter.OptionHandler{
+		{
+			Name: "Import From Tagged File",
+			Run:  c.importTrack,
+		},
+	}
+}
+
+func (c *TrackImportController) importTrack(ctx context.Context) error {
+	scanner := bufio.NewScanner(os.Stdin)
+
+	fmt.Print("Enter license ID: ")
+	scanner.Scan()
+	licenseID, err := uuid.Parse(scanner.Text())
+	if err != nil {
+		return fmt.Errorf("failed to parse license ID: %w", err)
+	}
+
+	params := &entity.TrackImportParams{LicenseID: licenseID}
+
+	fmt.Print("Enter fallback genre ID (empty to skip): ")
+	scanner.Scan()
+	if genreID := scanner.Text(); genreID != "" {
+		if params.GenreID, err = uuid.Parse(genreID); err != nil {
+			return fmt.Errorf("failed to parse genre ID: %w", err)
+		}
+	}
+
+	fmt.Print("Enter path to audio file: ")
+	scanner.Scan()
+	filePath := scanner.Text()
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+	params.Size = info.Size()
+
+	report, err := c.importService.ImportTrack(ctx, session.Claims(), params, file)
+	if err != nil {
+		return fmt.Errorf("failed to import track: %w", err)
+	}
+
+	output.PrintTrackImportReport(report)
+	return nil
+}
@@ -0,0 +1,61 @@
+package track_cli_ctrl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/output"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	"github.com/hahaclassic/orpheon/backend/pkg/cmdrouter"
+)
+
+const waveformWidth = 60 // points, one per terminal column
+
+type TrackWaveformController struct {
+	waveformService track.TrackWaveformService
+	segmentService  track.TrackSegmentService
+}
+
+func NewTrackWaveformController(waveformService track.TrackWaveformService,
+	segmentService track.TrackSegmentService) *TrackWaveformController {
+	return &TrackWaveformController{
+		waveformService: waveformService,
+		segmentService:  segmentService,
+	}
+}
+
+func (c *TrackWaveformController) Menu() []cmdrouter.OptionHandler {
+	return []cmdrouter.OptionHandler{
+		{
+			Name: "Show Waveform",
+			Run:  c.showWaveform,
+		},
+	}
+}
+
+func (c *TrackWaveformController) showWaveform(ctx context.Context) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	fmt.Print("Enter track ID: ")
+	scanner.Scan()
+
+	trackID, err := uuid.Parse(scanner.Text())
+	if err != nil {
+		return fmt.Errorf("failed to parse track ID: %w", err)
+	}
+
+	waveform, err := c.waveformService.GetWaveform(ctx, trackID, waveformWidth)
+	if err != nil {
+		return fmt.Errorf("failed to get waveform: %w", err)
+	}
+
+	segments, err := c.segmentService.GetSegments(ctx, trackID)
+	if err != nil {
+		return fmt.Errorf("failed to get segments: %w", err)
+	}
+
+	output.PrintWaveform(waveform, segments)
+	return nil
+}
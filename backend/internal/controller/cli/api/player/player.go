@@ -69,6 +69,10 @@ func (c *PlayerController) Menu() []cmdrouter.OptionHandler {
 			Name: "Listen Track",
 			Run:  c.listenTrack,
 		},
+		{
+			Name: "Normalization",
+			Run:  c.switchNormalization,
+		},
 	}
 }
 
@@ -184,3 +188,9 @@ func (c *PlayerController) seekTo(ctx context.Context) error {
 	time.Sleep(5 * time.Millisecond)
 	return nil
 }
+
+// switchNormalization cycles through off, track and album normalization.
+func (c *PlayerController) switchNormalization(ctx context.Context) error {
+	fmt.Println("Normalization:", c.player.SwitchNormalization())
+	return nil
+}
@@ -0,0 +1,218 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/session"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+)
+
+var audioExtensions = map[string]bool{
+	".mp3":  true,
+	".flac": true,
+	".ogg":  true,
+	".wav":  true,
+}
+
+var coverNames = []string{"cover.jpg", "cover.jpeg", "cover.png"}
+
+// "01 - Title", "01. Title" or "01 Title"
+var trackFileName = regexp.MustCompile(`^(\d+)\s*[-.]?\s+(.+)$`)
+
+// Entry is an audio file laid out as Artist/Album/NN - Title.ext.
+type Entry struct {
+	Path        string // relative to the library root, slash separated
+	Artist      string
+	Album       string
+	TrackNumber int // 0 if the file name has no number
+	Title       string
+	CoverPath   string // relative path of the album cover, empty if absent
+}
+
+// Scan walks the library root and returns its audio files in path order.
+// Files that are not nested exactly two directories deep are reported in skipped.
+func Scan(root string) (entries []*Entry, skipped []string, err error) {
+	covers := make(map[string]string)
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		parts := strings.Split(rel, "/")
+		name := strings.ToLower(parts[len(parts)-1])
+
+		if isCover(name) {
+			dir := filepath.ToSlash(filepath.Dir(rel))
+			if _, ok := covers[dir]; !ok {
+				covers[dir] = rel
+			}
+			return nil
+		}
+		if !audioExtensions[filepath.Ext(name)] {
+			return nil
+		}
+		if len(parts) != 3 {
+			skipped = append(skipped, rel)
+			return nil
+		}
+
+		entries = append(entries, parseEntry(rel, parts))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, entry := range entries {
+		entry.CoverPath = covers[filepath.ToSlash(filepath.Dir(entry.Path))]
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+
+	return entries, skipped, nil
+}
+
+func parseEntry(rel string, parts []string) *Entry {
+	entry := &Entry{
+		Path:   rel,
+		Artist: strings.TrimSpace(parts[0]),
+		Album:  strings.TrimSpace(parts[1]),
+	}
+
+	name := strings.TrimSuffix(parts[2], filepath.Ext(parts[2]))
+	entry.Title = strings.TrimSpace(name)
+
+	if match := trackFileName.FindStringSubmatch(name); match != nil {
+		entry.TrackNumber, _ = strconv.Atoi(match[1])
+		entry.Title = strings.TrimSpace(match[2])
+	}
+
+	return entry
+}
+
+func isCover(name string) bool {
+	for _, cover := range coverNames {
+		if name == cover {
+			return true
+		}
+	}
+	return false
+}
+
+type Importer struct {
+	importService track.TrackImportService
+}
+
+func NewImporter(importService track.TrackImportService) *Importer {
+	return &Importer{
+		importService: importService,
+	}
+}
+
+// Import imports every entry of the library that is not recorded as done
+// in the state, saving the state after each file so an interrupted run
+// can be resumed. Failed files are reported and retried on the next run.
+func (i *Importer) Import(ctx context.Context, root string, params entity.TrackImportParams, state *State) error {
+	entries, skipped, err := Scan(root)
+	if err != nil {
+		return fmt.Errorf("failed to scan library: %w", err)
+	}
+	for _, path := range skipped {
+		fmt.Printf("Skipped %s: expected Artist/Album/NN - Title layout\n", path)
+	}
+
+	var imported, failed int
+	for n, entry := range entries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		progress := fmt.Sprintf("[%d/%d] %s", n+1, len(entries), entry.Path)
+		if state.Done(entry.Path) {
+			continue
+		}
+
+		report, err := i.importEntry(ctx, root, params, entry)
+		if err != nil {
+			failed++
+			fmt.Printf("%s: %v\n", progress, err)
+			state.Fail(entry.Path, err)
+		} else {
+			imported++
+			fmt.Printf("%s: track %s\n", progress, status(report.Track.Created))
+			state.Complete(entry.Path, report)
+		}
+
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("failed to save import state: %w", err)
+		}
+	}
+
+	fmt.Printf("Imported %d, failed %d, already done %d of %d files\n",
+		imported, failed, len(entries)-imported-failed, len(entries))
+
+	if failed > 0 {
+		return fmt.Errorf("%d files failed to import, run the command again to retry", failed)
+	}
+	return nil
+}
+
+func (i *Importer) importEntry(ctx context.Context, root string, params entity.TrackImportParams,
+	entry *Entry) (*entity.TrackImportReport, error) {
+	params.Tags = &entity.AudioTags{
+		Title:       entry.Title,
+		Artist:      entry.Artist,
+		Album:       entry.Album,
+		TrackNumber: entry.TrackNumber,
+	}
+
+	if entry.CoverPath != "" {
+		cover, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(entry.CoverPath)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read cover: %w", err)
+		}
+		params.Tags.Cover = cover
+	}
+
+	file, err := os.Open(filepath.Join(root, filepath.FromSlash(entry.Path)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	params.Size = info.Size()
+
+	return i.importService.ImportTrack(ctx, session.Claims(), &params, file)
+}
+
+func status(created bool) string {
+	if created {
+		return "created"
+	}
+	return "matched"
+}
@@ -0,0 +1,96 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/session"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, paths ...string) {
+	for _, path := range paths {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(path), 0644))
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root,
+		"Artist/Album/02 - Second Song.flac",
+		"Artist/Album/01. First Song.mp3",
+		"Artist/Album/Cover.jpg",
+		"Artist/Album/notes.txt",
+		"Artist/Other Album/Untitled.ogg",
+		"Artist/loose.mp3",
+	)
+
+	entries, skipped, err := Scan(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Artist/loose.mp3"}, skipped)
+	assert.Equal(t, []*Entry{
+		{Path: "Artist/Album/01. First Song.mp3", Artist: "Artist", Album: "Album",
+			TrackNumber: 1, Title: "First Song", CoverPath: "Artist/Album/Cover.jpg"},
+		{Path: "Artist/Album/02 - Second Song.flac", Artist: "Artist", Album: "Album",
+			TrackNumber: 2, Title: "Second Song", CoverPath: "Artist/Album/Cover.jpg"},
+		{Path: "Artist/Other Album/Untitled.ogg", Artist: "Artist", Album: "Other Album",
+			Title: "Untitled"},
+	}, entries)
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.False(t, state.Done("a.mp3"))
+
+	state.Complete("a.mp3", &entity.TrackImportReport{Track: entity.ImportedObject{ID: uuid.New()}})
+	state.Fail("b.mp3", errors.New("import error"))
+	require.NoError(t, state.Save())
+
+	loaded, err := LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, loaded.Done("a.mp3"))
+	assert.False(t, loaded.Done("b.mp3"))
+	assert.Equal(t, "import error", loaded.Files["b.mp3"].Error)
+}
+
+func TestImportResumes(t *testing.T) {
+	_ = session.Instance()
+	root := t.TempDir()
+	writeFiles(t, root,
+		"Artist/Album/01 - Done.mp3",
+		"Artist/Album/02 - Failing.mp3",
+		"Artist/Album/03 - New.mp3",
+		"Artist/Album/cover.jpg",
+	)
+
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, err)
+	state.Complete("Artist/Album/01 - Done.mp3", &entity.TrackImportReport{})
+
+	service := mocks.NewTrackImportService(t)
+	service.On("ImportTrack", mock.Anything, mock.Anything, mock.MatchedBy(func(p *entity.TrackImportParams) bool {
+		return p.Tags.Title == "Failing"
+	}), mock.Anything).Return(nil, errors.New("import error")).Once()
+	service.On("ImportTrack", mock.Anything, mock.Anything, mock.MatchedBy(func(p *entity.TrackImportParams) bool {
+		return p.Tags.Title == "New" && p.Tags.TrackNumber == 3 && p.Tags.Artist == "Artist" &&
+			p.Tags.Album == "Album" && len(p.Tags.Cover) > 0 && p.Size > 0
+	}), mock.Anything).Return(&entity.TrackImportReport{Track: entity.ImportedObject{ID: uuid.New(), Created: true}}, nil).Once()
+
+	err = NewImporter(service).Import(context.Background(), root, entity.TrackImportParams{LicenseID: uuid.New()}, state)
+	assert.Error(t, err)
+	assert.True(t, state.Done("Artist/Album/03 - New.mp3"))
+	assert.False(t, state.Done("Artist/Album/02 - Failing.mp3"))
+}
@@ -0,0 +1,95 @@
+package library
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+// FileState is the import result of a single library file.
+type FileState struct {
+	TrackID    uuid.UUID `json:"track_id,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// State records imported files in a local JSON file.
+type State struct {
+	path  string
+	Files map[string]*FileState `json:"files"`
+}
+
+// LoadState reads the state file, a missing file yields an empty state.
+func LoadState(path string) (*State, error) {
+	state := &State{
+		path:  path,
+		Files: make(map[string]*FileState),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]*FileState)
+	}
+
+	return state, nil
+}
+
+func (s *State) Done(path string) bool {
+	file, ok := s.Files[path]
+	return ok && file.Error == ""
+}
+
+func (s *State) Complete(path string, report *entity.TrackImportReport) {
+	s.Files[path] = &FileState{
+		TrackID:    report.Track.ID,
+		ImportedAt: time.Now(),
+	}
+}
+
+func (s *State) Fail(path string, err error) {
+	s.Files[path] = &FileState{
+		Error:      err.Error(),
+		ImportedAt: time.Now(),
+	}
+}
+
+// Save writes the state through a temporary file, so an interrupted
+// write never leaves a truncated state behind.
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".import-state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -211,3 +211,163 @@ func PrintStatsGraph(segments []*entity.Segment) {
 	fmt.Println("└" + strings.Repeat("─", graphWidth) + "┘")
 	fmt.Printf("Max streams: %d\n", maxStreams)
 }
+
+func PrintTrackImportReport(report *entity.TrackImportReport) {
+	status := func(created bool) string {
+		if created {
+			return "created"
+		}
+		return "matched"
+	}
+
+	tableData := [][]any{
+		{"Track", report.Track.ID, report.Track.Name, status(report.Track.Created)},
+		{"Album", report.Album.ID, report.Album.Name, status(report.Album.Created)},
+		{"Artist", report.Artist.ID, report.Artist.Name, status(report.Artist.Created)},
+		{"Genre", report.Genre.ID, report.Genre.Name, status(report.Genre.Created)},
+	}
+
+	tableoutput.PrintTable(table.StyleColoredDark,
+		[]string{"Object", "ID", "Name", "Status"}, tableData)
+	fmt.Println("Cover uploaded:", report.CoverUploaded)
+	if report.Duplicate {
+		fmt.Println("The album already has this track, the audio was not uploaded")
+	}
+}
+
+func PrintBlobScrubReport(report *entity.BlobScrubReport) {
+	var tableData [][]any
+	for _, group := range []struct {
+		status string
+		hashes []string
+	}{
+		{"corrupted", report.Corrupted},
+		{"missing", report.Missing},
+		{"orphaned", report.Orphaned},
+	} {
+		for _, hash := range group.hashes {
+			tableData = append(tableData, []any{hash, group.status})
+		}
+	}
+
+	fmt.Println("Blobs checked:", report.Checked)
+	if len(tableData) > 0 {
+		tableoutput.PrintTable(table.StyleColoredDark, []string{"Hash", "Status"}, tableData)
+	}
+	fmt.Println("Blobs quarantined:", len(report.Quarantined))
+	for _, trackID := range report.AffectedTracks {
+		fmt.Println("Track needs a new upload:", trackID)
+	}
+}
+
+func PrintAudioCacheStats(stats *entity.AudioCacheStats) {
+	fmt.Println("Blobs cached:", stats.Fills)
+	fmt.Printf("Cache usage: %d blobs, %d/%d MB\n", stats.Blobs, stats.Used>>20, stats.Capacity>>20)
+}
+
+func PrintStorageMigrationReport(report *entity.StorageMigrationReport) {
+	copied := "Copied"
+	if report.DryRun {
+		copied = "To copy"
+	}
+
+	var tableData [][]any
+	for _, kind := range report.Kinds {
+		if kind.Unsupported {
+			tableData = append(tableData, []any{kind.Kind, "unsupported", "", "", ""})
+			continue
+		}
+		tableData = append(tableData, []any{kind.Kind, kind.Total, kind.Copied, kind.Skipped, kind.Failed})
+	}
+	tableoutput.PrintTable(table.StyleColoredDark, []string{"Kind", "Total", copied, "Skipped", "Failed"}, tableData)
+
+	for _, kind := range report.Kinds {
+		for _, e := range kind.Errors {
+			fmt.Printf("%s: %s\n", kind.Kind, e)
+		}
+	}
+}
+
+func PrintCoverResizeReport(report *entity.CoverResizeReport) {
+	var tableData [][]any
+	for _, kind := range report.Kinds {
+		tableData = append(tableData, []any{kind.Kind, kind.Total, kind.Resized, kind.Skipped, kind.Failed})
+	}
+	tableoutput.PrintTable(table.StyleColoredDark, []string{"Kind", "Total", "Resized", "Skipped", "Failed"}, tableData)
+
+	for _, kind := range report.Kinds {
+		for _, e := range kind.Errors {
+			fmt.Printf("%s: %s\n", kind.Kind, e)
+		}
+	}
+}
+
+// PrintWaveform рисует пики трека и под ними тепловую полосу прослушиваний по сегментам.
+func PrintWaveform(waveform *entity.Waveform, segments []*entity.Segment) {
+	const graphHeight = 8
+
+	heat := []rune(" ░▒▓█")
+	width := len(waveform.Peaks)
+
+	fmt.Println("\nWaveform:")
+	fmt.Println("┌" + strings.Repeat("─", width) + "┐")
+	for y := graphHeight; y > 0; y-- {
+		fmt.Print("│")
+		for _, peak := range waveform.Peaks {
+			if int(peak)*graphHeight >= y*255 {
+				fmt.Print("█")
+			} else {
+				fmt.Print(" ")
+			}
+		}
+		fmt.Println("│")
+	}
+	fmt.Println("├" + strings.Repeat("─", width) + "┤")
+
+	// Накладываем статистику сегментов: столбец попадает в сегмент по доле длительности трека
+	duration, maxStreams := 0, uint64(0)
+	for _, seg := range segments {
+		duration = max(duration, seg.Range.End)
+		maxStreams = max(maxStreams, seg.TotalStreams)
+	}
+
+	fmt.Print("│")
+	for x := range width {
+		level := 0
+		second := x * duration / width
+		for _, seg := range segments {
+			if maxStreams > 0 && second >= seg.Range.Start && second < seg.Range.End {
+				level = int(seg.TotalStreams * uint64(len(heat)-1) / maxStreams)
+			}
+		}
+		fmt.Print(string(heat[level]))
+	}
+	fmt.Println("│")
+	fmt.Println("└" + strings.Repeat("─", width) + "┘")
+	fmt.Printf("Max streams: %d\n", maxStreams)
+}
+
+func PrintDeadLetters(letters []*entity.DeadLetter) {
+	var tableData [][]any
+	for _, letter := range letters {
+		track := "invalid payload"
+		if letter.Event != nil {
+			track = letter.Event.TrackID.String()
+		}
+		replayed := "-"
+		if letter.ReplayedAt != nil {
+			replayed = letter.ReplayedAt.Format("2006-01-02 15:04:05")
+		}
+		tableData = append(tableData, []any{letter.ID(), track, letter.Attempts,
+			letter.FailedAt.Format("2006-01-02 15:04:05"), replayed, letter.Error})
+	}
+
+	tableoutput.PrintTable(table.StyleColoredDark,
+		[]string{"ID", "Track ID", "Attempts", "Failed At", "Replayed At", "Error"}, tableData)
+}
+
+func PrintDeadLetterReplayReport(report *entity.DeadLetterReplayReport) {
+	fmt.Println("Replayed:", report.Replayed)
+	fmt.Println("Skipped (invalid payload):", report.Skipped)
+	fmt.Println("Skipped (replayed before):", report.AlreadyReplayed)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
 	"github.com/faiface/beep/mp3"
 	"github.com/faiface/beep/speaker"
 	"github.com/hahaclassic/orpheon/backend/internal/controller/cli/output"
@@ -64,12 +65,35 @@ func (s *streamBuffer) Close() error {
 	return nil
 }
 
+// Normalization selects the loudness measurement tracks are leveled with.
+type Normalization int
+
+const (
+	NormalizationOff Normalization = iota
+	NormalizationTrack
+	NormalizationAlbum
+)
+
+func (n Normalization) String() string {
+	switch n {
+	case NormalizationTrack:
+		return "track"
+	case NormalizationAlbum:
+		return "album"
+	default:
+		return "off"
+	}
+}
+
 type Player struct {
 	Queue                []*entity.TrackMeta
 	Current              int
 	CurrentSecond        int
 	IsPlaying            bool
+	seekSecond           int
+	Normalization        Normalization
 	audioFileService     track.AudioFileService
+	seekService          track.TrackSeekService
 	streamer             beep.StreamSeekCloser
 	ctrl                 *beep.Ctrl
 	format               beep.Format
@@ -79,9 +103,10 @@ type Player struct {
 	progressTickerCancel context.CancelFunc
 }
 
-func NewPlayer(audioFileService track.AudioFileService) *Player {
+func NewPlayer(audioFileService track.AudioFileService, seekService track.TrackSeekService) *Player {
 	return &Player{
 		audioFileService: audioFileService,
+		seekService:      seekService,
 		Queue:            []*entity.TrackMeta{},
 	}
 }
@@ -95,7 +120,10 @@ func (c *Player) Play(ctx context.Context) {
 	track := c.Queue[c.Current]
 	c.done = make(chan struct{})
 	c.IsPlaying = true
-	c.CurrentSecond = 0
+	// a track starts from the beginning unless SeekTo requested a position
+	c.CurrentSecond = c.seekSecond
+	c.seekSecond = 0
+	startSecond := c.CurrentSecond
 	c.mu.Unlock()
 
 	output.PrintTrack(track)
@@ -109,10 +137,10 @@ func (c *Player) Play(ctx context.Context) {
 	sb := newStreamBuffer()
 	ready := make(chan struct{}, 1)
 
-	go c.streamAudio(ctx, track, sb, ready, int64(c.CurrentSecond))
+	go c.streamAudio(ctx, track, sb, ready, startSecond)
 	<-ready
 
-	if err := c.startPlayback(sb); err != nil {
+	if err := c.startPlayback(sb, track); err != nil {
 		log.Printf("playback error: %v", err)
 		return
 	}
@@ -120,10 +148,25 @@ func (c *Player) Play(ctx context.Context) {
 	go c.trackProgress(ctx, track)
 }
 
-func (c *Player) streamAudio(ctx context.Context, track *entity.TrackMeta, sb *streamBuffer, ready chan struct{}, startSecond int64) {
-	var offset int64 = startSecond * 44100 * 4 // 44.1kHz * 4 bytes per frame (estimate)
+func (c *Player) streamAudio(ctx context.Context, track *entity.TrackMeta, sb *streamBuffer, ready chan struct{}, startSecond int) {
 	var total int
 
+	quality := playbackQuality(track)
+	offset := c.seekOffset(ctx, track, quality, startSecond)
+
+	_, content, err := c.audioFileService.GetAudioFile(ctx, track.ID, quality)
+	if err != nil {
+		sb.Close()
+		return
+	}
+	defer content.Close()
+
+	if _, err := content.Seek(offset, io.SeekStart); err != nil {
+		sb.Close()
+		return
+	}
+
+	buf := make([]byte, chunkSize)
 	for {
 		select {
 		case <-ctx.Done():
@@ -132,21 +175,15 @@ func (c *Player) streamAudio(ctx context.Context, track *entity.TrackMeta, sb *s
 		default:
 		}
 
-		chunk := &entity.AudioChunk{
-			TrackID: track.ID,
-			Start:   offset,
-			End:     offset + chunkSize,
+		n, err := content.Read(buf)
+		if n > 0 {
+			sb.Write(buf[:n])
+			total += n
 		}
-
-		resp, err := c.audioFileService.GetAudioChunk(ctx, chunk)
-		if err != nil || len(resp.Data) == 0 {
+		if err != nil {
 			break
 		}
 
-		sb.Write(resp.Data)
-		offset += int64(len(resp.Data))
-		total += len(resp.Data)
-
 		if total >= initialBuffer {
 			select {
 			case ready <- struct{}{}:
@@ -157,7 +194,35 @@ func (c *Player) streamAudio(ctx context.Context, track *entity.TrackMeta, sb *s
 	sb.Close()
 }
 
-func (c *Player) startPlayback(sb *streamBuffer) error {
+// seekOffset resolves the second to the offset of its MP3 frame,
+// playback starts from the beginning if it can not be resolved.
+func (c *Player) seekOffset(ctx context.Context, track *entity.TrackMeta, quality entity.AudioQuality, second int) int64 {
+	if second <= 0 {
+		return 0
+	}
+
+	offset, err := c.seekService.ResolveOffset(ctx, track.ID, quality, time.Duration(second)*time.Second)
+	if err != nil {
+		log.Printf("seek error: %v", err)
+		c.mu.Lock()
+		c.CurrentSecond = 0
+		c.mu.Unlock()
+		return 0
+	}
+
+	return offset
+}
+
+// playbackQuality picks the original file when it can be decoded as MP3
+// and the best transcoded rendition otherwise.
+func playbackQuality(track *entity.TrackMeta) entity.AudioQuality {
+	if track.Format == "" || track.Format == entity.FormatMP3 {
+		return entity.QualityOriginal
+	}
+	return entity.QualityHigh
+}
+
+func (c *Player) startPlayback(sb *streamBuffer, track *entity.TrackMeta) error {
 	streamer, format, err := mp3.Decode(sb)
 	if err != nil {
 		return fmt.Errorf("decode error: %w", err)
@@ -173,9 +238,19 @@ func (c *Player) startPlayback(sb *streamBuffer) error {
 	c.streamer = streamer
 	c.ctrl = ctrl
 	c.format = format
+	loudness := c.loudness(track)
 	c.mu.Unlock()
 
-	speaker.Play(beep.Seq(ctrl, beep.Callback(func() {
+	var leveled beep.Streamer = ctrl
+	if loudness != nil {
+		leveled = &effects.Volume{
+			Streamer: ctrl,
+			Base:     10,
+			Volume:   loudness.Gain() / 20, // dB to a power of 10 of the amplitude
+		}
+	}
+
+	speaker.Play(beep.Seq(leveled, beep.Callback(func() {
 		c.mu.Lock()
 		c.IsPlaying = false
 		c.mu.Unlock()
@@ -185,6 +260,31 @@ func (c *Player) startPlayback(sb *streamBuffer) error {
 	return nil
 }
 
+// loudness returns the measurement the track is leveled with, album mode falls
+// back to the track loudness until the album is analyzed. Must be called with c.mu held.
+func (c *Player) loudness(track *entity.TrackMeta) *entity.Loudness {
+	switch c.Normalization {
+	case NormalizationAlbum:
+		if track.AlbumLoudness != nil {
+			return track.AlbumLoudness
+		}
+		return track.Loudness
+	case NormalizationTrack:
+		return track.Loudness
+	default:
+		return nil
+	}
+}
+
+// SwitchNormalization cycles through off, track and album normalization and
+// returns the new mode. It applies from the next started track.
+func (c *Player) SwitchNormalization() Normalization {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Normalization = (c.Normalization + 1) % (NormalizationAlbum + 1)
+	return c.Normalization
+}
+
 func (c *Player) trackProgress(ctx context.Context, track *entity.TrackMeta) {
 	tickerCtx, cancel := context.WithCancel(ctx)
 	c.mu.Lock()
@@ -299,7 +399,7 @@ func (c *Player) SeekTo(second int) {
 		c.mu.Unlock()
 		return
 	}
-	c.CurrentSecond = second
+	c.seekSecond = second
 	c.mu.Unlock()
 
 	c.Stop()
@@ -3,13 +3,13 @@ package album_ctrl
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/serve"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/album"
 	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
@@ -37,9 +37,12 @@ func (c *AlbumCoverController) UploadCover(ctx *gin.Context) {
 	}
 
 	if err := c.service.UploadCover(ctx.Request.Context(), claims, cover); err != nil {
-		if errors.Is(err, commonerr.ErrForbidden) {
+		switch {
+		case errors.Is(err, commonerr.ErrForbidden):
 			ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		} else {
+		case errors.Is(err, commonerr.ErrInvalidImage):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload cover"})
 		}
 		return
@@ -55,17 +58,23 @@ func (c *AlbumCoverController) GetCover(ctx *gin.Context) {
 		return
 	}
 
-	cover, err := c.service.GetCover(ctx.Request.Context(), albumID)
+	size, err := entity.ParseCoverSize(ctx.Query("size"))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cover size"})
 		return
 	}
 
-	ctx.Header("Content-Type", "image/jpeg")
-	ctx.Header("Content-Length", fmt.Sprintf("%d", len(cover.Data)))
-	//ctx.Header("Cache-Control", "public, max-age=31536000") // Cache for 1 year
+	cover, err := c.service.GetCover(ctx.Request.Context(), albumID, size)
+	if err != nil {
+		if errors.Is(err, commonerr.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Cover not found"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
 
-	ctx.Data(http.StatusOK, "image/jpeg", cover.Data)
+	serve.Bytes(ctx, cover.ContentType, cover.ETag, cover.ModTime, cover.Data)
 }
 
 func (c *AlbumCoverController) DeleteCover(ctx *gin.Context) {
@@ -108,8 +117,8 @@ func (AlbumCoverController) parseAlbumCover(ctx *gin.Context) (*entity.Cover, er
 	}
 
 	contentType := file.Header.Get("Content-Type")
-	if contentType != "image/jpeg" && contentType != "image/png" {
-		return nil, errors.New("only JPEG and PNG files are allowed")
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return nil, errors.New("only JPEG, PNG and GIF files are allowed")
 	}
 
 	open, err := file.Open()
@@ -3,13 +3,13 @@ package artist_ctrl
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/serve"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/artist"
 	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
@@ -37,9 +37,12 @@ func (c *ArtistAvatarController) UploadAvatar(ctx *gin.Context) {
 	}
 
 	if err := c.service.UploadCover(ctx.Request.Context(), claims, cover); err != nil {
-		if errors.Is(err, commonerr.ErrForbidden) {
+		switch {
+		case errors.Is(err, commonerr.ErrForbidden):
 			ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		} else {
+		case errors.Is(err, commonerr.ErrInvalidImage):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload avatar"})
 		}
 		return
@@ -55,17 +58,23 @@ func (c *ArtistAvatarController) GetAvatar(ctx *gin.Context) {
 		return
 	}
 
-	avatar, err := c.service.GetCover(ctx.Request.Context(), artistID)
+	size, err := entity.ParseCoverSize(ctx.Query("size"))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid avatar size"})
 		return
 	}
 
-	ctx.Header("Content-Type", "image/jpeg") // or "image/png"
-	ctx.Header("Content-Length", fmt.Sprintf("%d", len(avatar.Data)))
-	//ctx.Header("Cache-Control", "public, max-age=31536000") // Cache for 1 year
+	avatar, err := c.service.GetCover(ctx.Request.Context(), artistID, size)
+	if err != nil {
+		if errors.Is(err, commonerr.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Avatar not found"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
 
-	ctx.Data(http.StatusOK, "image/jpeg", avatar.Data)
+	serve.Bytes(ctx, avatar.ContentType, avatar.ETag, avatar.ModTime, avatar.Data)
 }
 
 // DeleteAvatar deletes the artist's avatar
@@ -109,8 +118,8 @@ func (ArtistAvatarController) parseArtistAvatar(ctx *gin.Context) (*entity.Cover
 	}
 
 	contentType := file.Header.Get("Content-Type")
-	if contentType != "image/jpeg" && contentType != "image/png" {
-		return nil, errors.New("only JPEG and PNG files are allowed")
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return nil, errors.New("only JPEG, PNG and GIF files are allowed")
 	}
 
 	open, err := file.Open()
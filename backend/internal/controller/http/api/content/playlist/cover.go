@@ -3,13 +3,13 @@ package playlist_ctrl
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/serve"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/playlist"
 	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
@@ -39,7 +39,11 @@ func (c *PlaylistCoverController) UploadCover(ctx *gin.Context) {
 	}
 
 	if err := c.service.UploadCover(ctx.Request.Context(), claims, cover); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, commonerr.ErrInvalidImage) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -55,21 +59,25 @@ func (c *PlaylistCoverController) GetCover(ctx *gin.Context) {
 		return
 	}
 
-	cover, err := c.service.GetCover(ctx.Request.Context(), claims, playlistID)
+	size, err := entity.ParseCoverSize(ctx.Query("size"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cover size"})
+		return
+	}
+
+	cover, err := c.service.GetCover(ctx.Request.Context(), claims, playlistID, size)
 	if err != nil {
 		if errors.Is(err, commonerr.ErrForbidden) {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": "Failed to get cover"})
+		} else if errors.Is(err, commonerr.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Cover not found"})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
 		return
 	}
 
-	ctx.Header("Content-Type", "image/jpeg")
-	ctx.Header("Content-Length", fmt.Sprintf("%d", len(cover.Data)))
-	//ctx.Header("Cache-Control", "public, max-age=31536000") // Cache for 1 year
-
-	ctx.Data(http.StatusOK, "image/jpeg", cover.Data)
+	serve.Bytes(ctx, cover.ContentType, cover.ETag, cover.ModTime, cover.Data)
 }
 
 func (c *PlaylistCoverController) DeleteCover(ctx *gin.Context) {
@@ -108,8 +116,8 @@ func (PlaylistCoverController) parsePlaylistCover(ctx *gin.Context) (*entity.Cov
 	}
 
 	contentType := file.Header.Get("Content-Type")
-	if contentType != "image/jpeg" && contentType != "image/png" {
-		return nil, errors.New("only JPEG and PNG files are allowed")
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return nil, errors.New("only JPEG, PNG and GIF files are allowed")
 	}
 
 	open, err := file.Open()
@@ -0,0 +1,100 @@
+package stream_ctrl
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/dto"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/middleware"
+	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	stream_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/stream"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/stream"
+)
+
+const urlSuffix = "/url"
+
+// StreamController mints signed URLs for media GETs. Every media route gets
+// a sibling <route>/url returning the signed URL of the route itself.
+type StreamController struct {
+	service        stream.StreamTokenService
+	baseURL        string
+	authMiddleware gin.HandlerFunc
+}
+
+// NewStreamController creates the controller. The baseURL, e.g. of a CDN, is prepended
+// to the signed paths; with an empty baseURL the URLs are relative to the API host.
+func NewStreamController(service stream.StreamTokenService, baseURL string, authMiddleware gin.HandlerFunc) *StreamController {
+	return &StreamController{
+		service:        service,
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (c *StreamController) RegisterRoutes(router *gin.RouterGroup) {
+	protected := router.Group("")
+	protected.Use(c.authMiddleware)
+	{
+		protected.GET("/tracks/:id/audio"+urlSuffix, c.GetAudioURL)
+		protected.GET("/albums/:id/cover"+urlSuffix, c.imageURL(entity.StreamAlbumCover))
+		protected.GET("/playlists/:id/cover"+urlSuffix, c.imageURL(entity.StreamPlaylistCover))
+		protected.GET("/artists/:id/avatar"+urlSuffix, c.imageURL(entity.StreamArtistAvatar))
+	}
+}
+
+// GetAudioURL signs /tracks/:id/audio for the requested quality. Without a quality
+// the token is valid for every quality and for the HLS playlists of the track.
+func (c *StreamController) GetAudioURL(ctx *gin.Context) {
+	var (
+		quality entity.AudioQuality
+		err     error
+	)
+	if q := ctx.Query("quality"); q != "" {
+		if quality, err = entity.ParseAudioQuality(q); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quality"})
+			return
+		}
+	}
+
+	c.issue(ctx, entity.StreamAudio, quality)
+}
+
+func (c *StreamController) imageURL(resource entity.StreamResource) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		c.issue(ctx, resource, "")
+	}
+}
+
+func (c *StreamController) issue(ctx *gin.Context, resource entity.StreamResource, quality entity.AudioQuality) {
+	resourceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	token, err := c.service.IssueToken(ctx.Request.Context(), ctxclaims.GetClaims(ctx), resource, resourceID, quality)
+	if errors.Is(err, stream_service.ErrLoginRequired) {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := url.Values{middleware.StreamTokenParam: {token.Token}}
+	if quality != "" {
+		query.Set("quality", string(quality))
+	}
+
+	ctx.JSON(http.StatusOK, dto.StreamURL{
+		URL:       c.baseURL + strings.TrimSuffix(ctx.Request.URL.Path, urlSuffix) + "?" + query.Encode(),
+		Token:     token.Token,
+		ExpiresAt: token.ExpiresAt,
+	})
+}
@@ -1,30 +1,39 @@
 package track_ctrl
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"io"
-	"math"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/serve"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/audio"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/seek"
 	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 )
 
 type TrackAudioController struct {
-	service usecase.AudioFileService
+	service     usecase.AudioFileService
+	seekService usecase.TrackSeekService
 }
 
-func NewTrackAudioController(service usecase.AudioFileService) *TrackAudioController {
-	return &TrackAudioController{service: service}
+func NewTrackAudioController(service usecase.AudioFileService, seekService usecase.TrackSeekService) *TrackAudioController {
+	return &TrackAudioController{
+		service:     service,
+		seekService: seekService,
+	}
 }
 
+// GetAudioChunk serves the file with range support. With t=<seconds> the response
+// starts at the frame playing at that time, as for Range: bytes=<offset>-.
 func (c *TrackAudioController) GetAudioChunk(ctx *gin.Context) {
 	trackID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
@@ -32,61 +41,70 @@ func (c *TrackAudioController) GetAudioChunk(ctx *gin.Context) {
 		return
 	}
 
-	start, end, err := c.parseRangeHeader(ctx)
+	quality, err := entity.ParseAudioQuality(ctx.Query("quality"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quality"})
 		return
 	}
 
-	chunk := &entity.AudioChunk{
-		TrackID: trackID,
-		Start:   start,
-		End:     end,
+	if param := ctx.Query("t"); param != "" {
+		if !c.seek(ctx, trackID, quality, param) {
+			return
+		}
 	}
 
-	result, err := c.service.GetAudioChunk(ctx.Request.Context(), chunk)
+	file, content, err := c.service.GetAudioFile(ctx.Request.Context(), trackID, quality)
+	if errors.Is(err, commonerr.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close audio file", "error", err)
+		}
+	}()
 
-	ctx.Header("Content-Type", "audio/mpeg")
-	ctx.Header("Content-Length", fmt.Sprintf("%d", len(result.Data)))
-	ctx.Header("Content-Range", fmt.Sprintf("bytes %d-%d/%d", result.Start, result.End-1, result.End))
-	ctx.Header("Accept-Ranges", "bytes")
-
-	ctx.Data(http.StatusPartialContent, "audio/mpeg", result.Data)
+	serve.Content(ctx, file.Format.MIMEType(), file.ETag, file.ModTime, content)
 }
 
-func (c *TrackAudioController) parseRangeHeader(ctx *gin.Context) (int64, int64, error) {
-	rangeHeader := ctx.GetHeader("Range")
-	if rangeHeader == "" {
-		return 0, 0, errors.New("Range header is required")
+// seek turns the time into a range starting at the byte offset of its frame,
+// false means an error response has been written.
+func (c *TrackAudioController) seek(ctx *gin.Context, trackID uuid.UUID, quality entity.AudioQuality, param string) bool {
+	seconds, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time"})
+		return false
 	}
-
-	// Parse Range header (format: "bytes=start-end")
-	rangeStr := strings.TrimPrefix(rangeHeader, "bytes=")
-	parts := strings.Split(rangeStr, "-")
-	if len(parts) < 1 || len(parts) > 2 {
-		return 0, 0, errors.New("Invalid range format")
+	if ctx.GetHeader("Range") != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Either t or Range can be given"})
+		return false
 	}
 
-	start, err := strconv.ParseInt(parts[0], 10, 64)
-	if err != nil {
-		return 0, 0, errors.New("Invalid start range")
+	at := time.Duration(seconds * float64(time.Second))
+	offset, err := c.seekService.ResolveOffset(ctx.Request.Context(), trackID, quality, at)
+	switch {
+	case errors.Is(err, seek.ErrOutOfRange):
+		ctx.JSON(http.StatusRequestedRangeNotSatisfiable, gin.H{"error": err.Error()})
+		return false
+	case errors.Is(err, seek.ErrUnsupportedFormat):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	case errors.Is(err, commonerr.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return false
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
 	}
 
-	end := int64(0)
-	if len(parts) == 2 && parts[1] != "" {
-		end, err = strconv.ParseInt(parts[1], 10, 64)
-		if err != nil {
-			return 0, 0, errors.New("Invalid end range")
-		}
-	} else {
-		end = math.MaxInt64
-	}
+	ctx.Request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	ctx.Header("X-Seek-Offset", strconv.FormatInt(offset, 10))
 
-	return start, end, nil
+	return true
 }
 
 func (c *TrackAudioController) UploadAudioFile(ctx *gin.Context) {
@@ -96,13 +114,27 @@ func (c *TrackAudioController) UploadAudioFile(ctx *gin.Context) {
 		return
 	}
 
-	chunk, err := c.parseAudioChunk(ctx)
+	file, content, err := c.parseAudioFile(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close uploaded file", "error", err)
+		}
+	}()
 
-	if err := c.service.UploadAudioFile(ctx.Request.Context(), claims, chunk); err != nil {
+	err = c.service.UploadAudioFile(ctx.Request.Context(), claims, file, content)
+	if errors.Is(err, audio.ErrUnsupportedFormat) {
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Only MP3, FLAC, OGG/Vorbis and WAV files are allowed"})
+		return
+	}
+	if errors.Is(err, audio.ErrCorruptFile) {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -131,40 +163,28 @@ func (c *TrackAudioController) DeleteAudioFile(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Audio file deleted successfully"})
 }
 
-func (c *TrackAudioController) parseAudioChunk(ctx *gin.Context) (*entity.AudioChunk, error) {
+func (c *TrackAudioController) parseAudioFile(ctx *gin.Context) (*entity.AudioFile, multipart.File, error) {
 	trackID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		return nil, errors.New("invalid track ID")
+		return nil, nil, errors.New("invalid track ID")
 	}
 
 	file, err := ctx.FormFile("audio")
 	if err != nil {
-		return nil, errors.New("audio file not found")
+		return nil, nil, errors.New("audio file not found")
 	}
 
 	if file.Size > 30*1024*1024 { // 30MB limit
-		return nil, errors.New("file size exceeds 30MB limit")
-	}
-
-	contentType := file.Header.Get("Content-Type")
-	if contentType != "audio/mpeg" && contentType != "audio/mp3" {
-		return nil, errors.New("only MP3 files are allowed")
+		return nil, nil, errors.New("file size exceeds 30MB limit")
 	}
 
-	open, err := file.Open()
+	content, err := file.Open()
 	if err != nil {
-		return nil, errors.New("failed to open audio file")
-	}
-
-	buf := bytes.NewBuffer(nil)
-	if _, err := io.Copy(buf, open); err != nil {
-		return nil, errors.New("failed to copy audio file")
+		return nil, nil, errors.New("failed to open audio file")
 	}
 
-	return &entity.AudioChunk{
+	return &entity.AudioFile{
 		TrackID: trackID,
-		Start:   0,
-		End:     int64(len(buf.Bytes())),
-		Data:    buf.Bytes(),
-	}, nil
+		Size:    file.Size,
+	}, content, nil
 }
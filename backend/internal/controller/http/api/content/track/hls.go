@@ -0,0 +1,165 @@
+package track_ctrl
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/middleware"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/hls"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+const (
+	hlsContentType     = "application/vnd.apple.mpegurl"
+	hlsSegmentExt      = ".mp3"
+	hlsMediaPlaylist   = "playlist.m3u8"
+	mp3CodecAttributes = "mp4a.40.34"
+)
+
+type TrackHLSController struct {
+	service usecase.HLSService
+}
+
+func NewTrackHLSController(service usecase.HLSService) *TrackHLSController {
+	return &TrackHLSController{service: service}
+}
+
+func (c *TrackHLSController) GetMasterPlaylist(ctx *gin.Context) {
+	trackID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	renditions, err := c.service.GetRenditions(ctx.Request.Context(), trackID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	// a token bound to a quality only unlocks that rendition
+	if grant := middleware.StreamGrant(ctx); grant != nil && grant.Quality != "" {
+		renditions = slices.DeleteFunc(renditions, func(rendition *entity.HLSRendition) bool {
+			return rendition.Quality != grant.Quality
+		})
+		if len(renditions) == 0 {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Rendition not found"})
+			return
+		}
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	for _, rendition := range renditions {
+		fmt.Fprintf(b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,CODECS=\"%s\"\n", rendition.Bandwidth, mp3CodecAttributes)
+		fmt.Fprintf(b, "%s/%s%s\n", rendition.Quality, hlsMediaPlaylist, streamQuery(ctx))
+	}
+
+	ctx.Data(http.StatusOK, hlsContentType, []byte(b.String()))
+}
+
+func (c *TrackHLSController) GetMediaPlaylist(ctx *gin.Context) {
+	trackID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	quality, err := entity.ParseAudioQuality(ctx.Param("rendition"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Rendition not found"})
+		return
+	}
+
+	playlist, err := c.service.GetMediaPlaylist(ctx.Request.Context(), trackID, quality)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, hlsContentType, []byte(renderMediaPlaylist(playlist, streamQuery(ctx))))
+}
+
+func (c *TrackHLSController) GetSegment(ctx *gin.Context) {
+	trackID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	segmentParam := ctx.Param("segment")
+	segmentIdx, err := strconv.Atoi(strings.TrimSuffix(segmentParam, hlsSegmentExt))
+	if err != nil || !strings.HasSuffix(segmentParam, hlsSegmentExt) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid segment"})
+		return
+	}
+
+	quality, err := entity.ParseAudioQuality(ctx.Param("rendition"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Rendition not found"})
+		return
+	}
+
+	segment, content, err := c.service.GetSegment(ctx.Request.Context(), trackID, quality, segmentIdx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close audio file", "error", err)
+		}
+	}()
+
+	ctx.DataFromReader(http.StatusOK, segment.End-segment.Start, "audio/mpeg", content, nil)
+}
+
+func (TrackHLSController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, hls.ErrUnknownRendition), errors.Is(err, hls.ErrUnsupportedFormat),
+		errors.Is(err, commonerr.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Rendition not found"})
+	case errors.Is(err, hls.ErrSegmentOutOfBounds):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Segment not found"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func renderMediaPlaylist(playlist *entity.HLSMediaPlaylist, query string) string {
+	b := &strings.Builder{}
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	fmt.Fprintf(b, "#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(playlist.TargetDuration.Seconds())))
+	b.WriteString("#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-PLAYLIST-TYPE:VOD\n")
+
+	for _, segment := range playlist.Segments {
+		fmt.Fprintf(b, "#EXTINF:%.3f,\n", segment.Duration.Seconds())
+		fmt.Fprintf(b, "%d%s%s\n", segment.Idx, hlsSegmentExt, query)
+	}
+
+	b.WriteString("#EXT-X-ENDLIST\n")
+
+	return b.String()
+}
+
+// streamQuery carries the stream token of a signed playlist URL over to the URIs
+// listed in it, players resolve them relative to the playlist without its query.
+func streamQuery(ctx *gin.Context) string {
+	token := ctx.Query(middleware.StreamTokenParam)
+	if token == "" {
+		return ""
+	}
+
+	return "?" + url.Values{middleware.StreamTokenParam: {token}}.Encode()
+}
@@ -0,0 +1,64 @@
+package track_ctrl_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	track_ctrl "github.com/hahaclassic/orpheon/backend/internal/controller/http/api/content/track"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/middleware"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const token = "signed"
+
+type anonymous struct{}
+
+func (anonymous) ReadClaims(*gin.Context) *entity.Claims {
+	return nil
+}
+
+func requestMaster(t *testing.T, quality entity.AudioQuality) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	trackID := uuid.New()
+	tokens := mocks.NewStreamTokenService(t)
+	tokens.On("VerifyToken", mock.Anything, (*entity.Claims)(nil), token, entity.StreamAudio, trackID, entity.AudioQuality("")).
+		Return(&entity.StreamGrant{Resource: entity.StreamAudio, ResourceID: trackID, Quality: quality}, nil)
+	service := mocks.NewHLSService(t)
+	service.On("GetRenditions", mock.Anything, trackID).Return([]*entity.HLSRendition{
+		{Quality: entity.QualityLow, Bandwidth: 96_000},
+		{Quality: entity.QualityHigh, Bandwidth: 320_000},
+	}, nil)
+
+	router := gin.New()
+	router.GET("/tracks/:id/hls/master.m3u8",
+		middleware.NewStreamTokenMiddleware(tokens, true, anonymous{}).HLS(),
+		track_ctrl.NewTrackHLSController(service).GetMasterPlaylist)
+
+	req := httptest.NewRequest(http.MethodGet, "/tracks/"+trackID.String()+"/hls/master.m3u8?token="+token, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMasterPlaylistListsGrantedQuality(t *testing.T) {
+	w := requestMaster(t, entity.QualityLow)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "low/playlist.m3u8")
+	assert.NotContains(t, w.Body.String(), "high/playlist.m3u8")
+}
+
+func TestMasterPlaylistListsAllQualities(t *testing.T) {
+	w := requestMaster(t, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "low/playlist.m3u8")
+	assert.Contains(t, w.Body.String(), "high/playlist.m3u8")
+}
@@ -0,0 +1,96 @@
+package track_ctrl
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/audio"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/importer"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+type TrackImportController struct {
+	service     usecase.TrackImportService
+	maxFileSize int64
+}
+
+func NewTrackImportController(service usecase.TrackImportService, maxFileSize int64) *TrackImportController {
+	return &TrackImportController{
+		service:     service,
+		maxFileSize: maxFileSize,
+	}
+}
+
+// ImportTrack expects a multipart form with the tagged audio file in the "audio" field,
+// "license_id" and an optional "genre_id" used when the file has no genre tag.
+func (c *TrackImportController) ImportTrack(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	params := &entity.TrackImportParams{}
+
+	var err error
+	if params.LicenseID, err = uuid.Parse(ctx.PostForm("license_id")); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid license ID"})
+		return
+	}
+	if genreID := ctx.PostForm("genre_id"); genreID != "" {
+		if params.GenreID, err = uuid.Parse(genreID); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid genre ID"})
+			return
+		}
+	}
+
+	file, err := ctx.FormFile("audio")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "audio file not found"})
+		return
+	}
+	if file.Size > c.maxFileSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("file size exceeds %dMB limit", c.maxFileSize>>20)})
+		return
+	}
+	params.Size = file.Size
+
+	content, err := file.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to open audio file"})
+		return
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close uploaded file", "error", err)
+		}
+	}()
+
+	report, err := c.service.ImportTrack(ctx.Request.Context(), claims, params, content)
+	switch {
+	case errors.Is(err, commonerr.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	case errors.Is(err, commonerr.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Genre not found"})
+	case errors.Is(err, importer.ErrInvalidLicenseID), errors.Is(err, importer.ErrTagTooLarge):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, importer.ErrMissingTags), errors.Is(err, importer.ErrMissingGenre),
+		errors.Is(err, audio.ErrCorruptFile):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, audio.ErrUnsupportedFormat):
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Only MP3, FLAC, OGG/Vorbis and WAV files are allowed"})
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	case report.Duplicate:
+		ctx.JSON(http.StatusOK, report)
+	default:
+		ctx.JSON(http.StatusCreated, report)
+	}
+}
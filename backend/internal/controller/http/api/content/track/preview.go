@@ -0,0 +1,79 @@
+package track_ctrl
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/preview"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+type TrackPreviewController struct {
+	service usecase.TrackPreviewService
+}
+
+func NewTrackPreviewController(service usecase.TrackPreviewService) *TrackPreviewController {
+	return &TrackPreviewController{service: service}
+}
+
+// GetPreview streams the clip as MP3. Its position in the track is reported
+// in seconds by X-Preview-Start and X-Preview-Duration.
+func (c *TrackPreviewController) GetPreview(ctx *gin.Context) {
+	trackID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	var length time.Duration
+	if param := ctx.Query("length"); param != "" {
+		seconds, err := strconv.Atoi(param)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preview length"})
+			return
+		}
+		length = time.Duration(seconds) * time.Second
+	}
+
+	clip, content, err := c.service.GetPreview(ctx.Request.Context(), trackID, length)
+	if errors.Is(err, preview.ErrInvalidLength) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, commonerr.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return
+	}
+	if errors.Is(err, preview.ErrTooManyPreviews) {
+		ctx.Header("Retry-After", "1")
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close preview", "error", err)
+		}
+	}()
+
+	ctx.Header("Content-Type", "audio/mpeg")
+	ctx.Header("X-Preview-Start", strconv.FormatFloat(clip.Start.Seconds(), 'f', -1, 64))
+	ctx.Header("X-Preview-Duration", strconv.FormatFloat(clip.Duration.Seconds(), 'f', -1, 64))
+	// the hottest region moves slowly, a stale preview for an hour is fine
+	ctx.Header("Cache-Control", "public, max-age=3600")
+	ctx.Status(http.StatusOK)
+
+	if _, err := io.Copy(ctx.Writer, content); err != nil {
+		slog.Error("failed to stream preview", "track_id", trackID, "error", err)
+	}
+}
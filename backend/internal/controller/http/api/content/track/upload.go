@@ -0,0 +1,204 @@
+package track_ctrl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/dto"
+	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/audio"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/upload"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+const uploadOffsetHeader = "Upload-Offset"
+
+type TrackAudioUploadController struct {
+	service usecase.AudioUploadService
+}
+
+func NewTrackAudioUploadController(service usecase.AudioUploadService) *TrackAudioUploadController {
+	return &TrackAudioUploadController{service: service}
+}
+
+func (c *TrackAudioUploadController) CreateUpload(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	trackID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	var req dto.AudioUploadCreation
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.service.CreateUpload(ctx.Request.Context(), claims, trackID, req.Size)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", fmt.Sprintf("%s/%s", ctx.Request.URL.Path, res.ID))
+	ctx.Header(uploadOffsetHeader, strconv.FormatInt(res.Offset, 10))
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *TrackAudioUploadController) GetUpload(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	trackID, uploadID, err := c.parseIDs(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.service.GetUpload(ctx.Request.Context(), claims, trackID, uploadID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header(uploadOffsetHeader, strconv.FormatInt(res.Offset, 10))
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UploadChunk expects the raw bytes of the chunk in the body
+// and its position in the file in the Upload-Offset header.
+func (c *TrackAudioUploadController) UploadChunk(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	trackID, uploadID, err := c.parseIDs(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	offset, err := strconv.ParseInt(ctx.GetHeader(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Upload-Offset header"})
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, upload.MaxChunkSize+1))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read chunk"})
+		return
+	}
+	if len(data) > upload.MaxChunkSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Chunk size exceeds 64MB limit"})
+		return
+	}
+
+	res, err := c.service.UploadChunk(ctx.Request.Context(), claims, uploadID, &entity.AudioChunk{
+		TrackID: trackID,
+		Start:   offset,
+		End:     offset + int64(len(data)),
+		Data:    data,
+	})
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header(uploadOffsetHeader, strconv.FormatInt(res.Offset, 10))
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *TrackAudioUploadController) CompleteUpload(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	trackID, uploadID, err := c.parseIDs(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.CompleteUpload(ctx.Request.Context(), claims, trackID, uploadID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Audio file uploaded successfully"})
+}
+
+func (c *TrackAudioUploadController) AbortUpload(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	trackID, uploadID, err := c.parseIDs(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.AbortUpload(ctx.Request.Context(), claims, trackID, uploadID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Upload aborted successfully"})
+}
+
+func (TrackAudioUploadController) parseIDs(ctx *gin.Context) (trackID uuid.UUID, uploadID uuid.UUID, err error) {
+	trackID, err = uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("Invalid track ID")
+	}
+
+	uploadID, err = uuid.Parse(ctx.Param("upload_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("Invalid upload ID")
+	}
+
+	return trackID, uploadID, nil
+}
+
+func (TrackAudioUploadController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, commonerr.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	case errors.Is(err, commonerr.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+	case errors.Is(err, upload.ErrOffsetMismatch), errors.Is(err, upload.ErrUploadIncomplete):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, upload.ErrInvalidTrackID), errors.Is(err, upload.ErrInvalidSize),
+		errors.Is(err, upload.ErrInvalidChunkSize):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, audio.ErrUnsupportedFormat):
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Only MP3, FLAC, OGG/Vorbis and WAV files are allowed"})
+	case errors.Is(err, audio.ErrCorruptFile):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,71 @@
+package track_ctrl
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/dto"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/waveform"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+const defaultWaveformPoints = 1024
+
+type TrackWaveformController struct {
+	service usecase.TrackWaveformService
+}
+
+func NewTrackWaveformController(service usecase.TrackWaveformService) *TrackWaveformController {
+	return &TrackWaveformController{service: service}
+}
+
+// GetWaveform responds with JSON, or with one byte per point if format=binary is given.
+func (c *TrackWaveformController) GetWaveform(ctx *gin.Context) {
+	trackID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid track ID"})
+		return
+	}
+
+	points := defaultWaveformPoints
+	if param := ctx.Query("points"); param != "" {
+		if points, err = strconv.Atoi(param); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid number of points"})
+			return
+		}
+	}
+
+	res, err := c.service.GetWaveform(ctx.Request.Context(), trackID, points)
+	if errors.Is(err, waveform.ErrInvalidPoints) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, commonerr.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Waveform not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if ctx.Query("format") == "binary" {
+		ctx.Data(http.StatusOK, "application/octet-stream", res.Peaks)
+		return
+	}
+
+	peaks := make([]int, len(res.Peaks))
+	for i, peak := range res.Peaks {
+		peaks[i] = int(peak)
+	}
+
+	ctx.JSON(http.StatusOK, dto.Waveform{
+		TrackID: res.TrackID,
+		Points:  res.Points,
+		Peaks:   peaks,
+	})
+}
@@ -0,0 +1,111 @@
+package stats_ctrl
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/charts"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	stats "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+)
+
+type ChartController struct {
+	chartService stats.ChartService
+}
+
+func NewChartController(chartService stats.ChartService) *ChartController {
+	return &ChartController{chartService: chartService}
+}
+
+func (c *ChartController) RegisterRoutes(router *gin.RouterGroup) {
+	charts := router.Group("/charts")
+	{
+		charts.GET("/tracks", c.GetTrackChart)
+		charts.GET("/tracks/weekly", c.GetWeeklyChart)
+	}
+}
+
+func (c *ChartController) parseChartRequest(ctx *gin.Context) (*entity.ChartRequest, error) {
+	period, err := entity.ParseChartPeriod(ctx.DefaultQuery("period", string(entity.ChartWeek)))
+	if err != nil {
+		return nil, err
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "30"))
+	if err != nil {
+		return nil, errors.New("invalid limit parameter")
+	}
+
+	var genreID uuid.UUID
+	if genre := ctx.Query("genre"); genre != "" {
+		if genreID, err = uuid.Parse(genre); err != nil {
+			return nil, errors.New("invalid genre parameter")
+		}
+	}
+
+	return &entity.ChartRequest{
+		Period: period,
+		Limit:  limit,
+		Filters: entity.Filters{
+			GenreID: genreID,
+			Country: ctx.Query("country"),
+		},
+	}, nil
+}
+
+// GetTrackChart returns the most played tracks of the last day, week or month:
+//
+//	GET /charts/tracks?period=week&genre=:genre_id&country=:country&limit=30
+func (c *ChartController) GetTrackChart(ctx *gin.Context) {
+	req, err := c.parseChartRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := c.chartService.GetTrackChart(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, charts.ErrInvalidLimit) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get track chart"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// GetWeeklyChart returns the snapshot of the week containing the given date,
+// the last completed week by default:
+//
+//	GET /charts/tracks/weekly?week=2025-06-02
+func (c *ChartController) GetWeeklyChart(ctx *gin.Context) {
+	week := entity.WeekStart(time.Now()).AddDate(0, 0, -7)
+	if param := ctx.Query("week"); param != "" {
+		var err error
+		if week, err = time.Parse(time.DateOnly, param); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid week parameter"})
+			return
+		}
+	}
+
+	chart, err := c.chartService.GetWeeklyChart(ctx.Request.Context(), week)
+	if err != nil {
+		if errors.Is(err, commonerr.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Weekly chart not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get weekly chart"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"week":    chart.Week.Format(time.DateOnly),
+		"entries": chart.Entries,
+	})
+}
@@ -0,0 +1,136 @@
+package stats_ctrl
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/dto"
+	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/history"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/aggregator"
+	stats "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+)
+
+type HistoryController struct {
+	historyService    stats.ListeningHistoryService
+	contentAggregator aggregator.ContentAggregator
+}
+
+func NewHistoryController(historyService stats.ListeningHistoryService,
+	contentAggregator aggregator.ContentAggregator) *HistoryController {
+	return &HistoryController{
+		historyService:    historyService,
+		contentAggregator: contentAggregator,
+	}
+}
+
+// GetHistory returns the recently played tracks, the latest first:
+//
+//	GET /me/history?limit=30&offset=0
+func (c *HistoryController) GetHistory(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "30"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+		return
+	}
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset parameter"})
+		return
+	}
+
+	entries, err := c.historyService.GetHistory(ctx.Request.Context(), claims, limit, offset)
+	if err != nil {
+		if errors.Is(err, history.ErrInvalidPagination) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get listening history"})
+		return
+	}
+
+	trackIDs := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		trackIDs[i] = entry.TrackID
+	}
+
+	tracks, err := c.contentAggregator.GetTracksByIDs(ctx.Request.Context(), trackIDs...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get listening history"})
+		return
+	}
+
+	aggregated := make([]*entity.ListeningHistoryEntryAggregated, len(entries))
+	for i, entry := range entries {
+		aggregated[i] = &entity.ListeningHistoryEntryAggregated{
+			Track:      tracks[i],
+			ListenedAt: entry.ListenedAt,
+			Seconds:    entry.Seconds,
+			Context:    entry.Context,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, aggregated)
+}
+
+func (c *HistoryController) ClearHistory(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := c.historyService.ClearHistory(ctx.Request.Context(), claims); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear listening history"})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *HistoryController) GetHistoryPause(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	paused, err := c.historyService.IsHistoryPaused(ctx.Request.Context(), claims)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get listening history pause"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.HistoryPause{Paused: paused})
+}
+
+func (c *HistoryController) SetHistoryPause(ctx *gin.Context) {
+	claims := ctxclaims.GetClaims(ctx)
+	if claims == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var pause dto.HistoryPause
+	if err := ctx.ShouldBindJSON(&pause); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.historyService.SetHistoryPaused(ctx.Request.Context(), claims, pause.Paused); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to pause listening history"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pause)
+}
@@ -1,21 +1,25 @@
 package stats_ctrl
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/publisher"
 	stats "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
 )
 
+// StatController only publishes the listening events, the stats are updated by the consumer.
 type StatController struct {
-	statService stats.ListeningStatService
+	publisher stats.ListeningEventPublisher
 }
 
-func NewStatController(statService stats.ListeningStatService) *StatController {
-	return &StatController{statService: statService}
+func NewStatController(publisher stats.ListeningEventPublisher) *StatController {
+	return &StatController{publisher: publisher}
 }
 
 func (c *StatController) UpdateStat(ctx *gin.Context) {
@@ -38,10 +42,29 @@ func (c *StatController) UpdateStat(ctx *gin.Context) {
 	}
 
 	event.TrackID = trackID
+	if event.Context != nil {
+		if err := event.Context.Validate(); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	// clients sending no ID are not protected from double counting on retries,
+	// the ID still dedupes redeliveries of the event bus
+	if event.EventID == uuid.Nil {
+		event.EventID = uuid.New()
+	}
+	if event.ListenedAt.IsZero() {
+		event.ListenedAt = time.Now()
+	}
 
-	if err := c.statService.UpdateStat(ctx.Request.Context(), &event); err != nil {
+	err = c.publisher.PublishListeningEvent(ctx.Request.Context(), &event)
+	switch {
+	case errors.Is(err, publisher.ErrShortListeningTime):
+		// nothing to count
+		ctx.Status(http.StatusNoContent)
+	case err != nil:
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+	default:
+		ctx.Status(http.StatusAccepted)
 	}
-	ctx.Status(http.StatusNoContent)
 }
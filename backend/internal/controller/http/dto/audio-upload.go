@@ -0,0 +1,5 @@
+package dto
+
+type AudioUploadCreation struct {
+	Size int64 `json:"size"`
+}
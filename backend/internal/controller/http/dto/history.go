@@ -0,0 +1,5 @@
+package dto
+
+type HistoryPause struct {
+	Paused bool `json:"paused"`
+}
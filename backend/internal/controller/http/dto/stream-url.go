@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// StreamURL is a signed media URL. The token is returned separately
+// for clients that build URLs themselves, e.g. for HLS.
+type StreamURL struct {
+	URL       string    `json:"url"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+// Waveform lists the peaks as numbers, a byte slice would be encoded as base64.
+type Waveform struct {
+	TrackID uuid.UUID `json:"track_id"`
+	Points  int       `json:"points"`
+	Peaks   []int     `json:"peaks"`
+}
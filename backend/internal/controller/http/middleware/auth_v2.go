@@ -6,7 +6,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	jwttokens "github.com/hahaclassic/orpheon/backend/internal/adapters/tokens/jwt"
+	ctxclaims "github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/claims"
 	"github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/cookie"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/auth"
 )
 
@@ -49,6 +51,15 @@ func (a *AuthMiddleware) Optional() gin.HandlerFunc {
 	}
 }
 
+// ReadClaims returns nil if the request has no valid session.
+func (a *AuthMiddleware) ReadClaims(c *gin.Context) *entity.Claims {
+	if err := a.setClaims(c); err != nil {
+		return nil
+	}
+
+	return ctxclaims.GetClaims(c)
+}
+
 func (a *AuthMiddleware) setClaims(c *gin.Context) error {
 	accessToken, err := c.Cookie(cookie.AccessCookieName)
 	if err != nil || accessToken == "" {
@@ -32,6 +32,7 @@ type AlbumRouter struct {
 	albumCoverController  AlbumCoverController
 	albumTrackController  AlbumTrackController
 	genreAssignController GenreAssignController
+	coverStreamAuth       gin.HandlerFunc
 	authMiddleware        gin.HandlerFunc
 }
 
@@ -40,6 +41,7 @@ func NewAlbumRouter(
 	albumCoverController AlbumCoverController,
 	albumTrackController AlbumTrackController,
 	genreAssignController GenreAssignController,
+	coverStreamAuth gin.HandlerFunc,
 	authMiddleware gin.HandlerFunc,
 ) *AlbumRouter {
 	return &AlbumRouter{
@@ -47,6 +49,7 @@ func NewAlbumRouter(
 		albumCoverController:  albumCoverController,
 		albumTrackController:  albumTrackController,
 		genreAssignController: genreAssignController,
+		coverStreamAuth:       coverStreamAuth,
 		authMiddleware:        authMiddleware,
 	}
 }
@@ -70,7 +73,7 @@ func (r *AlbumRouter) RegisterRoutes(router *gin.RouterGroup) {
 	}
 
 	coverGroup := albumGroup.Group("/:id/cover")
-	coverGroup.GET("", r.albumCoverController.GetCover)
+	coverGroup.GET("", r.coverStreamAuth, r.albumCoverController.GetCover)
 
 	coverProtected := coverGroup.Group("")
 	coverProtected.Use(r.authMiddleware)
@@ -31,6 +31,7 @@ type ArtistRouter struct {
 	artistController       ArtistController
 	artistAvatarController ArtistAvatarController
 	artistAssignController ArtistAssignController
+	avatarStreamAuth       gin.HandlerFunc
 	authMiddleware         gin.HandlerFunc
 }
 
@@ -38,12 +39,14 @@ func NewArtistRouter(
 	artistController ArtistController,
 	artistAvatarController ArtistAvatarController,
 	artistAssignController ArtistAssignController,
+	avatarStreamAuth gin.HandlerFunc,
 	authMiddleware gin.HandlerFunc,
 ) *ArtistRouter {
 	return &ArtistRouter{
 		artistController:       artistController,
 		artistAvatarController: artistAvatarController,
 		artistAssignController: artistAssignController,
+		avatarStreamAuth:       avatarStreamAuth,
 		authMiddleware:         authMiddleware,
 	}
 }
@@ -70,7 +73,7 @@ func (r *ArtistRouter) RegisterRoutes(router *gin.RouterGroup) {
 	}
 
 	avatarGroup := artistGroup.Group("/:id/avatar")
-	avatarGroup.GET("", r.artistAvatarController.GetAvatar)
+	avatarGroup.GET("", r.avatarStreamAuth, r.artistAvatarController.GetAvatar)
 
 	avatarProtected := avatarGroup.Group("")
 	avatarProtected.Use(r.authMiddleware)
@@ -27,6 +27,7 @@ type PlaylistRouter struct {
 	playlistMetaController  PlaylistMetaController
 	playlistTrackController PlaylistTrackController
 	playlistCoverController PlaylistCoverController
+	coverStreamAuth         gin.HandlerFunc
 	authMiddleware          gin.HandlerFunc
 }
 
@@ -34,12 +35,14 @@ func NewPlaylistRouter(
 	playlistMetaController PlaylistMetaController,
 	playlistTrackController PlaylistTrackController,
 	playlistCoverController PlaylistCoverController,
+	coverStreamAuth gin.HandlerFunc,
 	authMiddleware gin.HandlerFunc,
 ) *PlaylistRouter {
 	return &PlaylistRouter{
 		playlistMetaController:  playlistMetaController,
 		playlistTrackController: playlistTrackController,
 		playlistCoverController: playlistCoverController,
+		coverStreamAuth:         coverStreamAuth,
 		authMiddleware:          authMiddleware,
 	}
 }
@@ -57,7 +60,7 @@ func (r *PlaylistRouter) RegisterRoutes(router *gin.RouterGroup) {
 
 	coverGroup := playlistGroup.Group("/:id/cover")
 	{
-		coverGroup.GET("", r.playlistCoverController.GetCover)
+		coverGroup.GET("", r.coverStreamAuth, r.playlistCoverController.GetCover)
 		coverGroup.POST("", r.playlistCoverController.UploadCover)
 		coverGroup.DELETE("", r.playlistCoverController.DeleteCover)
 	}
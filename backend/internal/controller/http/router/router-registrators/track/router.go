@@ -21,11 +21,43 @@ type TrackAudioController interface {
 	DeleteAudioFile(c *gin.Context)
 }
 
+type TrackAudioUploadController interface {
+	CreateUpload(c *gin.Context)
+	GetUpload(c *gin.Context)
+	UploadChunk(c *gin.Context)
+	CompleteUpload(c *gin.Context)
+	AbortUpload(c *gin.Context)
+}
+
+type TrackWaveformController interface {
+	GetWaveform(c *gin.Context)
+}
+
+type TrackPreviewController interface {
+	GetPreview(c *gin.Context)
+}
+
+type TrackImportController interface {
+	ImportTrack(c *gin.Context)
+}
+
+type TrackHLSController interface {
+	GetMasterPlaylist(c *gin.Context)
+	GetMediaPlaylist(c *gin.Context)
+	GetSegment(c *gin.Context)
+}
+
 type ArtistAssignController interface {
 	AssignArtistToTrack(c *gin.Context)
 	UnassignArtistFromTrack(c *gin.Context)
 }
 
+// StreamAuthMiddleware validates signed stream URLs.
+type StreamAuthMiddleware interface {
+	Audio() gin.HandlerFunc
+	HLS() gin.HandlerFunc
+}
+
 type StatController interface {
 	UpdateStat(c *gin.Context)
 }
@@ -34,23 +66,41 @@ type TrackRouter struct {
 	trackMetaController    TrackMetaController
 	segmentService         TrackSegmentController
 	audioService           TrackAudioController
+	uploadController       TrackAudioUploadController
+	hlsController          TrackHLSController
+	importController       TrackImportController
+	waveformController     TrackWaveformController
+	previewController      TrackPreviewController
 	artistAssignController ArtistAssignController
 	statController         StatController
+	streamAuth             StreamAuthMiddleware
 	authMiddleware         gin.HandlerFunc
 }
 
 func NewTrackRouter(trackMetaController TrackMetaController,
 	segmentService TrackSegmentController,
 	audioService TrackAudioController,
+	uploadController TrackAudioUploadController,
+	hlsController TrackHLSController,
+	importController TrackImportController,
+	waveformController TrackWaveformController,
+	previewController TrackPreviewController,
 	statController StatController,
 	artistAssignController ArtistAssignController,
+	streamAuth StreamAuthMiddleware,
 	authMiddleware gin.HandlerFunc) *TrackRouter {
 	return &TrackRouter{
 		trackMetaController:    trackMetaController,
 		segmentService:         segmentService,
 		audioService:           audioService,
+		uploadController:       uploadController,
+		hlsController:          hlsController,
+		importController:       importController,
+		waveformController:     waveformController,
+		previewController:      previewController,
 		statController:         statController,
 		artistAssignController: artistAssignController,
+		streamAuth:             streamAuth,
 		authMiddleware:         authMiddleware,
 	}
 }
@@ -60,11 +110,14 @@ func (r *TrackRouter) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		tracks.GET("/:id", r.trackMetaController.GetTrack)
 		tracks.GET("/:id/segments", r.segmentService.GetSegments)
+		tracks.GET("/:id/waveform", r.waveformController.GetWaveform)
+		tracks.GET("/:id/preview", r.previewController.GetPreview)
 
 		tracksProtected := tracks.Group("")
 		tracksProtected.Use(r.authMiddleware)
 		{
 			tracksProtected.POST("", r.trackMetaController.CreateTrack)
+			tracksProtected.POST("/import", r.importController.ImportTrack)
 			tracksProtected.PUT("/:id", r.trackMetaController.UpdateTrack)
 			tracksProtected.DELETE("/:id", r.trackMetaController.DeleteTrack)
 			tracksProtected.POST("/:id/stats", r.statController.UpdateStat)
@@ -72,13 +125,27 @@ func (r *TrackRouter) RegisterRoutes(router *gin.RouterGroup) {
 
 		tracksAudio := tracks.Group("/:id/audio")
 		{
-			tracksAudio.GET("", r.audioService.GetAudioChunk)
+			tracksAudio.GET("", r.streamAuth.Audio(), r.audioService.GetAudioChunk)
 			tracksAudioProtected := tracksAudio.Group("")
 			tracksAudioProtected.Use(r.authMiddleware)
 			{
 				tracksAudioProtected.POST("", r.audioService.UploadAudioFile)
 				tracksAudioProtected.DELETE("", r.audioService.DeleteAudioFile)
+
+				tracksAudioProtected.POST("/uploads", r.uploadController.CreateUpload)
+				tracksAudioProtected.GET("/uploads/:upload_id", r.uploadController.GetUpload)
+				tracksAudioProtected.PATCH("/uploads/:upload_id", r.uploadController.UploadChunk)
+				tracksAudioProtected.DELETE("/uploads/:upload_id", r.uploadController.AbortUpload)
+				tracksAudioProtected.POST("/uploads/:upload_id/complete", r.uploadController.CompleteUpload)
 			}
 		}
+
+		tracksHLS := tracks.Group("/:id/hls")
+		tracksHLS.Use(r.streamAuth.HLS())
+		{
+			tracksHLS.GET("/master.m3u8", r.hlsController.GetMasterPlaylist)
+			tracksHLS.GET("/:rendition/playlist.m3u8", r.hlsController.GetMediaPlaylist)
+			tracksHLS.GET("/:rendition/:segment", r.hlsController.GetSegment)
+		}
 	}
 }
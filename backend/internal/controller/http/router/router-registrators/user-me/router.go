@@ -21,22 +21,32 @@ type PlaylistFavoritesController interface {
 	RemoveFromFavorites(c *gin.Context)
 }
 
+type HistoryController interface {
+	GetHistory(c *gin.Context)
+	ClearHistory(c *gin.Context)
+	GetHistoryPause(c *gin.Context)
+	SetHistoryPause(c *gin.Context)
+}
+
 type UserMeRouter struct {
 	playlistMetaController      PlaylistMetaController
 	userController              UserController
 	playlistFavoritesController PlaylistFavoritesController
+	historyController           HistoryController
 	authMiddleware              gin.HandlerFunc
 }
 
 func NewMeRouter(playlistMetaController PlaylistMetaController,
 	userController UserController,
 	playlistFavoritesController PlaylistFavoritesController,
+	historyController HistoryController,
 	authMiddleware gin.HandlerFunc) *UserMeRouter {
 
 	return &UserMeRouter{
 		playlistMetaController:      playlistMetaController,
 		userController:              userController,
 		playlistFavoritesController: playlistFavoritesController,
+		historyController:           historyController,
 		authMiddleware:              authMiddleware,
 	}
 }
@@ -49,6 +59,10 @@ func (r *UserMeRouter) RegisterRoutes(router *gin.RouterGroup) {
 		me.GET("/favorites", r.playlistFavoritesController.GetFavoritePlaylists)
 		me.POST("/favorites/:playlist_id", r.playlistFavoritesController.AddToFavorites)
 		me.DELETE("/favorites/:playlist_id", r.playlistFavoritesController.RemoveFromFavorites)
+		me.GET("/history", r.historyController.GetHistory)
+		me.DELETE("/history", r.historyController.ClearHistory)
+		me.GET("/history/pause", r.historyController.GetHistoryPause)
+		me.PUT("/history/pause", r.historyController.SetHistoryPause)
 		me.GET("", r.userController.GetMe)
 		me.PUT("", r.userController.UpdateMe)
 	}
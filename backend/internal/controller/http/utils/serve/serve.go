@@ -0,0 +1,29 @@
+package serve
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Content writes the content with RFC 7233 range semantics: single, suffix and
+// multiple ranges, If-Range and 416 for unsatisfiable ranges. Conditional
+// requests are answered with 304 using the strong ETag and the modification time.
+func Content(ctx *gin.Context, contentType, etag string, modTime time.Time, content io.ReadSeeker) {
+	ctx.Header("Content-Type", contentType)
+	if etag != "" {
+		ctx.Header("ETag", etag)
+	}
+	// the same URL may serve a new file after re-upload, so caches must revalidate
+	ctx.Header("Cache-Control", "public, no-cache")
+
+	http.ServeContent(ctx.Writer, ctx.Request, "", modTime, content)
+}
+
+// Bytes is Content for data already read into memory.
+func Bytes(ctx *gin.Context, contentType, etag string, modTime time.Time, data []byte) {
+	Content(ctx, contentType, etag, modTime, bytes.NewReader(data))
+}
@@ -0,0 +1,98 @@
+package serve_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hahaclassic/orpheon/backend/internal/controller/http/utils/serve"
+	"github.com/stretchr/testify/assert"
+)
+
+const etag = `"abc"`
+
+var modTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func request(headers map[string]string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/", func(ctx *gin.Context) {
+		serve.Bytes(ctx, "audio/mpeg", etag, modTime, []byte("0123456789"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestWithoutRange(t *testing.T) {
+	w := request(nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0123456789", w.Body.String())
+	assert.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Equal(t, modTime.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		rangeHeader  string
+		body         string
+		contentRange string
+	}{
+		{name: "closed", rangeHeader: "bytes=2-4", body: "234", contentRange: "bytes 2-4/10"},
+		{name: "open-ended", rangeHeader: "bytes=7-", body: "789", contentRange: "bytes 7-9/10"},
+		{name: "suffix", rangeHeader: "bytes=-2", body: "89", contentRange: "bytes 8-9/10"},
+		{name: "end beyond size", rangeHeader: "bytes=8-100", body: "89", contentRange: "bytes 8-9/10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(map[string]string{"Range": tt.rangeHeader})
+
+			assert.Equal(t, http.StatusPartialContent, w.Code)
+			assert.Equal(t, tt.body, w.Body.String())
+			assert.Equal(t, tt.contentRange, w.Header().Get("Content-Range"))
+		})
+	}
+}
+
+func TestMultipleRanges(t *testing.T) {
+	w := request(map[string]string{"Range": "bytes=0-1,5-6"})
+
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "multipart/byteranges")
+}
+
+func TestUnsatisfiableRange(t *testing.T) {
+	w := request(map[string]string{"Range": "bytes=20-"})
+
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+	assert.Equal(t, "bytes */10", w.Header().Get("Content-Range"))
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	w := request(map[string]string{"If-None-Match": etag})
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
+
+func TestIfRange(t *testing.T) {
+	w := request(map[string]string{"Range": "bytes=0-1", "If-Range": etag})
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+
+	// the representation has changed, so the whole content is sent
+	w = request(map[string]string{"Range": "bytes=0-1", "If-Range": `"stale"`})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0123456789", w.Body.String())
+}
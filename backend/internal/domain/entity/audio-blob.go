@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AudioBlob is stored audio content addressed by the SHA-256 of its bytes,
+// identical files of several tracks share one blob.
+type AudioBlob struct {
+	Hash    string    `json:"hash"` // hex encoded
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// IsBlobHash reports whether s is a hex encoded SHA-256, so it is safe to use as a file or object name.
+func IsBlobHash(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// BlobScrubReport lists the blobs that failed verification.
+type BlobScrubReport struct {
+	Checked     int      `json:"checked"`
+	Corrupted   []string `json:"corrupted"`   // content does not match the hash
+	Missing     []string `json:"missing"`     // referenced by tracks but not stored
+	Orphaned    []string `json:"orphaned"`    // stored but not referenced by any track
+	Quarantined []string `json:"quarantined"` // moved aside, so they are no longer served
+	// AffectedTracks have files in corrupted or missing blobs and need a new upload.
+	AffectedTracks []uuid.UUID `json:"affected_tracks"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+// AudioCacheStats are the counters of the local audio cache since it was started.
+type AudioCacheStats struct {
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Fills     int64 `json:"fills"`
+	Evictions int64 `json:"evictions"`
+	Blobs     int   `json:"blobs"`
+	Used      int64 `json:"used"`     // bytes
+	Capacity  int64 `json:"capacity"` // bytes
+}
+
+func (s AudioCacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
@@ -3,8 +3,10 @@ package entity
 import "github.com/google/uuid"
 
 type AudioChunk struct {
-	Data    []byte    `json:"data"`
-	TrackID uuid.UUID `json:"track_id"`
-	Start   int64     `json:"start"`
-	End     int64     `json:"end"`
+	Data    []byte       `json:"data"`
+	TrackID uuid.UUID    `json:"track_id"`
+	Start   int64        `json:"start"`
+	End     int64        `json:"end"`
+	Quality AudioQuality `json:"quality"`
+	Format  AudioFormat  `json:"format"`
 }
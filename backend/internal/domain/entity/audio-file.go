@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AudioFile describes a stored audio file. Its content is never kept in memory,
+// it is passed alongside as a stream.
+type AudioFile struct {
+	TrackID uuid.UUID    `json:"track_id"`
+	Quality AudioQuality `json:"quality"`
+	Format  AudioFormat  `json:"format"`
+	Size    int64        `json:"size"` // -1 if unknown until the upload is finished
+	Hash    string       `json:"hash"` // SHA-256 of the content, empty until the file is stored
+	ETag    string       `json:"etag"` // strong validator of the stored content, quoted
+	ModTime time.Time    `json:"mod_time"`
+}
+
+// LegacyAudioName is the name a file of the track was stored under before
+// audio was stored by content: <trackID> for the original, <trackID>_<quality> otherwise.
+func LegacyAudioName(trackID uuid.UUID, quality AudioQuality) string {
+	if quality == "" || quality == QualityOriginal {
+		return trackID.String()
+	}
+	return trackID.String() + "_" + string(quality)
+}
+
+// ParseLegacyAudioName is the reverse of LegacyAudioName, false if the name is not one of it.
+func ParseLegacyAudioName(name string) (uuid.UUID, AudioQuality, bool) {
+	id, quality, _ := strings.Cut(name, "_")
+
+	trackID, err := uuid.Parse(id)
+	if err != nil || len(id) != len(trackID.String()) {
+		return uuid.Nil, "", false
+	}
+
+	parsed, err := ParseAudioQuality(quality)
+	if err != nil || (quality != "" && parsed == QualityOriginal) {
+		return uuid.Nil, "", false
+	}
+
+	return trackID, parsed, true
+}
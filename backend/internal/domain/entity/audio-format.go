@@ -0,0 +1,45 @@
+package entity
+
+import "errors"
+
+var ErrUnknownAudioFormat = errors.New("unknown audio format")
+
+type AudioFormat string
+
+const (
+	FormatMP3  AudioFormat = "mp3"
+	FormatFLAC AudioFormat = "flac"
+	FormatOGG  AudioFormat = "ogg" // Ogg container with Vorbis audio
+	FormatWAV  AudioFormat = "wav"
+)
+
+var AudioFormats = []AudioFormat{FormatMP3, FormatFLAC, FormatOGG, FormatWAV}
+
+func (f AudioFormat) MIMEType() string {
+	switch f {
+	case FormatFLAC:
+		return "audio/flac"
+	case FormatOGG:
+		return "audio/ogg"
+	case FormatWAV:
+		return "audio/wav"
+	default:
+		return "audio/mpeg"
+	}
+}
+
+func (f AudioFormat) Extension() string {
+	if f == "" {
+		return "." + string(FormatMP3)
+	}
+	return "." + string(f)
+}
+
+func ParseAudioFormatMIME(mime string) (AudioFormat, error) {
+	for _, f := range AudioFormats {
+		if f.MIMEType() == mime {
+			return f, nil
+		}
+	}
+	return "", ErrUnknownAudioFormat
+}
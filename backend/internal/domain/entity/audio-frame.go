@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// AudioFrame describes a single frame of an encoded audio stream.
+type AudioFrame struct {
+	Offset   int64         `json:"offset"` // byte offset of the frame header
+	Size     int64         `json:"size"`
+	Duration time.Duration `json:"duration"`
+	Bitrate  int           `json:"bitrate"` // bits per second
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// AudioInfo holds the properties of an audio file measured from its content.
+type AudioInfo struct {
+	Format     AudioFormat   `json:"format"`
+	Duration   time.Duration `json:"duration"`
+	Bitrate    int           `json:"bitrate"`     // average, bits per second
+	SampleRate int           `json:"sample_rate"` // Hz
+}
+
+// Seconds returns the duration rounded the way TrackMeta.Duration stores it,
+// tracks are never shorter than a second.
+func (i *AudioInfo) Seconds() int {
+	return max(1, int(math.Round(i.Duration.Seconds())))
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "errors"
+
+var ErrUnknownAudioQuality = errors.New("unknown audio quality")
+
+type AudioQuality string
+
+const (
+	QualityOriginal AudioQuality = "original" // file as it was uploaded
+	QualityLow      AudioQuality = "low"
+	QualityMedium   AudioQuality = "medium"
+	QualityHigh     AudioQuality = "high"
+)
+
+// AudioRenditions are the transcoded qualities produced on upload, from highest to lowest.
+var AudioRenditions = []AudioQuality{QualityHigh, QualityMedium, QualityLow}
+
+// AudioQualities are all qualities a track may be stored in.
+var AudioQualities = append([]AudioQuality{QualityOriginal}, AudioRenditions...)
+
+// Bitrate returns the target bitrate of the rendition in kbps, 0 for the original file.
+func (q AudioQuality) Bitrate() int {
+	switch q {
+	case QualityLow:
+		return 96
+	case QualityMedium:
+		return 160
+	case QualityHigh:
+		return 320
+	default:
+		return 0
+	}
+}
+
+func ParseAudioQuality(s string) (AudioQuality, error) {
+	switch q := AudioQuality(s); q {
+	case "":
+		return QualityOriginal, nil
+	case QualityOriginal, QualityLow, QualityMedium, QualityHigh:
+		return q, nil
+	default:
+		return "", ErrUnknownAudioQuality
+	}
+}
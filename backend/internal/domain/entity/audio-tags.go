@@ -0,0 +1,12 @@
+package entity
+
+// AudioTags holds the metadata embedded in an audio file.
+type AudioTags struct {
+	Title       string `json:"title"`
+	Artist      string `json:"artist"`
+	Album       string `json:"album"`
+	TrackNumber int    `json:"track_number"` // 0 if unknown
+	Year        int    `json:"year"`         // 0 if unknown
+	Genre       string `json:"genre"`
+	Cover       []byte `json:"-"` // embedded front cover, nil if absent
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "github.com/google/uuid"
+
+// AudioUpload is a resumable upload session. The file is sent in consecutive
+// chunks and becomes the track's audio once all Size bytes are received.
+type AudioUpload struct {
+	ID      uuid.UUID `json:"id"`
+	TrackID uuid.UUID `json:"track_id"`
+	Size    int64     `json:"size"`   // total file size declared on creation
+	Offset  int64     `json:"offset"` // bytes received so far
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownChartPeriod = errors.New("unknown chart period")
+
+// ChartPeriod is the rolling period a chart counts the plays for.
+type ChartPeriod string
+
+const (
+	ChartDay   ChartPeriod = "day"
+	ChartWeek  ChartPeriod = "week"
+	ChartMonth ChartPeriod = "month"
+)
+
+func ParseChartPeriod(s string) (ChartPeriod, error) {
+	switch p := ChartPeriod(s); p {
+	case ChartDay, ChartWeek, ChartMonth:
+		return p, nil
+	default:
+		return "", ErrUnknownChartPeriod
+	}
+}
+
+type ChartRequest struct {
+	Period  ChartPeriod
+	Filters Filters
+	Limit   int
+}
+
+// ChartQuery selects the plays in [From, To). Hourly picks the hourly buckets
+// instead of the daily ones, so From and To must be whole hours or whole days.
+type ChartQuery struct {
+	From    time.Time
+	To      time.Time
+	Hourly  bool
+	Filters Filters
+	Limit   int
+}
+
+type ChartEntry struct {
+	Position         int       `json:"position"`
+	PreviousPosition *int      `json:"previous_position"` // nil if the track is new in the chart
+	TrackID          uuid.UUID `json:"track_id"`
+	Plays            int       `json:"plays"`
+}
+
+// WeeklyChart is a snapshot of the chart of the week starting on Monday, Week, in UTC.
+type WeeklyChart struct {
+	Week    time.Time     `json:"week"`
+	Entries []*ChartEntry `json:"entries"`
+}
+
+type ChartEntryAggregated struct {
+	Position         int                  `json:"position"`
+	PreviousPosition *int                 `json:"previous_position"`
+	Plays            int                  `json:"plays"`
+	Track            *TrackMetaAggregated `json:"track"`
+}
+
+type WeeklyChartAggregated struct {
+	Week    time.Time               `json:"week"`
+	Entries []*ChartEntryAggregated `json:"entries"`
+}
+
+// WeekStart returns the Monday of the week of t in UTC.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,15 @@
+package entity
+
+// CoverResizeKindReport counts the covers of one kind of object resized by a backfill.
+type CoverResizeKindReport struct {
+	Kind    StorageKind `json:"kind"`
+	Total   int         `json:"total"`
+	Resized int         `json:"resized"`
+	Skipped int         `json:"skipped"` // already stored in every size
+	Failed  int         `json:"failed"`
+	Errors  []string    `json:"errors"`
+}
+
+type CoverResizeReport struct {
+	Kinds []*CoverResizeKindReport `json:"kinds"`
+}
@@ -1,6 +1,15 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownCoverSize = errors.New("unknown cover size")
 
 type CoverObjectType string
 
@@ -9,7 +18,33 @@ const (
 	CoverPlaylist CoverObjectType = "playlist"
 )
 
+// CoverSizes are the sides, in pixels, of the square images every uploaded cover
+// is stored in, from smallest to largest. Uploads are never scaled up, so the
+// larger sizes of a small upload hold the upload's own size.
+var CoverSizes = []int{64, 300, 640, 1200}
+
+// LargestCoverSize is served when no size is requested.
+var LargestCoverSize = slices.Max(CoverSizes)
+
 type Cover struct {
-	ObjectID uuid.UUID `json:"object_id"`
-	Data     []byte    `json:"data"`
+	ObjectID    uuid.UUID `json:"object_id"`
+	Size        int       `json:"size"` // one of CoverSizes, 0 for covers stored before resizing
+	ContentType string    `json:"content_type"`
+	Generated   bool      `json:"generated"` // made of other covers rather than uploaded
+	Data        []byte    `json:"data"`
+	ETag        string    `json:"etag"` // strong validator of the stored content, quoted
+	ModTime     time.Time `json:"mod_time"`
+}
+
+func ParseCoverSize(s string) (int, error) {
+	if s == "" {
+		return LargestCoverSize, nil
+	}
+
+	size, err := strconv.Atoi(s)
+	if err != nil || !slices.Contains(CoverSizes, size) {
+		return 0, ErrUnknownCoverSize
+	}
+
+	return size, nil
 }
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeadLetter is a listening event the consumer gave up on, kept with the error
+// of the last attempt. Event is nil if the payload is not a valid event.
+// ReplayedAt is set once the event is published again.
+type DeadLetter struct {
+	Topic     string          `json:"topic"`
+	Partition int             `json:"partition"`
+	Offset    int64           `json:"offset"`
+	Payload   []byte          `json:"payload"`
+	Event     *ListeningEvent `json:"event"`
+	Error     string          `json:"error"`
+	Attempts  int             `json:"attempts"`
+	FailedAt  time.Time       `json:"failed_at"`
+
+	ReplayedAt *time.Time `json:"replayed_at,omitempty"`
+}
+
+// ID identifies the dead letter as <partition>:<offset>.
+func (l *DeadLetter) ID() string {
+	return fmt.Sprintf("%d:%d", l.Partition, l.Offset)
+}
+
+type DeadLetterReplayReport struct {
+	Replayed        int `json:"replayed"`
+	Skipped         int `json:"skipped"` // the payload is not a valid event
+	AlreadyReplayed int `json:"already_replayed"`
+}
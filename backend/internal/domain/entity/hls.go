@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HLSRendition struct {
+	Quality   AudioQuality `json:"quality"`
+	Bandwidth int          `json:"bandwidth"` // bits per second
+}
+
+// HLSSegment is a byte range [Start, End) of the audio file aligned to frame boundaries.
+type HLSSegment struct {
+	Idx      int           `json:"idx"`
+	Start    int64         `json:"start"`
+	End      int64         `json:"end"`
+	Duration time.Duration `json:"duration"`
+}
+
+type HLSMediaPlaylist struct {
+	TrackID        uuid.UUID     `json:"track_id"`
+	Quality        AudioQuality  `json:"quality"`
+	TargetDuration time.Duration `json:"target_duration"`
+	Segments       []*HLSSegment `json:"segments"`
+}
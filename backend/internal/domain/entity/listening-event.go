@@ -1,9 +1,52 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type ListeningEvent struct {
-	TrackID uuid.UUID `json:"track_id"`
-	UserID  uuid.UUID `json:"user_id"`
-	Ranges  []*Range  `json:"ranges"` // e.g. [[2, 39], [55, 141]] - listened from 2 to 39 seconds, then 55 to 141
+	// EventID is generated by the client, so a retried event is counted once
+	EventID    uuid.UUID `json:"event_id"`
+	ListenedAt time.Time `json:"listened_at"`
+	// ReceivedAt is set by the server when the event is published
+	ReceivedAt time.Time `json:"received_at"`
+	TrackID    uuid.UUID `json:"track_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Ranges     []*Range  `json:"ranges"` // e.g. [[2, 39], [55, 141]] - listened from 2 to 39 seconds, then 55 to 141
+	// Context is nil if the track wasn't started from a playlist, an album or an artist
+	Context *ListeningContext `json:"context,omitempty"`
+}
+
+// MaxListenedAtAge is how long a client may hold an event back, e.g. one played offline.
+const MaxListenedAtAge = 24 * time.Hour
+
+// ClampedListenedAt returns the listening time set by the client, kept within
+// MaxListenedAtAge before the time the event was received. Events received
+// before ReceivedAt was set are bounded by now.
+func (e *ListeningEvent) ClampedListenedAt(now time.Time) time.Time {
+	received := e.ReceivedAt
+	if received.IsZero() || received.After(now) {
+		received = now
+	}
+
+	switch earliest := received.Add(-MaxListenedAtAge); {
+	case e.ListenedAt.IsZero() || e.ListenedAt.After(received):
+		return received
+	case e.ListenedAt.Before(earliest):
+		return earliest
+	default:
+		return e.ListenedAt
+	}
+}
+
+// TotalSeconds sums the listened ranges.
+func (e *ListeningEvent) TotalSeconds() int {
+	total := 0
+	for _, r := range e.Ranges {
+		total += r.End - r.Start
+	}
+
+	return total
 }
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownListeningContext = errors.New("unknown listening context")
+
+// ListeningContextType is where the track was started from.
+type ListeningContextType string
+
+const (
+	ListeningContextPlaylist ListeningContextType = "playlist"
+	ListeningContextAlbum    ListeningContextType = "album"
+	ListeningContextArtist   ListeningContextType = "artist"
+)
+
+type ListeningContext struct {
+	Type ListeningContextType `json:"type"`
+	ID   uuid.UUID            `json:"id"`
+}
+
+func (c *ListeningContext) Validate() error {
+	switch c.Type {
+	case ListeningContextPlaylist, ListeningContextAlbum, ListeningContextArtist:
+	default:
+		return ErrUnknownListeningContext
+	}
+	if c.ID == uuid.Nil {
+		return ErrUnknownListeningContext
+	}
+
+	return nil
+}
+
+type ListeningHistoryEntry struct {
+	EventID    uuid.UUID         `json:"event_id"`
+	UserID     uuid.UUID         `json:"user_id"`
+	TrackID    uuid.UUID         `json:"track_id"`
+	ListenedAt time.Time         `json:"listened_at"`
+	Seconds    int               `json:"seconds"`
+	Context    *ListeningContext `json:"context"`
+}
+
+type ListeningHistoryEntryAggregated struct {
+	Track      *TrackMetaAggregated `json:"track"`
+	ListenedAt time.Time            `json:"listened_at"`
+	Seconds    int                  `json:"seconds"`
+	Context    *ListeningContext    `json:"context"`
+}
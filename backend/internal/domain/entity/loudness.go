@@ -0,0 +1,31 @@
+package entity
+
+const (
+	// ReferenceLoudness is the level tracks are normalized to, as in ReplayGain 2.0.
+	ReferenceLoudness = -18.0 // LUFS
+	// MaxTruePeak is the level normalization never raises the peak above.
+	MaxTruePeak = -1.0 // dBTP
+)
+
+// Loudness is measured per EBU R128.
+type Loudness struct {
+	Integrated float64 `json:"integrated"` // LUFS
+	TruePeak   float64 `json:"true_peak"`  // dBTP
+}
+
+// NewLoudness builds a measurement from nullable values, it returns nil if either is unknown.
+func NewLoudness(integrated, truePeak *float64) *Loudness {
+	if integrated == nil || truePeak == nil {
+		return nil
+	}
+	return &Loudness{
+		Integrated: *integrated,
+		TruePeak:   *truePeak,
+	}
+}
+
+// Gain returns the gain in dB that brings the audio to ReferenceLoudness,
+// limited so that the peak stays below MaxTruePeak.
+func (l *Loudness) Gain() float64 {
+	return min(ReferenceLoudness-l.Integrated, MaxTruePeak-l.TruePeak)
+}
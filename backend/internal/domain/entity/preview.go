@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PreviewClip is the part of a track played as its preview,
+// faded in and out over Fade.
+type PreviewClip struct {
+	TrackID  uuid.UUID     `json:"track_id"`
+	Start    time.Duration `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Fade     time.Duration `json:"fade"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// SeekTable maps playback time to the byte offsets of the frames of an MP3 blob.
+// It depends on the content only, so it is keyed by the blob hash.
+type SeekTable struct {
+	Hash     string        `json:"hash"`
+	Interval time.Duration `json:"interval"`
+	Offsets  []int64       `json:"offsets"` // Offsets[i] is the frame playing at i*Interval
+}
+
+// Offset returns the offset of the frame playing at the given time,
+// false if the time is past the end of the file.
+func (t *SeekTable) Offset(at time.Duration) (int64, bool) {
+	if at < 0 || t.Interval <= 0 {
+		return 0, false
+	}
+
+	idx := int(at / t.Interval)
+	if idx >= len(t.Offsets) {
+		return 0, false
+	}
+
+	return t.Offsets[idx], true
+}
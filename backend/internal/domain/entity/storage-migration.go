@@ -0,0 +1,34 @@
+package entity
+
+// StorageKind is a kind of stored content copied by a storage migration.
+type StorageKind string
+
+const (
+	StorageAudio          StorageKind = "audio"
+	StorageWaveforms      StorageKind = "waveforms"
+	StoragePlaylistCovers StorageKind = "playlist_covers"
+	StorageAlbumCovers    StorageKind = "album_covers"
+	StorageArtistAvatars  StorageKind = "artist_avatars"
+)
+
+type StorageMigrationOptions struct {
+	Concurrency int  `json:"concurrency"`
+	DryRun      bool `json:"dry_run"` // only count what would be copied
+}
+
+// StorageKindReport counts the items of one kind of content. In a dry run
+// Copied counts the items that would be copied.
+type StorageKindReport struct {
+	Kind        StorageKind `json:"kind"`
+	Unsupported bool        `json:"unsupported"` // one of the backends cannot store the kind
+	Total       int         `json:"total"`
+	Copied      int         `json:"copied"`
+	Skipped     int         `json:"skipped"` // already in the sink or gone from the source
+	Failed      int         `json:"failed"`
+	Errors      []string    `json:"errors"`
+}
+
+type StorageMigrationReport struct {
+	DryRun bool                 `json:"dry_run"`
+	Kinds  []*StorageKindReport `json:"kinds"`
+}
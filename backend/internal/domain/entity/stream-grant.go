@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StreamResource is the kind of media a stream token gives access to.
+type StreamResource string
+
+const (
+	StreamAudio         StreamResource = "audio"
+	StreamAlbumCover    StreamResource = "album_cover"
+	StreamPlaylistCover StreamResource = "playlist_cover"
+	StreamArtistAvatar  StreamResource = "artist_avatar"
+)
+
+// StreamGrant is the content of a signed stream URL: which resource may be
+// fetched, in which quality and until when.
+type StreamGrant struct {
+	Resource   StreamResource
+	ResourceID uuid.UUID
+	Quality    AudioQuality // empty grants every quality
+	UserID     uuid.UUID    // uuid.Nil for anonymous grants
+	ExpiresAt  time.Time
+}
+
+// Allows reports whether the grant covers the resource. An empty quality
+// stands for requests that are not bound to a single quality, e.g. HLS master playlists.
+func (g *StreamGrant) Allows(resource StreamResource, resourceID uuid.UUID, quality AudioQuality) bool {
+	if g.Resource != resource || g.ResourceID != resourceID {
+		return false
+	}
+
+	return g.Quality == "" || quality == "" || g.Quality == quality
+}
+
+type StreamToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
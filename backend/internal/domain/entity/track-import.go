@@ -0,0 +1,29 @@
+package entity
+
+import "github.com/google/uuid"
+
+type TrackImportParams struct {
+	LicenseID uuid.UUID `json:"license_id"`
+	GenreID   uuid.UUID `json:"genre_id"` // used when the file has no genre tag
+	Size      int64     `json:"size"`     // -1 if unknown
+	// Tags are known in advance, e.g. from the file path. Set fields take
+	// precedence over the tags read from the file.
+	Tags *AudioTags `json:"tags,omitempty"`
+}
+
+// ImportedObject is a record matched by name or created during an import.
+type ImportedObject struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Created bool      `json:"created"`
+}
+
+type TrackImportReport struct {
+	Track         ImportedObject `json:"track"`
+	Album         ImportedObject `json:"album"`
+	Artist        ImportedObject `json:"artist"`
+	Genre         ImportedObject `json:"genre"`
+	CoverUploaded bool           `json:"cover_uploaded"`
+	// the album already has the track, its audio is left as is
+	Duplicate bool `json:"duplicate"`
+}
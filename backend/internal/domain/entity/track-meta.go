@@ -3,26 +3,37 @@ package entity
 import "github.com/google/uuid"
 
 type TrackMeta struct {
-	ID           uuid.UUID `json:"id"`
-	GenreID      uuid.UUID `json:"genre_id"`
-	Name         string    `json:"name"`
-	Duration     int       `json:"duration"`
-	Explicit     bool      `json:"explicit"`
-	LicenseID    uuid.UUID `json:"license_id"`
-	AlbumID      uuid.UUID `json:"album_id"`
-	TrackNumber  int       `json:"track_number"`
-	TotalStreams int       `json:"total_streams"`
+	ID           uuid.UUID   `json:"id"`
+	GenreID      uuid.UUID   `json:"genre_id"`
+	Name         string      `json:"name"`
+	Duration     int         `json:"duration"`
+	Explicit     bool        `json:"explicit"`
+	LicenseID    uuid.UUID   `json:"license_id"`
+	AlbumID      uuid.UUID   `json:"album_id"`
+	TrackNumber  int         `json:"track_number"`
+	TotalStreams int         `json:"total_streams"`
+	Format       AudioFormat `json:"format"`
+	Bitrate      int         `json:"bitrate"`     // bits per second, 0 until audio is uploaded
+	SampleRate   int         `json:"sample_rate"` // Hz, 0 until audio is uploaded
+	// Loudness is nil until the audio is analyzed, AlbumLoudness covers all analyzed tracks of the album.
+	Loudness      *Loudness `json:"loudness"`
+	AlbumLoudness *Loudness `json:"album_loudness"`
 }
 
 type TrackMetaAggregated struct {
-	ID           uuid.UUID     `json:"id"`
-	Genre        *Genre        `json:"genre"`
-	Name         string        `json:"name"`
-	Duration     int           `json:"duration"`
-	Explicit     bool          `json:"explicit"`
-	License      *License      `json:"license"`
-	TrackNumber  int           `json:"track_number"`
-	TotalStreams int           `json:"total_streams"`
-	Album        *AlbumMeta    `json:"album"`
-	Artists      []*ArtistMeta `json:"artists"`
+	ID            uuid.UUID     `json:"id"`
+	Genre         *Genre        `json:"genre"`
+	Name          string        `json:"name"`
+	Duration      int           `json:"duration"`
+	Explicit      bool          `json:"explicit"`
+	License       *License      `json:"license"`
+	TrackNumber   int           `json:"track_number"`
+	TotalStreams  int           `json:"total_streams"`
+	Format        AudioFormat   `json:"format"`
+	Bitrate       int           `json:"bitrate"`
+	SampleRate    int           `json:"sample_rate"`
+	Loudness      *Loudness     `json:"loudness"`
+	AlbumLoudness *Loudness     `json:"album_loudness"`
+	Album         *AlbumMeta    `json:"album"`
+	Artists       []*ArtistMeta `json:"artists"`
 }
@@ -0,0 +1,14 @@
+package entity
+
+import "github.com/google/uuid"
+
+// WaveformResolutions are the numbers of points the waveform of a track is stored with.
+var WaveformResolutions = []int{256, 1024, 4096}
+
+// Waveform holds the peak amplitude of every point of a track, scaled
+// so that the loudest point of the track is 255.
+type Waveform struct {
+	TrackID uuid.UUID `json:"track_id"`
+	Points  int       `json:"points"`
+	Peaks   []uint8   `json:"peaks"`
+}
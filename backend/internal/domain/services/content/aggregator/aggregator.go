@@ -2,6 +2,7 @@ package content_aggregator
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/genre"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/license"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
 )
 
@@ -71,22 +73,49 @@ func (a *ContentAggregator) GetTracksByIDs(ctx context.Context, trackIDs ...uuid
 		}
 
 		tracks[i] = &entity.TrackMetaAggregated{
-			ID:           trackMeta.ID,
-			Name:         trackMeta.Name,
-			Duration:     trackMeta.Duration,
-			Explicit:     trackMeta.Explicit,
-			TrackNumber:  trackMeta.TrackNumber,
-			TotalStreams: trackMeta.TotalStreams,
-			License:      license,
-			Album:        album,
-			Artists:      artists,
-			Genre:        genre,
+			ID:            trackMeta.ID,
+			Name:          trackMeta.Name,
+			Duration:      trackMeta.Duration,
+			Explicit:      trackMeta.Explicit,
+			TrackNumber:   trackMeta.TrackNumber,
+			TotalStreams:  trackMeta.TotalStreams,
+			Format:        trackMeta.Format,
+			Bitrate:       trackMeta.Bitrate,
+			SampleRate:    trackMeta.SampleRate,
+			Loudness:      trackMeta.Loudness,
+			AlbumLoudness: trackMeta.AlbumLoudness,
+			License:       license,
+			Album:         album,
+			Artists:       artists,
+			Genre:         genre,
 		}
 	}
 
 	return tracks, nil
 }
 
+func (a *ContentAggregator) GetExistingTracksByIDs(ctx context.Context, trackIDs ...uuid.UUID) (_ []*entity.TrackMetaAggregated, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetExistingTracksByIDs, err)
+	}()
+
+	tracks := make([]*entity.TrackMeta, 0, len(trackIDs))
+
+	for _, id := range trackIDs {
+		trackMeta, err := a.trackService.GetTrackMeta(ctx, id)
+		if errors.Is(err, commonerr.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tracks = append(tracks, trackMeta)
+	}
+
+	return a.GetTracks(ctx, tracks...)
+}
+
 func (a *ContentAggregator) GetAlbumsByIDs(ctx context.Context, albumIDs ...uuid.UUID) (_ []*entity.AlbumMetaAggregated, err error) {
 	defer func() {
 		err = errwrap.WrapIfErr(usecase.ErrGetAlbumsAggregatedByIDs, err)
@@ -195,16 +224,21 @@ func (a *ContentAggregator) GetTracks(ctx context.Context, tracks ...*entity.Tra
 		}
 
 		aggregated[i] = &entity.TrackMetaAggregated{
-			ID:           track.ID,
-			Name:         track.Name,
-			Duration:     track.Duration,
-			Explicit:     track.Explicit,
-			TrackNumber:  track.TrackNumber,
-			TotalStreams: track.TotalStreams,
-			License:      license,
-			Album:        album,
-			Artists:      artists,
-			Genre:        genre,
+			ID:            track.ID,
+			Name:          track.Name,
+			Duration:      track.Duration,
+			Explicit:      track.Explicit,
+			TrackNumber:   track.TrackNumber,
+			TotalStreams:  track.TotalStreams,
+			Format:        track.Format,
+			Bitrate:       track.Bitrate,
+			SampleRate:    track.SampleRate,
+			Loudness:      track.Loudness,
+			AlbumLoudness: track.AlbumLoudness,
+			License:       license,
+			Album:         album,
+			Artists:       artists,
+			Genre:         genre,
 		}
 	}
 
@@ -1 +1,82 @@
 package content_aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContentAggregatorSuite struct {
+	suite.Suite
+	aggregator *ContentAggregator
+	tracks     *mocks.TrackMetaService
+	artists    *mocks.ArtistAssignService
+	albums     *mocks.AlbumMetaService
+	licenses   *mocks.LicenseService
+	genres     *mocks.GenreService
+	ctx        context.Context
+}
+
+func TestContentAggregatorSuite(t *testing.T) {
+	suite.Run(t, new(ContentAggregatorSuite))
+}
+
+func (s *ContentAggregatorSuite) SetupTest() {
+	s.tracks = mocks.NewTrackMetaService(s.T())
+	s.artists = mocks.NewArtistAssignService(s.T())
+	s.albums = mocks.NewAlbumMetaService(s.T())
+	s.licenses = mocks.NewLicenseService(s.T())
+	s.genres = mocks.NewGenreService(s.T())
+	s.aggregator = NewContentAggregator(s.tracks, s.artists, s.albums, s.licenses, s.genres)
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func TrackMeta() *entity.TrackMeta {
+	return &entity.TrackMeta{
+		ID:        uuid.New(),
+		Name:      "Track",
+		AlbumID:   uuid.New(),
+		GenreID:   uuid.New(),
+		LicenseID: uuid.New(),
+	}
+}
+
+func (s *ContentAggregatorSuite) expectTrack(track *entity.TrackMeta) {
+	s.tracks.On("GetTrackMeta", s.ctx, track.ID).Return(track, nil)
+	s.artists.On("GetArtistByTrack", s.ctx, track.ID).Return([]*entity.ArtistMeta{}, nil)
+	s.albums.On("GetAlbum", s.ctx, track.AlbumID).Return(&entity.AlbumMeta{ID: track.AlbumID}, nil)
+	s.licenses.On("GetLicenseByID", s.ctx, track.LicenseID).Return(&entity.License{ID: track.LicenseID}, nil)
+	s.genres.On("GetGenreByID", s.ctx, track.GenreID).Return(&entity.Genre{ID: track.GenreID}, nil)
+}
+
+// GetExistingTracksByIDs
+func (s *ContentAggregatorSuite) TestGetExistingTracksByIDsSkipsMissing() {
+	first, second := TrackMeta(), TrackMeta()
+	deleted := uuid.New()
+	s.expectTrack(first)
+	s.expectTrack(second)
+	s.tracks.On("GetTrackMeta", s.ctx, deleted).Return(nil, fmt.Errorf("%w: track", commonerr.ErrNotFound))
+
+	tracks, err := s.aggregator.GetExistingTracksByIDs(s.ctx, first.ID, deleted, second.ID)
+	s.Require().NoError(err)
+	s.Require().Len(tracks, 2)
+	s.Equal(first.ID, tracks[0].ID)
+	s.Equal(first.AlbumID, tracks[0].Album.ID)
+	s.Equal(second.ID, tracks[1].ID)
+}
+
+func (s *ContentAggregatorSuite) TestGetExistingTracksByIDsError() {
+	s.tracks.On("GetTrackMeta", s.ctx, mock.Anything).Return(nil, errors.New("db error"))
+
+	_, err := s.aggregator.GetExistingTracksByIDs(s.ctx, uuid.New())
+	s.Error(err)
+}
@@ -2,6 +2,7 @@ package cover
 
 import (
 	"context"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
@@ -11,27 +12,39 @@ import (
 )
 
 type AlbumCoverRepository interface {
-	GetCover(ctx context.Context, albumID uuid.UUID) (*entity.Cover, error)
-	SaveCover(ctx context.Context, cover *entity.Cover) error
+	GetCover(ctx context.Context, albumID uuid.UUID, size int) (*entity.Cover, error)
+	// SaveCovers stores every size of a cover, replacing the stored sizes only once all are written.
+	SaveCovers(ctx context.Context, covers []*entity.Cover) error
 	DeleteCover(ctx context.Context, albumID uuid.UUID) error
 }
 
+// CoverProcessor validates an uploaded image and encodes it into every one of entity.CoverSizes.
+type CoverProcessor interface {
+	ProcessCover(data []byte) ([]*entity.Cover, error)
+}
+
 type AlbumCoverService struct {
-	repo AlbumCoverRepository
+	repo      AlbumCoverRepository
+	processor CoverProcessor
 }
 
-func New(repo AlbumCoverRepository) *AlbumCoverService {
+func New(repo AlbumCoverRepository, processor CoverProcessor) *AlbumCoverService {
 	return &AlbumCoverService{
-		repo: repo,
+		repo:      repo,
+		processor: processor,
 	}
 }
 
-func (c *AlbumCoverService) GetCover(ctx context.Context, albumID uuid.UUID) (_ *entity.Cover, err error) {
+func (c *AlbumCoverService) GetCover(ctx context.Context, albumID uuid.UUID, size int) (_ *entity.Cover, err error) {
 	defer func() {
 		err = errwrap.WrapIfErr(usecase.ErrGetCover, err)
 	}()
 
-	return c.repo.GetCover(ctx, albumID)
+	if !slices.Contains(entity.CoverSizes, size) {
+		return nil, entity.ErrUnknownCoverSize
+	}
+
+	return c.repo.GetCover(ctx, albumID, size)
 }
 
 func (c *AlbumCoverService) UploadCover(ctx context.Context, claims *entity.Claims, cover *entity.Cover) (err error) {
@@ -43,7 +56,16 @@ func (c *AlbumCoverService) UploadCover(ctx context.Context, claims *entity.Clai
 		return commonerr.ErrForbidden
 	}
 
-	return c.repo.SaveCover(ctx, cover)
+	covers, err := c.processor.ProcessCover(cover.Data)
+	if err != nil {
+		return err
+	}
+
+	for _, sized := range covers {
+		sized.ObjectID = cover.ObjectID
+	}
+
+	return c.repo.SaveCovers(ctx, covers)
 }
 
 func (c *AlbumCoverService) DeleteCover(ctx context.Context, claims *entity.Claims, albumID uuid.UUID) (err error) {
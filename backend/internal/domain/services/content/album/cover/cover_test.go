@@ -39,6 +39,15 @@ func (CoverObjectMother) UserClaims() *entity.Claims {
 	return &entity.Claims{AccessLvl: entity.User}
 }
 
+// Processed returns the covers the processor makes of an upload, the object ID is set by the service.
+func (CoverObjectMother) Processed(albumID uuid.UUID) []*entity.Cover {
+	covers := make([]*entity.Cover, len(entity.CoverSizes))
+	for i, size := range entity.CoverSizes {
+		covers[i] = &entity.Cover{ObjectID: albumID, Size: size, ContentType: "image/png", Data: []byte{byte(i)}}
+	}
+	return covers
+}
+
 // --- Suite ---
 
 type CoverServiceSuite struct {
@@ -47,13 +56,15 @@ type CoverServiceSuite struct {
 	ctx       context.Context
 	service   *cover.AlbumCoverService
 	repo      *mocks.AlbumCoverRepository
+	processor *mocks.CoverProcessor
 	objMother *CoverObjectMother
 }
 
 func (s *CoverServiceSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.repo = mocks.NewAlbumCoverRepository(s.T())
-	s.service = cover.New(s.repo)
+	s.processor = mocks.NewCoverProcessor(s.T())
+	s.service = cover.New(s.repo, s.processor)
 	s.objMother = &CoverObjectMother{}
 }
 
@@ -63,21 +74,29 @@ func (s *CoverServiceSuite) TestGetCover_Success() {
 	albumID := s.objMother.DefaultAlbumID()
 	expected := s.objMother.DefaultCover()
 
-	s.repo.On("GetCover", s.ctx, albumID).Return(expected, nil)
+	s.repo.On("GetCover", s.ctx, albumID, 640).Return(expected, nil)
 
-	result, err := s.service.GetCover(s.ctx, albumID)
+	result, err := s.service.GetCover(s.ctx, albumID, 640)
 
 	s.NoError(err)
 	s.Equal(expected, result)
 	s.repo.AssertExpectations(s.T())
 }
 
+func (s *CoverServiceSuite) TestGetCover_UnknownSize() {
+	result, err := s.service.GetCover(s.ctx, s.objMother.DefaultAlbumID(), 500)
+
+	s.Nil(result)
+	s.ErrorIs(err, entity.ErrUnknownCoverSize)
+	s.ErrorIs(err, usecase.ErrGetCover)
+}
+
 func (s *CoverServiceSuite) TestGetCover_RepoError() {
 	albumID := s.objMother.DefaultAlbumID()
 
-	s.repo.On("GetCover", s.ctx, albumID).Return(nil, errors.New("db error"))
+	s.repo.On("GetCover", s.ctx, albumID, entity.LargestCoverSize).Return(nil, errors.New("db error"))
 
-	result, err := s.service.GetCover(s.ctx, albumID)
+	result, err := s.service.GetCover(s.ctx, albumID, entity.LargestCoverSize)
 
 	s.Error(err)
 	s.Nil(result)
@@ -91,7 +110,8 @@ func (s *CoverServiceSuite) TestUploadCover_AdminSuccess() {
 	claims := s.objMother.AdminClaims()
 	cov := s.objMother.DefaultCover()
 
-	s.repo.On("SaveCover", s.ctx, cov).Return(nil)
+	s.processor.On("ProcessCover", cov.Data).Return(s.objMother.Processed(uuid.Nil), nil)
+	s.repo.On("SaveCovers", s.ctx, s.objMother.Processed(cov.ObjectID)).Return(nil).Once()
 
 	err := s.service.UploadCover(s.ctx, claims, cov)
 
@@ -99,11 +119,24 @@ func (s *CoverServiceSuite) TestUploadCover_AdminSuccess() {
 	s.repo.AssertExpectations(s.T())
 }
 
+func (s *CoverServiceSuite) TestUploadCover_InvalidImage() {
+	claims := s.objMother.AdminClaims()
+	cov := s.objMother.DefaultCover()
+
+	s.processor.On("ProcessCover", cov.Data).Return(nil, commonerr.ErrInvalidImage)
+
+	err := s.service.UploadCover(s.ctx, claims, cov)
+
+	s.ErrorIs(err, commonerr.ErrInvalidImage)
+	s.ErrorIs(err, usecase.ErrUploadCover)
+}
+
 func (s *CoverServiceSuite) TestUploadCover_AdminRepoError() {
 	claims := s.objMother.AdminClaims()
 	cov := s.objMother.DefaultCover()
 
-	s.repo.On("SaveCover", s.ctx, cov).Return(errors.New("db error"))
+	s.processor.On("ProcessCover", cov.Data).Return(s.objMother.Processed(uuid.Nil), nil)
+	s.repo.On("SaveCovers", s.ctx, s.objMother.Processed(cov.ObjectID)).Return(errors.New("db error"))
 
 	err := s.service.UploadCover(s.ctx, claims, cov)
 
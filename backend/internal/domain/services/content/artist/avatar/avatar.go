@@ -2,6 +2,7 @@ package avatar
 
 import (
 	"context"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
@@ -11,27 +12,39 @@ import (
 )
 
 type ArtistAvatarRepository interface {
-	SaveCover(ctx context.Context, cover *entity.Cover) error
-	GetCover(ctx context.Context, artistID uuid.UUID) (*entity.Cover, error)
+	// SaveCovers stores every size of a cover, replacing the stored sizes only once all are written.
+	SaveCovers(ctx context.Context, covers []*entity.Cover) error
+	GetCover(ctx context.Context, artistID uuid.UUID, size int) (*entity.Cover, error)
 	DeleteCover(ctx context.Context, artistID uuid.UUID) error
 }
 
+// CoverProcessor validates an uploaded image and encodes it into every one of entity.CoverSizes.
+type CoverProcessor interface {
+	ProcessCover(data []byte) ([]*entity.Cover, error)
+}
+
 type ArtistCoverService struct {
-	repo ArtistAvatarRepository
+	repo      ArtistAvatarRepository
+	processor CoverProcessor
 }
 
-func NewArtistCoverService(repo ArtistAvatarRepository) *ArtistCoverService {
+func NewArtistCoverService(repo ArtistAvatarRepository, processor CoverProcessor) *ArtistCoverService {
 	return &ArtistCoverService{
-		repo: repo,
+		repo:      repo,
+		processor: processor,
 	}
 }
 
-func (s *ArtistCoverService) GetCover(ctx context.Context, artistID uuid.UUID) (_ *entity.Cover, err error) {
+func (s *ArtistCoverService) GetCover(ctx context.Context, artistID uuid.UUID, size int) (_ *entity.Cover, err error) {
 	defer func() {
 		err = errwrap.WrapIfErr(usecase.ErrGetAvatar, err)
 	}()
 
-	return s.repo.GetCover(ctx, artistID)
+	if !slices.Contains(entity.CoverSizes, size) {
+		return nil, entity.ErrUnknownCoverSize
+	}
+
+	return s.repo.GetCover(ctx, artistID, size)
 }
 
 func (s *ArtistCoverService) UploadCover(ctx context.Context, claims *entity.Claims, cover *entity.Cover) (err error) {
@@ -43,7 +56,16 @@ func (s *ArtistCoverService) UploadCover(ctx context.Context, claims *entity.Cla
 		return commonerr.ErrForbidden
 	}
 
-	return s.repo.SaveCover(ctx, cover)
+	avatars, err := s.processor.ProcessCover(cover.Data)
+	if err != nil {
+		return err
+	}
+
+	for _, avatar := range avatars {
+		avatar.ObjectID = cover.ObjectID
+	}
+
+	return s.repo.SaveCovers(ctx, avatars)
 }
 
 func (s *ArtistCoverService) DeleteCover(ctx context.Context, claims *entity.Claims, artistID uuid.UUID) (err error) {
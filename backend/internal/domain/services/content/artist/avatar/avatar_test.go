@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
@@ -38,6 +39,15 @@ func (ArtistAvatarObjectMother) DefaultCover() *entity.Cover {
 	}
 }
 
+// Processed returns the avatars the processor makes of an upload, the object ID is set by the service.
+func (ArtistAvatarObjectMother) Processed(artistID uuid.UUID) []*entity.Cover {
+	avatars := make([]*entity.Cover, len(entity.CoverSizes))
+	for i, size := range entity.CoverSizes {
+		avatars[i] = &entity.Cover{ObjectID: artistID, Size: size, ContentType: "image/jpeg", Data: []byte{byte(i)}}
+	}
+	return avatars
+}
+
 // --- Suite ---
 
 type ArtistAvatarServiceSuite struct {
@@ -45,6 +55,7 @@ type ArtistAvatarServiceSuite struct {
 
 	ctx       context.Context
 	repo      *mocks.ArtistAvatarRepository
+	processor *mocks.CoverProcessor
 	service   *avatar.ArtistCoverService
 	objMother *ArtistAvatarObjectMother
 }
@@ -56,7 +67,8 @@ func TestArtistAvatarServiceSuite(t *testing.T) {
 func (s *ArtistAvatarServiceSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.repo = mocks.NewArtistAvatarRepository(s.T())
-	s.service = avatar.NewArtistCoverService(s.repo)
+	s.processor = mocks.NewCoverProcessor(s.T())
+	s.service = avatar.NewArtistCoverService(s.repo, s.processor)
 	s.objMother = &ArtistAvatarObjectMother{}
 }
 
@@ -66,21 +78,29 @@ func (s *ArtistAvatarServiceSuite) TestGetCover_Success() {
 	artistID := s.objMother.DefaultArtistID()
 	expected := s.objMother.DefaultCover()
 
-	s.repo.On("GetCover", s.ctx, artistID).Return(expected, nil)
+	s.repo.On("GetCover", s.ctx, artistID, 64).Return(expected, nil)
 
-	got, err := s.service.GetCover(s.ctx, artistID)
+	got, err := s.service.GetCover(s.ctx, artistID, 64)
 
 	s.NoError(err)
 	s.Equal(expected, got)
 	s.repo.AssertExpectations(s.T())
 }
 
+func (s *ArtistAvatarServiceSuite) TestGetCover_UnknownSize() {
+	got, err := s.service.GetCover(s.ctx, s.objMother.DefaultArtistID(), 0)
+
+	s.ErrorIs(err, entity.ErrUnknownCoverSize)
+	s.ErrorIs(err, usecase.ErrGetAvatar)
+	s.Nil(got)
+}
+
 func (s *ArtistAvatarServiceSuite) TestGetCover_RepoError() {
 	artistID := s.objMother.DefaultArtistID()
 
-	s.repo.On("GetCover", s.ctx, artistID).Return(nil, errors.New("repo error"))
+	s.repo.On("GetCover", s.ctx, artistID, entity.LargestCoverSize).Return(nil, errors.New("repo error"))
 
-	got, err := s.service.GetCover(s.ctx, artistID)
+	got, err := s.service.GetCover(s.ctx, artistID, entity.LargestCoverSize)
 
 	s.ErrorIs(err, usecase.ErrGetAvatar)
 	s.Nil(got)
@@ -93,7 +113,8 @@ func (s *ArtistAvatarServiceSuite) TestUploadCover_Success() {
 	admin := s.objMother.AdminClaims()
 	cover := s.objMother.DefaultCover()
 
-	s.repo.On("SaveCover", s.ctx, cover).Return(nil)
+	s.processor.On("ProcessCover", cover.Data).Return(s.objMother.Processed(uuid.Nil), nil)
+	s.repo.On("SaveCovers", s.ctx, s.objMother.Processed(cover.ObjectID)).Return(nil).Once()
 
 	err := s.service.UploadCover(s.ctx, admin, cover)
 
@@ -101,6 +122,19 @@ func (s *ArtistAvatarServiceSuite) TestUploadCover_Success() {
 	s.repo.AssertExpectations(s.T())
 }
 
+func (s *ArtistAvatarServiceSuite) TestUploadCover_InvalidImage() {
+	admin := s.objMother.AdminClaims()
+	cover := s.objMother.DefaultCover()
+
+	s.processor.On("ProcessCover", cover.Data).Return(nil, commonerr.ErrInvalidImage)
+
+	err := s.service.UploadCover(s.ctx, admin, cover)
+
+	s.ErrorIs(err, commonerr.ErrInvalidImage)
+	s.ErrorIs(err, usecase.ErrUploadAvatar)
+	s.repo.AssertNotCalled(s.T(), "SaveCovers", mock.Anything, mock.Anything)
+}
+
 func (s *ArtistAvatarServiceSuite) TestUploadCover_Forbidden() {
 	user := s.objMother.UserClaims()
 	cover := s.objMother.DefaultCover()
@@ -108,14 +142,15 @@ func (s *ArtistAvatarServiceSuite) TestUploadCover_Forbidden() {
 	err := s.service.UploadCover(s.ctx, user, cover)
 
 	s.ErrorIs(err, commonerr.ErrForbidden)
-	s.repo.AssertNotCalled(s.T(), "SaveCover", s.ctx, cover)
+	s.repo.AssertNotCalled(s.T(), "SaveCovers", mock.Anything, mock.Anything)
 }
 
 func (s *ArtistAvatarServiceSuite) TestUploadCover_RepoError() {
 	admin := s.objMother.AdminClaims()
 	cover := s.objMother.DefaultCover()
 
-	s.repo.On("SaveCover", s.ctx, cover).Return(errors.New("db error"))
+	s.processor.On("ProcessCover", cover.Data).Return(s.objMother.Processed(uuid.Nil), nil)
+	s.repo.On("SaveCovers", s.ctx, s.objMother.Processed(cover.ObjectID)).Return(errors.New("db error"))
 
 	err := s.service.UploadCover(s.ctx, admin, cover)
 
@@ -0,0 +1,157 @@
+package cover
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/album"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/playlist"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// collageTiles is the number of album covers in a full collage, fewer albums give the first cover alone.
+	collageTiles = 4
+
+	// The playlists without album covers are remembered for a while, album covers
+	// uploaded meanwhile don't change the playlist, so they show up after the TTL.
+	noCollageCacheSize = 1024
+	noCollageTTL       = 10 * time.Minute
+)
+
+// collageNamespace derives the IDs the collages are stored under next to the uploaded covers.
+var collageNamespace = uuid.MustParse("5b0c6f3e-2d7a-4c1e-9a43-6f1d2e8b7c90")
+
+// CollageMaker tiles the album covers into a playlist cover and encodes it into every one of entity.CoverSizes.
+type CollageMaker interface {
+	MakeCollage(images [][]byte) ([]*entity.Cover, error)
+}
+
+type collages struct {
+	meta        usecase.PlaylistMetaService
+	tracks      usecase.PlaylistTrackService
+	albumCovers album.AlbumCoverService
+	maker       CollageMaker
+
+	// the updated_at of the playlists found without album covers
+	noCollage *expirable.LRU[uuid.UUID, time.Time]
+	// one collage of a playlist is made at a time, concurrent requests share it
+	making singleflight.Group
+}
+
+func WithCollages(meta usecase.PlaylistMetaService, tracks usecase.PlaylistTrackService,
+	albumCovers album.AlbumCoverService, maker CollageMaker) OptionFunc {
+	return func(c *PlaylistCoverService) {
+		c.collages = &collages{
+			meta:        meta,
+			tracks:      tracks,
+			albumCovers: albumCovers,
+			maker:       maker,
+			noCollage:   expirable.NewLRU[uuid.UUID, time.Time](noCollageCacheSize, nil, noCollageTTL),
+		}
+	}
+}
+
+func collageID(playlistID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(collageNamespace, playlistID[:])
+}
+
+// collage serves the stored collage unless the playlist changed after it was made. Changes of
+// the track list bump the playlist's updated_at, so they always make a new collage.
+func (c *PlaylistCoverService) collage(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID,
+	size int) (*entity.Cover, error) {
+	meta, err := c.collages.meta.GetMeta(ctx, claims, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if checked, ok := c.collages.noCollage.Get(playlistID); ok && !meta.UpdatedAt.After(checked) {
+		return nil, commonerr.ErrNotFound
+	}
+
+	id := collageID(playlistID)
+	cached, err := c.repo.GetCover(ctx, id, size)
+	if err != nil && !errors.Is(err, commonerr.ErrNotFound) {
+		return nil, err
+	}
+
+	if err != nil || cached.ModTime.Before(meta.UpdatedAt) {
+		_, err, _ = c.collages.making.Do(playlistID.String(), func() (any, error) {
+			return nil, c.makeCollage(ctx, claims, playlistID)
+		})
+		if errors.Is(err, commonerr.ErrNotFound) {
+			c.collages.noCollage.Add(playlistID, meta.UpdatedAt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if cached, err = c.repo.GetCover(ctx, id, size); err != nil {
+			return nil, err
+		}
+	}
+
+	cached.ObjectID = playlistID
+	cached.Generated = true
+
+	return cached, nil
+}
+
+// makeCollage stores the collage of the covers of the first distinct albums
+// in the playlist, albums without a cover are skipped.
+func (c *PlaylistCoverService) makeCollage(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) error {
+	tracks, err := c.collages.tracks.GetAllTracks(ctx, claims, playlistID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[uuid.UUID]struct{}, collageTiles)
+	images := make([][]byte, 0, collageTiles)
+	for _, track := range tracks {
+		if len(images) == collageTiles {
+			break
+		}
+		if _, ok := seen[track.AlbumID]; ok || track.AlbumID == uuid.Nil {
+			continue
+		}
+		seen[track.AlbumID] = struct{}{}
+
+		cover, err := c.collages.albumCovers.GetCover(ctx, track.AlbumID, collageTileSize())
+		if errors.Is(err, commonerr.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		images = append(images, cover.Data)
+	}
+
+	if len(images) == 0 {
+		return commonerr.ErrNotFound
+	}
+
+	covers, err := c.collages.maker.MakeCollage(images)
+	if err != nil {
+		return err
+	}
+
+	for _, cover := range covers {
+		cover.ObjectID = collageID(playlistID)
+	}
+
+	return c.repo.SaveCovers(ctx, covers)
+}
+
+// collageTileSize is the smallest album cover size filling a tile of the largest collage.
+func collageTileSize() int {
+	for _, size := range entity.CoverSizes {
+		if size*2 >= entity.LargestCoverSize {
+			return size
+		}
+	}
+	return entity.LargestCoverSize
+}
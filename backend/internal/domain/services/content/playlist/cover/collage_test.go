@@ -0,0 +1,225 @@
+package cover
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/playlist"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CollageObjectMother struct{}
+
+func (CollageObjectMother) Tracks(albumIDs ...uuid.UUID) []*entity.TrackMeta {
+	tracks := make([]*entity.TrackMeta, len(albumIDs))
+	for i, albumID := range albumIDs {
+		tracks[i] = &entity.TrackMeta{ID: uuid.New(), AlbumID: albumID}
+	}
+	return tracks
+}
+
+func (CollageObjectMother) AlbumCover(albumID uuid.UUID) *entity.Cover {
+	return &entity.Cover{ObjectID: albumID, Size: 640, Data: []byte(albumID.String())}
+}
+
+func (CollageObjectMother) Collage() []*entity.Cover {
+	covers := make([]*entity.Cover, len(entity.CoverSizes))
+	for i, size := range entity.CoverSizes {
+		covers[i] = &entity.Cover{Size: size, ContentType: "image/jpeg", Data: []byte{byte(i)}}
+	}
+	return covers
+}
+
+type PlaylistCollageSuite struct {
+	suite.Suite
+
+	ctx         context.Context
+	policy      *mocks.PlaylistPolicyService
+	repo        *mocks.PlaylistCoverRepository
+	meta        *mocks.PlaylistMetaService
+	tracks      *mocks.PlaylistTrackService
+	albumCovers *mocks.AlbumCoverService
+	maker       *mocks.CollageMaker
+	svc         *PlaylistCoverService
+	mother      CollageObjectMother
+
+	claims     *entity.Claims
+	playlistID uuid.UUID
+	updatedAt  time.Time
+}
+
+func TestPlaylistCollageSuite(t *testing.T) {
+	suite.Run(t, new(PlaylistCollageSuite))
+}
+
+func (s *PlaylistCollageSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.policy = mocks.NewPlaylistPolicyService(s.T())
+	s.repo = mocks.NewPlaylistCoverRepository(s.T())
+	s.meta = mocks.NewPlaylistMetaService(s.T())
+	s.tracks = mocks.NewPlaylistTrackService(s.T())
+	s.albumCovers = mocks.NewAlbumCoverService(s.T())
+	s.maker = mocks.NewCollageMaker(s.T())
+	s.svc = New(s.repo, s.policy, mocks.NewCoverProcessor(s.T()),
+		WithCollages(s.meta, s.tracks, s.albumCovers, s.maker))
+
+	s.claims = &entity.Claims{UserID: uuid.New()}
+	s.playlistID = uuid.New()
+	s.updatedAt = time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	s.policy.On("CanView", s.ctx, s.claims, s.playlistID).Return(nil).Maybe()
+}
+
+func (s *PlaylistCollageSuite) expectNoUpload() {
+	s.repo.On("GetCover", s.ctx, s.playlistID, 300).Return(nil, commonerr.ErrNotFound)
+	s.meta.On("GetMeta", s.ctx, s.claims, s.playlistID).
+		Return(&entity.PlaylistMeta{ID: s.playlistID, UpdatedAt: s.updatedAt}, nil)
+}
+
+func (s *PlaylistCollageSuite) expectCollageSaved(made time.Time) {
+	collage := s.mother.Collage()
+	s.maker.On("MakeCollage", mock.Anything).Return(collage, nil)
+	s.repo.On("SaveCovers", s.ctx, mock.MatchedBy(func(covers []*entity.Cover) bool {
+		for i, c := range covers {
+			if c.ObjectID != collageID(s.playlistID) || c.Size != collage[i].Size {
+				return false
+			}
+		}
+		return len(covers) == len(collage)
+	})).Return(nil).Once()
+	s.repo.On("GetCover", s.ctx, collageID(s.playlistID), 300).
+		Return(&entity.Cover{ObjectID: collageID(s.playlistID), Size: 300, Data: []byte{1}, ModTime: made}, nil).Once()
+}
+
+func (s *PlaylistCollageSuite) TestUploadedCoverWins() {
+	uploaded := &entity.Cover{ObjectID: s.playlistID, Size: 300, Data: []byte("uploaded")}
+	s.repo.On("GetCover", s.ctx, s.playlistID, 300).Return(uploaded, nil)
+
+	cover, err := s.svc.GetCover(s.ctx, s.claims, s.playlistID, 300)
+
+	s.Require().NoError(err)
+	s.Equal(uploaded, cover)
+	s.False(cover.Generated)
+}
+
+func (s *PlaylistCollageSuite) TestMakesCollageOfFirstDistinctAlbums() {
+	a, b, noCover, c, d, e := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s.expectNoUpload()
+	s.repo.On("GetCover", s.ctx, collageID(s.playlistID), 300).Return(nil, commonerr.ErrNotFound).Once()
+	s.tracks.On("GetAllTracks", s.ctx, s.claims, s.playlistID).
+		Return(s.mother.Tracks(a, a, b, uuid.Nil, noCover, b, c, d, e), nil)
+	for _, albumID := range []uuid.UUID{a, b, c, d} {
+		s.albumCovers.On("GetCover", s.ctx, albumID, 640).Return(s.mother.AlbumCover(albumID), nil)
+	}
+	s.albumCovers.On("GetCover", s.ctx, noCover, 640).Return(nil, commonerr.ErrNotFound)
+	s.expectCollageSaved(s.updatedAt.Add(time.Second))
+
+	cover, err := s.svc.GetCover(s.ctx, s.claims, s.playlistID, 300)
+
+	s.Require().NoError(err)
+	s.True(cover.Generated)
+	s.Equal(s.playlistID, cover.ObjectID)
+	s.maker.AssertCalled(s.T(), "MakeCollage", [][]byte{
+		[]byte(a.String()), []byte(b.String()), []byte(c.String()), []byte(d.String()),
+	})
+	s.albumCovers.AssertNotCalled(s.T(), "GetCover", s.ctx, e, 640)
+}
+
+func (s *PlaylistCollageSuite) TestServesFreshCollage() {
+	s.expectNoUpload()
+	cached := &entity.Cover{ObjectID: collageID(s.playlistID), Size: 300, Data: []byte{1}, ModTime: s.updatedAt}
+	s.repo.On("GetCover", s.ctx, collageID(s.playlistID), 300).Return(cached, nil)
+
+	cover, err := s.svc.GetCover(s.ctx, s.claims, s.playlistID, 300)
+
+	s.Require().NoError(err)
+	s.True(cover.Generated)
+	s.Equal(cached.Data, cover.Data)
+	s.tracks.AssertNotCalled(s.T(), "GetAllTracks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *PlaylistCollageSuite) TestRemakesStaleCollage() {
+	albumID := uuid.New()
+	s.expectNoUpload()
+	stale := &entity.Cover{ObjectID: collageID(s.playlistID), Size: 300, ModTime: s.updatedAt.Add(-time.Minute)}
+	s.repo.On("GetCover", s.ctx, collageID(s.playlistID), 300).Return(stale, nil).Once()
+	s.tracks.On("GetAllTracks", s.ctx, s.claims, s.playlistID).Return(s.mother.Tracks(albumID), nil)
+	s.albumCovers.On("GetCover", s.ctx, albumID, 640).Return(s.mother.AlbumCover(albumID), nil)
+	s.expectCollageSaved(s.updatedAt.Add(time.Second))
+
+	cover, err := s.svc.GetCover(s.ctx, s.claims, s.playlistID, 300)
+
+	s.Require().NoError(err)
+	s.Equal(s.updatedAt.Add(time.Second), cover.ModTime)
+}
+
+func (s *PlaylistCollageSuite) TestNoAlbumCovers() {
+	albumID := uuid.New()
+	s.expectNoUpload()
+	s.repo.On("GetCover", s.ctx, collageID(s.playlistID), 300).Return(nil, commonerr.ErrNotFound)
+	s.tracks.On("GetAllTracks", s.ctx, s.claims, s.playlistID).Return(s.mother.Tracks(albumID), nil).Once()
+	s.albumCovers.On("GetCover", s.ctx, albumID, 640).Return(nil, commonerr.ErrNotFound).Once()
+
+	cover, err := s.svc.GetCover(s.ctx, s.claims, s.playlistID, 300)
+
+	s.Nil(cover)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+	s.ErrorIs(err, usecase.ErrGetCover)
+
+	// remembered until the playlist changes
+	_, err = s.svc.GetCover(s.ctx, s.claims, s.playlistID, 300)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+func (s *PlaylistCollageSuite) TestConcurrentRequestsMakeOneCollage() {
+	albumID := uuid.New()
+	s.expectNoUpload()
+	s.repo.On("GetCover", s.ctx, collageID(s.playlistID), 300).Return(nil, commonerr.ErrNotFound).Twice()
+	s.tracks.On("GetAllTracks", s.ctx, s.claims, s.playlistID).Return(s.mother.Tracks(albumID), nil).Once()
+	s.albumCovers.On("GetCover", s.ctx, albumID, 640).Return(s.mother.AlbumCover(albumID), nil).Once()
+
+	making, release := make(chan struct{}), make(chan struct{})
+	collage := s.mother.Collage()
+	s.maker.On("MakeCollage", mock.Anything).Run(func(mock.Arguments) {
+		close(making)
+		<-release
+	}).Return(collage, nil).Once()
+	s.repo.On("SaveCovers", s.ctx, mock.Anything).Return(nil).Once()
+	s.repo.On("GetCover", s.ctx, collageID(s.playlistID), 300).
+		Return(func(context.Context, uuid.UUID, int) *entity.Cover {
+			return &entity.Cover{ObjectID: collageID(s.playlistID), Size: 300, ModTime: s.updatedAt}
+		}, nil).Twice()
+
+	var wg sync.WaitGroup
+	get := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.svc.GetCover(s.ctx, s.claims, s.playlistID, 300)
+			s.NoError(err)
+		}()
+	}
+
+	get()
+	<-making
+	// the second request joins the collage in progress
+	get()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func (s *PlaylistCollageSuite) TestDeleteCoverDeletesCollage() {
+	s.policy.On("CanDelete", s.ctx, s.claims, s.playlistID).Return(nil)
+	s.repo.On("DeleteCover", s.ctx, s.playlistID).Return(nil)
+	s.repo.On("DeleteCover", s.ctx, collageID(s.playlistID)).Return(nil)
+
+	s.NoError(s.svc.DeleteCover(s.ctx, s.claims, s.playlistID))
+}
@@ -2,42 +2,73 @@ package cover
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/playlist"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
 )
 
 type PlaylistCoverRepository interface {
-	SaveCover(ctx context.Context, cover *entity.Cover) error
-	GetCover(ctx context.Context, playlistID uuid.UUID) (*entity.Cover, error)
+	// SaveCovers stores every size of a cover, replacing the stored sizes only once all are written.
+	SaveCovers(ctx context.Context, covers []*entity.Cover) error
+	GetCover(ctx context.Context, playlistID uuid.UUID, size int) (*entity.Cover, error)
 	DeleteCover(ctx context.Context, playlistID uuid.UUID) error
 }
 
+// CoverProcessor validates an uploaded image and encodes it into every one of entity.CoverSizes.
+type CoverProcessor interface {
+	ProcessCover(data []byte) ([]*entity.Cover, error)
+}
+
 type PlaylistCoverService struct {
-	policy usecase.PlaylistPolicyService
-	repo   PlaylistCoverRepository
+	policy    usecase.PlaylistPolicyService
+	repo      PlaylistCoverRepository
+	processor CoverProcessor
+	collages  *collages
 }
 
-func New(repo PlaylistCoverRepository, policy usecase.PlaylistPolicyService) *PlaylistCoverService {
-	return &PlaylistCoverService{
-		policy: policy,
-		repo:   repo,
+type OptionFunc func(*PlaylistCoverService)
+
+func New(repo PlaylistCoverRepository, policy usecase.PlaylistPolicyService, processor CoverProcessor,
+	options ...OptionFunc) *PlaylistCoverService {
+	service := &PlaylistCoverService{
+		policy:    policy,
+		repo:      repo,
+		processor: processor,
 	}
+	for _, configure := range options {
+		configure(service)
+	}
+
+	return service
 }
 
-func (c *PlaylistCoverService) GetCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) (_ *entity.Cover, err error) {
+// GetCover falls back to a collage of the album covers if no cover was uploaded and collages are enabled.
+func (c *PlaylistCoverService) GetCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID,
+	size int) (_ *entity.Cover, err error) {
 	defer func() {
 		err = errwrap.WrapIfErr(usecase.ErrGetCover, err)
 	}()
 
+	if !slices.Contains(entity.CoverSizes, size) {
+		return nil, entity.ErrUnknownCoverSize
+	}
+
 	err = c.policy.CanView(ctx, claims, playlistID)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.repo.GetCover(ctx, playlistID)
+	cover, err := c.repo.GetCover(ctx, playlistID, size)
+	if errors.Is(err, commonerr.ErrNotFound) && c.collages != nil {
+		return c.collage(ctx, claims, playlistID, size)
+	}
+
+	return cover, err
 }
 
 func (c *PlaylistCoverService) UploadCover(ctx context.Context, claims *entity.Claims, cover *entity.Cover) (err error) {
@@ -50,7 +81,16 @@ func (c *PlaylistCoverService) UploadCover(ctx context.Context, claims *entity.C
 		return err
 	}
 
-	return c.repo.SaveCover(ctx, cover)
+	covers, err := c.processor.ProcessCover(cover.Data)
+	if err != nil {
+		return err
+	}
+
+	for _, sized := range covers {
+		sized.ObjectID = cover.ObjectID
+	}
+
+	return c.repo.SaveCovers(ctx, covers)
 }
 
 func (c *PlaylistCoverService) DeleteCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) (err error) {
@@ -63,5 +103,12 @@ func (c *PlaylistCoverService) DeleteCover(ctx context.Context, claims *entity.C
 		return err
 	}
 
-	return c.repo.DeleteCover(ctx, playlistID)
+	if err = c.repo.DeleteCover(ctx, playlistID); err != nil {
+		return err
+	}
+	if c.collages != nil {
+		return c.repo.DeleteCover(ctx, collageID(playlistID))
+	}
+
+	return nil
 }
@@ -24,14 +24,24 @@ func (PlaylistCoverObjectMother) Cover(playlistID uuid.UUID) *entity.Cover {
 	return &entity.Cover{ObjectID: playlistID, Data: []byte("image")}
 }
 
+// Processed returns the covers the processor makes of an upload, the object ID is set by the service.
+func (PlaylistCoverObjectMother) Processed(playlistID uuid.UUID) []*entity.Cover {
+	covers := make([]*entity.Cover, len(entity.CoverSizes))
+	for i, size := range entity.CoverSizes {
+		covers[i] = &entity.Cover{ObjectID: playlistID, Size: size, ContentType: "image/jpeg", Data: []byte{byte(i)}}
+	}
+	return covers
+}
+
 type PlaylistCoverServiceSuite struct {
 	suite.Suite
 
-	ctx    context.Context
-	policy *mocks.PlaylistPolicyService
-	repo   *mocks.PlaylistCoverRepository
-	svc    *PlaylistCoverService
-	mother PlaylistCoverObjectMother
+	ctx       context.Context
+	policy    *mocks.PlaylistPolicyService
+	repo      *mocks.PlaylistCoverRepository
+	processor *mocks.CoverProcessor
+	svc       *PlaylistCoverService
+	mother    PlaylistCoverObjectMother
 }
 
 func TestPlaylistCoverServiceSuite(t *testing.T) {
@@ -42,7 +52,8 @@ func (s *PlaylistCoverServiceSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.policy = mocks.NewPlaylistPolicyService(s.T())
 	s.repo = mocks.NewPlaylistCoverRepository(s.T())
-	s.svc = New(s.repo, s.policy)
+	s.processor = mocks.NewCoverProcessor(s.T())
+	s.svc = New(s.repo, s.policy, s.processor)
 	s.mother = PlaylistCoverObjectMother{}
 }
 
@@ -56,18 +67,27 @@ func (s *PlaylistCoverServiceSuite) TestGetCover() {
 	s.Run("success", func() {
 		s.SetupTest()
 		s.policy.On("CanView", s.ctx, claims, playlistID).Return(nil)
-		s.repo.On("GetCover", s.ctx, playlistID).Return(cov, nil)
+		s.repo.On("GetCover", s.ctx, playlistID, 300).Return(cov, nil)
 
-		result, err := s.svc.GetCover(s.ctx, claims, playlistID)
+		result, err := s.svc.GetCover(s.ctx, claims, playlistID, 300)
 		s.NoError(err)
 		s.Equal(cov, result)
 	})
 
+	s.Run("unknown size", func() {
+		s.SetupTest()
+
+		result, err := s.svc.GetCover(s.ctx, claims, playlistID, 100)
+		s.Nil(result)
+		s.ErrorIs(err, entity.ErrUnknownCoverSize)
+		s.ErrorIs(err, usecase.ErrGetCover)
+	})
+
 	s.Run("policy denied", func() {
 		s.SetupTest()
 		s.policy.On("CanView", s.ctx, claims, playlistID).Return(commonerr.ErrForbidden)
 
-		result, err := s.svc.GetCover(s.ctx, claims, playlistID)
+		result, err := s.svc.GetCover(s.ctx, claims, playlistID, entity.LargestCoverSize)
 		s.Nil(result)
 		s.ErrorIs(err, usecase.ErrGetCover)
 	})
@@ -75,9 +95,9 @@ func (s *PlaylistCoverServiceSuite) TestGetCover() {
 	s.Run("repo error", func() {
 		s.SetupTest()
 		s.policy.On("CanView", s.ctx, claims, playlistID).Return(nil)
-		s.repo.On("GetCover", s.ctx, playlistID).Return(nil, errors.New("repo fail"))
+		s.repo.On("GetCover", s.ctx, playlistID, entity.LargestCoverSize).Return(nil, errors.New("repo fail"))
 
-		result, err := s.svc.GetCover(s.ctx, claims, playlistID)
+		result, err := s.svc.GetCover(s.ctx, claims, playlistID, entity.LargestCoverSize)
 		s.Nil(result)
 		s.ErrorIs(err, usecase.ErrGetCover)
 	})
@@ -93,12 +113,23 @@ func (s *PlaylistCoverServiceSuite) TestUploadCover() {
 	s.Run("success", func() {
 		s.SetupTest()
 		s.policy.On("CanEdit", s.ctx, claims, playlistID).Return(nil)
-		s.repo.On("SaveCover", s.ctx, cov).Return(nil)
+		s.processor.On("ProcessCover", cov.Data).Return(s.mother.Processed(uuid.Nil), nil)
+		s.repo.On("SaveCovers", s.ctx, s.mother.Processed(playlistID)).Return(nil).Once()
 
 		err := s.svc.UploadCover(s.ctx, claims, cov)
 		s.NoError(err)
 	})
 
+	s.Run("invalid image", func() {
+		s.SetupTest()
+		s.policy.On("CanEdit", s.ctx, claims, playlistID).Return(nil)
+		s.processor.On("ProcessCover", cov.Data).Return(nil, commonerr.ErrInvalidImage)
+
+		err := s.svc.UploadCover(s.ctx, claims, cov)
+		s.ErrorIs(err, commonerr.ErrInvalidImage)
+		s.ErrorIs(err, usecase.ErrUploadCover)
+	})
+
 	s.Run("policy denied", func() {
 		s.SetupTest()
 		s.policy.On("CanEdit", s.ctx, claims, playlistID).Return(commonerr.ErrForbidden)
@@ -110,7 +141,8 @@ func (s *PlaylistCoverServiceSuite) TestUploadCover() {
 	s.Run("repo error", func() {
 		s.SetupTest()
 		s.policy.On("CanEdit", s.ctx, claims, playlistID).Return(nil)
-		s.repo.On("SaveCover", s.ctx, cov).Return(errors.New("save fail"))
+		s.processor.On("ProcessCover", cov.Data).Return(s.mother.Processed(uuid.Nil), nil)
+		s.repo.On("SaveCovers", s.ctx, s.mother.Processed(playlistID)).Return(errors.New("save fail"))
 
 		err := s.svc.UploadCover(s.ctx, claims, cov)
 		s.ErrorIs(err, usecase.ErrUploadCover)
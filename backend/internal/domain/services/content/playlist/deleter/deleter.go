@@ -29,9 +29,7 @@ type FavoritesDeletionService interface {
 }
 
 type PlaylistCoverDeletionService interface {
-	GetCover(ctx context.Context, claims *entity.Claims, objectID uuid.UUID) (*entity.Cover, error)
 	DeleteCover(ctx context.Context, claims *entity.Claims, objectID uuid.UUID) error
-	UploadCover(ctx context.Context, claims *entity.Claims, cover *entity.Cover) error
 }
 
 type PlaylistDeleter struct {
@@ -99,20 +97,21 @@ func (p *PlaylistDeleter) DeletePlaylist(ctx context.Context, claims *entity.Cla
 		rollbacks = append(rollbacks, rollback)
 	}
 
-	if p.cover != nil {
-		rollback, err := p.deleteCover(ctx, claims, playlistID)
+	if p.tracks != nil {
+		rollback, err := p.deleteAllTracks(ctx, claims, playlistID)
 		if err != nil {
 			return err
 		}
 		rollbacks = append(rollbacks, rollback)
 	}
 
-	if p.tracks != nil {
-		rollback, err := p.deleteAllTracks(ctx, claims, playlistID)
-		if err != nil {
+	// the cover can't be restored, so it goes right before the meta. A playlist
+	// left after a failed meta deletion gets a collage instead.
+	if p.cover != nil {
+		err = p.cover.DeleteCover(ctx, claims, playlistID)
+		if err != nil && !errors.Is(err, commonerr.ErrNotFound) {
 			return err
 		}
-		rollbacks = append(rollbacks, rollback)
 	}
 
 	if p.meta != nil {
@@ -167,27 +166,6 @@ func (p *PlaylistDeleter) deleteAllTracks(ctx context.Context, claims *entity.Cl
 	}, nil
 }
 
-func (p *PlaylistDeleter) deleteCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) (rollback, error) {
-	cover, err := p.cover.GetCover(ctx, claims, playlistID)
-	if errors.Is(err, commonerr.ErrNotFound) {
-		return func() error {
-			return nil
-		}, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	err = p.cover.DeleteCover(ctx, claims, playlistID)
-	if err != nil {
-		return nil, err
-	}
-
-	return func() error {
-		return p.cover.UploadCover(ctx, claims, cover)
-	}, nil
-}
-
 func (p *PlaylistDeleter) deleteMeta(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) error {
 	return p.meta.DeleteMeta(ctx, claims, playlistID)
 }
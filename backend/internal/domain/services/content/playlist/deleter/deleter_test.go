@@ -23,9 +23,6 @@ func (PlaylistDeleterObjectMother) TrackMeta() []*entity.TrackMeta {
 	return []*entity.TrackMeta{{ID: uuid.New()}}
 }
 func (PlaylistDeleterObjectMother) UserIDs() []uuid.UUID { return []uuid.UUID{uuid.New()} }
-func (PlaylistDeleterObjectMother) Cover() *entity.Cover {
-	return &entity.Cover{ObjectID: uuid.New(), Data: []byte("image")}
-}
 
 type PlaylistDeleterSuite struct {
 	suite.Suite
@@ -58,7 +55,6 @@ func (s *PlaylistDeleterSuite) TestDeletePlaylistSuccess() {
 	playlistID := s.mother.PlaylistID()
 	tracks := s.mother.TrackMeta()
 	userIDs := s.mother.UserIDs()
-	cover := s.mother.Cover()
 
 	// Настройка моков для успешного выполнения
 	s.meta.On("DeleteMeta", s.ctx, claims, playlistID).Return(nil)
@@ -66,7 +62,6 @@ func (s *PlaylistDeleterSuite) TestDeletePlaylistSuccess() {
 	s.tracks.On("DeleteAllTracks", s.ctx, claims, playlistID).Return(nil)
 	s.fav.On("GetUsersWithFavoritePlaylist", s.ctx, claims, playlistID, true).Return(userIDs, nil)
 	s.fav.On("DeleteFromAllFavorites", s.ctx, claims, playlistID, true).Return(nil)
-	s.coverSvc.On("GetCover", s.ctx, claims, playlistID).Return(cover, nil)
 	s.coverSvc.On("DeleteCover", s.ctx, claims, playlistID).Return(nil)
 
 	svc := deleter.New(
@@ -108,7 +103,7 @@ func (s *PlaylistDeleterSuite) TestDeletePlaylistCoverError() {
 	s.fav.On("GetUsersWithFavoritePlaylist", s.ctx, claims, playlistID, true).Return(userIDs, nil)
 	s.fav.On("DeleteFromAllFavorites", s.ctx, claims, playlistID, true).Return(nil)
 	s.fav.On("AddPlaylistToAllFavorites", s.ctx, claims, userIDs, playlistID).Return(nil)
-	s.coverSvc.On("GetCover", s.ctx, claims, playlistID).Return(nil, errors.New("cover fail"))
+	s.coverSvc.On("DeleteCover", s.ctx, claims, playlistID).Return(errors.New("cover fail"))
 
 	svc := deleter.New(
 		deleter.WithMetaDeletion(s.meta),
@@ -125,15 +120,10 @@ func (s *PlaylistDeleterSuite) TestDeletePlaylistTrackError() {
 	claims := s.mother.Claims()
 	playlistID := s.mother.PlaylistID()
 	userIDs := s.mother.UserIDs()
-	cover := s.mother.Cover()
 
 	s.fav.On("GetUsersWithFavoritePlaylist", s.ctx, claims, playlistID, true).Return(userIDs, nil)
 	s.fav.On("DeleteFromAllFavorites", s.ctx, claims, playlistID, true).Return(nil)
 	s.fav.On("AddPlaylistToAllFavorites", s.ctx, claims, userIDs, playlistID).Return(nil)
-	s.coverSvc.On("GetCover", s.ctx, claims, playlistID).Return(cover, nil)
-	s.coverSvc.On("DeleteCover", s.ctx, claims, playlistID).Return(nil)
-	s.coverSvc.On("UploadCover", s.ctx, claims, cover).Return(nil)
-
 	s.tracks.On("GetAllTracks", s.ctx, claims, playlistID).Return(nil, errors.New("track fail"))
 
 	svc := deleter.New(
@@ -152,15 +142,12 @@ func (s *PlaylistDeleterSuite) TestDeletePlaylistMetaError() {
 	playlistID := s.mother.PlaylistID()
 	tracks := s.mother.TrackMeta()
 	userIDs := s.mother.UserIDs()
-	cover := s.mother.Cover()
 
 	s.fav.On("GetUsersWithFavoritePlaylist", s.ctx, claims, playlistID, true).Return(userIDs, nil)
 	s.fav.On("DeleteFromAllFavorites", s.ctx, claims, playlistID, true).Return(nil)
 	s.fav.On("AddPlaylistToAllFavorites", s.ctx, claims, userIDs, playlistID).Return(nil)
 
-	s.coverSvc.On("GetCover", s.ctx, claims, playlistID).Return(cover, nil)
 	s.coverSvc.On("DeleteCover", s.ctx, claims, playlistID).Return(nil)
-	s.coverSvc.On("UploadCover", s.ctx, claims, cover).Return(nil)
 
 	s.tracks.On("GetAllTracks", s.ctx, claims, playlistID).Return(tracks, nil)
 	s.tracks.On("DeleteAllTracks", s.ctx, claims, playlistID).Return(nil)
@@ -0,0 +1,299 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+type BlobStorage interface {
+	ListBlobs(ctx context.Context) ([]string, error)
+	OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error)
+	WriteBlob(ctx context.Context, content io.Reader, size int64) (*entity.AudioBlob, error)
+	DeleteBlob(ctx context.Context, hash string) error
+}
+
+type WaveformStorage interface {
+	SaveWaveform(ctx context.Context, waveform *entity.Waveform) error
+	GetWaveform(ctx context.Context, trackID uuid.UUID, points int) (*entity.Waveform, error)
+}
+
+type CoverStorage interface {
+	ListCovers(ctx context.Context) ([]uuid.UUID, error)
+	SaveCover(ctx context.Context, cover *entity.Cover) error
+	// GetCover returns the cover stored before resizing, with the size 0, if the size is missing.
+	GetCover(ctx context.Context, objectID uuid.UUID, size int) (*entity.Cover, error)
+}
+
+// AudioTrackLister lists the tracks that may have waveforms, the storages cannot list them.
+type AudioTrackLister interface {
+	ListAudioTracks(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// blobCopier trusts blobs already in the sink: blobs are named by their hash
+// and written atomically, so a present blob was verified when it was copied.
+type blobCopier struct {
+	from BlobStorage
+	to   BlobStorage
+}
+
+func (c *blobCopier) list(ctx context.Context) ([]string, error) {
+	return c.from.ListBlobs(ctx)
+}
+
+func (c *blobCopier) copy(ctx context.Context, hash string, dryRun bool) (bool, error) {
+	_, present, err := c.to.OpenBlob(ctx, hash)
+	if err == nil {
+		closeContent(present)
+		return false, nil
+	}
+	if !errors.Is(err, commonerr.ErrNotFound) {
+		return false, err
+	}
+
+	blob, content, err := c.from.OpenBlob(ctx, hash)
+	if errors.Is(err, commonerr.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer closeContent(content)
+
+	if dryRun {
+		return true, nil
+	}
+
+	stored, err := c.to.WriteBlob(ctx, content, blob.Size)
+	if err != nil {
+		return false, err
+	}
+	if stored.Hash != hash {
+		c.discard(ctx, stored.Hash)
+		return false, fmt.Errorf("%w: source content hashes to %s", ErrChecksumMismatch, stored.Hash)
+	}
+
+	if err := c.verify(ctx, hash); err != nil {
+		c.discard(ctx, hash)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// verify reads the copy back from the sink.
+func (c *blobCopier) verify(ctx context.Context, hash string) error {
+	_, content, err := c.to.OpenBlob(ctx, hash)
+	if err != nil {
+		return err
+	}
+	defer closeContent(content)
+
+	sum := sha256.New()
+	if _, err := io.Copy(sum, content); err != nil {
+		return err
+	}
+	if hex.EncodeToString(sum.Sum(nil)) != hash {
+		return fmt.Errorf("%w: copy in the sink differs", ErrChecksumMismatch)
+	}
+
+	return nil
+}
+
+func (c *blobCopier) discard(ctx context.Context, hash string) {
+	if err := c.to.DeleteBlob(ctx, hash); err != nil {
+		slog.Error("failed to delete broken copy of blob", "hash", hash, "error", err)
+	}
+}
+
+type waveformCopier struct {
+	from   WaveformStorage
+	to     WaveformStorage
+	tracks AudioTrackLister
+}
+
+// list keys the waveforms as <track ID>/<points>.
+func (c *waveformCopier) list(ctx context.Context) ([]string, error) {
+	trackIDs, err := c.tracks.ListAudioTracks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(trackIDs)*len(entity.WaveformResolutions))
+	for _, trackID := range trackIDs {
+		for _, points := range entity.WaveformResolutions {
+			keys = append(keys, fmt.Sprintf("%s/%d", trackID, points))
+		}
+	}
+
+	return keys, nil
+}
+
+func (c *waveformCopier) copy(ctx context.Context, key string, dryRun bool) (bool, error) {
+	trackID, points, err := parseWaveformKey(key)
+	if err != nil {
+		return false, err
+	}
+
+	waveform, err := c.from.GetWaveform(ctx, trackID, points)
+	if errors.Is(err, commonerr.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	present, err := c.to.GetWaveform(ctx, trackID, points)
+	if err == nil && checksum(present.Peaks) == checksum(waveform.Peaks) {
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, commonerr.ErrNotFound) {
+		return false, err
+	}
+
+	if dryRun {
+		return true, nil
+	}
+
+	if err := c.to.SaveWaveform(ctx, waveform); err != nil {
+		return false, err
+	}
+
+	saved, err := c.to.GetWaveform(ctx, trackID, points)
+	if err != nil {
+		return false, err
+	}
+	if checksum(saved.Peaks) != checksum(waveform.Peaks) {
+		return false, fmt.Errorf("%w: copy in the sink differs", ErrChecksumMismatch)
+	}
+
+	return true, nil
+}
+
+func parseWaveformKey(key string) (uuid.UUID, int, error) {
+	id, points, _ := strings.Cut(key, "/")
+
+	trackID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("invalid waveform key: %w", err)
+	}
+	n, err := strconv.Atoi(points)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("invalid waveform key: %w", err)
+	}
+
+	return trackID, n, nil
+}
+
+// coverCopier copies every size of the covers. A cover stored before resizing is
+// returned for each of its sizes, so it is copied once and skipped for the rest.
+type coverCopier struct {
+	from CoverStorage
+	to   CoverStorage
+}
+
+func newCoverCopier(from, to CoverStorage) copier {
+	if from == nil || to == nil {
+		return nil
+	}
+	return &coverCopier{from: from, to: to}
+}
+
+// list keys the covers as <object ID>/<size>.
+func (c *coverCopier) list(ctx context.Context) ([]string, error) {
+	ids, err := c.from.ListCovers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(ids)*len(entity.CoverSizes))
+	for _, id := range ids {
+		for _, size := range entity.CoverSizes {
+			keys = append(keys, fmt.Sprintf("%s/%d", id, size))
+		}
+	}
+
+	return keys, nil
+}
+
+func (c *coverCopier) copy(ctx context.Context, key string, dryRun bool) (bool, error) {
+	objectID, size, err := parseCoverKey(key)
+	if err != nil {
+		return false, err
+	}
+
+	cover, err := c.from.GetCover(ctx, objectID, size)
+	if errors.Is(err, commonerr.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	present, err := c.to.GetCover(ctx, objectID, cover.Size)
+	if err == nil && checksum(present.Data) == checksum(cover.Data) {
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, commonerr.ErrNotFound) {
+		return false, err
+	}
+
+	if dryRun {
+		return true, nil
+	}
+
+	err = c.to.SaveCover(ctx, &entity.Cover{
+		ObjectID:    objectID,
+		Size:        cover.Size,
+		ContentType: cover.ContentType,
+		Data:        cover.Data,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	saved, err := c.to.GetCover(ctx, objectID, cover.Size)
+	if err != nil {
+		return false, err
+	}
+	if checksum(saved.Data) != checksum(cover.Data) {
+		return false, fmt.Errorf("%w: copy in the sink differs", ErrChecksumMismatch)
+	}
+
+	return true, nil
+}
+
+func parseCoverKey(key string) (uuid.UUID, int, error) {
+	id, size, _ := strings.Cut(key, "/")
+
+	objectID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("invalid cover key: %w", err)
+	}
+	n, err := strconv.Atoi(size)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("invalid cover key: %w", err)
+	}
+
+	return objectID, n, nil
+}
+
+func checksum(data []byte) [sha256.Size]byte {
+	return sha256.Sum256(data)
+}
+
+func closeContent(content io.Closer) {
+	if err := content.Close(); err != nil {
+		slog.Error("failed to close blob", "error", err)
+	}
+}
@@ -0,0 +1,175 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/storage"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+const DefaultConcurrency = 4
+
+var (
+	ErrInvalidConcurrency = errors.New("invalid concurrency")
+	ErrChecksumMismatch   = errors.New("checksum mismatch")
+	ErrItemsFailed        = errors.New("some items failed to copy")
+)
+
+// Backend holds the storages of one storage backend, nil storages are not supported by it.
+type Backend struct {
+	Audio          BlobStorage
+	Waveforms      WaveformStorage
+	PlaylistCovers CoverStorage
+	AlbumCovers    CoverStorage
+	ArtistAvatars  CoverStorage
+}
+
+type StorageMigrationService struct {
+	from   *Backend
+	to     *Backend
+	tracks AudioTrackLister
+}
+
+func New(from, to *Backend, tracks AudioTrackLister) *StorageMigrationService {
+	return &StorageMigrationService{
+		from:   from,
+		to:     to,
+		tracks: tracks,
+	}
+}
+
+// copier copies the items of one kind of content, the items are keyed by strings.
+type copier interface {
+	list(ctx context.Context) ([]string, error)
+	// copy returns false if there was nothing to copy.
+	copy(ctx context.Context, key string, dryRun bool) (bool, error)
+}
+
+// MigrateStorage returns the report of the kinds processed so far also on failure.
+func (s *StorageMigrationService) MigrateStorage(ctx context.Context, claims *entity.Claims,
+	opts *entity.StorageMigrationOptions) (_ *entity.StorageMigrationReport, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrMigrateStorage, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency == 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency < 0 {
+		return nil, ErrInvalidConcurrency
+	}
+
+	report := &entity.StorageMigrationReport{DryRun: opts.DryRun}
+	for _, kind := range []entity.StorageKind{
+		entity.StorageAudio,
+		entity.StorageWaveforms,
+		entity.StoragePlaylistCovers,
+		entity.StorageAlbumCovers,
+		entity.StorageArtistAvatars,
+	} {
+		kindReport := &entity.StorageKindReport{Kind: kind}
+		report.Kinds = append(report.Kinds, kindReport)
+
+		c := s.copier(kind)
+		if c == nil {
+			kindReport.Unsupported = true
+			continue
+		}
+
+		keys, err := c.list(ctx)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", kind, err)
+		}
+		kindReport.Total = len(keys)
+
+		migrate(ctx, c, keys, kindReport, concurrency, opts.DryRun)
+		slog.Info("storage kind migrated", "kind", kind, "total", kindReport.Total,
+			"copied", kindReport.Copied, "skipped", kindReport.Skipped, "failed", kindReport.Failed)
+
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+	}
+
+	for _, kindReport := range report.Kinds {
+		if kindReport.Failed > 0 {
+			return report, ErrItemsFailed
+		}
+	}
+
+	return report, nil
+}
+
+// copier returns nil if either backend cannot store the kind.
+func (s *StorageMigrationService) copier(kind entity.StorageKind) copier {
+	switch kind {
+	case entity.StorageAudio:
+		if s.from.Audio != nil && s.to.Audio != nil {
+			return &blobCopier{from: s.from.Audio, to: s.to.Audio}
+		}
+	case entity.StorageWaveforms:
+		if s.from.Waveforms != nil && s.to.Waveforms != nil {
+			return &waveformCopier{from: s.from.Waveforms, to: s.to.Waveforms, tracks: s.tracks}
+		}
+	case entity.StoragePlaylistCovers:
+		return newCoverCopier(s.from.PlaylistCovers, s.to.PlaylistCovers)
+	case entity.StorageAlbumCovers:
+		return newCoverCopier(s.from.AlbumCovers, s.to.AlbumCovers)
+	case entity.StorageArtistAvatars:
+		return newCoverCopier(s.from.ArtistAvatars, s.to.ArtistAvatars)
+	}
+	return nil
+}
+
+// migrate copies the items by a pool of workers and stops taking new ones once the context is done.
+func migrate(ctx context.Context, c copier, keys []string, report *entity.StorageKindReport, concurrency int, dryRun bool) {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan string)
+	)
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				copied, err := c.copy(ctx, key, dryRun)
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					report.Failed++
+					report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", key, err))
+				case copied:
+					report.Copied++
+				default:
+					report.Skipped++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+loop:
+	for _, key := range keys {
+		select {
+		case jobs <- key:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,279 @@
+package migration_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/storage/migration"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/storage"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageMigrationServiceSuite struct {
+	suite.Suite
+	fromBlobs  *mocks.BlobStorage
+	toBlobs    *mocks.BlobStorage
+	fromCovers *mocks.CoverStorage
+	toCovers   *mocks.CoverStorage
+	fromWaves  *mocks.WaveformStorage
+	toWaves    *mocks.WaveformStorage
+	tracks     *mocks.AudioTrackLister
+	ctx        context.Context
+}
+
+func TestStorageMigrationServiceSuite(t *testing.T) {
+	suite.Run(t, new(StorageMigrationServiceSuite))
+}
+
+func (s *StorageMigrationServiceSuite) SetupTest() {
+	s.fromBlobs = mocks.NewBlobStorage(s.T())
+	s.toBlobs = mocks.NewBlobStorage(s.T())
+	s.fromCovers = mocks.NewCoverStorage(s.T())
+	s.toCovers = mocks.NewCoverStorage(s.T())
+	s.fromWaves = mocks.NewWaveformStorage(s.T())
+	s.toWaves = mocks.NewWaveformStorage(s.T())
+	s.tracks = mocks.NewAudioTrackLister(s.T())
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func AdminClaims() *entity.Claims {
+	return &entity.Claims{UserID: uuid.New(), AccessLvl: entity.Admin}
+}
+
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+func Content(data string) content {
+	return content{bytes.NewReader([]byte(data))}
+}
+
+func Hash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func Blob(data string) *entity.AudioBlob {
+	return &entity.AudioBlob{Hash: Hash(data), Size: int64(len(data))}
+}
+
+func NotFound() error {
+	return commonerr.ErrNotFound
+}
+
+func (s *StorageMigrationServiceSuite) AudioOnly() *migration.StorageMigrationService {
+	return migration.New(
+		&migration.Backend{Audio: s.fromBlobs},
+		&migration.Backend{Audio: s.toBlobs},
+		s.tracks,
+	)
+}
+
+func (s *StorageMigrationServiceSuite) CoversOnly() *migration.StorageMigrationService {
+	return migration.New(
+		&migration.Backend{AlbumCovers: s.fromCovers},
+		&migration.Backend{AlbumCovers: s.toCovers},
+		s.tracks,
+	)
+}
+
+func (s *StorageMigrationServiceSuite) Migrate(service *migration.StorageMigrationService,
+	dryRun bool) (*entity.StorageMigrationReport, error) {
+	return service.MigrateStorage(s.ctx, AdminClaims(), &entity.StorageMigrationOptions{
+		Concurrency: 2,
+		DryRun:      dryRun,
+	})
+}
+
+func KindReport(report *entity.StorageMigrationReport, kind entity.StorageKind) *entity.StorageKindReport {
+	for _, r := range report.Kinds {
+		if r.Kind == kind {
+			return r
+		}
+	}
+	return nil
+}
+
+// Tests
+func (s *StorageMigrationServiceSuite) TestCopiesMissingBlobs() {
+	hash := Hash("audio")
+	s.fromBlobs.On("ListBlobs", mock.Anything).Return([]string{hash}, nil)
+	s.toBlobs.On("OpenBlob", mock.Anything, hash).Return(nil, nil, NotFound()).Once()
+	s.fromBlobs.On("OpenBlob", mock.Anything, hash).Return(Blob("audio"), Content("audio"), nil)
+	s.toBlobs.On("WriteBlob", mock.Anything, mock.Anything, int64(5)).Return(Blob("audio"), nil)
+	s.toBlobs.On("OpenBlob", mock.Anything, hash).Return(Blob("audio"), Content("audio"), nil).Once()
+
+	report, err := s.Migrate(s.AudioOnly(), false)
+
+	s.Require().NoError(err)
+	audio := KindReport(report, entity.StorageAudio)
+	s.Equal(1, audio.Total)
+	s.Equal(1, audio.Copied)
+	s.True(KindReport(report, entity.StorageAlbumCovers).Unsupported)
+}
+
+func (s *StorageMigrationServiceSuite) TestSkipsBlobsInSink() {
+	hash := Hash("audio")
+	s.fromBlobs.On("ListBlobs", mock.Anything).Return([]string{hash}, nil)
+	s.toBlobs.On("OpenBlob", mock.Anything, hash).Return(Blob("audio"), Content("audio"), nil)
+
+	report, err := s.Migrate(s.AudioOnly(), false)
+
+	s.Require().NoError(err)
+	s.Equal(1, KindReport(report, entity.StorageAudio).Skipped)
+}
+
+func (s *StorageMigrationServiceSuite) TestDiscardsCorruptedSource() {
+	hash := Hash("audio")
+	s.fromBlobs.On("ListBlobs", mock.Anything).Return([]string{hash}, nil)
+	s.toBlobs.On("OpenBlob", mock.Anything, hash).Return(nil, nil, NotFound())
+	s.fromBlobs.On("OpenBlob", mock.Anything, hash).Return(Blob("audio"), Content("audi0"), nil)
+	s.toBlobs.On("WriteBlob", mock.Anything, mock.Anything, int64(5)).Return(Blob("audi0"), nil)
+	s.toBlobs.On("DeleteBlob", mock.Anything, Hash("audi0")).Return(nil)
+
+	report, err := s.Migrate(s.AudioOnly(), false)
+
+	s.ErrorIs(err, usecase.ErrMigrateStorage)
+	s.ErrorIs(err, migration.ErrItemsFailed)
+	audio := KindReport(report, entity.StorageAudio)
+	s.Equal(1, audio.Failed)
+	s.Contains(audio.Errors[0], migration.ErrChecksumMismatch.Error())
+}
+
+func (s *StorageMigrationServiceSuite) TestDiscardsCopyFailingVerification() {
+	hash := Hash("audio")
+	s.fromBlobs.On("ListBlobs", mock.Anything).Return([]string{hash}, nil)
+	s.toBlobs.On("OpenBlob", mock.Anything, hash).Return(nil, nil, NotFound()).Once()
+	s.fromBlobs.On("OpenBlob", mock.Anything, hash).Return(Blob("audio"), Content("audio"), nil)
+	s.toBlobs.On("WriteBlob", mock.Anything, mock.Anything, int64(5)).Return(Blob("audio"), nil)
+	s.toBlobs.On("OpenBlob", mock.Anything, hash).Return(Blob("audio"), Content("audi0"), nil).Once()
+	s.toBlobs.On("DeleteBlob", mock.Anything, hash).Return(nil)
+
+	report, err := s.Migrate(s.AudioOnly(), false)
+
+	s.ErrorIs(err, migration.ErrItemsFailed)
+	s.Equal(1, KindReport(report, entity.StorageAudio).Failed)
+}
+
+func (s *StorageMigrationServiceSuite) TestDryRunDoesNotWrite() {
+	hash := Hash("audio")
+	s.fromBlobs.On("ListBlobs", mock.Anything).Return([]string{hash}, nil)
+	s.toBlobs.On("OpenBlob", mock.Anything, hash).Return(nil, nil, NotFound())
+	s.fromBlobs.On("OpenBlob", mock.Anything, hash).Return(Blob("audio"), Content("audio"), nil)
+
+	report, err := s.Migrate(s.AudioOnly(), true)
+
+	s.Require().NoError(err)
+	s.True(report.DryRun)
+	s.Equal(1, KindReport(report, entity.StorageAudio).Copied)
+	s.toBlobs.AssertNotCalled(s.T(), "WriteBlob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *StorageMigrationServiceSuite) TestListError() {
+	s.fromBlobs.On("ListBlobs", mock.Anything).Return(nil, errors.New("storage error"))
+
+	report, err := s.Migrate(s.AudioOnly(), false)
+
+	s.ErrorIs(err, usecase.ErrMigrateStorage)
+	s.Len(report.Kinds, 1)
+}
+
+func Cover(id uuid.UUID, size int, data string) *entity.Cover {
+	return &entity.Cover{ObjectID: id, Size: size, Data: []byte(data)}
+}
+
+func (s *StorageMigrationServiceSuite) TestCopiesCoverSizes() {
+	id := uuid.New()
+	same := entity.CoverSizes[0]
+
+	s.fromCovers.On("ListCovers", mock.Anything).Return([]uuid.UUID{id}, nil)
+	s.fromCovers.On("GetCover", mock.Anything, id, same).Return(Cover(id, same, "same"), nil)
+	s.toCovers.On("GetCover", mock.Anything, id, same).Return(Cover(id, same, "same"), nil)
+	for _, size := range entity.CoverSizes[1:] {
+		cover := Cover(id, size, fmt.Sprint(size))
+		s.fromCovers.On("GetCover", mock.Anything, id, size).Return(cover, nil)
+		s.toCovers.On("GetCover", mock.Anything, id, size).Return(nil, NotFound()).Once()
+		s.toCovers.On("SaveCover", mock.Anything, cover).Return(nil)
+		s.toCovers.On("GetCover", mock.Anything, id, size).Return(cover, nil).Once()
+	}
+
+	report, err := s.Migrate(s.CoversOnly(), false)
+
+	s.Require().NoError(err)
+	covers := KindReport(report, entity.StorageAlbumCovers)
+	s.Equal(len(entity.CoverSizes), covers.Total)
+	s.Equal(len(entity.CoverSizes)-1, covers.Copied)
+	s.Equal(1, covers.Skipped)
+	s.True(KindReport(report, entity.StorageAudio).Unsupported)
+}
+
+func (s *StorageMigrationServiceSuite) TestCopiesLegacyCoverOnce() {
+	id := uuid.New()
+	legacy := Cover(id, 0, "legacy")
+
+	s.fromCovers.On("ListCovers", mock.Anything).Return([]uuid.UUID{id}, nil)
+	s.fromCovers.On("GetCover", mock.Anything, id, mock.Anything).Return(legacy, nil)
+	s.toCovers.On("GetCover", mock.Anything, id, 0).Return(nil, NotFound()).Once()
+	s.toCovers.On("GetCover", mock.Anything, id, 0).Return(legacy, nil)
+	s.toCovers.On("SaveCover", mock.Anything, legacy).Return(nil).Once()
+
+	report, err := s.Migrate(s.CoversOnly(), false)
+
+	s.Require().NoError(err)
+	covers := KindReport(report, entity.StorageAlbumCovers)
+	s.Equal(1, covers.Copied)
+	s.Equal(len(entity.CoverSizes)-1, covers.Skipped)
+}
+
+func (s *StorageMigrationServiceSuite) TestCopiesExistingWaveforms() {
+	trackID := uuid.New()
+	service := migration.New(
+		&migration.Backend{Waveforms: s.fromWaves},
+		&migration.Backend{Waveforms: s.toWaves},
+		s.tracks,
+	)
+	waveform := &entity.Waveform{TrackID: trackID, Points: entity.WaveformResolutions[0], Peaks: []uint8{1, 2, 3}}
+
+	s.tracks.On("ListAudioTracks", mock.Anything).Return([]uuid.UUID{trackID}, nil)
+	s.fromWaves.On("GetWaveform", mock.Anything, trackID, waveform.Points).Return(waveform, nil)
+	s.fromWaves.On("GetWaveform", mock.Anything, trackID, mock.Anything).Return(nil, NotFound())
+	s.toWaves.On("GetWaveform", mock.Anything, trackID, waveform.Points).Return(nil, NotFound()).Once()
+	s.toWaves.On("SaveWaveform", mock.Anything, waveform).Return(nil)
+	s.toWaves.On("GetWaveform", mock.Anything, trackID, waveform.Points).Return(waveform, nil).Once()
+
+	report, err := s.Migrate(service, false)
+
+	s.Require().NoError(err)
+	waveforms := KindReport(report, entity.StorageWaveforms)
+	s.Equal(len(entity.WaveformResolutions), waveforms.Total)
+	s.Equal(1, waveforms.Copied)
+	s.Equal(len(entity.WaveformResolutions)-1, waveforms.Skipped)
+}
+
+func (s *StorageMigrationServiceSuite) TestRequiresAdmin() {
+	_, err := s.AudioOnly().MigrateStorage(s.ctx, &entity.Claims{UserID: uuid.New(), AccessLvl: entity.User},
+		&entity.StorageMigrationOptions{})
+
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
+
+func (s *StorageMigrationServiceSuite) TestInvalidConcurrency() {
+	_, err := s.AudioOnly().MigrateStorage(s.ctx, AdminClaims(), &entity.StorageMigrationOptions{Concurrency: -1})
+
+	s.ErrorIs(err, migration.ErrInvalidConcurrency)
+}
@@ -0,0 +1,129 @@
+package resize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/storage"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+var ErrCoversFailed = errors.New("some covers failed to resize")
+
+type CoverResizeStorage interface {
+	ListCovers(ctx context.Context) ([]uuid.UUID, error)
+	// GetCover returns the cover stored before resizing, with the size 0, if the size is missing.
+	GetCover(ctx context.Context, objectID uuid.UUID, size int) (*entity.Cover, error)
+	SaveCovers(ctx context.Context, covers []*entity.Cover) error
+}
+
+// CoverProcessor validates an uploaded image and encodes it into every one of entity.CoverSizes.
+type CoverProcessor interface {
+	ProcessCover(data []byte) ([]*entity.Cover, error)
+}
+
+// Covers holds the cover storages to backfill.
+type Covers struct {
+	PlaylistCovers CoverResizeStorage
+	AlbumCovers    CoverResizeStorage
+	ArtistAvatars  CoverResizeStorage
+}
+
+type CoverResizeService struct {
+	covers    *Covers
+	processor CoverProcessor
+}
+
+func New(covers *Covers, processor CoverProcessor) *CoverResizeService {
+	return &CoverResizeService{
+		covers:    covers,
+		processor: processor,
+	}
+}
+
+// ResizeCovers returns the report of the kinds processed so far also on failure.
+// The original of a resized cover is kept, it is deleted with the cover.
+func (s *CoverResizeService) ResizeCovers(ctx context.Context, claims *entity.Claims) (_ *entity.CoverResizeReport, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrResizeCovers, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+
+	report := &entity.CoverResizeReport{}
+	for _, kind := range []struct {
+		kind  entity.StorageKind
+		store CoverResizeStorage
+	}{
+		{entity.StoragePlaylistCovers, s.covers.PlaylistCovers},
+		{entity.StorageAlbumCovers, s.covers.AlbumCovers},
+		{entity.StorageArtistAvatars, s.covers.ArtistAvatars},
+	} {
+		kindReport := &entity.CoverResizeKindReport{Kind: kind.kind}
+		report.Kinds = append(report.Kinds, kindReport)
+
+		ids, err := kind.store.ListCovers(ctx)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", kind.kind, err)
+		}
+		kindReport.Total = len(ids)
+
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+
+			resized, err := s.resize(ctx, kind.store, id)
+			switch {
+			case err != nil:
+				kindReport.Failed++
+				kindReport.Errors = append(kindReport.Errors, fmt.Sprintf("%s: %v", id, err))
+			case resized:
+				kindReport.Resized++
+			default:
+				kindReport.Skipped++
+			}
+		}
+		slog.Info("covers resized", "kind", kind.kind, "total", kindReport.Total,
+			"resized", kindReport.Resized, "skipped", kindReport.Skipped, "failed", kindReport.Failed)
+	}
+
+	for _, kindReport := range report.Kinds {
+		if kindReport.Failed > 0 {
+			return report, ErrCoversFailed
+		}
+	}
+
+	return report, nil
+}
+
+// resize returns false if the cover is already stored in every size or is gone.
+func (s *CoverResizeService) resize(ctx context.Context, store CoverResizeStorage, objectID uuid.UUID) (bool, error) {
+	cover, err := store.GetCover(ctx, objectID, entity.LargestCoverSize)
+	if errors.Is(err, commonerr.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if cover.Size != 0 {
+		return false, nil
+	}
+
+	covers, err := s.processor.ProcessCover(cover.Data)
+	if err != nil {
+		return false, err
+	}
+	for _, sized := range covers {
+		sized.ObjectID = objectID
+	}
+
+	return true, store.SaveCovers(ctx, covers)
+}
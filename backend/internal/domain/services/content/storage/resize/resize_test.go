@@ -0,0 +1,134 @@
+package resize_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/storage/resize"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/storage"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CoverResizeServiceSuite struct {
+	suite.Suite
+	playlists *mocks.CoverResizeStorage
+	albums    *mocks.CoverResizeStorage
+	avatars   *mocks.CoverResizeStorage
+	processor *mocks.CoverProcessor
+	service   *resize.CoverResizeService
+	ctx       context.Context
+}
+
+func TestCoverResizeServiceSuite(t *testing.T) {
+	suite.Run(t, new(CoverResizeServiceSuite))
+}
+
+func (s *CoverResizeServiceSuite) SetupTest() {
+	s.playlists = mocks.NewCoverResizeStorage(s.T())
+	s.albums = mocks.NewCoverResizeStorage(s.T())
+	s.avatars = mocks.NewCoverResizeStorage(s.T())
+	s.processor = mocks.NewCoverProcessor(s.T())
+	s.service = resize.New(&resize.Covers{
+		PlaylistCovers: s.playlists,
+		AlbumCovers:    s.albums,
+		ArtistAvatars:  s.avatars,
+	}, s.processor)
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func AdminClaims() *entity.Claims {
+	return &entity.Claims{UserID: uuid.New(), AccessLvl: entity.Admin}
+}
+
+func Original(objectID uuid.UUID) *entity.Cover {
+	return &entity.Cover{ObjectID: objectID, ContentType: "image/png", Data: []byte("original")}
+}
+
+func Processed() []*entity.Cover {
+	covers := make([]*entity.Cover, 0, len(entity.CoverSizes))
+	for _, size := range entity.CoverSizes {
+		covers = append(covers, &entity.Cover{Size: size, ContentType: "image/jpeg", Data: []byte("sized")})
+	}
+	return covers
+}
+
+func (s *CoverResizeServiceSuite) NoCovers(stores ...*mocks.CoverResizeStorage) {
+	for _, store := range stores {
+		store.On("ListCovers", s.ctx).Return([]uuid.UUID{}, nil)
+	}
+}
+
+// Tests
+func (s *CoverResizeServiceSuite) TestForbiddenForNonAdmin() {
+	_, err := s.service.ResizeCovers(s.ctx, &entity.Claims{AccessLvl: entity.User})
+
+	s.ErrorIs(err, commonerr.ErrForbidden)
+	s.ErrorIs(err, usecase.ErrResizeCovers)
+}
+
+func (s *CoverResizeServiceSuite) TestResizesOriginalCovers() {
+	original, resized, gone := uuid.New(), uuid.New(), uuid.New()
+	s.NoCovers(s.playlists, s.avatars)
+	s.albums.On("ListCovers", s.ctx).Return([]uuid.UUID{original, resized, gone}, nil)
+	s.albums.On("GetCover", s.ctx, original, entity.LargestCoverSize).Return(Original(original), nil)
+	s.albums.On("GetCover", s.ctx, resized, entity.LargestCoverSize).
+		Return(&entity.Cover{ObjectID: resized, Size: entity.LargestCoverSize}, nil)
+	s.albums.On("GetCover", s.ctx, gone, entity.LargestCoverSize).Return(nil, commonerr.ErrNotFound)
+	s.processor.On("ProcessCover", []byte("original")).Return(Processed(), nil)
+	s.albums.On("SaveCovers", s.ctx, mock.MatchedBy(func(covers []*entity.Cover) bool {
+		for _, cover := range covers {
+			if cover.ObjectID != original {
+				return false
+			}
+		}
+		return len(covers) == len(entity.CoverSizes)
+	})).Return(nil).Once()
+
+	report, err := s.service.ResizeCovers(s.ctx, AdminClaims())
+
+	s.Require().NoError(err)
+	s.Require().Len(report.Kinds, 3)
+	albums := report.Kinds[1]
+	s.Equal(entity.StorageAlbumCovers, albums.Kind)
+	s.Equal(3, albums.Total)
+	s.Equal(1, albums.Resized)
+	s.Equal(2, albums.Skipped)
+	s.Zero(albums.Failed)
+}
+
+func (s *CoverResizeServiceSuite) TestInvalidImageFailsOnlyItsCover() {
+	broken, valid := uuid.New(), uuid.New()
+	s.NoCovers(s.playlists, s.albums)
+	s.avatars.On("ListCovers", s.ctx).Return([]uuid.UUID{broken, valid}, nil)
+	s.avatars.On("GetCover", s.ctx, broken, entity.LargestCoverSize).
+		Return(&entity.Cover{ObjectID: broken, Data: []byte("broken")}, nil)
+	s.avatars.On("GetCover", s.ctx, valid, entity.LargestCoverSize).Return(Original(valid), nil)
+	s.processor.On("ProcessCover", []byte("broken")).Return(nil, commonerr.ErrInvalidImage)
+	s.processor.On("ProcessCover", []byte("original")).Return(Processed(), nil)
+	s.avatars.On("SaveCovers", s.ctx, mock.Anything).Return(nil).Once()
+
+	report, err := s.service.ResizeCovers(s.ctx, AdminClaims())
+
+	s.ErrorIs(err, resize.ErrCoversFailed)
+	s.ErrorIs(err, usecase.ErrResizeCovers)
+	avatars := report.Kinds[2]
+	s.Equal(1, avatars.Resized)
+	s.Equal(1, avatars.Failed)
+	s.Len(avatars.Errors, 1)
+}
+
+func (s *CoverResizeServiceSuite) TestListErrorStops() {
+	s.playlists.On("ListCovers", s.ctx).Return(nil, commonerr.ErrNotFound)
+
+	report, err := s.service.ResizeCovers(s.ctx, AdminClaims())
+
+	s.Error(err)
+	s.Len(report.Kinds, 1)
+	s.albums.AssertNotCalled(s.T(), "ListCovers", mock.Anything)
+}
@@ -0,0 +1,112 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/stream"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+const DefaultTokenTTL = 15 * time.Minute
+
+var (
+	ErrLoginRequired     = errors.New("login required to stream")
+	ErrInvalidResourceID = errors.New("invalid resource id")
+	ErrInvalidToken      = errors.New("invalid stream token")
+	ErrTokenExpired      = errors.New("stream token expired")
+	ErrTokenMismatch     = errors.New("stream token does not grant the resource")
+	ErrTokenUserMismatch = errors.New("stream token was issued to another user")
+)
+
+type StreamTokenSigner interface {
+	Sign(grant *entity.StreamGrant) (string, error)
+	Parse(token string) (*entity.StreamGrant, error)
+}
+
+type StreamTokenService struct {
+	signer        StreamTokenSigner
+	ttl           time.Duration
+	loginRequired bool
+}
+
+// New creates the service. A non-positive ttl falls back to DefaultTokenTTL.
+// With loginRequired, tokens are issued to authenticated users only.
+func New(signer StreamTokenSigner, ttl time.Duration, loginRequired bool) *StreamTokenService {
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+
+	return &StreamTokenService{
+		signer:        signer,
+		ttl:           ttl,
+		loginRequired: loginRequired,
+	}
+}
+
+func (s *StreamTokenService) IssueToken(ctx context.Context, claims *entity.Claims, resource entity.StreamResource,
+	resourceID uuid.UUID, quality entity.AudioQuality) (_ *entity.StreamToken, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrIssueStreamToken, err)
+	}()
+
+	if resourceID == uuid.Nil {
+		return nil, ErrInvalidResourceID
+	}
+	if claims == nil && s.loginRequired {
+		return nil, ErrLoginRequired
+	}
+
+	grant := &entity.StreamGrant{
+		Resource:   resource,
+		ResourceID: resourceID,
+		Quality:    quality,
+		ExpiresAt:  time.Now().Add(s.ttl).Truncate(time.Second),
+	}
+	if claims != nil {
+		grant.UserID = claims.UserID
+	}
+
+	token, err := s.signer.Sign(grant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.StreamToken{
+		Token:     token,
+		ExpiresAt: grant.ExpiresAt,
+	}, nil
+}
+
+// VerifyToken checks the user of the grant against the claims of the request, if it has any.
+// Requests without claims, e.g. from a CDN, are granted by the token alone.
+func (s *StreamTokenService) VerifyToken(ctx context.Context, claims *entity.Claims, token string,
+	resource entity.StreamResource, resourceID uuid.UUID, quality entity.AudioQuality) (_ *entity.StreamGrant, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrVerifyStreamToken, err)
+	}()
+
+	grant, err := s.signer.Parse(token)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidToken, err)
+	}
+
+	if !time.Now().Before(grant.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+	if !grant.Allows(resource, resourceID, quality) {
+		return nil, ErrTokenMismatch
+	}
+	// an anonymous grant issued before the login was required
+	if grant.UserID == uuid.Nil && s.loginRequired {
+		return nil, ErrLoginRequired
+	}
+	if grant.UserID != uuid.Nil && claims != nil && claims.UserID != grant.UserID {
+		return nil, ErrTokenUserMismatch
+	}
+
+	return grant, nil
+}
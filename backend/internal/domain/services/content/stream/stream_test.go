@@ -0,0 +1,164 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/stream"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamTokenServiceSuite struct {
+	suite.Suite
+	service *stream.StreamTokenService
+	signer  *mocks.StreamTokenSigner
+	ctx     context.Context
+	trackID uuid.UUID
+}
+
+func TestStreamTokenServiceSuite(t *testing.T) {
+	suite.Run(t, new(StreamTokenServiceSuite))
+}
+
+func (s *StreamTokenServiceSuite) SetupTest() {
+	s.signer = mocks.NewStreamTokenSigner(s.T())
+	s.service = stream.New(s.signer, time.Minute, false)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+}
+
+// Object Mother
+func (s *StreamTokenServiceSuite) Grant(quality entity.AudioQuality, expiresIn time.Duration) *entity.StreamGrant {
+	return &entity.StreamGrant{
+		Resource:   entity.StreamAudio,
+		ResourceID: s.trackID,
+		Quality:    quality,
+		ExpiresAt:  time.Now().Add(expiresIn),
+	}
+}
+
+// IssueToken
+func (s *StreamTokenServiceSuite) TestIssueToken() {
+	claims := &entity.Claims{UserID: uuid.New(), AccessLvl: entity.User}
+	s.signer.On("Sign", mock.MatchedBy(func(grant *entity.StreamGrant) bool {
+		return grant.Resource == entity.StreamAudio && grant.ResourceID == s.trackID &&
+			grant.Quality == entity.QualityHigh && grant.UserID == claims.UserID &&
+			time.Until(grant.ExpiresAt) <= time.Minute && time.Until(grant.ExpiresAt) > 55*time.Second
+	})).Return("signed", nil)
+
+	res, err := s.service.IssueToken(s.ctx, claims, entity.StreamAudio, s.trackID, entity.QualityHigh)
+	s.Require().NoError(err)
+	s.Equal("signed", res.Token)
+	s.False(res.ExpiresAt.IsZero())
+}
+
+func (s *StreamTokenServiceSuite) TestIssueTokenAnonymous() {
+	s.signer.On("Sign", mock.MatchedBy(func(grant *entity.StreamGrant) bool {
+		return grant.UserID == uuid.Nil
+	})).Return("signed", nil)
+
+	_, err := s.service.IssueToken(s.ctx, nil, entity.StreamAudio, s.trackID, "")
+	s.NoError(err)
+}
+
+func (s *StreamTokenServiceSuite) TestIssueTokenLoginRequired() {
+	service := stream.New(s.signer, time.Minute, true)
+
+	_, err := service.IssueToken(s.ctx, nil, entity.StreamAudio, s.trackID, "")
+	s.ErrorIs(err, stream.ErrLoginRequired)
+}
+
+func (s *StreamTokenServiceSuite) TestIssueTokenInvalidResourceID() {
+	_, err := s.service.IssueToken(s.ctx, nil, entity.StreamAlbumCover, uuid.Nil, "")
+	s.ErrorIs(err, stream.ErrInvalidResourceID)
+}
+
+func (s *StreamTokenServiceSuite) TestIssueTokenSignerError() {
+	s.signer.On("Sign", mock.Anything).Return("", errors.New("signer error"))
+
+	_, err := s.service.IssueToken(s.ctx, nil, entity.StreamAudio, s.trackID, "")
+	s.Error(err)
+}
+
+// VerifyToken
+func (s *StreamTokenServiceSuite) TestVerifyToken() {
+	grant := s.Grant(entity.QualityHigh, time.Minute)
+	s.signer.On("Parse", "token").Return(grant, nil)
+
+	res, err := s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, s.trackID, entity.QualityHigh)
+	s.NoError(err)
+	s.Equal(grant, res)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyTokenAnyQuality() {
+	s.signer.On("Parse", "token").Return(s.Grant("", time.Minute), nil)
+
+	_, err := s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, s.trackID, entity.QualityLow)
+	s.NoError(err)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyTokenExpired() {
+	s.signer.On("Parse", "token").Return(s.Grant(entity.QualityHigh, -time.Second), nil)
+
+	_, err := s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, s.trackID, entity.QualityHigh)
+	s.ErrorIs(err, stream.ErrTokenExpired)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyTokenOtherQuality() {
+	s.signer.On("Parse", "token").Return(s.Grant(entity.QualityLow, time.Minute), nil)
+
+	_, err := s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, s.trackID, entity.QualityOriginal)
+	s.ErrorIs(err, stream.ErrTokenMismatch)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyTokenOtherResource() {
+	s.signer.On("Parse", "token").Return(s.Grant("", time.Minute), nil)
+
+	_, err := s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, uuid.New(), "")
+	s.ErrorIs(err, stream.ErrTokenMismatch)
+
+	_, err = s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAlbumCover, s.trackID, "")
+	s.ErrorIs(err, stream.ErrTokenMismatch)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyTokenInvalid() {
+	s.signer.On("Parse", "token").Return(nil, errors.New("bad signature"))
+
+	_, err := s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, s.trackID, "")
+	s.ErrorIs(err, stream.ErrInvalidToken)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyTokenOfUser() {
+	grant := s.Grant("", time.Minute)
+	grant.UserID = uuid.New()
+	s.signer.On("Parse", "token").Return(grant, nil)
+
+	_, err := s.service.VerifyToken(s.ctx, &entity.Claims{UserID: grant.UserID}, "token", entity.StreamAudio, s.trackID, "")
+	s.NoError(err)
+
+	_, err = s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, s.trackID, "")
+	s.NoError(err)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyTokenOfOtherUser() {
+	grant := s.Grant("", time.Minute)
+	grant.UserID = uuid.New()
+	s.signer.On("Parse", "token").Return(grant, nil)
+
+	_, err := s.service.VerifyToken(s.ctx, &entity.Claims{UserID: uuid.New()}, "token", entity.StreamAudio, s.trackID, "")
+	s.ErrorIs(err, stream.ErrTokenUserMismatch)
+}
+
+func (s *StreamTokenServiceSuite) TestVerifyAnonymousTokenWhenLoginRequired() {
+	s.service = stream.New(s.signer, time.Minute, true)
+	s.signer.On("Parse", "token").Return(s.Grant("", time.Minute), nil)
+
+	_, err := s.service.VerifyToken(s.ctx, nil, "token", entity.StreamAudio, s.trackID, "")
+	s.ErrorIs(err, stream.ErrLoginRequired)
+}
@@ -1,8 +1,12 @@
 package audio
 
 import (
+	"bufio"
 	"context"
 	"errors"
+	"io"
+	"log/slog"
+	"math"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
@@ -11,46 +15,99 @@ import (
 	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
 )
 
+// formatHeaderSize is the number of leading bytes passed to format detection,
+// it is large enough to skip an ID3v2 tag with an embedded cover.
+const formatHeaderSize = 1 << 20 // 1MB
+
 var (
-	ErrInvalidChunkParams = errors.New("invalid chunk parameters")
-	ErrInvalidTrackID     = errors.New("invalid track id")
+	ErrInvalidTrackID    = errors.New("invalid track id")
+	ErrUnsupportedFormat = errors.New("unsupported audio format")
+	ErrCorruptFile       = errors.New("corrupt audio file")
+	ErrNoDuration        = errors.New("audio has no duration")
 )
 
 type AudioFileRepository interface {
-	GetAudioChunk(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error)
-	UploadAudioFile(ctx context.Context, chunk *entity.AudioChunk) error
+	OpenAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)
+	UploadAudioFile(ctx context.Context, file *entity.AudioFile, content io.Reader) error
 	DeleteFile(ctx context.Context, trackID uuid.UUID) error
 }
 
 type AudioConverter interface {
-	ChangeBitrate(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error)
+	ChangeBitrate(ctx context.Context, src io.Reader, quality entity.AudioQuality) (io.ReadCloser, error)
+}
+
+type FormatDetector interface {
+	DetectFormat(header []byte) (entity.AudioFormat, error)
+}
+
+type AudioAnalyzer interface {
+	Analyze(r io.Reader) (*entity.AudioInfo, error)
+}
+
+type TrackAudioInfoRepository interface {
+	GetByID(ctx context.Context, trackID uuid.UUID) (*entity.TrackMeta, error)
+	UpdateAudioInfo(ctx context.Context, trackID uuid.UUID, info *entity.AudioInfo) error
+}
+
+// JobQueue runs the jobs after the request enqueuing them is done.
+type JobQueue interface {
+	Enqueue(ctx context.Context, name string, job func(ctx context.Context) error) error
 }
 
 type AudioFileService struct {
-	converter AudioConverter
-	repo      AudioFileRepository
+	converter      AudioConverter
+	repo           AudioFileRepository
+	detector       FormatDetector
+	analyzer       AudioAnalyzer
+	trackRepo      TrackAudioInfoRepository
+	segmentService usecase.TrackSegmentService
+	waveforms      usecase.TrackWaveformService
+	loudness       usecase.TrackLoudnessService
+	seekTables     usecase.TrackSeekService
+	jobs           JobQueue
 }
 
-func New(repo AudioFileRepository, converter AudioConverter) *AudioFileService {
+func New(
+	repo AudioFileRepository,
+	converter AudioConverter,
+	detector FormatDetector,
+	analyzer AudioAnalyzer,
+	trackRepo TrackAudioInfoRepository,
+	segmentService usecase.TrackSegmentService,
+	waveforms usecase.TrackWaveformService,
+	loudness usecase.TrackLoudnessService,
+	seekTables usecase.TrackSeekService,
+	jobs JobQueue,
+) *AudioFileService {
 	return &AudioFileService{
-		repo:      repo,
-		converter: converter,
+		repo:           repo,
+		converter:      converter,
+		detector:       detector,
+		analyzer:       analyzer,
+		trackRepo:      trackRepo,
+		segmentService: segmentService,
+		waveforms:      waveforms,
+		loudness:       loudness,
+		seekTables:     seekTables,
+		jobs:           jobs,
 	}
 }
 
-func (a *AudioFileService) GetAudioChunk(ctx context.Context, chunk *entity.AudioChunk) (result *entity.AudioChunk, err error) {
+func (a *AudioFileService) GetAudioFile(ctx context.Context, trackID uuid.UUID,
+	quality entity.AudioQuality) (_ *entity.AudioFile, _ io.ReadSeekCloser, err error) {
 	defer func() {
-		err = errwrap.WrapIfErr(usecase.ErrGetAudioChunk, err)
+		err = errwrap.WrapIfErr(usecase.ErrGetAudioFile, err)
 	}()
 
-	if chunk.End <= chunk.Start {
-		return nil, ErrInvalidChunkParams
+	if trackID == uuid.Nil {
+		return nil, nil, ErrInvalidTrackID
 	}
 
-	return a.repo.GetAudioChunk(ctx, chunk)
+	return a.repo.OpenAudioFile(ctx, trackID, quality)
 }
 
-func (a *AudioFileService) UploadAudioFile(ctx context.Context, claims *entity.Claims, chunk *entity.AudioChunk) (err error) {
+func (a *AudioFileService) UploadAudioFile(ctx context.Context, claims *entity.Claims,
+	file *entity.AudioFile, content io.Reader) (err error) {
 	defer func() {
 		err = errwrap.WrapIfErr(usecase.ErrUploadAudioFile, err)
 	}()
@@ -58,18 +115,165 @@ func (a *AudioFileService) UploadAudioFile(ctx context.Context, claims *entity.C
 	switch {
 	case claims == nil || claims.AccessLvl != entity.Admin:
 		return commonerr.ErrForbidden
-	case chunk.End <= chunk.Start || chunk.Start != 0 || chunk.End != int64(len(chunk.Data)):
-		return ErrInvalidChunkParams
-	case chunk.TrackID == uuid.Nil:
+	case file.TrackID == uuid.Nil:
 		return ErrInvalidTrackID
 	}
 
-	converted, err := a.converter.ChangeBitrate(ctx, chunk)
+	src := bufio.NewReaderSize(content, formatHeaderSize)
+	header, err := src.Peek(formatHeaderSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	format, err := a.detector.DetectFormat(header)
+	if err != nil {
+		return errwrap.Wrap(ErrUnsupportedFormat, err)
+	}
+
+	original := &entity.AudioFile{
+		TrackID: file.TrackID,
+		Quality: entity.QualityOriginal,
+		Format:  format,
+		Size:    file.Size,
+	}
+	if err = a.repo.UploadAudioFile(ctx, original, src); err != nil {
+		return err
+	}
+
+	// MP3 originals are validated before spending time on transcoding
+	var info *entity.AudioInfo
+	if format == entity.FormatMP3 {
+		if info, err = a.analyze(ctx, file.TrackID, entity.QualityOriginal); err != nil {
+			return err
+		}
+	}
+
+	for _, quality := range entity.AudioRenditions {
+		if err = a.uploadRendition(ctx, file.TrackID, quality); err != nil {
+			a.deleteFiles(ctx, file.TrackID)
+			return err
+		}
+	}
+
+	// other formats are measured through the best rendition, which is always MP3
+	if info == nil {
+		if info, err = a.analyze(ctx, file.TrackID, entity.QualityHigh); err != nil {
+			return err
+		}
+		info.Format = format
+		if file.Size > 0 {
+			info.Bitrate = int(math.Round(float64(file.Size*8) / info.Duration.Seconds()))
+		}
+	}
+
+	if err = a.updateAudioInfo(ctx, file.TrackID, info); err != nil {
+		return err
+	}
+
+	// the waveform is only drawn by players, failing to build it does not fail the upload
+	if err := a.waveforms.GenerateWaveform(ctx, file.TrackID); err != nil {
+		slog.Error("failed to generate waveform", "track_id", file.TrackID, "error", err)
+	}
+	// loudness is measured through the whole original, so the upload does not wait
+	// for it. Without loudness the track is played as is
+	trackID := file.TrackID
+	if err := a.jobs.Enqueue(ctx, "analyze loudness "+trackID.String(), func(ctx context.Context) error {
+		return a.loudness.AnalyzeLoudness(ctx, trackID)
+	}); err != nil {
+		slog.Error("failed to enqueue loudness analysis", "track_id", trackID, "error", err)
+	}
+	// seek tables are also built on the first seek
+	if err := a.seekTables.GenerateSeekTables(ctx, file.TrackID); err != nil {
+		slog.Error("failed to generate seek tables", "track_id", file.TrackID, "error", err)
+	}
+
+	return nil
+}
+
+// analyze measures the stored file, a corrupt or empty file is deleted with all its renditions.
+func (a *AudioFileService) analyze(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioInfo, error) {
+	_, content, err := a.repo.OpenAudioFile(ctx, trackID, quality)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close audio file", "error", err)
+		}
+	}()
+
+	info, err := a.analyzer.Analyze(content)
+	if err == nil && info.Duration <= 0 {
+		err = ErrNoDuration
+	}
+	if err != nil {
+		a.deleteFiles(ctx, trackID)
+		return nil, errwrap.Wrap(ErrCorruptFile, err)
+	}
+
+	return info, nil
+}
+
+// deleteFiles removes the original and the renditions of a failed upload.
+func (a *AudioFileService) deleteFiles(ctx context.Context, trackID uuid.UUID) {
+	if err := a.repo.DeleteFile(ctx, trackID); err != nil {
+		slog.Error("failed to delete audio files of a failed upload", "track_id", trackID, "error", err)
+	}
+}
+
+// updateAudioInfo stores the measured properties with the track. Segments are
+// sliced by the duration, so they are regenerated if the entered one was wrong.
+func (a *AudioFileService) updateAudioInfo(ctx context.Context, trackID uuid.UUID, info *entity.AudioInfo) error {
+	track, err := a.trackRepo.GetByID(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
+	if err = a.trackRepo.UpdateAudioInfo(ctx, trackID, info); err != nil {
+		return err
+	}
+
+	if track.Duration == info.Seconds() {
+		return nil
+	}
+
+	if err = a.segmentService.DeleteSegments(ctx, trackID); err != nil {
+		return err
+	}
+
+	return a.segmentService.CreateSegments(ctx, trackID, info.Seconds())
+}
+
+// uploadRendition transcodes the stored original, so the uploaded
+// content does not have to be kept around for every rendition.
+func (a *AudioFileService) uploadRendition(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) error {
+	_, original, err := a.repo.OpenAudioFile(ctx, trackID, entity.QualityOriginal)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := original.Close(); err != nil {
+			slog.Error("failed to close audio file", "error", err)
+		}
+	}()
+
+	converted, err := a.converter.ChangeBitrate(ctx, original, quality)
 	if err != nil {
 		return err
 	}
 
-	return a.repo.UploadAudioFile(ctx, converted)
+	rendition := &entity.AudioFile{
+		TrackID: trackID,
+		Quality: quality,
+		Format:  entity.FormatMP3,
+		Size:    -1,
+	}
+	if err = a.repo.UploadAudioFile(ctx, rendition, converted); err != nil {
+		_ = converted.Close()
+		return err
+	}
+
+	return converted.Close()
 }
 
 func (a *AudioFileService) DeleteAudioFile(ctx context.Context, claims *entity.Claims, trackID uuid.UUID) (err error) {
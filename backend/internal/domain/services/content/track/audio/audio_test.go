@@ -1,9 +1,12 @@
 package audio_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
@@ -20,6 +23,14 @@ type AudioFileServiceSuite struct {
 	service   *audio.AudioFileService
 	repo      *mocks.AudioFileRepository
 	converter *mocks.AudioConverter
+	detector  *mocks.FormatDetector
+	analyzer  *mocks.AudioAnalyzer
+	trackRepo *mocks.TrackAudioInfoRepository
+	segments  *mocks.TrackSegmentService
+	waveforms *mocks.TrackWaveformService
+	loudness  *mocks.TrackLoudnessService
+	seek      *mocks.TrackSeekService
+	jobs      *jobQueue
 	ctx       context.Context
 	trackID   uuid.UUID
 }
@@ -31,31 +42,98 @@ func TestAudioFileServiceSuite(t *testing.T) {
 func (s *AudioFileServiceSuite) SetupTest() {
 	s.repo = mocks.NewAudioFileRepository(s.T())
 	s.converter = mocks.NewAudioConverter(s.T())
-	s.service = audio.New(s.repo, s.converter)
+	s.detector = mocks.NewFormatDetector(s.T())
+	s.analyzer = mocks.NewAudioAnalyzer(s.T())
+	s.trackRepo = mocks.NewTrackAudioInfoRepository(s.T())
+	s.segments = mocks.NewTrackSegmentService(s.T())
+	s.waveforms = mocks.NewTrackWaveformService(s.T())
+	s.loudness = mocks.NewTrackLoudnessService(s.T())
+	s.seek = mocks.NewTrackSeekService(s.T())
+	s.jobs = &jobQueue{}
+	s.service = audio.New(s.repo, s.converter, s.detector, s.analyzer, s.trackRepo, s.segments, s.waveforms,
+		s.loudness, s.seek, s.jobs)
 	s.ctx = context.Background()
 	s.trackID = uuid.New()
 }
 
 // Object Mother
-func ValidAudioChunk(trackID uuid.UUID, size int64) *entity.AudioChunk {
+
+// jobQueue keeps the enqueued jobs, so they are run after the upload returns.
+type jobQueue struct {
+	jobs []func(ctx context.Context) error
+}
+
+func (q *jobQueue) Enqueue(_ context.Context, _ string, job func(ctx context.Context) error) error {
+	q.jobs = append(q.jobs, job)
+	return nil
+}
+
+func (q *jobQueue) run(ctx context.Context) []error {
+	var errs []error
+	for _, job := range q.jobs {
+		errs = append(errs, job(ctx))
+	}
+	return errs
+}
+
+func AudioData(size int) []byte {
 	data := make([]byte, size)
 	for i := range data {
 		data[i] = byte(i)
 	}
-	return &entity.AudioChunk{
+	return data
+}
+
+func NewAudioFile(trackID uuid.UUID, size int64) *entity.AudioFile {
+	return &entity.AudioFile{
+		TrackID: trackID,
+		Size:    size,
+	}
+}
+
+func OriginalFile(trackID uuid.UUID, format entity.AudioFormat, size int64) *entity.AudioFile {
+	return &entity.AudioFile{
+		TrackID: trackID,
+		Quality: entity.QualityOriginal,
+		Format:  format,
+		Size:    size,
+	}
+}
+
+func RenditionFile(trackID uuid.UUID, quality entity.AudioQuality) *entity.AudioFile {
+	return &entity.AudioFile{
 		TrackID: trackID,
-		Start:   0,
-		End:     size,
-		Data:    data,
+		Quality: quality,
+		Format:  entity.FormatMP3,
+		Size:    -1,
 	}
 }
 
-func InvalidAudioChunk() *entity.AudioChunk {
-	return &entity.AudioChunk{
-		TrackID: uuid.New(),
-		Start:   10,
-		End:     5,
-		Data:    []byte{},
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+func Content(data []byte) content {
+	return content{bytes.NewReader(data)}
+}
+
+func MP3Info(duration time.Duration) *entity.AudioInfo {
+	return &entity.AudioInfo{
+		Format:     entity.FormatMP3,
+		Duration:   duration,
+		Bitrate:    320000,
+		SampleRate: 44100,
+	}
+}
+
+func Track(trackID uuid.UUID, duration int) *entity.TrackMeta {
+	return &entity.TrackMeta{
+		ID:       trackID,
+		Duration: duration,
 	}
 }
 
@@ -67,52 +145,176 @@ func UserClaims() *entity.Claims {
 	return &entity.Claims{AccessLvl: entity.User}
 }
 
-// GetAudioChunk
-func (s *AudioFileServiceSuite) TestGetAudioChunkValid() {
-	chunk := ValidAudioChunk(s.trackID, 10)
-	s.repo.On("GetAudioChunk", mock.Anything, chunk).Return(chunk, nil)
+// GetAudioFile
+func (s *AudioFileServiceSuite) TestGetAudioFileValid() {
+	file := OriginalFile(s.trackID, entity.FormatMP3, 10)
+	data := Content(AudioData(10))
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(file, data, nil)
 
-	res, err := s.service.GetAudioChunk(s.ctx, chunk)
+	res, rsc, err := s.service.GetAudioFile(s.ctx, s.trackID, entity.QualityOriginal)
 	assert.NoError(s.T(), err)
-	assert.Equal(s.T(), chunk, res)
+	assert.Equal(s.T(), file, res)
+	assert.Equal(s.T(), data, rsc)
 }
 
-func (s *AudioFileServiceSuite) TestGetAudioChunkInvalidParams() {
-	chunk := InvalidAudioChunk()
-	res, err := s.service.GetAudioChunk(s.ctx, chunk)
-	assert.ErrorIs(s.T(), err, audio.ErrInvalidChunkParams)
+func (s *AudioFileServiceSuite) TestGetAudioFileInvalidTrackID() {
+	res, rsc, err := s.service.GetAudioFile(s.ctx, uuid.Nil, entity.QualityOriginal)
+	assert.ErrorIs(s.T(), err, audio.ErrInvalidTrackID)
 	assert.Nil(s.T(), res)
+	assert.Nil(s.T(), rsc)
 }
 
-func (s *AudioFileServiceSuite) TestGetAudioChunkRepoError() {
-	chunk := ValidAudioChunk(s.trackID, 10)
-	s.repo.On("GetAudioChunk", mock.Anything, chunk).Return(nil, errors.New("repo error"))
+func (s *AudioFileServiceSuite) TestGetAudioFileRepoError() {
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityHigh).Return(nil, nil, errors.New("repo error"))
 
-	res, err := s.service.GetAudioChunk(s.ctx, chunk)
+	res, _, err := s.service.GetAudioFile(s.ctx, s.trackID, entity.QualityHigh)
 	assert.Error(s.T(), err)
 	assert.Nil(s.T(), res)
 }
 
 // UploadAudioFile
+func (s *AudioFileServiceSuite) expectOriginal(format entity.AudioFormat, data []byte) {
+	s.detector.On("DetectFormat", data).Return(format, nil)
+	s.repo.On("UploadAudioFile", mock.Anything, OriginalFile(s.trackID, format, int64(len(data))), mock.Anything).
+		Return(nil).Once()
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(OriginalFile(s.trackID, format, int64(len(data))), Content(data), nil)
+}
+
+func (s *AudioFileServiceSuite) expectRenditions() {
+	for _, quality := range entity.AudioRenditions {
+		s.converter.On("ChangeBitrate", mock.Anything, mock.Anything, quality).
+			Return(io.NopCloser(bytes.NewReader([]byte(quality))), nil)
+		s.repo.On("UploadAudioFile", mock.Anything, RenditionFile(s.trackID, quality), mock.Anything).
+			Return(nil).Once()
+	}
+}
+
 func (s *AudioFileServiceSuite) TestUploadAudioFileValid() {
-	chunk := ValidAudioChunk(s.trackID, 10)
-	s.converter.On("ChangeBitrate", mock.Anything, chunk).Return(chunk, nil)
-	s.repo.On("UploadAudioFile", mock.Anything, chunk).Return(nil)
+	data := AudioData(10)
+	s.expectOriginal(entity.FormatFLAC, data)
+	s.expectRenditions()
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityHigh).
+		Return(RenditionFile(s.trackID, entity.QualityHigh), Content([]byte("high")), nil)
+	s.analyzer.On("Analyze", mock.Anything).Return(MP3Info(2*time.Second), nil)
+	s.trackRepo.On("GetByID", mock.Anything, s.trackID).Return(Track(s.trackID, 2), nil)
+	s.trackRepo.On("UpdateAudioInfo", mock.Anything, s.trackID, &entity.AudioInfo{
+		Format:     entity.FormatFLAC,
+		Duration:   2 * time.Second,
+		Bitrate:    40, // 10 bytes in 2 seconds
+		SampleRate: 44100,
+	}).Return(nil)
+	s.waveforms.On("GenerateWaveform", mock.Anything, s.trackID).Return(nil)
+	s.seek.On("GenerateSeekTables", mock.Anything, s.trackID).Return(nil)
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
+	assert.NoError(s.T(), err)
 
-	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), chunk)
+	// the loudness is measured in the background
+	s.loudness.AssertNotCalled(s.T(), "AnalyzeLoudness", mock.Anything, mock.Anything)
+	s.loudness.On("AnalyzeLoudness", mock.Anything, s.trackID).Return(nil)
+	assert.Equal(s.T(), []error{nil}, s.jobs.run(s.ctx))
+}
+
+func (s *AudioFileServiceSuite) TestUploadAudioFileRegeneratesSegments() {
+	data := AudioData(10)
+	info := MP3Info(200 * time.Second)
+	s.expectOriginal(entity.FormatMP3, data)
+	s.analyzer.On("Analyze", mock.Anything).Return(info, nil)
+	s.expectRenditions()
+	s.trackRepo.On("GetByID", mock.Anything, s.trackID).Return(Track(s.trackID, 180), nil)
+	s.trackRepo.On("UpdateAudioInfo", mock.Anything, s.trackID, info).Return(nil)
+	s.segments.On("DeleteSegments", mock.Anything, s.trackID).Return(nil)
+	s.segments.On("CreateSegments", mock.Anything, s.trackID, 200).Return(nil)
+	s.waveforms.On("GenerateWaveform", mock.Anything, s.trackID).Return(errors.New("ffmpeg error"))
+	s.seek.On("GenerateSeekTables", mock.Anything, s.trackID).Return(errors.New("parse error"))
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
 	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.jobs.jobs, 1)
+}
+
+func (s *AudioFileServiceSuite) TestUploadAudioFileCorrupt() {
+	data := AudioData(10)
+	s.expectOriginal(entity.FormatMP3, data)
+	s.analyzer.On("Analyze", mock.Anything).Return(nil, errors.New("no frames"))
+	s.repo.On("DeleteFile", mock.Anything, s.trackID).Return(nil)
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
+	assert.ErrorIs(s.T(), err, audio.ErrCorruptFile)
+	s.converter.AssertNotCalled(s.T(), "ChangeBitrate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AudioFileServiceSuite) TestUploadAudioFileUnsupportedFormat() {
+	data := AudioData(10)
+	s.detector.On("DetectFormat", data).Return(entity.AudioFormat(""), errors.New("unknown format"))
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
+	assert.ErrorIs(s.T(), err, audio.ErrUnsupportedFormat)
+}
+
+func (s *AudioFileServiceSuite) TestUploadAudioFileConverterError() {
+	data := AudioData(10)
+	s.expectOriginal(entity.FormatMP3, data)
+	s.analyzer.On("Analyze", mock.Anything).Return(MP3Info(time.Second), nil)
+	s.converter.On("ChangeBitrate", mock.Anything, mock.Anything, entity.AudioRenditions[0]).
+		Return(nil, errors.New("convert error"))
+	s.repo.On("DeleteFile", mock.Anything, s.trackID).Return(nil).Once()
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
+	assert.Error(s.T(), err)
+}
+
+func (s *AudioFileServiceSuite) TestUploadAudioFileRenditionUploadError() {
+	data := AudioData(10)
+	s.expectOriginal(entity.FormatMP3, data)
+	s.analyzer.On("Analyze", mock.Anything).Return(MP3Info(time.Second), nil)
+	s.converter.On("ChangeBitrate", mock.Anything, mock.Anything, mock.Anything).
+		Return(io.NopCloser(bytes.NewReader([]byte("rendition"))), nil)
+	s.repo.On("UploadAudioFile", mock.Anything, RenditionFile(s.trackID, entity.AudioRenditions[0]), mock.Anything).
+		Return(nil).Once()
+	s.repo.On("UploadAudioFile", mock.Anything, RenditionFile(s.trackID, entity.AudioRenditions[1]), mock.Anything).
+		Return(errors.New("upload error")).Once()
+	s.repo.On("DeleteFile", mock.Anything, s.trackID).Return(nil).Once()
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
+	assert.Error(s.T(), err)
+	s.trackRepo.AssertNotCalled(s.T(), "UpdateAudioInfo", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AudioFileServiceSuite) TestUploadAudioFileNoDuration() {
+	data := AudioData(10)
+	s.expectOriginal(entity.FormatFLAC, data)
+	s.expectRenditions()
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityHigh).
+		Return(RenditionFile(s.trackID, entity.QualityHigh), Content([]byte("high")), nil)
+	s.analyzer.On("Analyze", mock.Anything).Return(MP3Info(0), nil)
+	s.repo.On("DeleteFile", mock.Anything, s.trackID).Return(nil).Once()
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
+	assert.ErrorIs(s.T(), err, audio.ErrCorruptFile)
+	assert.ErrorIs(s.T(), err, audio.ErrNoDuration)
+	s.trackRepo.AssertNotCalled(s.T(), "UpdateAudioInfo", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AudioFileServiceSuite) TestUploadAudioFileRepoError() {
+	data := AudioData(10)
+	s.detector.On("DetectFormat", data).Return(entity.FormatMP3, nil)
+	s.repo.On("UploadAudioFile", mock.Anything, OriginalFile(s.trackID, entity.FormatMP3, 10), mock.Anything).
+		Return(errors.New("upload error"))
+
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(data))
+	assert.Error(s.T(), err)
 }
 
 func (s *AudioFileServiceSuite) TestUploadAudioFileNotAdmin() {
-	chunk := ValidAudioChunk(s.trackID, 10)
-	err := s.service.UploadAudioFile(s.ctx, UserClaims(), chunk)
+	err := s.service.UploadAudioFile(s.ctx, UserClaims(), NewAudioFile(s.trackID, 10), bytes.NewReader(AudioData(10)))
 	assert.ErrorIs(s.T(), err, commonerr.ErrForbidden)
 }
 
-func (s *AudioFileServiceSuite) TestUploadAudioFileInvalidChunk() {
-	chunk := InvalidAudioChunk()
-	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), chunk)
-	assert.ErrorIs(s.T(), err, audio.ErrInvalidChunkParams)
+func (s *AudioFileServiceSuite) TestUploadAudioFileInvalidTrackID() {
+	err := s.service.UploadAudioFile(s.ctx, AdminClaims(), NewAudioFile(uuid.Nil, 10), bytes.NewReader(AudioData(10)))
+	assert.ErrorIs(s.T(), err, audio.ErrInvalidTrackID)
 }
 
 // DeleteAudioFile
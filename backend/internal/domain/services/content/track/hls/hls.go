@@ -0,0 +1,290 @@
+package hls
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	TargetSegmentDuration = 10 // seconds
+
+	// a layout takes a few kilobytes, a segment per ten seconds of audio
+	CacheSize = 1024
+	CacheTTL  = time.Hour
+)
+
+var (
+	ErrInvalidTrackID     = errors.New("invalid track id")
+	ErrUnknownRendition   = errors.New("unknown rendition")
+	ErrSegmentOutOfBounds = errors.New("segment index out of bounds")
+	ErrUnsupportedFormat  = errors.New("audio format can not be segmented")
+)
+
+type AudioFileOpener interface {
+	OpenAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)
+}
+
+type FrameParser interface {
+	ParseFrames(r io.Reader) ([]*entity.AudioFrame, error)
+}
+
+// layout is what the playlist and the bandwidth of an MP3 file are built from,
+// so the frames of a file are parsed once rather than for every segment.
+type layout struct {
+	segments    []*entity.HLSSegment
+	peakBitrate int
+}
+
+type HLSService struct {
+	repo   AudioFileOpener
+	parser FrameParser
+	// layouts are cached by the hash of the file, the content of a hash never changes
+	cache *expirable.LRU[string, *layout]
+}
+
+func New(repo AudioFileOpener, parser FrameParser) *HLSService {
+	return &HLSService{
+		repo:   repo,
+		parser: parser,
+		cache:  expirable.NewLRU[string, *layout](CacheSize, nil, CacheTTL),
+	}
+}
+
+func (s *HLSService) GetRenditions(ctx context.Context, trackID uuid.UUID) (_ []*entity.HLSRendition, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetHLSRenditions, err)
+	}()
+
+	if trackID == uuid.Nil {
+		return nil, ErrInvalidTrackID
+	}
+
+	renditions := make([]*entity.HLSRendition, 0, len(entity.AudioQualities))
+	for _, quality := range entity.AudioQualities {
+		bandwidth, err := s.getBandwidth(ctx, trackID, quality)
+		if errors.Is(err, commonerr.ErrNotFound) || errors.Is(err, ErrUnsupportedFormat) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		renditions = append(renditions, &entity.HLSRendition{
+			Quality:   quality,
+			Bandwidth: bandwidth,
+		})
+	}
+
+	if len(renditions) == 0 {
+		return nil, commonerr.ErrNotFound
+	}
+
+	return renditions, nil
+}
+
+func (s *HLSService) GetMediaPlaylist(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (_ *entity.HLSMediaPlaylist, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetHLSMediaPlaylist, err)
+	}()
+
+	return s.getMediaPlaylist(ctx, trackID, quality)
+}
+
+// GetSegment returns the segment with the content of its byte range, the caller must close the content.
+func (s *HLSService) GetSegment(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality,
+	segmentIdx int) (_ *entity.HLSSegment, _ io.ReadSeekCloser, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetHLSSegment, err)
+	}()
+
+	if quality != entity.QualityOriginal && quality.Bitrate() == 0 {
+		return nil, nil, ErrUnknownRendition
+	}
+
+	_, content, layout, err := s.openLayout(ctx, trackID, quality)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if segmentIdx < 0 || segmentIdx >= len(layout.segments) {
+		closeContent(content)
+		return nil, nil, ErrSegmentOutOfBounds
+	}
+	segment := layout.segments[segmentIdx]
+
+	return segment, &segmentContent{
+		SectionReader: io.NewSectionReader(readerAt(content), segment.Start, segment.End-segment.Start),
+		Closer:        content,
+	}, nil
+}
+
+func (s *HLSService) getMediaPlaylist(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.HLSMediaPlaylist, error) {
+	if quality != entity.QualityOriginal && quality.Bitrate() == 0 {
+		return nil, ErrUnknownRendition
+	}
+
+	_, content, layout, err := s.openLayout(ctx, trackID, quality)
+	if err != nil {
+		return nil, err
+	}
+	closeContent(content)
+
+	playlist := &entity.HLSMediaPlaylist{
+		TrackID:  trackID,
+		Quality:  quality,
+		Segments: layout.segments,
+	}
+	for _, segment := range layout.segments {
+		playlist.TargetDuration = max(playlist.TargetDuration, segment.Duration)
+	}
+
+	return playlist, nil
+}
+
+// getBandwidth returns the nominal bitrate of a transcoded rendition or the peak
+// bitrate of the original file. Missing renditions yield commonerr.ErrNotFound.
+func (s *HLSService) getBandwidth(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (int, error) {
+	if bitrate := quality.Bitrate(); bitrate > 0 {
+		_, content, err := s.repo.OpenAudioFile(ctx, trackID, quality)
+		if err != nil {
+			return 0, err
+		}
+		closeContent(content)
+
+		return bitrate * 1000, nil
+	}
+
+	_, content, layout, err := s.openLayout(ctx, trackID, quality)
+	if err != nil {
+		return 0, err
+	}
+	closeContent(content)
+
+	return layout.peakBitrate, nil
+}
+
+// openLayout opens the file and returns its layout along with it. The frames are parsed
+// only if the layout of the content is not cached, the caller closes the content.
+func (s *HLSService) openLayout(ctx context.Context, trackID uuid.UUID,
+	quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, *layout, error) {
+	if trackID == uuid.Nil {
+		return nil, nil, nil, ErrInvalidTrackID
+	}
+
+	file, content, err := s.repo.OpenAudioFile(ctx, trackID, quality)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// only MP3 is split into segments, lossless originals are served through transcoded renditions
+	if file.Format != "" && file.Format != entity.FormatMP3 {
+		closeContent(content)
+		return nil, nil, nil, ErrUnsupportedFormat
+	}
+
+	if cached, ok := s.cache.Get(file.Hash); ok {
+		return file, content, cached, nil
+	}
+
+	frames, err := s.parser.ParseFrames(content)
+	if err != nil {
+		closeContent(content)
+		return nil, nil, nil, err
+	}
+
+	parsed := &layout{
+		segments:    splitIntoSegments(frames),
+		peakBitrate: peakBitrate(frames),
+	}
+	// the files without a hash, stored before audio was stored by content, are not cached
+	if file.Hash != "" {
+		s.cache.Add(file.Hash, parsed)
+	}
+
+	return file, content, parsed, nil
+}
+
+// splitIntoSegments groups consecutive frames into segments of at least
+// TargetSegmentDuration seconds, so every segment starts on a frame boundary.
+func splitIntoSegments(frames []*entity.AudioFrame) []*entity.HLSSegment {
+	segments := make([]*entity.HLSSegment, 0)
+
+	var current *entity.HLSSegment
+	for _, frame := range frames {
+		if current == nil {
+			current = &entity.HLSSegment{
+				Idx:   len(segments),
+				Start: frame.Offset,
+			}
+		}
+
+		current.End = frame.Offset + frame.Size
+		current.Duration += frame.Duration
+
+		if current.Duration.Seconds() >= TargetSegmentDuration {
+			segments = append(segments, current)
+			current = nil
+		}
+	}
+
+	if current != nil {
+		segments = append(segments, current)
+	}
+
+	return segments
+}
+
+func peakBitrate(frames []*entity.AudioFrame) int {
+	peak := 0
+	for _, frame := range frames {
+		peak = max(peak, frame.Bitrate)
+	}
+	return peak
+}
+
+// segmentContent is the byte range of a segment, closing it closes the whole file.
+type segmentContent struct {
+	*io.SectionReader
+	io.Closer
+}
+
+// readerAt reads the content at an offset directly if it supports it
+// and seeks to the offset before every read otherwise.
+func readerAt(content io.ReadSeeker) io.ReaderAt {
+	if r, ok := content.(io.ReaderAt); ok {
+		return r
+	}
+	return &seekingReaderAt{content}
+}
+
+type seekingReaderAt struct {
+	io.ReadSeeker
+}
+
+func (r *seekingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := r.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	n, err := io.ReadFull(r.ReadSeeker, p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func closeContent(content io.Closer) {
+	if err := content.Close(); err != nil {
+		slog.Error("failed to close audio file", "error", err)
+	}
+}
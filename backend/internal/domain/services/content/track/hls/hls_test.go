@@ -0,0 +1,254 @@
+package hls_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/hls"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HLSServiceSuite struct {
+	suite.Suite
+	service *hls.HLSService
+	repo    *mocks.AudioFileOpener
+	parser  *mocks.FrameParser
+	ctx     context.Context
+	trackID uuid.UUID
+}
+
+func TestHLSServiceSuite(t *testing.T) {
+	suite.Run(t, new(HLSServiceSuite))
+}
+
+func (s *HLSServiceSuite) SetupTest() {
+	s.repo = mocks.NewAudioFileOpener(s.T())
+	s.parser = mocks.NewFrameParser(s.T())
+	s.service = hls.New(s.repo, s.parser)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+}
+
+// Object Mother
+func Frames(count int, size int64, duration time.Duration) []*entity.AudioFrame {
+	frames := make([]*entity.AudioFrame, count)
+	for i := range frames {
+		frames[i] = &entity.AudioFrame{
+			Offset:   100 + int64(i)*size,
+			Size:     size,
+			Duration: duration,
+			Bitrate:  128000,
+		}
+	}
+	return frames
+}
+
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+// seekOnly hides the ReadAt of the content, as a stream of a remote storage would.
+type seekOnly struct {
+	io.ReadSeekCloser
+}
+
+// Content returns the data of a file made of Frames(count, size, ...).
+func Content(count int, size int64) content {
+	data := make([]byte, 100+int64(count)*size)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return content{bytes.NewReader(data)}
+}
+
+func (s *HLSServiceSuite) File(quality entity.AudioQuality, format entity.AudioFormat) *entity.AudioFile {
+	return &entity.AudioFile{
+		TrackID: s.trackID,
+		Quality: quality,
+		Format:  format,
+		Size:    -1,
+	}
+}
+
+func (s *HLSServiceSuite) expectWholeFile(quality entity.AudioQuality, frames []*entity.AudioFrame) {
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, quality).
+		Return(s.File(quality, entity.FormatMP3), Content(len(frames), 400), nil)
+	s.parser.On("ParseFrames", mock.Anything).Return(frames, nil)
+}
+
+func (s *HLSServiceSuite) expectRendition(quality entity.AudioQuality, err error) {
+	if err != nil {
+		s.repo.On("OpenAudioFile", mock.Anything, s.trackID, quality).Return(nil, nil, err)
+		return
+	}
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, quality).
+		Return(s.File(quality, entity.FormatMP3), Content(1, 400), nil)
+}
+
+// GetRenditions
+func (s *HLSServiceSuite) TestGetRenditions() {
+	s.expectWholeFile(entity.QualityOriginal, Frames(10, 400, time.Second))
+	s.expectRendition(entity.QualityHigh, nil)
+	s.expectRendition(entity.QualityMedium, nil)
+	s.expectRendition(entity.QualityLow, commonerr.ErrNotFound)
+
+	res, err := s.service.GetRenditions(s.ctx, s.trackID)
+	s.NoError(err)
+	s.Equal([]*entity.HLSRendition{
+		{Quality: entity.QualityOriginal, Bandwidth: 128000},
+		{Quality: entity.QualityHigh, Bandwidth: 320000},
+		{Quality: entity.QualityMedium, Bandwidth: 160000},
+	}, res)
+}
+
+func (s *HLSServiceSuite) TestGetRenditionsSkipsLosslessOriginal() {
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(s.File(entity.QualityOriginal, entity.FormatFLAC), Content(1, 400), nil)
+	for _, quality := range entity.AudioRenditions {
+		s.expectRendition(quality, nil)
+	}
+
+	res, err := s.service.GetRenditions(s.ctx, s.trackID)
+	s.NoError(err)
+	s.Require().Len(res, len(entity.AudioRenditions))
+	s.Equal(entity.QualityHigh, res[0].Quality)
+}
+
+func (s *HLSServiceSuite) TestGetRenditionsNotFound() {
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, mock.Anything).Return(nil, nil, commonerr.ErrNotFound)
+
+	_, err := s.service.GetRenditions(s.ctx, s.trackID)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+func (s *HLSServiceSuite) TestGetRenditionsInvalidTrackID() {
+	_, err := s.service.GetRenditions(s.ctx, uuid.Nil)
+	s.ErrorIs(err, hls.ErrInvalidTrackID)
+}
+
+func (s *HLSServiceSuite) TestGetRenditionsRepoError() {
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, mock.Anything).Return(nil, nil, errors.New("repo error"))
+
+	_, err := s.service.GetRenditions(s.ctx, s.trackID)
+	s.Error(err)
+}
+
+// GetMediaPlaylist
+func (s *HLSServiceSuite) TestGetMediaPlaylistSplitsOnFrameBoundaries() {
+	s.expectWholeFile(entity.QualityOriginal, Frames(25, 400, time.Second))
+
+	res, err := s.service.GetMediaPlaylist(s.ctx, s.trackID, entity.QualityOriginal)
+	s.NoError(err)
+	s.Require().Len(res.Segments, 3)
+	s.Equal(10*time.Second, res.TargetDuration)
+
+	s.Equal(&entity.HLSSegment{Idx: 0, Start: 100, End: 4100, Duration: 10 * time.Second}, res.Segments[0])
+	s.Equal(&entity.HLSSegment{Idx: 1, Start: 4100, End: 8100, Duration: 10 * time.Second}, res.Segments[1])
+	s.Equal(&entity.HLSSegment{Idx: 2, Start: 8100, End: 10100, Duration: 5 * time.Second}, res.Segments[2])
+}
+
+func (s *HLSServiceSuite) TestGetMediaPlaylistRendition() {
+	s.expectWholeFile(entity.QualityLow, Frames(12, 400, time.Second))
+
+	res, err := s.service.GetMediaPlaylist(s.ctx, s.trackID, entity.QualityLow)
+	s.NoError(err)
+	s.Equal(entity.QualityLow, res.Quality)
+	s.Len(res.Segments, 2)
+}
+
+func (s *HLSServiceSuite) TestGetMediaPlaylistUnknownRendition() {
+	_, err := s.service.GetMediaPlaylist(s.ctx, s.trackID, "lossless")
+	s.ErrorIs(err, hls.ErrUnknownRendition)
+}
+
+func (s *HLSServiceSuite) TestGetMediaPlaylistParserError() {
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(s.File(entity.QualityOriginal, entity.FormatMP3), Content(1, 400), nil)
+	s.parser.On("ParseFrames", mock.Anything).Return(nil, errors.New("parse error"))
+
+	_, err := s.service.GetMediaPlaylist(s.ctx, s.trackID, entity.QualityOriginal)
+	s.Error(err)
+}
+
+// GetSegment
+func (s *HLSServiceSuite) TestGetSegment() {
+	s.expectWholeFile(entity.QualityOriginal, Frames(25, 400, time.Second))
+
+	res, content, err := s.service.GetSegment(s.ctx, s.trackID, entity.QualityOriginal, 1)
+	s.Require().NoError(err)
+	defer content.Close()
+	s.Equal(int64(4100), res.Start)
+	s.Equal(int64(8100), res.End)
+
+	data, err := io.ReadAll(content)
+	s.Require().NoError(err)
+	s.Require().Len(data, 4000)
+	s.Equal(byte(4100%256), data[0])
+}
+
+func (s *HLSServiceSuite) TestGetSegmentSeeksContentWithoutReaderAt() {
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(s.File(entity.QualityOriginal, entity.FormatMP3), seekOnly{Content(25, 400)}, nil)
+	s.parser.On("ParseFrames", mock.Anything).Return(Frames(25, 400, time.Second), nil)
+
+	_, content, err := s.service.GetSegment(s.ctx, s.trackID, entity.QualityOriginal, 2)
+	s.Require().NoError(err)
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	s.Require().NoError(err)
+	s.Require().Len(data, 2000)
+	s.Equal(byte(8100%256), data[0])
+}
+
+func (s *HLSServiceSuite) TestGetSegmentOutOfBounds() {
+	s.expectWholeFile(entity.QualityOriginal, Frames(25, 400, time.Second))
+
+	_, _, err := s.service.GetSegment(s.ctx, s.trackID, entity.QualityOriginal, 3)
+	s.ErrorIs(err, hls.ErrSegmentOutOfBounds)
+}
+
+func (s *HLSServiceSuite) TestGetSegmentParsesFileOnce() {
+	file := s.File(entity.QualityOriginal, entity.FormatMP3)
+	file.Hash = "hash"
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(file, Content(25, 400), nil)
+	s.parser.On("ParseFrames", mock.Anything).Return(Frames(25, 400, time.Second), nil).Once()
+
+	for idx := range 3 {
+		res, content, err := s.service.GetSegment(s.ctx, s.trackID, entity.QualityOriginal, idx)
+		s.Require().NoError(err)
+		s.Require().NoError(content.Close())
+		s.Equal(int64(100+idx*4000), res.Start)
+	}
+
+	playlist, err := s.service.GetMediaPlaylist(s.ctx, s.trackID, entity.QualityOriginal)
+	s.Require().NoError(err)
+	s.Len(playlist.Segments, 3)
+	s.parser.AssertNumberOfCalls(s.T(), "ParseFrames", 1)
+}
+
+func (s *HLSServiceSuite) TestGetSegmentWithoutHashIsNotCached() {
+	s.expectWholeFile(entity.QualityOriginal, Frames(25, 400, time.Second))
+
+	for idx := range 2 {
+		_, content, err := s.service.GetSegment(s.ctx, s.trackID, entity.QualityOriginal, idx)
+		s.Require().NoError(err)
+		s.Require().NoError(content.Close())
+	}
+
+	s.parser.AssertNumberOfCalls(s.T(), "ParseFrames", 2)
+}
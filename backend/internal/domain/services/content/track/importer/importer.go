@@ -0,0 +1,405 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/album"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/artist"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/genre"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+const (
+	tagHeaderSize = 10
+	MaxTagSize    = 16 << 20 // 16MB, enough for high resolution cover art
+)
+
+var (
+	ErrInvalidLicenseID = errors.New("invalid license id")
+	ErrMissingTags      = errors.New("title, artist and album tags are required")
+	ErrMissingGenre     = errors.New("file has no genre tag and no fallback genre is given")
+	ErrTagTooLarge      = errors.New("tag exceeds size limit")
+)
+
+type TagReader interface {
+	TagSize(header []byte) int
+	ReadTags(header []byte) (*entity.AudioTags, error)
+}
+
+// ImportRepository looks up the matched records by name and deletes the records
+// created by a failed import, unless something else refers to them meanwhile.
+type ImportRepository interface {
+	GetGenreByTitle(ctx context.Context, title string) (*entity.Genre, error)
+	GetArtistByName(ctx context.Context, name string) (*entity.ArtistMeta, error)
+	GetArtistAlbum(ctx context.Context, artistID uuid.UUID, title string) (*entity.AlbumMeta, error)
+	GetAlbumTrack(ctx context.Context, albumID uuid.UUID, name string) (*entity.TrackMeta, error)
+	NextTrackNumber(ctx context.Context, albumID uuid.UUID) (int, error)
+	DeleteAlbumIfUnused(ctx context.Context, albumID uuid.UUID) (bool, error)
+	DeleteArtistIfUnused(ctx context.Context, artistID uuid.UUID) error
+	DeleteGenreIfUnused(ctx context.Context, genreID uuid.UUID) error
+}
+
+type TrackImportService struct {
+	repo                ImportRepository
+	artistService       artist.ArtistMetaService
+	artistAssignService artist.ArtistAssignService
+	albumService        album.AlbumMetaService
+	albumCoverService   album.AlbumCoverService
+	genreService        genre.GenreService
+	genreAssignService  genre.GenreAssignService
+	trackService        usecase.TrackMetaService
+	audioService        usecase.AudioFileService
+	tagReader           TagReader
+}
+
+func New(repo ImportRepository,
+	artistService artist.ArtistMetaService,
+	artistAssignService artist.ArtistAssignService,
+	albumService album.AlbumMetaService,
+	albumCoverService album.AlbumCoverService,
+	genreService genre.GenreService,
+	genreAssignService genre.GenreAssignService,
+	trackService usecase.TrackMetaService,
+	audioService usecase.AudioFileService,
+	tagReader TagReader) *TrackImportService {
+	return &TrackImportService{
+		repo:                repo,
+		artistService:       artistService,
+		artistAssignService: artistAssignService,
+		albumService:        albumService,
+		albumCoverService:   albumCoverService,
+		genreService:        genreService,
+		genreAssignService:  genreAssignService,
+		trackService:        trackService,
+		audioService:        audioService,
+		tagReader:           tagReader,
+	}
+}
+
+func (s *TrackImportService) ImportTrack(ctx context.Context, claims *entity.Claims,
+	params *entity.TrackImportParams, content io.Reader) (_ *entity.TrackImportReport, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrImportTrack, err)
+	}()
+
+	switch {
+	case claims == nil || claims.AccessLvl != entity.Admin:
+		return nil, commonerr.ErrForbidden
+	case params == nil || params.LicenseID == uuid.Nil:
+		return nil, ErrInvalidLicenseID
+	}
+
+	tag, err := s.readTag(content)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.tagReader.ReadTags(tag)
+	if err != nil && params.Tags == nil {
+		return nil, errwrap.Wrap(ErrMissingTags, err)
+	}
+	tags = mergeTags(params.Tags, tags)
+	if tags.Title == "" || tags.Artist == "" || tags.Album == "" {
+		return nil, ErrMissingTags
+	}
+
+	report := &entity.TrackImportReport{}
+	defer func() {
+		if err != nil {
+			s.deleteCreated(ctx, claims, report)
+		}
+	}()
+
+	if report.Genre, err = s.importGenre(ctx, claims, tags, params.GenreID); err != nil {
+		return nil, err
+	}
+	if report.Artist, err = s.importArtist(ctx, claims, tags); err != nil {
+		return nil, err
+	}
+	if report.Album, err = s.importAlbum(ctx, claims, tags, params, report); err != nil {
+		return nil, err
+	}
+	if report.Track, err = s.importTrack(ctx, claims, tags, params, report); err != nil {
+		return nil, err
+	}
+	// the audio of a track already in the album is not replaced by an import
+	if !report.Track.Created {
+		report.Duplicate = true
+		return report, nil
+	}
+
+	// the tag is part of the file, so the audio service sees the whole content
+	file := &entity.AudioFile{
+		TrackID: report.Track.ID,
+		Size:    params.Size,
+	}
+	if err = s.audioService.UploadAudioFile(ctx, claims, file, io.MultiReader(bytes.NewReader(tag), content)); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// readTag reads the ID3v2 tag from the beginning of the content. If there
+// is no tag, the returned bytes are the first bytes of the audio itself.
+func (s *TrackImportService) readTag(content io.Reader) ([]byte, error) {
+	header := make([]byte, tagHeaderSize)
+	n, err := io.ReadFull(content, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	header = header[:n]
+
+	size := s.tagReader.TagSize(header)
+	if size > MaxTagSize {
+		return nil, ErrTagTooLarge
+	}
+	if size <= len(header) {
+		return header, nil
+	}
+
+	tag := make([]byte, size)
+	copy(tag, header)
+	if _, err = io.ReadFull(content, tag[len(header):]); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *TrackImportService) importGenre(ctx context.Context, claims *entity.Claims,
+	tags *entity.AudioTags, fallbackID uuid.UUID) (entity.ImportedObject, error) {
+	if tags.Genre == "" {
+		if fallbackID == uuid.Nil {
+			return entity.ImportedObject{}, ErrMissingGenre
+		}
+
+		genre, err := s.genreService.GetGenreByID(ctx, fallbackID)
+		if err != nil {
+			return entity.ImportedObject{}, err
+		}
+		return entity.ImportedObject{ID: genre.ID, Name: genre.Title}, nil
+	}
+
+	genre, err := s.repo.GetGenreByTitle(ctx, tags.Genre)
+	if err == nil {
+		return entity.ImportedObject{ID: genre.ID, Name: genre.Title}, nil
+	}
+	if !errors.Is(err, commonerr.ErrNotFound) {
+		return entity.ImportedObject{}, err
+	}
+
+	genre = &entity.Genre{Title: tags.Genre}
+	if err = s.genreService.CreateGenre(ctx, claims, genre); err != nil {
+		return entity.ImportedObject{}, err
+	}
+
+	return entity.ImportedObject{ID: genre.ID, Name: genre.Title, Created: true}, nil
+}
+
+func (s *TrackImportService) importArtist(ctx context.Context, claims *entity.Claims,
+	tags *entity.AudioTags) (entity.ImportedObject, error) {
+	artist, err := s.repo.GetArtistByName(ctx, tags.Artist)
+	if err == nil {
+		return entity.ImportedObject{ID: artist.ID, Name: artist.Name}, nil
+	}
+	if !errors.Is(err, commonerr.ErrNotFound) {
+		return entity.ImportedObject{}, err
+	}
+
+	artist = &entity.ArtistMeta{Name: tags.Artist}
+	if err = s.artistService.CreateArtistMeta(ctx, claims, artist); err != nil {
+		return entity.ImportedObject{}, err
+	}
+
+	return entity.ImportedObject{ID: artist.ID, Name: artist.Name, Created: true}, nil
+}
+
+// importAlbum matches the album by title among the albums of the imported artist.
+// A created album gets the artist and genre assigned. The embedded cover is
+// uploaded to an album without one.
+func (s *TrackImportService) importAlbum(ctx context.Context, claims *entity.Claims, tags *entity.AudioTags,
+	params *entity.TrackImportParams, report *entity.TrackImportReport) (entity.ImportedObject, error) {
+	album, err := s.repo.GetArtistAlbum(ctx, report.Artist.ID, tags.Album)
+	if err == nil {
+		imported := entity.ImportedObject{ID: album.ID, Name: album.Title}
+		return imported, s.importCover(ctx, claims, tags, album.ID, report)
+	}
+	if !errors.Is(err, commonerr.ErrNotFound) {
+		return entity.ImportedObject{}, err
+	}
+
+	album = &entity.AlbumMeta{
+		Title:       tags.Album,
+		LicenseID:   params.LicenseID,
+		ReleaseDate: releaseDate(tags.Year),
+	}
+	if _, err = s.albumService.CreateAlbum(ctx, claims, album); err != nil {
+		return entity.ImportedObject{}, err
+	}
+	// reported before the assignments, so a failed import deletes the album
+	report.Album = entity.ImportedObject{ID: album.ID, Name: album.Title, Created: true}
+
+	if err = s.artistAssignService.AssignArtistToAlbum(ctx, claims, report.Artist.ID, album.ID); err != nil {
+		return report.Album, err
+	}
+	if err = s.genreAssignService.AssignGenreToAlbum(ctx, claims, report.Genre.ID, album.ID); err != nil {
+		return report.Album, err
+	}
+
+	return report.Album, s.importCover(ctx, claims, tags, album.ID, report)
+}
+
+// importCover uploads the embedded cover unless the album already has a cover.
+func (s *TrackImportService) importCover(ctx context.Context, claims *entity.Claims, tags *entity.AudioTags,
+	albumID uuid.UUID, report *entity.TrackImportReport) error {
+	if len(tags.Cover) == 0 {
+		return nil
+	}
+
+	_, err := s.albumCoverService.GetCover(ctx, albumID, entity.CoverSizes[0])
+	if err == nil || !errors.Is(err, commonerr.ErrNotFound) {
+		return err
+	}
+
+	cover := &entity.Cover{
+		ObjectID: albumID,
+		Data:     tags.Cover,
+	}
+	// a broken embedded picture should not fail the import of the audio
+	err = s.albumCoverService.UploadCover(ctx, claims, cover)
+	switch {
+	case errors.Is(err, commonerr.ErrInvalidImage):
+		slog.Warn("skipping invalid embedded cover", "album_id", albumID, "error", err)
+	case err != nil:
+		return err
+	default:
+		report.CoverUploaded = true
+	}
+
+	return nil
+}
+
+// importTrack matches the track by name within the album. The duration of a
+// created track is a placeholder until the audio upload measures it.
+func (s *TrackImportService) importTrack(ctx context.Context, claims *entity.Claims, tags *entity.AudioTags,
+	params *entity.TrackImportParams, report *entity.TrackImportReport) (entity.ImportedObject, error) {
+	track, err := s.repo.GetAlbumTrack(ctx, report.Album.ID, tags.Title)
+	if err == nil {
+		return entity.ImportedObject{ID: track.ID, Name: track.Name}, nil
+	}
+	if !errors.Is(err, commonerr.ErrNotFound) {
+		return entity.ImportedObject{}, err
+	}
+
+	track = &entity.TrackMeta{
+		Name:        tags.Title,
+		GenreID:     report.Genre.ID,
+		Duration:    1,
+		LicenseID:   params.LicenseID,
+		AlbumID:     report.Album.ID,
+		TrackNumber: tags.TrackNumber,
+	}
+	if track.TrackNumber <= 0 {
+		if track.TrackNumber, err = s.repo.NextTrackNumber(ctx, report.Album.ID); err != nil {
+			return entity.ImportedObject{}, err
+		}
+	}
+
+	if _, err = s.trackService.CreateTrackMeta(ctx, claims, track); err != nil {
+		return entity.ImportedObject{}, err
+	}
+	imported := entity.ImportedObject{ID: track.ID, Name: track.Name, Created: true}
+
+	return imported, s.artistAssignService.AssignArtistToTrack(ctx, claims, report.Artist.ID, track.ID)
+}
+
+// deleteCreated deletes what a failed import created. The album, artist and genre
+// are kept if a concurrent import matched them, their cleanup errors are only logged.
+func (s *TrackImportService) deleteCreated(ctx context.Context, claims *entity.Claims, report *entity.TrackImportReport) {
+	// the import may have failed because the request was cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	if report.Track.Created {
+		if err := s.trackService.DeleteTrackMeta(ctx, claims, report.Track.ID); err != nil {
+			slog.Error("failed to delete imported track", "track_id", report.Track.ID, "error", err)
+			return
+		}
+	}
+
+	if report.Album.Created {
+		deleted, err := s.repo.DeleteAlbumIfUnused(ctx, report.Album.ID)
+		if err != nil {
+			slog.Error("failed to delete imported album", "album_id", report.Album.ID, "error", err)
+			return
+		}
+		if deleted && report.CoverUploaded {
+			if err := s.albumCoverService.DeleteCover(ctx, claims, report.Album.ID); err != nil {
+				slog.Error("failed to delete imported cover", "album_id", report.Album.ID, "error", err)
+			}
+		}
+	}
+
+	if report.Artist.Created {
+		if err := s.repo.DeleteArtistIfUnused(ctx, report.Artist.ID); err != nil {
+			slog.Error("failed to delete imported artist", "artist_id", report.Artist.ID, "error", err)
+		}
+	}
+	if report.Genre.Created {
+		if err := s.repo.DeleteGenreIfUnused(ctx, report.Genre.ID); err != nil {
+			slog.Error("failed to delete imported genre", "genre_id", report.Genre.ID, "error", err)
+		}
+	}
+}
+
+// mergeTags fills the unset fields of primary from fallback, either may be nil.
+func mergeTags(primary, fallback *entity.AudioTags) *entity.AudioTags {
+	merged := &entity.AudioTags{}
+	if fallback != nil {
+		*merged = *fallback
+	}
+	if primary == nil {
+		return merged
+	}
+
+	if primary.Title != "" {
+		merged.Title = primary.Title
+	}
+	if primary.Artist != "" {
+		merged.Artist = primary.Artist
+	}
+	if primary.Album != "" {
+		merged.Album = primary.Album
+	}
+	if primary.TrackNumber > 0 {
+		merged.TrackNumber = primary.TrackNumber
+	}
+	if primary.Year > 0 {
+		merged.Year = primary.Year
+	}
+	if primary.Genre != "" {
+		merged.Genre = primary.Genre
+	}
+	if len(primary.Cover) > 0 {
+		merged.Cover = primary.Cover
+	}
+
+	return merged
+}
+
+func releaseDate(year int) time.Time {
+	now := time.Now().UTC()
+	if year <= 0 || year > now.Year() {
+		return now.Truncate(24 * time.Hour)
+	}
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
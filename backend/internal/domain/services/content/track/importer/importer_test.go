@@ -0,0 +1,330 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/importer"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackImportServiceSuite struct {
+	suite.Suite
+	service             *importer.TrackImportService
+	repo                *mocks.ImportRepository
+	artistService       *mocks.ArtistMetaService
+	artistAssignService *mocks.ArtistAssignService
+	albumService        *mocks.AlbumMetaService
+	albumCoverService   *mocks.AlbumCoverService
+	genreService        *mocks.GenreService
+	genreAssignService  *mocks.GenreAssignService
+	trackService        *mocks.TrackMetaService
+	audioService        *mocks.AudioFileService
+	tagReader           *mocks.TagReader
+	ctx                 context.Context
+	params              *entity.TrackImportParams
+}
+
+func TestTrackImportServiceSuite(t *testing.T) {
+	suite.Run(t, new(TrackImportServiceSuite))
+}
+
+func (s *TrackImportServiceSuite) SetupTest() {
+	s.repo = mocks.NewImportRepository(s.T())
+	s.artistService = mocks.NewArtistMetaService(s.T())
+	s.artistAssignService = mocks.NewArtistAssignService(s.T())
+	s.albumService = mocks.NewAlbumMetaService(s.T())
+	s.albumCoverService = mocks.NewAlbumCoverService(s.T())
+	s.genreService = mocks.NewGenreService(s.T())
+	s.genreAssignService = mocks.NewGenreAssignService(s.T())
+	s.trackService = mocks.NewTrackMetaService(s.T())
+	s.audioService = mocks.NewAudioFileService(s.T())
+	s.tagReader = mocks.NewTagReader(s.T())
+	s.service = importer.New(s.repo, s.artistService, s.artistAssignService, s.albumService, s.albumCoverService,
+		s.genreService, s.genreAssignService, s.trackService, s.audioService, s.tagReader)
+	s.ctx = context.Background()
+	s.params = &entity.TrackImportParams{LicenseID: uuid.New(), Size: 1024}
+}
+
+// Object Mother
+func AdminClaims() *entity.Claims {
+	return &entity.Claims{AccessLvl: entity.Admin}
+}
+
+func Tags() *entity.AudioTags {
+	return &entity.AudioTags{
+		Title:       "Song",
+		Artist:      "Artist",
+		Album:       "Album",
+		TrackNumber: 3,
+		Year:        2001,
+		Genre:       "Rock",
+		Cover:       []byte("cover"),
+	}
+}
+
+// File returns a file made of a 20 byte tag followed by the audio.
+func File() []byte {
+	return append([]byte("ID3\x03\x00\x00\x00\x00\x00\x0a0123456789"), "audio"...)
+}
+
+func (s *TrackImportServiceSuite) expectTags(tags *entity.AudioTags) {
+	s.tagReader.On("TagSize", File()[:10]).Return(20)
+	s.tagReader.On("ReadTags", File()[:20]).Return(tags, nil)
+}
+
+func (s *TrackImportServiceSuite) expectUpload(trackID uuid.UUID) {
+	s.audioService.On("UploadAudioFile", mock.Anything, mock.Anything,
+		&entity.AudioFile{TrackID: trackID, Size: s.params.Size}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			data, err := io.ReadAll(args.Get(3).(io.Reader))
+			s.NoError(err)
+			s.Equal(File(), data)
+		}).Return(nil)
+}
+
+func (s *TrackImportServiceSuite) expectCreated(genreID, artistID, albumID, trackID uuid.UUID) {
+	s.repo.On("GetGenreByTitle", mock.Anything, "Rock").Return(nil, commonerr.ErrNotFound)
+	s.genreService.On("CreateGenre", mock.Anything, mock.Anything, &entity.Genre{Title: "Rock"}).
+		Run(func(args mock.Arguments) { args.Get(2).(*entity.Genre).ID = genreID }).Return(nil)
+
+	s.repo.On("GetArtistByName", mock.Anything, "Artist").Return(nil, commonerr.ErrNotFound)
+	s.artistService.On("CreateArtistMeta", mock.Anything, mock.Anything, &entity.ArtistMeta{Name: "Artist"}).
+		Run(func(args mock.Arguments) { args.Get(2).(*entity.ArtistMeta).ID = artistID }).Return(nil)
+
+	s.repo.On("GetArtistAlbum", mock.Anything, artistID, "Album").Return(nil, commonerr.ErrNotFound)
+	s.albumService.On("CreateAlbum", mock.Anything, mock.Anything, mock.MatchedBy(func(a *entity.AlbumMeta) bool {
+		return a.Title == "Album" && a.LicenseID == s.params.LicenseID && a.ReleaseDate.Year() == 2001
+	})).Run(func(args mock.Arguments) { args.Get(2).(*entity.AlbumMeta).ID = albumID }).Return(albumID, nil)
+	s.artistAssignService.On("AssignArtistToAlbum", mock.Anything, mock.Anything, artistID, albumID).Return(nil)
+	s.genreAssignService.On("AssignGenreToAlbum", mock.Anything, mock.Anything, genreID, albumID).Return(nil)
+	s.albumCoverService.On("GetCover", mock.Anything, albumID, mock.Anything).Return(nil, commonerr.ErrNotFound)
+	s.albumCoverService.On("UploadCover", mock.Anything, mock.Anything,
+		&entity.Cover{ObjectID: albumID, Data: []byte("cover")}).Return(nil)
+
+	s.repo.On("GetAlbumTrack", mock.Anything, albumID, "Song").Return(nil, commonerr.ErrNotFound)
+	s.trackService.On("CreateTrackMeta", mock.Anything, mock.Anything, &entity.TrackMeta{
+		Name: "Song", GenreID: genreID, Duration: 1, LicenseID: s.params.LicenseID, AlbumID: albumID, TrackNumber: 3,
+	}).Run(func(args mock.Arguments) { args.Get(2).(*entity.TrackMeta).ID = trackID }).Return(trackID, nil)
+	s.artistAssignService.On("AssignArtistToTrack", mock.Anything, mock.Anything, artistID, trackID).Return(nil)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackCreatesEverything() {
+	genreID, artistID, albumID, trackID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s.expectTags(Tags())
+	s.expectCreated(genreID, artistID, albumID, trackID)
+	s.expectUpload(trackID)
+
+	report, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.NoError(err)
+	s.Equal(&entity.TrackImportReport{
+		Track:         entity.ImportedObject{ID: trackID, Name: "Song", Created: true},
+		Album:         entity.ImportedObject{ID: albumID, Name: "Album", Created: true},
+		Artist:        entity.ImportedObject{ID: artistID, Name: "Artist", Created: true},
+		Genre:         entity.ImportedObject{ID: genreID, Name: "Rock", Created: true},
+		CoverUploaded: true,
+	}, report)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackDeletesCreatedOnFailure() {
+	genreID, artistID, albumID, trackID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s.expectTags(Tags())
+	s.expectCreated(genreID, artistID, albumID, trackID)
+	s.audioService.On("UploadAudioFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("storage error"))
+
+	s.trackService.On("DeleteTrackMeta", mock.Anything, mock.Anything, trackID).Return(nil)
+	s.repo.On("DeleteAlbumIfUnused", mock.Anything, albumID).Return(true, nil)
+	s.albumCoverService.On("DeleteCover", mock.Anything, mock.Anything, albumID).Return(nil)
+	s.repo.On("DeleteArtistIfUnused", mock.Anything, artistID).Return(nil)
+	s.repo.On("DeleteGenreIfUnused", mock.Anything, genreID).Return(nil)
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.Error(err)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackKeepsAlbumMatchedMeanwhile() {
+	genreID, artistID, albumID, trackID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s.expectTags(Tags())
+	s.expectCreated(genreID, artistID, albumID, trackID)
+	s.audioService.On("UploadAudioFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("storage error"))
+
+	// a concurrent import added its track to the album, so the cover stays with it
+	s.trackService.On("DeleteTrackMeta", mock.Anything, mock.Anything, trackID).Return(nil)
+	s.repo.On("DeleteAlbumIfUnused", mock.Anything, albumID).Return(false, nil)
+	s.repo.On("DeleteArtistIfUnused", mock.Anything, artistID).Return(nil)
+	s.repo.On("DeleteGenreIfUnused", mock.Anything, genreID).Return(nil)
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.Error(err)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackMatchesExisting() {
+	genre := &entity.Genre{ID: uuid.New(), Title: "rock"}
+	artist := &entity.ArtistMeta{ID: uuid.New(), Name: "ARTIST"}
+	album := &entity.AlbumMeta{ID: uuid.New(), Title: "Album"}
+	track := &entity.TrackMeta{ID: uuid.New(), Name: "Song", AlbumID: album.ID}
+	s.expectTags(Tags())
+
+	s.repo.On("GetGenreByTitle", mock.Anything, "Rock").Return(genre, nil)
+	s.repo.On("GetArtistByName", mock.Anything, "Artist").Return(artist, nil)
+	s.repo.On("GetArtistAlbum", mock.Anything, artist.ID, "Album").Return(album, nil)
+	s.albumCoverService.On("GetCover", mock.Anything, album.ID, mock.Anything).Return(&entity.Cover{ObjectID: album.ID}, nil)
+	s.repo.On("GetAlbumTrack", mock.Anything, album.ID, "Song").Return(track, nil)
+
+	// the audio of the matched track is not uploaded again
+	report, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.NoError(err)
+	s.Equal(&entity.TrackImportReport{
+		Track:     entity.ImportedObject{ID: track.ID, Name: "Song"},
+		Album:     entity.ImportedObject{ID: album.ID, Name: "Album"},
+		Artist:    entity.ImportedObject{ID: artist.ID, Name: "ARTIST"},
+		Genre:     entity.ImportedObject{ID: genre.ID, Name: "rock"},
+		Duplicate: true,
+	}, report)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackAddsCoverToExistingAlbum() {
+	genre := &entity.Genre{ID: uuid.New(), Title: "Rock"}
+	artist := &entity.ArtistMeta{ID: uuid.New(), Name: "Artist"}
+	album := &entity.AlbumMeta{ID: uuid.New(), Title: "Album"}
+	track := &entity.TrackMeta{ID: uuid.New(), Name: "Song", AlbumID: album.ID}
+	s.expectTags(Tags())
+
+	s.repo.On("GetGenreByTitle", mock.Anything, "Rock").Return(genre, nil)
+	s.repo.On("GetArtistByName", mock.Anything, "Artist").Return(artist, nil)
+	s.repo.On("GetArtistAlbum", mock.Anything, artist.ID, "Album").Return(album, nil)
+	s.albumCoverService.On("GetCover", mock.Anything, album.ID, mock.Anything).Return(nil, commonerr.ErrNotFound)
+	s.albumCoverService.On("UploadCover", mock.Anything, mock.Anything,
+		&entity.Cover{ObjectID: album.ID, Data: []byte("cover")}).Return(nil)
+	s.repo.On("GetAlbumTrack", mock.Anything, album.ID, "Song").Return(track, nil)
+
+	report, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.NoError(err)
+	s.False(report.Album.Created)
+	s.True(report.CoverUploaded)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackNumberedAfterExistingTracks() {
+	tags := Tags()
+	tags.TrackNumber = 0
+	album := &entity.AlbumMeta{ID: uuid.New(), Title: "Album"}
+	genre := &entity.Genre{ID: uuid.New(), Title: "Rock"}
+	artist := &entity.ArtistMeta{ID: uuid.New(), Name: "Artist"}
+	s.expectTags(tags)
+
+	s.repo.On("GetGenreByTitle", mock.Anything, "Rock").Return(genre, nil)
+	s.repo.On("GetArtistByName", mock.Anything, "Artist").Return(artist, nil)
+	s.repo.On("GetArtistAlbum", mock.Anything, artist.ID, "Album").Return(album, nil)
+	s.albumCoverService.On("GetCover", mock.Anything, album.ID, mock.Anything).Return(&entity.Cover{ObjectID: album.ID}, nil)
+	s.repo.On("GetAlbumTrack", mock.Anything, album.ID, "Song").Return(nil, commonerr.ErrNotFound)
+	s.repo.On("NextTrackNumber", mock.Anything, album.ID).Return(3, nil)
+	s.trackService.On("CreateTrackMeta", mock.Anything, mock.Anything, mock.MatchedBy(func(t *entity.TrackMeta) bool {
+		return t.TrackNumber == 3
+	})).Return(uuid.New(), nil)
+	s.artistAssignService.On("AssignArtistToTrack", mock.Anything, mock.Anything, artist.ID, mock.Anything).Return(nil)
+	s.audioService.On("UploadAudioFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	report, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.NoError(err)
+	s.True(report.Track.Created)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackFallbackGenre() {
+	tags := Tags()
+	tags.Genre = ""
+	s.params.GenreID = uuid.New()
+	s.expectTags(tags)
+
+	s.genreService.On("GetGenreByID", mock.Anything, s.params.GenreID).
+		Return(&entity.Genre{ID: s.params.GenreID, Title: "Pop"}, nil)
+	s.repo.On("GetArtistByName", mock.Anything, "Artist").Return(nil, errors.New("repo error"))
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.Error(err)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackMissingGenre() {
+	tags := Tags()
+	tags.Genre = ""
+	s.expectTags(tags)
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.ErrorIs(err, importer.ErrMissingGenre)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackMissingTags() {
+	tags := Tags()
+	tags.Album = ""
+	s.expectTags(tags)
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.ErrorIs(err, importer.ErrMissingTags)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackNoTag() {
+	audio := []byte("fLaC\x00\x00\x00\x22\x00\x00audio")
+	s.tagReader.On("TagSize", audio[:10]).Return(0)
+	s.tagReader.On("ReadTags", audio[:10]).Return(nil, errors.New("no tag"))
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(audio))
+	s.ErrorIs(err, importer.ErrMissingTags)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackTagTooLarge() {
+	s.tagReader.On("TagSize", File()[:10]).Return(importer.MaxTagSize + 1)
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.ErrorIs(err, importer.ErrTagTooLarge)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackInvalidLicense() {
+	s.params.LicenseID = uuid.Nil
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.ErrorIs(err, importer.ErrInvalidLicenseID)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackForbidden() {
+	_, err := s.service.ImportTrack(s.ctx, &entity.Claims{AccessLvl: entity.User}, s.params, bytes.NewReader(File()))
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackKnownTagsTakePrecedence() {
+	s.params.Tags = &entity.AudioTags{Title: "Path Title", Album: "Path Album"}
+	genre := &entity.Genre{ID: uuid.New(), Title: "Rock"}
+	artist := &entity.ArtistMeta{ID: uuid.New(), Name: "Artist"}
+	album := &entity.AlbumMeta{ID: uuid.New(), Title: "path album"}
+	track := &entity.TrackMeta{ID: uuid.New(), Name: "path title"}
+	s.expectTags(Tags())
+
+	s.repo.On("GetGenreByTitle", mock.Anything, "Rock").Return(genre, nil)
+	s.repo.On("GetArtistByName", mock.Anything, "Artist").Return(artist, nil)
+	s.repo.On("GetArtistAlbum", mock.Anything, artist.ID, "Path Album").Return(album, nil)
+	s.albumCoverService.On("GetCover", mock.Anything, album.ID, mock.Anything).Return(&entity.Cover{ObjectID: album.ID}, nil)
+	s.repo.On("GetAlbumTrack", mock.Anything, album.ID, "Path Title").Return(track, nil)
+
+	report, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(File()))
+	s.NoError(err)
+	s.Equal(album.ID, report.Album.ID)
+	s.Equal(track.ID, report.Track.ID)
+}
+
+func (s *TrackImportServiceSuite) TestImportTrackKnownTagsWithoutFileTag() {
+	audio := []byte("fLaC\x00\x00\x00\x22\x00\x00audio")
+	s.params.Tags = &entity.AudioTags{Title: "Song", Artist: "Artist", Album: "Album"}
+	s.tagReader.On("TagSize", audio[:10]).Return(0)
+	s.tagReader.On("ReadTags", audio[:10]).Return(nil, errors.New("no tag"))
+
+	_, err := s.service.ImportTrack(s.ctx, AdminClaims(), s.params, bytes.NewReader(audio))
+	s.ErrorIs(err, importer.ErrMissingGenre)
+}
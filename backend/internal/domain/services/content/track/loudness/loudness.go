@@ -0,0 +1,147 @@
+package loudness
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/album"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+var ErrInvalidTrackID = errors.New("invalid track id")
+
+type AudioFileOpener interface {
+	OpenAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)
+}
+
+type LoudnessMeter interface {
+	MeasureLoudness(ctx context.Context, src io.Reader) (*entity.Loudness, error)
+}
+
+type TrackLoudnessRepository interface {
+	GetByID(ctx context.Context, trackID uuid.UUID) (*entity.TrackMeta, error)
+	UpdateLoudness(ctx context.Context, trackID uuid.UUID, loudness *entity.Loudness) error
+	// UpdateAlbumLoudness sets the album loudness of every track of the album, nil clears it.
+	UpdateAlbumLoudness(ctx context.Context, albumID uuid.UUID, loudness *entity.Loudness) error
+}
+
+type TrackLoudnessService struct {
+	audioRepo         AudioFileOpener
+	meter             LoudnessMeter
+	repo              TrackLoudnessRepository
+	albumTrackService album.AlbumTrackService
+}
+
+func New(
+	audioRepo AudioFileOpener,
+	meter LoudnessMeter,
+	repo TrackLoudnessRepository,
+	albumTrackService album.AlbumTrackService,
+) *TrackLoudnessService {
+	return &TrackLoudnessService{
+		audioRepo:         audioRepo,
+		meter:             meter,
+		repo:              repo,
+		albumTrackService: albumTrackService,
+	}
+}
+
+// AnalyzeLoudness measures the original of the track, then recomputes the
+// album loudness from all analyzed tracks of its album.
+func (s *TrackLoudnessService) AnalyzeLoudness(ctx context.Context, trackID uuid.UUID) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrAnalyzeLoudness, err)
+	}()
+
+	if trackID == uuid.Nil {
+		return ErrInvalidTrackID
+	}
+
+	loudness, err := s.measure(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.UpdateLoudness(ctx, trackID, loudness); err != nil {
+		return err
+	}
+
+	track, err := s.repo.GetByID(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
+	return s.updateAlbumLoudness(ctx, track.AlbumID)
+}
+
+// UpdateAlbumLoudness is called when a track leaves the album, the album loudness
+// is cleared if none of the tracks left is analyzed.
+func (s *TrackLoudnessService) UpdateAlbumLoudness(ctx context.Context, albumID uuid.UUID) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrUpdateAlbumLoudness, err)
+	}()
+
+	return s.updateAlbumLoudness(ctx, albumID)
+}
+
+func (s *TrackLoudnessService) updateAlbumLoudness(ctx context.Context, albumID uuid.UUID) error {
+	tracks, err := s.albumTrackService.GetAllTracks(ctx, albumID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateAlbumLoudness(ctx, albumID, AlbumLoudness(tracks))
+}
+
+func (s *TrackLoudnessService) measure(ctx context.Context, trackID uuid.UUID) (*entity.Loudness, error) {
+	_, content, err := s.audioRepo.OpenAudioFile(ctx, trackID, entity.QualityOriginal)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close audio file", "error", err)
+		}
+	}()
+
+	return s.meter.MeasureLoudness(ctx, content)
+}
+
+// AlbumLoudness combines the loudness of the analyzed tracks as if they were
+// played in a row: the energies are averaged with the durations as weights and
+// the peak is the highest one. It returns nil if no track is analyzed.
+func AlbumLoudness(tracks []*entity.TrackMeta) *entity.Loudness {
+	var (
+		energy   float64
+		duration float64
+		album    *entity.Loudness
+	)
+
+	for _, track := range tracks {
+		if track.Loudness == nil {
+			continue
+		}
+
+		weight := float64(max(track.Duration, 1))
+		energy += weight * math.Pow(10, track.Loudness.Integrated/10)
+		duration += weight
+
+		if album == nil {
+			album = &entity.Loudness{TruePeak: track.Loudness.TruePeak}
+		}
+		album.TruePeak = max(album.TruePeak, track.Loudness.TruePeak)
+	}
+
+	if album == nil {
+		return nil
+	}
+	album.Integrated = math.Round(10*math.Log10(energy/duration)*100) / 100
+
+	return album
+}
@@ -0,0 +1,146 @@
+package loudness_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/loudness"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackLoudnessServiceSuite struct {
+	suite.Suite
+	service     *loudness.TrackLoudnessService
+	audioRepo   *mocks.AudioFileOpener
+	meter       *mocks.LoudnessMeter
+	repo        *mocks.TrackLoudnessRepository
+	albumTracks *mocks.AlbumTrackService
+	ctx         context.Context
+	trackID     uuid.UUID
+	albumID     uuid.UUID
+}
+
+func TestTrackLoudnessServiceSuite(t *testing.T) {
+	suite.Run(t, new(TrackLoudnessServiceSuite))
+}
+
+func (s *TrackLoudnessServiceSuite) SetupTest() {
+	s.audioRepo = mocks.NewAudioFileOpener(s.T())
+	s.meter = mocks.NewLoudnessMeter(s.T())
+	s.repo = mocks.NewTrackLoudnessRepository(s.T())
+	s.albumTracks = mocks.NewAlbumTrackService(s.T())
+	s.service = loudness.New(s.audioRepo, s.meter, s.repo, s.albumTracks)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+	s.albumID = uuid.New()
+}
+
+// Object Mother
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+func Track(duration int, loudness *entity.Loudness) *entity.TrackMeta {
+	return &entity.TrackMeta{
+		ID:       uuid.New(),
+		Duration: duration,
+		Loudness: loudness,
+	}
+}
+
+func (s *TrackLoudnessServiceSuite) expectMeasure(measured *entity.Loudness) {
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(&entity.AudioFile{TrackID: s.trackID}, content{bytes.NewReader([]byte("audio"))}, nil)
+	s.meter.On("MeasureLoudness", mock.Anything, mock.Anything).Return(measured, nil)
+}
+
+// AnalyzeLoudness
+func (s *TrackLoudnessServiceSuite) TestAnalyzeLoudness() {
+	measured := &entity.Loudness{Integrated: -10, TruePeak: -0.5}
+	s.expectMeasure(measured)
+	s.repo.On("UpdateLoudness", mock.Anything, s.trackID, measured).Return(nil)
+	s.repo.On("GetByID", mock.Anything, s.trackID).Return(&entity.TrackMeta{ID: s.trackID, AlbumID: s.albumID}, nil)
+	s.albumTracks.On("GetAllTracks", mock.Anything, s.albumID).Return([]*entity.TrackMeta{
+		Track(100, measured),
+		Track(100, &entity.Loudness{Integrated: -10, TruePeak: -3}),
+		Track(100, nil),
+	}, nil)
+	s.repo.On("UpdateAlbumLoudness", mock.Anything, s.albumID, &entity.Loudness{Integrated: -10, TruePeak: -0.5}).Return(nil)
+
+	err := s.service.AnalyzeLoudness(s.ctx, s.trackID)
+	s.NoError(err)
+}
+
+func (s *TrackLoudnessServiceSuite) TestAnalyzeLoudnessInvalidTrackID() {
+	err := s.service.AnalyzeLoudness(s.ctx, uuid.Nil)
+	s.ErrorIs(err, loudness.ErrInvalidTrackID)
+}
+
+func (s *TrackLoudnessServiceSuite) TestAnalyzeLoudnessNotFound() {
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil, nil, commonerr.ErrNotFound)
+
+	err := s.service.AnalyzeLoudness(s.ctx, s.trackID)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+func (s *TrackLoudnessServiceSuite) TestAnalyzeLoudnessMeterError() {
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(&entity.AudioFile{TrackID: s.trackID}, content{bytes.NewReader(nil)}, nil)
+	s.meter.On("MeasureLoudness", mock.Anything, mock.Anything).Return(nil, errors.New("ffmpeg error"))
+
+	err := s.service.AnalyzeLoudness(s.ctx, s.trackID)
+	s.Error(err)
+}
+
+func (s *TrackLoudnessServiceSuite) TestAnalyzeLoudnessRepoError() {
+	measured := &entity.Loudness{Integrated: -14, TruePeak: -1}
+	s.expectMeasure(measured)
+	s.repo.On("UpdateLoudness", mock.Anything, s.trackID, measured).Return(errors.New("repo error"))
+
+	err := s.service.AnalyzeLoudness(s.ctx, s.trackID)
+	s.Error(err)
+}
+
+// UpdateAlbumLoudness
+func (s *TrackLoudnessServiceSuite) TestUpdateAlbumLoudnessClearedWithoutAnalyzedTracks() {
+	s.albumTracks.On("GetAllTracks", mock.Anything, s.albumID).Return([]*entity.TrackMeta{Track(100, nil)}, nil)
+	s.repo.On("UpdateAlbumLoudness", mock.Anything, s.albumID, (*entity.Loudness)(nil)).Return(nil)
+
+	err := s.service.UpdateAlbumLoudness(s.ctx, s.albumID)
+	s.NoError(err)
+}
+
+func (s *TrackLoudnessServiceSuite) TestUpdateAlbumLoudnessError() {
+	s.albumTracks.On("GetAllTracks", mock.Anything, s.albumID).Return(nil, errors.New("db error"))
+
+	err := s.service.UpdateAlbumLoudness(s.ctx, s.albumID)
+	s.ErrorIs(err, usecase.ErrUpdateAlbumLoudness)
+}
+
+// AlbumLoudness
+func (s *TrackLoudnessServiceSuite) TestAlbumLoudnessWeightsByDuration() {
+	res := loudness.AlbumLoudness([]*entity.TrackMeta{
+		Track(300, &entity.Loudness{Integrated: -10, TruePeak: -1}),
+		Track(100, &entity.Loudness{Integrated: -20, TruePeak: -4}),
+	})
+	s.Require().NotNil(res)
+	// 10*log10((300*0.1 + 100*0.01) / 400)
+	s.InDelta(-11.12, res.Integrated, 0.01)
+	s.Equal(-1.0, res.TruePeak)
+}
+
+func (s *TrackLoudnessServiceSuite) TestAlbumLoudnessNotAnalyzed() {
+	s.Nil(loudness.AlbumLoudness([]*entity.TrackMeta{Track(100, nil)}))
+}
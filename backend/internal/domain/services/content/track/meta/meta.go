@@ -3,6 +3,7 @@ package meta
 import (
 	"context"
 	"errors"
+	"log/slog"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
@@ -26,10 +27,13 @@ type TrackMetaRepository interface {
 type TrackMetaService struct {
 	repo           TrackMetaRepository
 	segmentService track.TrackSegmentService
+	loudness       track.TrackLoudnessService
+	audioFiles     track.AudioFileService
 }
 
-func NewTrackMetaService(repo TrackMetaRepository, segmentService track.TrackSegmentService) *TrackMetaService {
-	return &TrackMetaService{repo: repo, segmentService: segmentService}
+func NewTrackMetaService(repo TrackMetaRepository, segmentService track.TrackSegmentService,
+	loudness track.TrackLoudnessService, audioFiles track.AudioFileService) *TrackMetaService {
+	return &TrackMetaService{repo: repo, segmentService: segmentService, loudness: loudness, audioFiles: audioFiles}
 }
 
 func (s *TrackMetaService) GetTrackMeta(ctx context.Context, trackID uuid.UUID) (_ *entity.TrackMeta, err error) {
@@ -91,9 +95,28 @@ func (s *TrackMetaService) DeleteTrackMeta(ctx context.Context, claims *entity.C
 		return commonerr.ErrForbidden
 	}
 
+	track, err := s.repo.GetByID(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
 	if err = s.segmentService.DeleteSegments(ctx, trackID); err != nil {
 		return err
 	}
 
-	return s.repo.Delete(ctx, trackID)
+	// the files are released before the row, the cascade would leave their blobs behind
+	if err = s.audioFiles.DeleteAudioFile(ctx, claims, trackID); err != nil {
+		return err
+	}
+
+	if err = s.repo.Delete(ctx, trackID); err != nil {
+		return err
+	}
+
+	// the album loudness included the deleted track, a stale one only shifts the playback volume
+	if err := s.loudness.UpdateAlbumLoudness(ctx, track.AlbumID); err != nil {
+		slog.Error("failed to update album loudness", "album_id", track.AlbumID, "error", err)
+	}
+
+	return nil
 }
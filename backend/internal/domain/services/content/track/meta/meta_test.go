@@ -42,6 +42,8 @@ type TrackMetaServiceSuite struct {
 	service        *meta.TrackMetaService
 	repo           *mocks.TrackMetaRepository
 	segmentService *mocks.TrackSegmentService
+	loudness       *mocks.TrackLoudnessService
+	audioFiles     *mocks.AudioFileService
 
 	objMother *TrackMetaObjectMother
 }
@@ -50,7 +52,9 @@ func (s *TrackMetaServiceSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.repo = mocks.NewTrackMetaRepository(s.T())
 	s.segmentService = mocks.NewTrackSegmentService(s.T())
-	s.service = meta.NewTrackMetaService(s.repo, s.segmentService)
+	s.loudness = mocks.NewTrackLoudnessService(s.T())
+	s.audioFiles = mocks.NewAudioFileService(s.T())
+	s.service = meta.NewTrackMetaService(s.repo, s.segmentService, s.loudness, s.audioFiles)
 	s.objMother = &TrackMetaObjectMother{}
 }
 
@@ -126,8 +130,11 @@ func (s *TrackMetaServiceSuite) TestDeleteTrackMeta_Success() {
 	track := s.objMother.DefaultTrackMeta()
 	claims := s.objMother.AdminClaims()
 
+	s.repo.On("GetByID", s.ctx, track.ID).Return(track, nil)
 	s.segmentService.On("DeleteSegments", s.ctx, track.ID).Return(nil)
+	s.audioFiles.On("DeleteAudioFile", s.ctx, claims, track.ID).Return(nil)
 	s.repo.On("Delete", s.ctx, track.ID).Return(nil)
+	s.loudness.On("UpdateAlbumLoudness", s.ctx, track.AlbumID).Return(nil)
 
 	err := s.service.DeleteTrackMeta(s.ctx, claims, track.ID)
 
@@ -136,6 +143,35 @@ func (s *TrackMetaServiceSuite) TestDeleteTrackMeta_Success() {
 	s.repo.AssertExpectations(s.T())
 }
 
+func (s *TrackMetaServiceSuite) TestDeleteTrackMeta_AlbumLoudnessError() {
+	track := s.objMother.DefaultTrackMeta()
+	claims := s.objMother.AdminClaims()
+
+	s.repo.On("GetByID", s.ctx, track.ID).Return(track, nil)
+	s.segmentService.On("DeleteSegments", s.ctx, track.ID).Return(nil)
+	s.audioFiles.On("DeleteAudioFile", s.ctx, claims, track.ID).Return(nil)
+	s.repo.On("Delete", s.ctx, track.ID).Return(nil)
+	s.loudness.On("UpdateAlbumLoudness", s.ctx, track.AlbumID).Return(errors.New("db error"))
+
+	err := s.service.DeleteTrackMeta(s.ctx, claims, track.ID)
+
+	s.NoError(err)
+}
+
+func (s *TrackMetaServiceSuite) TestDeleteTrackMeta_AudioFileError() {
+	track := s.objMother.DefaultTrackMeta()
+	claims := s.objMother.AdminClaims()
+
+	s.repo.On("GetByID", s.ctx, track.ID).Return(track, nil)
+	s.segmentService.On("DeleteSegments", s.ctx, track.ID).Return(nil)
+	s.audioFiles.On("DeleteAudioFile", s.ctx, claims, track.ID).Return(errors.New("storage error"))
+
+	err := s.service.DeleteTrackMeta(s.ctx, claims, track.ID)
+
+	s.Error(err)
+	s.repo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
 func (s *TrackMetaServiceSuite) TestDeleteTrackMeta_Forbidden() {
 	track := s.objMother.DefaultTrackMeta()
 	claims := s.objMother.UserClaims()
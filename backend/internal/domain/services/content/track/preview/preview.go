@@ -0,0 +1,276 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	DefaultLength = 30 * time.Second
+	MinLength     = 5 * time.Second
+	MaxLength     = 60 * time.Second
+	// FallbackOffset is where previews of tracks without listening stats start.
+	FallbackOffset = 30 * time.Second
+	// FadeDuration is shortened for clips under four fades long.
+	FadeDuration = 2 * time.Second
+
+	// DefaultMaxEncodes bounds the previews encoded at once, cached ones are served regardless.
+	DefaultMaxEncodes = 4
+	// the encoded previews are kept in memory, MaxLength at the preview bitrate is about a megabyte
+	CacheSize = 64
+	CacheTTL  = time.Hour
+)
+
+var (
+	ErrInvalidTrackID  = errors.New("invalid track id")
+	ErrInvalidLength   = errors.New("preview length out of bounds")
+	ErrTooManyPreviews = errors.New("too many previews being encoded")
+)
+
+type AudioFileOpener interface {
+	OpenAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)
+}
+
+type PreviewClipper interface {
+	ClipPreview(ctx context.Context, src io.Reader, clip *entity.PreviewClip) (io.ReadCloser, error)
+}
+
+type TrackMetaGetter interface {
+	GetByID(ctx context.Context, trackID uuid.UUID) (*entity.TrackMeta, error)
+}
+
+type SegmentGetter interface {
+	GetSegments(ctx context.Context, trackID uuid.UUID) ([]*entity.Segment, error)
+}
+
+// cacheKey identifies an encoded preview by the content of the original and the clip.
+type cacheKey struct {
+	hash     string
+	start    time.Duration
+	duration time.Duration
+	fade     time.Duration
+}
+
+type TrackPreviewService struct {
+	audioRepo AudioFileOpener
+	clipper   PreviewClipper
+	trackRepo TrackMetaGetter
+	segments  SegmentGetter
+	encodes   chan struct{}
+	cache     *expirable.LRU[cacheKey, []byte]
+}
+
+type OptionFunc func(*TrackPreviewService)
+
+// WithMaxEncodes sets the number of previews encoded at once, DefaultMaxEncodes by default.
+func WithMaxEncodes(n int) OptionFunc {
+	return func(s *TrackPreviewService) {
+		if n > 0 {
+			s.encodes = make(chan struct{}, n)
+		}
+	}
+}
+
+func New(audioRepo AudioFileOpener, clipper PreviewClipper, trackRepo TrackMetaGetter, segments SegmentGetter,
+	opts ...OptionFunc) *TrackPreviewService {
+	s := &TrackPreviewService{
+		audioRepo: audioRepo,
+		clipper:   clipper,
+		trackRepo: trackRepo,
+		segments:  segments,
+		encodes:   make(chan struct{}, DefaultMaxEncodes),
+		cache:     expirable.NewLRU[cacheKey, []byte](CacheSize, nil, CacheTTL),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// GetPreview picks the window of the given length with the most streams recorded
+// for its segments. A zero length means DefaultLength. A preview not cached is
+// encoded only if fewer than the max encodes are running, ErrTooManyPreviews otherwise.
+func (s *TrackPreviewService) GetPreview(ctx context.Context, trackID uuid.UUID, length time.Duration) (_ *entity.PreviewClip, _ io.ReadCloser, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetPreview, err)
+	}()
+
+	if trackID == uuid.Nil {
+		return nil, nil, ErrInvalidTrackID
+	}
+	if length == 0 {
+		length = DefaultLength
+	}
+	if length < MinLength || length > MaxLength {
+		return nil, nil, ErrInvalidLength
+	}
+
+	track, err := s.trackRepo.GetByID(ctx, trackID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	segments, err := s.segments.GetSegments(ctx, trackID)
+	if err != nil {
+		// the preview is still playable from the fallback offset
+		slog.Error("failed to get segments for preview", "track_id", trackID, "error", err)
+	}
+
+	clip := selectClip(segments, time.Duration(track.Duration)*time.Second, length)
+	clip.TrackID = trackID
+
+	file, original, err := s.audioRepo.OpenAudioFile(ctx, trackID, entity.QualityOriginal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := cacheKey{hash: file.Hash, start: clip.Start, duration: clip.Duration, fade: clip.Fade}
+	if data, ok := s.cache.Get(key); ok {
+		closeContent(original)
+		return clip, io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	select {
+	case s.encodes <- struct{}{}:
+	default:
+		closeContent(original)
+		return nil, nil, ErrTooManyPreviews
+	}
+	release := func() { <-s.encodes }
+
+	content, err := s.clipper.ClipPreview(ctx, original, clip)
+	if err != nil {
+		release()
+		closeContent(original)
+		return nil, nil, err
+	}
+
+	p := &preview{ReadCloser: content, original: original, release: release}
+	if key.hash != "" {
+		// the files without a hash, stored before audio was stored by content, are not cached
+		p.cache = func(data []byte) { s.cache.Add(key, data) }
+	}
+
+	return clip, p, nil
+}
+
+// selectClip places the clip over the hottest window and keeps it inside the track.
+// An unknown duration is treated as long enough for any clip.
+func selectClip(segments []*entity.Segment, duration, length time.Duration) *entity.PreviewClip {
+	if duration > 0 && duration < length {
+		length = duration
+	}
+
+	start, ok := hottestStart(segments, length)
+	if !ok {
+		start = FallbackOffset
+	}
+	if duration > 0 {
+		start = min(start, duration-length)
+	}
+
+	return &entity.PreviewClip{
+		Start:    max(start, 0),
+		Duration: length,
+		Fade:     min(FadeDuration, length/4),
+	}
+}
+
+// hottestStart returns the start of the window with the most streams. The windows
+// start or end on segment boundaries, partially covered segments count in proportion
+// to the overlap. Ties go to the earliest window, no streams at all yield false.
+func hottestStart(segments []*entity.Segment, length time.Duration) (time.Duration, bool) {
+	var (
+		best      float64
+		bestStart time.Duration
+	)
+
+	for _, segment := range segments {
+		if segment.Range == nil {
+			continue
+		}
+
+		for _, start := range []time.Duration{
+			seconds(segment.Range.End) - length,
+			seconds(segment.Range.Start),
+		} {
+			start = max(start, 0)
+			streams := windowStreams(segments, start, start+length)
+			if streams > best || (streams == best && streams > 0 && start < bestStart) {
+				best, bestStart = streams, start
+			}
+		}
+	}
+
+	return bestStart, best > 0
+}
+
+func windowStreams(segments []*entity.Segment, start, end time.Duration) float64 {
+	var streams float64
+	for _, segment := range segments {
+		if segment.Range == nil || segment.Range.Len() <= 0 {
+			continue
+		}
+
+		overlap := min(end, seconds(segment.Range.End)) - max(start, seconds(segment.Range.Start))
+		if overlap > 0 {
+			streams += float64(segment.TotalStreams) * float64(overlap) / float64(seconds(segment.Range.Len()))
+		}
+	}
+	return streams
+}
+
+func seconds(s int) time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+// preview closes the original together with the clip read from it and frees
+// the encode slot. A clip read to the end without an error is cached.
+type preview struct {
+	io.ReadCloser
+	original  io.Closer
+	release   func()
+	cache     func(data []byte)
+	buf       bytes.Buffer
+	closeOnce sync.Once
+}
+
+func (p *preview) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	if p.cache != nil {
+		p.buf.Write(b[:n])
+		if errors.Is(err, io.EOF) {
+			p.cache(bytes.Clone(p.buf.Bytes()))
+			p.cache = nil
+		}
+	}
+
+	return n, err
+}
+
+func (p *preview) Close() (err error) {
+	p.closeOnce.Do(func() {
+		err = p.ReadCloser.Close()
+		closeContent(p.original)
+		p.release()
+	})
+	return err
+}
+
+func closeContent(content io.Closer) {
+	if err := content.Close(); err != nil {
+		slog.Error("failed to close audio file", "error", err)
+	}
+}
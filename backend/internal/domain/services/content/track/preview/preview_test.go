@@ -0,0 +1,232 @@
+package preview_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/preview"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackPreviewServiceSuite struct {
+	suite.Suite
+	service   *preview.TrackPreviewService
+	audioRepo *mocks.AudioFileOpener
+	clipper   *mocks.PreviewClipper
+	trackRepo *mocks.TrackMetaGetter
+	segments  *mocks.SegmentGetter
+	ctx       context.Context
+	trackID   uuid.UUID
+}
+
+func TestTrackPreviewServiceSuite(t *testing.T) {
+	suite.Run(t, new(TrackPreviewServiceSuite))
+}
+
+func (s *TrackPreviewServiceSuite) SetupTest() {
+	s.audioRepo = mocks.NewAudioFileOpener(s.T())
+	s.clipper = mocks.NewPreviewClipper(s.T())
+	s.trackRepo = mocks.NewTrackMetaGetter(s.T())
+	s.segments = mocks.NewSegmentGetter(s.T())
+	s.service = preview.New(s.audioRepo, s.clipper, s.trackRepo, s.segments)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+}
+
+// Object Mother
+type content struct {
+	*bytes.Reader
+	closed bool
+}
+
+func (c *content) Close() error {
+	c.closed = true
+	return nil
+}
+
+// Segments splits a track into 10-second segments with the given streams.
+func (s *TrackPreviewServiceSuite) Segments(streams ...uint64) []*entity.Segment {
+	segments := make([]*entity.Segment, len(streams))
+	for i, total := range streams {
+		segments[i] = &entity.Segment{
+			TrackID:      s.trackID,
+			Idx:          i,
+			TotalStreams: total,
+			Range:        &entity.Range{Start: i * 10, End: (i + 1) * 10},
+		}
+	}
+	return segments
+}
+
+func (s *TrackPreviewServiceSuite) expectTrack(duration int, segments []*entity.Segment) {
+	s.trackRepo.On("GetByID", mock.Anything, s.trackID).Return(&entity.TrackMeta{ID: s.trackID, Duration: duration}, nil)
+	s.segments.On("GetSegments", mock.Anything, s.trackID).Return(segments, nil)
+}
+
+// expectClip expects the clip to start at start and returns the original file.
+func (s *TrackPreviewServiceSuite) expectClip(start, duration time.Duration) *content {
+	original := &content{Reader: bytes.NewReader([]byte("audio"))}
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(&entity.AudioFile{TrackID: s.trackID, Format: entity.FormatMP3, Hash: "hash"}, original, nil)
+	s.clipper.On("ClipPreview", mock.Anything, original, mock.MatchedBy(func(clip *entity.PreviewClip) bool {
+		return clip.Start == start && clip.Duration == duration
+	})).Return(io.NopCloser(bytes.NewReader([]byte("clip"))), nil)
+	return original
+}
+
+// GetPreview
+func (s *TrackPreviewServiceSuite) TestGetPreviewHottestWindow() {
+	s.expectTrack(120, s.Segments(0, 1, 0, 0, 0, 9, 8, 7, 1, 0, 0, 0))
+	original := s.expectClip(50*time.Second, 30*time.Second)
+
+	clip, res, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.Require().NoError(err)
+	s.Equal(s.trackID, clip.TrackID)
+	s.Equal(preview.FadeDuration, clip.Fade)
+
+	data, err := io.ReadAll(res)
+	s.NoError(err)
+	s.Equal("clip", string(data))
+
+	s.NoError(res.Close())
+	s.True(original.closed)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewWindowEndsOnSegment() {
+	// the 15-second windows starting at 40s and 45s tie, the earliest one wins
+	s.expectTrack(120, s.Segments(0, 0, 0, 2, 9, 9, 0, 0, 0, 0, 0, 0))
+	s.expectClip(40*time.Second, 15*time.Second)
+
+	_, _, err := s.service.GetPreview(s.ctx, s.trackID, 15*time.Second)
+	s.NoError(err)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewClampedToTrackEnd() {
+	s.expectTrack(120, s.Segments(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 9))
+	s.expectClip(90*time.Second, 30*time.Second)
+
+	_, _, err := s.service.GetPreview(s.ctx, s.trackID, 30*time.Second)
+	s.NoError(err)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewFallbackWithoutStats() {
+	s.expectTrack(120, s.Segments(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0))
+	s.expectClip(preview.FallbackOffset, 30*time.Second)
+
+	_, _, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.NoError(err)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewFallbackOnSegmentsError() {
+	s.trackRepo.On("GetByID", mock.Anything, s.trackID).Return(&entity.TrackMeta{ID: s.trackID, Duration: 200}, nil)
+	s.segments.On("GetSegments", mock.Anything, s.trackID).Return(nil, errors.New("db error"))
+	s.expectClip(preview.FallbackOffset, 30*time.Second)
+
+	_, _, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.NoError(err)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewShortTrack() {
+	s.expectTrack(20, s.Segments(5, 1))
+	s.expectClip(0, 20*time.Second)
+
+	clip, _, err := s.service.GetPreview(s.ctx, s.trackID, 30*time.Second)
+	s.Require().NoError(err)
+	s.Equal(preview.FadeDuration, clip.Fade)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewShortFade() {
+	s.expectTrack(120, nil)
+	s.expectClip(preview.FallbackOffset, 5*time.Second)
+
+	clip, _, err := s.service.GetPreview(s.ctx, s.trackID, preview.MinLength)
+	s.Require().NoError(err)
+	s.Equal(1250*time.Millisecond, clip.Fade)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewInvalidLength() {
+	_, _, err := s.service.GetPreview(s.ctx, s.trackID, 2*time.Minute)
+	s.ErrorIs(err, preview.ErrInvalidLength)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewInvalidTrackID() {
+	_, _, err := s.service.GetPreview(s.ctx, uuid.Nil, 0)
+	s.ErrorIs(err, preview.ErrInvalidTrackID)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewNoAudio() {
+	s.expectTrack(120, nil)
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil, nil, commonerr.ErrNotFound)
+
+	_, _, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewCached() {
+	s.expectTrack(120, nil)
+	s.expectClip(preview.FallbackOffset, 30*time.Second)
+
+	_, res, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.Require().NoError(err)
+	_, err = io.ReadAll(res)
+	s.NoError(err)
+	s.NoError(res.Close())
+
+	_, res, err = s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.Require().NoError(err)
+	data, err := io.ReadAll(res)
+	s.NoError(err)
+	s.Equal("clip", string(data))
+	s.clipper.AssertNumberOfCalls(s.T(), "ClipPreview", 1)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewNotCachedUntilRead() {
+	s.expectTrack(120, nil)
+	s.expectClip(preview.FallbackOffset, 30*time.Second)
+
+	_, res, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.Require().NoError(err)
+	s.NoError(res.Close())
+
+	_, _, err = s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.Require().NoError(err)
+	s.clipper.AssertNumberOfCalls(s.T(), "ClipPreview", 2)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewTooManyEncodes() {
+	s.service = preview.New(s.audioRepo, s.clipper, s.trackRepo, s.segments, preview.WithMaxEncodes(1))
+	s.expectTrack(120, nil)
+	original := s.expectClip(preview.FallbackOffset, 30*time.Second)
+
+	_, res, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.Require().NoError(err)
+
+	_, _, err = s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.ErrorIs(err, preview.ErrTooManyPreviews)
+	s.True(original.closed)
+
+	s.NoError(res.Close())
+	_, _, err = s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.NoError(err)
+}
+
+func (s *TrackPreviewServiceSuite) TestGetPreviewClipperError() {
+	s.expectTrack(120, nil)
+	original := &content{Reader: bytes.NewReader(nil)}
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(&entity.AudioFile{TrackID: s.trackID}, original, nil)
+	s.clipper.On("ClipPreview", mock.Anything, original, mock.Anything).Return(nil, errors.New("ffmpeg error"))
+
+	_, _, err := s.service.GetPreview(s.ctx, s.trackID, 0)
+	s.Error(err)
+	s.True(original.closed)
+}
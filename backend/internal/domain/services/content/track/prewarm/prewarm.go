@@ -0,0 +1,62 @@
+package prewarm
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+var ErrInvalidTrackCount = errors.New("invalid track count")
+
+type PopularBlobLister interface {
+	ListPopularBlobs(ctx context.Context, tracks int) ([]string, error)
+}
+
+type BlobPrewarmer interface {
+	Prewarm(ctx context.Context, hashes []string) (int, error)
+	Stats() entity.AudioCacheStats
+}
+
+type AudioCachePrewarmService struct {
+	index PopularBlobLister
+	cache BlobPrewarmer
+}
+
+func New(index PopularBlobLister, cache BlobPrewarmer) *AudioCachePrewarmService {
+	return &AudioCachePrewarmService{
+		index: index,
+		cache: cache,
+	}
+}
+
+// PrewarmAudioCache returns the stats of the cache after prewarming, also when
+// it is interrupted by an error.
+func (s *AudioCachePrewarmService) PrewarmAudioCache(ctx context.Context, claims *entity.Claims,
+	tracks int) (_ *entity.AudioCacheStats, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrPrewarmAudioCache, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+	if tracks <= 0 {
+		return nil, ErrInvalidTrackCount
+	}
+
+	hashes, err := s.index.ListPopularBlobs(ctx, tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	warmed, err := s.cache.Prewarm(ctx, hashes)
+	slog.Info("audio cache prewarmed", "tracks", tracks, "blobs", len(hashes), "warmed", warmed)
+
+	stats := s.cache.Stats()
+	return &stats, err
+}
@@ -0,0 +1,90 @@
+package prewarm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/prewarm"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AudioCachePrewarmServiceSuite struct {
+	suite.Suite
+	service *prewarm.AudioCachePrewarmService
+	index   *mocks.PopularBlobLister
+	cache   *mocks.BlobPrewarmer
+	ctx     context.Context
+}
+
+func TestAudioCachePrewarmServiceSuite(t *testing.T) {
+	suite.Run(t, new(AudioCachePrewarmServiceSuite))
+}
+
+func (s *AudioCachePrewarmServiceSuite) SetupTest() {
+	s.index = mocks.NewPopularBlobLister(s.T())
+	s.cache = mocks.NewBlobPrewarmer(s.T())
+	s.service = prewarm.New(s.index, s.cache)
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func AdminClaims() *entity.Claims {
+	return &entity.Claims{UserID: uuid.New(), AccessLvl: entity.Admin}
+}
+
+func Stats() entity.AudioCacheStats {
+	return entity.AudioCacheStats{Fills: 2, Blobs: 2, Used: 200, Capacity: 1000}
+}
+
+// Tests
+func (s *AudioCachePrewarmServiceSuite) TestPrewarmAudioCache() {
+	hashes := []string{"a", "b"}
+	s.index.On("ListPopularBlobs", mock.Anything, 10).Return(hashes, nil)
+	s.cache.On("Prewarm", mock.Anything, hashes).Return(2, nil)
+	s.cache.On("Stats").Return(Stats())
+
+	stats, err := s.service.PrewarmAudioCache(s.ctx, AdminClaims(), 10)
+
+	s.Require().NoError(err)
+	s.Equal(Stats(), *stats)
+}
+
+func (s *AudioCachePrewarmServiceSuite) TestReturnsStatsOnPrewarmError() {
+	prewarmErr := errors.New("origin unavailable")
+	s.index.On("ListPopularBlobs", mock.Anything, 10).Return([]string{"a", "b"}, nil)
+	s.cache.On("Prewarm", mock.Anything, mock.Anything).Return(1, prewarmErr)
+	s.cache.On("Stats").Return(Stats())
+
+	stats, err := s.service.PrewarmAudioCache(s.ctx, AdminClaims(), 10)
+
+	s.ErrorIs(err, usecase.ErrPrewarmAudioCache)
+	s.ErrorIs(err, prewarmErr)
+	s.NotNil(stats)
+}
+
+func (s *AudioCachePrewarmServiceSuite) TestRequiresAdmin() {
+	_, err := s.service.PrewarmAudioCache(s.ctx, &entity.Claims{UserID: uuid.New(), AccessLvl: entity.User}, 10)
+
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
+
+func (s *AudioCachePrewarmServiceSuite) TestInvalidTrackCount() {
+	_, err := s.service.PrewarmAudioCache(s.ctx, AdminClaims(), 0)
+
+	s.ErrorIs(err, prewarm.ErrInvalidTrackCount)
+}
+
+func (s *AudioCachePrewarmServiceSuite) TestListError() {
+	s.index.On("ListPopularBlobs", mock.Anything, 10).Return(nil, errors.New("db error"))
+
+	_, err := s.service.PrewarmAudioCache(s.ctx, AdminClaims(), 10)
+
+	s.ErrorIs(err, usecase.ErrPrewarmAudioCache)
+}
@@ -0,0 +1,224 @@
+package scrub
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+// OrphanGracePeriod keeps blobs of uploads in progress from being reported,
+// a blob is written before the file of the track points to it.
+const OrphanGracePeriod = time.Hour
+
+type QuarantineStorage interface {
+	ListBlobs(ctx context.Context) ([]string, error)
+	OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error)
+	QuarantineBlob(ctx context.Context, hash string) error
+}
+
+type BlobReferenceIndex interface {
+	ListReferencedBlobs(ctx context.Context) ([]string, error)
+	GetBlobTracks(ctx context.Context, hash string) ([]uuid.UUID, error)
+	DeleteBlobReferences(ctx context.Context, hash string) error
+	// QuarantineOrphanedBlob calls quarantine under the lock of the blob, false
+	// if a file was pointed to the blob since it was listed.
+	QuarantineOrphanedBlob(ctx context.Context, hash string, quarantine func(ctx context.Context) error) (bool, error)
+}
+
+// BlobCache keeps local copies of the blobs, which must not outlive the originals.
+type BlobCache interface {
+	EvictBlob(ctx context.Context, hash string) error
+}
+
+type AudioScrubService struct {
+	blobs QuarantineStorage
+	index BlobReferenceIndex
+	cache BlobCache
+}
+
+type OptionFunc func(*AudioScrubService)
+
+// WithCache evicts the quarantined and missing blobs from the cache,
+// so they are not served from there.
+func WithCache(cache BlobCache) OptionFunc {
+	return func(s *AudioScrubService) {
+		s.cache = cache
+	}
+}
+
+func New(blobs QuarantineStorage, index BlobReferenceIndex, opts ...OptionFunc) *AudioScrubService {
+	s := &AudioScrubService{
+		blobs: blobs,
+		index: index,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *AudioScrubService) ScrubAudio(ctx context.Context, claims *entity.Claims,
+	quarantine bool) (_ *entity.BlobScrubReport, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrScrubAudio, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+
+	// referenced blobs are listed first: a blob is always stored before it is
+	// referenced, so a blob written meanwhile is never taken for a missing one
+	referenced, err := s.index.ListReferencedBlobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.blobs.ListBlobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.verify(ctx, stored, referenced)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hash := range slices.Concat(report.Corrupted, report.Missing) {
+		trackIDs, err := s.index.GetBlobTracks(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		report.AffectedTracks = append(report.AffectedTracks, trackIDs...)
+	}
+
+	if quarantine {
+		if err := s.quarantine(ctx, report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func (s *AudioScrubService) verify(ctx context.Context, stored, referenced []string) (*entity.BlobScrubReport, error) {
+	report := &entity.BlobScrubReport{
+		Corrupted:      make([]string, 0),
+		Missing:        make([]string, 0),
+		Orphaned:       make([]string, 0),
+		Quarantined:    make([]string, 0),
+		AffectedTracks: make([]uuid.UUID, 0),
+	}
+
+	isReferenced := make(map[string]bool, len(referenced))
+	for _, hash := range referenced {
+		isReferenced[hash] = true
+	}
+
+	isStored := make(map[string]bool, len(stored))
+	for _, hash := range stored {
+		isStored[hash] = true
+
+		blob, valid, err := s.check(ctx, hash)
+		if errors.Is(err, commonerr.ErrNotFound) {
+			continue // deleted meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Checked++
+
+		switch {
+		case !valid:
+			report.Corrupted = append(report.Corrupted, hash)
+		case !isReferenced[hash] && time.Since(blob.ModTime) >= OrphanGracePeriod:
+			report.Orphaned = append(report.Orphaned, hash)
+		}
+	}
+
+	for _, hash := range referenced {
+		if !isStored[hash] {
+			report.Missing = append(report.Missing, hash)
+		}
+	}
+
+	return report, nil
+}
+
+// check re-hashes the content of the blob.
+func (s *AudioScrubService) check(ctx context.Context, hash string) (*entity.AudioBlob, bool, error) {
+	blob, content, err := s.blobs.OpenBlob(ctx, hash)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close blob", "error", err)
+		}
+	}()
+
+	sum := sha256.New()
+	if _, err := io.Copy(sum, content); err != nil {
+		return nil, false, err
+	}
+
+	return blob, hex.EncodeToString(sum.Sum(nil)) == hash, nil
+}
+
+// quarantine moves broken and orphaned blobs aside, the files pointing to
+// broken or missing blobs are deleted, so the tracks can be uploaded again.
+// An orphan an upload was deduplicated onto since the listing is kept.
+func (s *AudioScrubService) quarantine(ctx context.Context, report *entity.BlobScrubReport) error {
+	for _, hash := range report.Corrupted {
+		if err := s.blobs.QuarantineBlob(ctx, hash); err != nil {
+			return err
+		}
+		report.Quarantined = append(report.Quarantined, hash)
+	}
+
+	adopted := make([]string, 0)
+	for _, hash := range report.Orphaned {
+		quarantined, err := s.index.QuarantineOrphanedBlob(ctx, hash, func(ctx context.Context) error {
+			return s.blobs.QuarantineBlob(ctx, hash)
+		})
+		if err != nil {
+			return err
+		}
+		if !quarantined {
+			adopted = append(adopted, hash)
+			continue
+		}
+		report.Quarantined = append(report.Quarantined, hash)
+	}
+	report.Orphaned = slices.DeleteFunc(report.Orphaned, func(hash string) bool {
+		return slices.Contains(adopted, hash)
+	})
+
+	for _, hash := range slices.Concat(report.Corrupted, report.Missing) {
+		if err := s.index.DeleteBlobReferences(ctx, hash); err != nil {
+			return err
+		}
+	}
+
+	if s.cache != nil {
+		for _, hash := range slices.Concat(report.Corrupted, report.Orphaned, report.Missing) {
+			if err := s.cache.EvictBlob(ctx, hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package scrub_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/scrub"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AudioScrubServiceSuite struct {
+	suite.Suite
+	service *scrub.AudioScrubService
+	blobs   *mocks.QuarantineStorage
+	index   *mocks.BlobReferenceIndex
+	ctx     context.Context
+}
+
+func TestAudioScrubServiceSuite(t *testing.T) {
+	suite.Run(t, new(AudioScrubServiceSuite))
+}
+
+func (s *AudioScrubServiceSuite) SetupTest() {
+	s.blobs = mocks.NewQuarantineStorage(s.T())
+	s.index = mocks.NewBlobReferenceIndex(s.T())
+	s.service = scrub.New(s.blobs, s.index)
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func AdminClaims() *entity.Claims {
+	return &entity.Claims{UserID: uuid.New(), AccessLvl: entity.Admin}
+}
+
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+func Hash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// expectBlob stores data under hash, modified long enough ago not to be an upload in progress.
+func (s *AudioScrubServiceSuite) expectBlob(hash, data string) {
+	s.blobs.On("OpenBlob", mock.Anything, hash).Return(&entity.AudioBlob{
+		Hash:    hash,
+		Size:    int64(len(data)),
+		ModTime: time.Now().Add(-2 * scrub.OrphanGracePeriod),
+	}, content{bytes.NewReader([]byte(data))}, nil)
+}
+
+// ScrubAudio
+func (s *AudioScrubServiceSuite) TestScrubAudio() {
+	valid, corrupted, orphaned, missing := Hash("valid"), Hash("corrupted"), Hash("orphaned"), Hash("missing")
+	corruptedTrack, missingTrack := uuid.New(), uuid.New()
+
+	s.index.On("ListReferencedBlobs", mock.Anything).Return([]string{valid, corrupted, missing}, nil)
+	s.blobs.On("ListBlobs", mock.Anything).Return([]string{valid, corrupted, orphaned}, nil)
+	s.expectBlob(valid, "valid")
+	s.expectBlob(corrupted, "bit rot")
+	s.expectBlob(orphaned, "orphaned")
+	s.index.On("GetBlobTracks", mock.Anything, corrupted).Return([]uuid.UUID{corruptedTrack}, nil)
+	s.index.On("GetBlobTracks", mock.Anything, missing).Return([]uuid.UUID{missingTrack}, nil)
+
+	report, err := s.service.ScrubAudio(s.ctx, AdminClaims(), false)
+	s.Require().NoError(err)
+	s.Equal(3, report.Checked)
+	s.Equal([]string{corrupted}, report.Corrupted)
+	s.Equal([]string{missing}, report.Missing)
+	s.Equal([]string{orphaned}, report.Orphaned)
+	s.Empty(report.Quarantined)
+	s.Equal([]uuid.UUID{corruptedTrack, missingTrack}, report.AffectedTracks)
+	s.blobs.AssertNotCalled(s.T(), "QuarantineBlob", mock.Anything, mock.Anything)
+}
+
+func (s *AudioScrubServiceSuite) TestScrubAudioQuarantine() {
+	corrupted, orphaned, missing := Hash("corrupted"), Hash("orphaned"), Hash("missing")
+
+	s.index.On("ListReferencedBlobs", mock.Anything).Return([]string{corrupted, missing}, nil)
+	s.blobs.On("ListBlobs", mock.Anything).Return([]string{corrupted, orphaned}, nil)
+	s.expectBlob(corrupted, "bit rot")
+	s.expectBlob(orphaned, "orphaned")
+	s.index.On("GetBlobTracks", mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+	s.blobs.On("QuarantineBlob", mock.Anything, corrupted).Return(nil)
+	s.blobs.On("QuarantineBlob", mock.Anything, orphaned).Return(nil)
+	s.index.EXPECT().QuarantineOrphanedBlob(mock.Anything, orphaned, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ string, quarantine func(context.Context) error) (bool, error) {
+			return true, quarantine(ctx)
+		})
+	s.index.On("DeleteBlobReferences", mock.Anything, corrupted).Return(nil)
+	s.index.On("DeleteBlobReferences", mock.Anything, missing).Return(nil)
+
+	report, err := s.service.ScrubAudio(s.ctx, AdminClaims(), true)
+	s.Require().NoError(err)
+	s.Equal([]string{corrupted, orphaned}, report.Quarantined)
+}
+
+func (s *AudioScrubServiceSuite) TestScrubAudioKeepsAdoptedOrphans() {
+	adopted := Hash("adopted")
+
+	s.index.On("ListReferencedBlobs", mock.Anything).Return([]string{}, nil)
+	s.blobs.On("ListBlobs", mock.Anything).Return([]string{adopted}, nil)
+	s.expectBlob(adopted, "adopted")
+	s.index.On("QuarantineOrphanedBlob", mock.Anything, adopted, mock.Anything).Return(false, nil)
+
+	report, err := s.service.ScrubAudio(s.ctx, AdminClaims(), true)
+	s.Require().NoError(err)
+	s.Empty(report.Orphaned)
+	s.Empty(report.Quarantined)
+	s.blobs.AssertNotCalled(s.T(), "QuarantineBlob", mock.Anything, mock.Anything)
+}
+
+func (s *AudioScrubServiceSuite) TestScrubAudioQuarantineEvictsCache() {
+	corrupted, missing := Hash("corrupted"), Hash("missing")
+	cache := mocks.NewBlobCache(s.T())
+	s.service = scrub.New(s.blobs, s.index, scrub.WithCache(cache))
+
+	s.index.On("ListReferencedBlobs", mock.Anything).Return([]string{corrupted, missing}, nil)
+	s.blobs.On("ListBlobs", mock.Anything).Return([]string{corrupted}, nil)
+	s.expectBlob(corrupted, "bit rot")
+	s.index.On("GetBlobTracks", mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+	s.blobs.On("QuarantineBlob", mock.Anything, corrupted).Return(nil)
+	s.index.On("DeleteBlobReferences", mock.Anything, mock.Anything).Return(nil)
+	cache.On("EvictBlob", mock.Anything, corrupted).Return(nil).Once()
+	cache.On("EvictBlob", mock.Anything, missing).Return(nil).Once()
+
+	_, err := s.service.ScrubAudio(s.ctx, AdminClaims(), true)
+	s.Require().NoError(err)
+}
+
+func (s *AudioScrubServiceSuite) TestScrubAudioSkipsRecentOrphans() {
+	recent := Hash("uploading")
+
+	s.index.On("ListReferencedBlobs", mock.Anything).Return([]string{}, nil)
+	s.blobs.On("ListBlobs", mock.Anything).Return([]string{recent}, nil)
+	s.blobs.On("OpenBlob", mock.Anything, recent).Return(&entity.AudioBlob{
+		Hash:    recent,
+		ModTime: time.Now(),
+	}, content{bytes.NewReader([]byte("uploading"))}, nil)
+
+	report, err := s.service.ScrubAudio(s.ctx, AdminClaims(), true)
+	s.Require().NoError(err)
+	s.Equal(1, report.Checked)
+	s.Empty(report.Orphaned)
+	s.Empty(report.Quarantined)
+}
+
+func (s *AudioScrubServiceSuite) TestScrubAudioSkipsDeletedBlobs() {
+	deleted := Hash("deleted")
+
+	s.index.On("ListReferencedBlobs", mock.Anything).Return([]string{}, nil)
+	s.blobs.On("ListBlobs", mock.Anything).Return([]string{deleted}, nil)
+	s.blobs.On("OpenBlob", mock.Anything, deleted).Return(nil, nil, commonerr.ErrNotFound)
+
+	report, err := s.service.ScrubAudio(s.ctx, AdminClaims(), false)
+	s.Require().NoError(err)
+	s.Equal(0, report.Checked)
+}
+
+func (s *AudioScrubServiceSuite) TestScrubAudioForbidden() {
+	_, err := s.service.ScrubAudio(s.ctx, &entity.Claims{AccessLvl: entity.User}, false)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+
+	_, err = s.service.ScrubAudio(s.ctx, nil, false)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
+
+func (s *AudioScrubServiceSuite) TestScrubAudioIndexError() {
+	s.index.On("ListReferencedBlobs", mock.Anything).Return(nil, errors.New("db error"))
+
+	_, err := s.service.ScrubAudio(s.ctx, AdminClaims(), false)
+	s.Error(err)
+}
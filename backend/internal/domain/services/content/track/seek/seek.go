@@ -0,0 +1,186 @@
+package seek
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+// Interval is the time between two entries of a seek table.
+const Interval = time.Second
+
+var (
+	ErrInvalidTrackID    = errors.New("invalid track id")
+	ErrUnsupportedFormat = errors.New("only MP3 files can be seeked by time")
+	ErrOutOfRange        = errors.New("seek time is out of the track")
+)
+
+type AudioFileOpener interface {
+	OpenAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)
+}
+
+// AudioFileIndex looks the stored files up without reading them.
+type AudioFileIndex interface {
+	// GetAudioFile returns commonerr.ErrNotFound for files not indexed yet, e.g. stored before the blobs.
+	GetAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, error)
+}
+
+type FrameParser interface {
+	ParseFrames(r io.Reader) ([]*entity.AudioFrame, error)
+}
+
+type SeekTableRepository interface {
+	GetSeekTable(ctx context.Context, hash string) (*entity.SeekTable, error)
+	SaveSeekTable(ctx context.Context, table *entity.SeekTable) error
+}
+
+type TrackSeekService struct {
+	audioRepo AudioFileOpener
+	index     AudioFileIndex
+	parser    FrameParser
+	repo      SeekTableRepository
+}
+
+func New(audioRepo AudioFileOpener, index AudioFileIndex, parser FrameParser, repo SeekTableRepository) *TrackSeekService {
+	return &TrackSeekService{
+		audioRepo: audioRepo,
+		index:     index,
+		parser:    parser,
+		repo:      repo,
+	}
+}
+
+func (s *TrackSeekService) GenerateSeekTables(ctx context.Context, trackID uuid.UUID) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGenerateSeekTables, err)
+	}()
+
+	if trackID == uuid.Nil {
+		return ErrInvalidTrackID
+	}
+
+	for _, quality := range entity.AudioQualities {
+		_, err := s.getSeekTable(ctx, trackID, quality)
+		if errors.Is(err, commonerr.ErrNotFound) || errors.Is(err, ErrUnsupportedFormat) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TrackSeekService) ResolveOffset(ctx context.Context, trackID uuid.UUID,
+	quality entity.AudioQuality, at time.Duration) (_ int64, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrResolveSeekOffset, err)
+	}()
+
+	if trackID == uuid.Nil {
+		return 0, ErrInvalidTrackID
+	}
+	if at < 0 {
+		return 0, ErrOutOfRange
+	}
+
+	table, err := s.getSeekTable(ctx, trackID, quality)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, ok := table.Offset(at)
+	if !ok {
+		return 0, ErrOutOfRange
+	}
+
+	return offset, nil
+}
+
+// getSeekTable returns the stored table of the file or builds it, so files
+// uploaded before seek tables existed are indexed on the first seek.
+// The file is only read to build a missing table.
+func (s *TrackSeekService) getSeekTable(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.SeekTable, error) {
+	file, err := s.index.GetAudioFile(ctx, trackID, quality)
+	if err != nil && !errors.Is(err, commonerr.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		if file.Format != "" && file.Format != entity.FormatMP3 {
+			return nil, ErrUnsupportedFormat
+		}
+
+		table, err := s.repo.GetSeekTable(ctx, file.Hash)
+		if err == nil {
+			return table, nil
+		}
+		if !errors.Is(err, commonerr.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	return s.buildSeekTable(ctx, trackID, quality)
+}
+
+// buildSeekTable parses the frames of the file, a file stored before the blobs
+// is indexed by opening it.
+func (s *TrackSeekService) buildSeekTable(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.SeekTable, error) {
+	file, content, err := s.audioRepo.OpenAudioFile(ctx, trackID, quality)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close audio file", "error", err)
+		}
+	}()
+
+	if file.Format != "" && file.Format != entity.FormatMP3 {
+		return nil, ErrUnsupportedFormat
+	}
+
+	frames, err := s.parser.ParseFrames(content)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &entity.SeekTable{
+		Hash:     file.Hash,
+		Interval: Interval,
+		Offsets:  frameOffsets(frames, Interval),
+	}
+
+	if file.Hash != "" {
+		// the table is rebuilt on the next seek
+		if err := s.repo.SaveSeekTable(ctx, table); err != nil {
+			slog.Error("failed to save seek table", "track_id", trackID, "quality", quality, "error", err)
+		}
+	}
+
+	return table, nil
+}
+
+// frameOffsets returns the offset of the frame playing at every multiple of the interval.
+func frameOffsets(frames []*entity.AudioFrame, interval time.Duration) []int64 {
+	offsets := make([]int64, 0)
+
+	var elapsed time.Duration
+	for _, frame := range frames {
+		end := elapsed + frame.Duration
+		for time.Duration(len(offsets))*interval < end {
+			offsets = append(offsets, frame.Offset)
+		}
+		elapsed = end
+	}
+
+	return offsets
+}
@@ -0,0 +1,198 @@
+package seek_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/seek"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackSeekServiceSuite struct {
+	suite.Suite
+	service *seek.TrackSeekService
+	repo    *mocks.AudioFileOpener
+	index   *mocks.AudioFileIndex
+	parser  *mocks.FrameParser
+	tables  *mocks.SeekTableRepository
+	ctx     context.Context
+	trackID uuid.UUID
+}
+
+func TestTrackSeekServiceSuite(t *testing.T) {
+	suite.Run(t, new(TrackSeekServiceSuite))
+}
+
+func (s *TrackSeekServiceSuite) SetupTest() {
+	s.repo = mocks.NewAudioFileOpener(s.T())
+	s.parser = mocks.NewFrameParser(s.T())
+	s.tables = mocks.NewSeekTableRepository(s.T())
+	s.index = mocks.NewAudioFileIndex(s.T())
+	s.service = seek.New(s.repo, s.index, s.parser, s.tables)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+}
+
+// Object Mother
+const hash = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+// Frames are 26ms MP3 frames of 418 bytes after a 100-byte tag.
+func Frames(count int) []*entity.AudioFrame {
+	frames := make([]*entity.AudioFrame, count)
+	for i := range frames {
+		frames[i] = &entity.AudioFrame{
+			Offset:   100 + int64(i)*418,
+			Size:     418,
+			Duration: 26 * time.Millisecond,
+			Bitrate:  128000,
+		}
+	}
+	return frames
+}
+
+func File(trackID uuid.UUID, quality entity.AudioQuality, format entity.AudioFormat) *entity.AudioFile {
+	return &entity.AudioFile{
+		TrackID: trackID,
+		Quality: quality,
+		Format:  format,
+		Hash:    hash,
+	}
+}
+
+// expectIndexed expects the file to be looked up in the index only.
+func (s *TrackSeekServiceSuite) expectIndexed(quality entity.AudioQuality, format entity.AudioFormat) {
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, quality).Return(File(s.trackID, quality, format), nil)
+}
+
+// expectFile expects the file to be opened to build its table.
+func (s *TrackSeekServiceSuite) expectFile(quality entity.AudioQuality, format entity.AudioFormat) {
+	s.expectIndexed(quality, format)
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, quality).
+		Return(File(s.trackID, quality, format), content{bytes.NewReader(nil)}, nil)
+}
+
+// ResolveOffset
+func (s *TrackSeekServiceSuite) TestResolveOffsetBuildsTable() {
+	s.expectFile(entity.QualityOriginal, entity.FormatMP3)
+	s.tables.On("GetSeekTable", mock.Anything, hash).Return(nil, commonerr.ErrNotFound)
+	s.parser.On("ParseFrames", mock.Anything).Return(Frames(200), nil)
+	s.tables.On("SaveSeekTable", mock.Anything, mock.MatchedBy(func(table *entity.SeekTable) bool {
+		// 200 frames of 26ms last 5.2 seconds
+		return table.Hash == hash && table.Interval == time.Second && len(table.Offsets) == 6
+	})).Return(nil)
+
+	offset, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityOriginal, 2*time.Second)
+	s.NoError(err)
+	// 2000ms falls into the frame starting at 76*26 = 1976ms
+	s.Equal(int64(100+76*418), offset)
+}
+
+func (s *TrackSeekServiceSuite) TestResolveOffsetStoredTable() {
+	s.expectIndexed(entity.QualityHigh, entity.FormatMP3)
+	s.tables.On("GetSeekTable", mock.Anything, hash).Return(&entity.SeekTable{
+		Hash:     hash,
+		Interval: time.Second,
+		Offsets:  []int64{0, 1000, 2000},
+	}, nil)
+
+	offset, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityHigh, 1500*time.Millisecond)
+	s.NoError(err)
+	s.Equal(int64(1000), offset)
+}
+
+func (s *TrackSeekServiceSuite) TestResolveOffsetSaveErrorIgnored() {
+	s.expectFile(entity.QualityOriginal, entity.FormatMP3)
+	s.tables.On("GetSeekTable", mock.Anything, hash).Return(nil, commonerr.ErrNotFound)
+	s.parser.On("ParseFrames", mock.Anything).Return(Frames(100), nil)
+	s.tables.On("SaveSeekTable", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	offset, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityOriginal, 0)
+	s.NoError(err)
+	s.Equal(int64(100), offset)
+}
+
+func (s *TrackSeekServiceSuite) TestResolveOffsetPastEnd() {
+	s.expectIndexed(entity.QualityOriginal, entity.FormatMP3)
+	s.tables.On("GetSeekTable", mock.Anything, hash).Return(&entity.SeekTable{
+		Hash:     hash,
+		Interval: time.Second,
+		Offsets:  []int64{0, 1000},
+	}, nil)
+
+	_, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityOriginal, 2*time.Second)
+	s.ErrorIs(err, seek.ErrOutOfRange)
+}
+
+func (s *TrackSeekServiceSuite) TestResolveOffsetNegative() {
+	_, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityOriginal, -time.Second)
+	s.ErrorIs(err, seek.ErrOutOfRange)
+}
+
+func (s *TrackSeekServiceSuite) TestResolveOffsetLegacyFile() {
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(File(s.trackID, entity.QualityOriginal, entity.FormatMP3), content{bytes.NewReader(nil)}, nil)
+	s.parser.On("ParseFrames", mock.Anything).Return(Frames(100), nil)
+	s.tables.On("SaveSeekTable", mock.Anything, mock.Anything).Return(nil)
+
+	offset, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityOriginal, time.Second)
+	s.NoError(err)
+	s.Equal(int64(100+38*418), offset)
+}
+
+func (s *TrackSeekServiceSuite) TestResolveOffsetLossless() {
+	s.expectIndexed(entity.QualityOriginal, entity.FormatFLAC)
+
+	_, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityOriginal, time.Second)
+	s.ErrorIs(err, seek.ErrUnsupportedFormat)
+}
+
+func (s *TrackSeekServiceSuite) TestResolveOffsetNotFound() {
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityLow).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityLow).Return(nil, nil, commonerr.ErrNotFound)
+
+	_, err := s.service.ResolveOffset(s.ctx, s.trackID, entity.QualityLow, time.Second)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+// GenerateSeekTables
+func (s *TrackSeekServiceSuite) TestGenerateSeekTables() {
+	s.expectIndexed(entity.QualityOriginal, entity.FormatFLAC)
+	s.expectFile(entity.QualityHigh, entity.FormatMP3)
+	s.expectFile(entity.QualityMedium, entity.FormatMP3)
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityLow).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityLow).Return(nil, nil, commonerr.ErrNotFound)
+	s.tables.On("GetSeekTable", mock.Anything, hash).Return(nil, commonerr.ErrNotFound)
+	s.parser.On("ParseFrames", mock.Anything).Return(Frames(100), nil)
+	s.tables.On("SaveSeekTable", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	s.NoError(s.service.GenerateSeekTables(s.ctx, s.trackID))
+}
+
+func (s *TrackSeekServiceSuite) TestGenerateSeekTablesParserError() {
+	s.expectFile(entity.QualityOriginal, entity.FormatMP3)
+	s.tables.On("GetSeekTable", mock.Anything, hash).Return(nil, commonerr.ErrNotFound)
+	s.parser.On("ParseFrames", mock.Anything).Return(nil, errors.New("parse error"))
+
+	s.Error(s.service.GenerateSeekTables(s.ctx, s.trackID))
+}
+
+func (s *TrackSeekServiceSuite) TestGenerateSeekTablesInvalidTrackID() {
+	s.ErrorIs(s.service.GenerateSeekTables(s.ctx, uuid.Nil), seek.ErrInvalidTrackID)
+}
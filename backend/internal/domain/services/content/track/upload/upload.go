@@ -0,0 +1,207 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+const (
+	// MinChunkSize is the minimum size of every chunk except the last one,
+	// it matches the minimum part size of S3 multipart uploads.
+	MinChunkSize  = 5 << 20  // 5MB
+	MaxUploadSize = 2 << 30  // 2GB
+	MaxChunkSize  = 64 << 20 // 64MB
+)
+
+var (
+	ErrInvalidTrackID   = errors.New("invalid track id")
+	ErrInvalidSize      = errors.New("invalid upload size")
+	ErrOffsetMismatch   = errors.New("chunk offset does not match upload offset")
+	ErrInvalidChunkSize = errors.New("invalid chunk size")
+	ErrUploadIncomplete = errors.New("upload is not complete")
+)
+
+type UploadStorage interface {
+	CreateUpload(ctx context.Context, upload *entity.AudioUpload) error
+	GetUpload(ctx context.Context, uploadID uuid.UUID) (*entity.AudioUpload, error)
+	WriteUploadChunk(ctx context.Context, upload *entity.AudioUpload, chunk *entity.AudioChunk) error
+	CompleteUpload(ctx context.Context, upload *entity.AudioUpload) (io.ReadCloser, error)
+	DeleteUpload(ctx context.Context, uploadID uuid.UUID) error
+}
+
+type AudioFileUploader interface {
+	UploadAudioFile(ctx context.Context, claims *entity.Claims, file *entity.AudioFile, content io.Reader) error
+}
+
+type AudioUploadService struct {
+	storage  UploadStorage
+	uploader AudioFileUploader
+}
+
+func New(storage UploadStorage, uploader AudioFileUploader) *AudioUploadService {
+	return &AudioUploadService{
+		storage:  storage,
+		uploader: uploader,
+	}
+}
+
+func (s *AudioUploadService) CreateUpload(ctx context.Context, claims *entity.Claims,
+	trackID uuid.UUID, size int64) (_ *entity.AudioUpload, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrCreateAudioUpload, err)
+	}()
+
+	switch {
+	case claims == nil || claims.AccessLvl != entity.Admin:
+		return nil, commonerr.ErrForbidden
+	case trackID == uuid.Nil:
+		return nil, ErrInvalidTrackID
+	case size <= 0 || size > MaxUploadSize:
+		return nil, ErrInvalidSize
+	}
+
+	upload := &entity.AudioUpload{
+		ID:      uuid.New(),
+		TrackID: trackID,
+		Size:    size,
+	}
+
+	if err = s.storage.CreateUpload(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (s *AudioUploadService) GetUpload(ctx context.Context, claims *entity.Claims,
+	trackID uuid.UUID, uploadID uuid.UUID) (_ *entity.AudioUpload, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetAudioUpload, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+
+	return s.getUpload(ctx, trackID, uploadID)
+}
+
+// UploadChunk appends the chunk to the upload. The chunk must start exactly at the
+// current offset, so a client that lost a response has to query the offset first.
+func (s *AudioUploadService) UploadChunk(ctx context.Context, claims *entity.Claims,
+	uploadID uuid.UUID, chunk *entity.AudioChunk) (_ *entity.AudioUpload, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrUploadAudioChunk, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+
+	upload, err := s.getUpload(ctx, chunk.TrackID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int64(len(chunk.Data))
+	end := chunk.Start + size
+
+	switch {
+	case chunk.Start != upload.Offset:
+		return nil, ErrOffsetMismatch
+	case size == 0 || size > MaxChunkSize || end > upload.Size:
+		return nil, ErrInvalidChunkSize
+	case end < upload.Size && size < MinChunkSize:
+		return nil, ErrInvalidChunkSize
+	}
+
+	chunk.End = end
+	if err = s.storage.WriteUploadChunk(ctx, upload, chunk); err != nil {
+		return nil, err
+	}
+
+	upload.Offset = end
+
+	return upload, nil
+}
+
+func (s *AudioUploadService) CompleteUpload(ctx context.Context, claims *entity.Claims,
+	trackID uuid.UUID, uploadID uuid.UUID) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrCompleteAudioUpload, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return commonerr.ErrForbidden
+	}
+
+	upload, err := s.getUpload(ctx, trackID, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if upload.Offset != upload.Size {
+		return ErrUploadIncomplete
+	}
+
+	content, err := s.storage.CompleteUpload(ctx, upload)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close upload content", "error", err)
+		}
+	}()
+
+	file := &entity.AudioFile{
+		TrackID: upload.TrackID,
+		Size:    upload.Size,
+	}
+
+	// the session is kept on failure, so completion can be retried or the upload aborted
+	if err = s.uploader.UploadAudioFile(ctx, claims, file, content); err != nil {
+		return err
+	}
+
+	return s.storage.DeleteUpload(ctx, upload.ID)
+}
+
+func (s *AudioUploadService) AbortUpload(ctx context.Context, claims *entity.Claims,
+	trackID uuid.UUID, uploadID uuid.UUID) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrAbortAudioUpload, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return commonerr.ErrForbidden
+	}
+
+	if _, err = s.getUpload(ctx, trackID, uploadID); err != nil {
+		return err
+	}
+
+	return s.storage.DeleteUpload(ctx, uploadID)
+}
+
+func (s *AudioUploadService) getUpload(ctx context.Context, trackID uuid.UUID, uploadID uuid.UUID) (*entity.AudioUpload, error) {
+	upload, err := s.storage.GetUpload(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// an upload is addressed through its track, do not leak sessions of other tracks
+	if upload.TrackID != trackID {
+		return nil, commonerr.ErrNotFound
+	}
+
+	return upload, nil
+}
@@ -0,0 +1,191 @@
+package upload_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/upload"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AudioUploadServiceSuite struct {
+	suite.Suite
+	service  *upload.AudioUploadService
+	storage  *mocks.UploadStorage
+	uploader *mocks.AudioFileUploader
+	ctx      context.Context
+	trackID  uuid.UUID
+	uploadID uuid.UUID
+}
+
+func TestAudioUploadServiceSuite(t *testing.T) {
+	suite.Run(t, new(AudioUploadServiceSuite))
+}
+
+func (s *AudioUploadServiceSuite) SetupTest() {
+	s.storage = mocks.NewUploadStorage(s.T())
+	s.uploader = mocks.NewAudioFileUploader(s.T())
+	s.service = upload.New(s.storage, s.uploader)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+	s.uploadID = uuid.New()
+}
+
+// Object Mother
+func AdminClaims() *entity.Claims {
+	return &entity.Claims{AccessLvl: entity.Admin}
+}
+
+func UserClaims() *entity.Claims {
+	return &entity.Claims{AccessLvl: entity.User}
+}
+
+func (s *AudioUploadServiceSuite) Upload(size, offset int64) *entity.AudioUpload {
+	return &entity.AudioUpload{
+		ID:      s.uploadID,
+		TrackID: s.trackID,
+		Size:    size,
+		Offset:  offset,
+	}
+}
+
+func (s *AudioUploadServiceSuite) Chunk(start int64, size int) *entity.AudioChunk {
+	return &entity.AudioChunk{
+		TrackID: s.trackID,
+		Start:   start,
+		End:     start + int64(size),
+		Data:    make([]byte, size),
+	}
+}
+
+// CreateUpload
+func (s *AudioUploadServiceSuite) TestCreateUpload() {
+	s.storage.On("CreateUpload", mock.Anything, mock.MatchedBy(func(u *entity.AudioUpload) bool {
+		return u.ID != uuid.Nil && u.TrackID == s.trackID && u.Size == 100 && u.Offset == 0
+	})).Return(nil)
+
+	res, err := s.service.CreateUpload(s.ctx, AdminClaims(), s.trackID, 100)
+	s.NoError(err)
+	s.Equal(s.trackID, res.TrackID)
+}
+
+func (s *AudioUploadServiceSuite) TestCreateUploadNotAdmin() {
+	_, err := s.service.CreateUpload(s.ctx, UserClaims(), s.trackID, 100)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
+
+func (s *AudioUploadServiceSuite) TestCreateUploadInvalidSize() {
+	_, err := s.service.CreateUpload(s.ctx, AdminClaims(), s.trackID, 0)
+	s.ErrorIs(err, upload.ErrInvalidSize)
+
+	_, err = s.service.CreateUpload(s.ctx, AdminClaims(), s.trackID, upload.MaxUploadSize+1)
+	s.ErrorIs(err, upload.ErrInvalidSize)
+}
+
+// GetUpload
+func (s *AudioUploadServiceSuite) TestGetUploadOfAnotherTrack() {
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(s.Upload(100, 0), nil)
+
+	_, err := s.service.GetUpload(s.ctx, AdminClaims(), uuid.New(), s.uploadID)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+// UploadChunk
+func (s *AudioUploadServiceSuite) TestUploadChunk() {
+	current := s.Upload(upload.MinChunkSize+10, 0)
+	chunk := s.Chunk(0, upload.MinChunkSize)
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(current, nil)
+	s.storage.On("WriteUploadChunk", mock.Anything, current, chunk).Return(nil)
+
+	res, err := s.service.UploadChunk(s.ctx, AdminClaims(), s.uploadID, chunk)
+	s.NoError(err)
+	s.Equal(int64(upload.MinChunkSize), res.Offset)
+}
+
+func (s *AudioUploadServiceSuite) TestUploadLastChunkMayBeSmall() {
+	current := s.Upload(upload.MinChunkSize+10, upload.MinChunkSize)
+	chunk := s.Chunk(upload.MinChunkSize, 10)
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(current, nil)
+	s.storage.On("WriteUploadChunk", mock.Anything, current, chunk).Return(nil)
+
+	res, err := s.service.UploadChunk(s.ctx, AdminClaims(), s.uploadID, chunk)
+	s.NoError(err)
+	s.Equal(res.Size, res.Offset)
+}
+
+func (s *AudioUploadServiceSuite) TestUploadChunkOffsetMismatch() {
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(s.Upload(100, 50), nil)
+
+	_, err := s.service.UploadChunk(s.ctx, AdminClaims(), s.uploadID, s.Chunk(40, 60))
+	s.ErrorIs(err, upload.ErrOffsetMismatch)
+}
+
+func (s *AudioUploadServiceSuite) TestUploadChunkTooSmall() {
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(s.Upload(upload.MinChunkSize+10, 0), nil)
+
+	_, err := s.service.UploadChunk(s.ctx, AdminClaims(), s.uploadID, s.Chunk(0, 10))
+	s.ErrorIs(err, upload.ErrInvalidChunkSize)
+}
+
+func (s *AudioUploadServiceSuite) TestUploadChunkOverflow() {
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(s.Upload(100, 50), nil)
+
+	_, err := s.service.UploadChunk(s.ctx, AdminClaims(), s.uploadID, s.Chunk(50, 51))
+	s.ErrorIs(err, upload.ErrInvalidChunkSize)
+}
+
+// CompleteUpload
+func (s *AudioUploadServiceSuite) TestCompleteUpload() {
+	current := s.Upload(10, 10)
+	content := io.NopCloser(bytes.NewReader(make([]byte, 10)))
+	file := &entity.AudioFile{TrackID: s.trackID, Size: 10}
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(current, nil)
+	s.storage.On("CompleteUpload", mock.Anything, current).Return(content, nil)
+	s.uploader.On("UploadAudioFile", mock.Anything, mock.Anything, file, content).Return(nil)
+	s.storage.On("DeleteUpload", mock.Anything, s.uploadID).Return(nil)
+
+	err := s.service.CompleteUpload(s.ctx, AdminClaims(), s.trackID, s.uploadID)
+	s.NoError(err)
+}
+
+func (s *AudioUploadServiceSuite) TestCompleteUploadIncomplete() {
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(s.Upload(10, 5), nil)
+
+	err := s.service.CompleteUpload(s.ctx, AdminClaims(), s.trackID, s.uploadID)
+	s.ErrorIs(err, upload.ErrUploadIncomplete)
+}
+
+func (s *AudioUploadServiceSuite) TestCompleteUploadKeepsSessionOnFailure() {
+	current := s.Upload(10, 10)
+	content := io.NopCloser(bytes.NewReader(make([]byte, 10)))
+	file := &entity.AudioFile{TrackID: s.trackID, Size: 10}
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(current, nil)
+	s.storage.On("CompleteUpload", mock.Anything, current).Return(content, nil)
+	s.uploader.On("UploadAudioFile", mock.Anything, mock.Anything, file, content).Return(errors.New("upload error"))
+
+	err := s.service.CompleteUpload(s.ctx, AdminClaims(), s.trackID, s.uploadID)
+	s.Error(err)
+	s.storage.AssertNotCalled(s.T(), "DeleteUpload", mock.Anything, mock.Anything)
+}
+
+// AbortUpload
+func (s *AudioUploadServiceSuite) TestAbortUpload() {
+	s.storage.On("GetUpload", mock.Anything, s.uploadID).Return(s.Upload(10, 5), nil)
+	s.storage.On("DeleteUpload", mock.Anything, s.uploadID).Return(nil)
+
+	err := s.service.AbortUpload(s.ctx, AdminClaims(), s.trackID, s.uploadID)
+	s.NoError(err)
+}
+
+func (s *AudioUploadServiceSuite) TestAbortUploadNotAdmin() {
+	err := s.service.AbortUpload(s.ctx, UserClaims(), s.trackID, s.uploadID)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
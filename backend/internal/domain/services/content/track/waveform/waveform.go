@@ -0,0 +1,192 @@
+package waveform
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/track"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+// blockSize is the number of decoded samples reduced to a single peak before
+// the peaks are downsampled to the stored resolutions.
+const blockSize = 64
+
+var (
+	ErrInvalidTrackID = errors.New("invalid track id")
+	ErrInvalidPoints  = errors.New("invalid number of points")
+)
+
+type AudioFileOpener interface {
+	OpenAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)
+}
+
+type PCMDecoder interface {
+	// DecodePCM decodes src to mono signed 16-bit little-endian samples.
+	DecodePCM(ctx context.Context, src io.Reader) (io.ReadCloser, error)
+}
+
+type WaveformRepository interface {
+	SaveWaveform(ctx context.Context, waveform *entity.Waveform) error
+	GetWaveform(ctx context.Context, trackID uuid.UUID, points int) (*entity.Waveform, error)
+}
+
+type TrackWaveformService struct {
+	audioRepo AudioFileOpener
+	decoder   PCMDecoder
+	repo      WaveformRepository
+}
+
+func New(audioRepo AudioFileOpener, decoder PCMDecoder, repo WaveformRepository) *TrackWaveformService {
+	return &TrackWaveformService{
+		audioRepo: audioRepo,
+		decoder:   decoder,
+		repo:      repo,
+	}
+}
+
+// GetWaveform downsamples the smallest stored resolution that has at least the requested number of points.
+func (s *TrackWaveformService) GetWaveform(ctx context.Context, trackID uuid.UUID, points int) (_ *entity.Waveform, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetWaveform, err)
+	}()
+
+	switch {
+	case trackID == uuid.Nil:
+		return nil, ErrInvalidTrackID
+	case points <= 0 || points > slices.Max(entity.WaveformResolutions):
+		return nil, ErrInvalidPoints
+	}
+
+	resolution := slices.Max(entity.WaveformResolutions)
+	for _, r := range entity.WaveformResolutions {
+		if r >= points && r < resolution {
+			resolution = r
+		}
+	}
+
+	waveform, err := s.repo.GetWaveform(ctx, trackID, resolution)
+	if err != nil {
+		return nil, err
+	}
+	if waveform.Points == points {
+		return waveform, nil
+	}
+
+	return &entity.Waveform{
+		TrackID: trackID,
+		Points:  points,
+		Peaks:   downsample(waveform.Peaks, points),
+	}, nil
+}
+
+func (s *TrackWaveformService) GenerateWaveform(ctx context.Context, trackID uuid.UUID) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGenerateWaveform, err)
+	}()
+
+	if trackID == uuid.Nil {
+		return ErrInvalidTrackID
+	}
+
+	blocks, err := s.readBlocks(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
+	loudest := slices.Max(append(blocks, 1))
+	normalized := make([]uint8, len(blocks))
+	for i, peak := range blocks {
+		normalized[i] = uint8(int(peak) * 255 / int(loudest))
+	}
+
+	for _, points := range entity.WaveformResolutions {
+		waveform := &entity.Waveform{
+			TrackID: trackID,
+			Points:  points,
+			Peaks:   downsample(normalized, points),
+		}
+		if err = s.repo.SaveWaveform(ctx, waveform); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readBlocks decodes the original and returns the peak of every block of samples.
+func (s *TrackWaveformService) readBlocks(ctx context.Context, trackID uuid.UUID) ([]uint16, error) {
+	_, original, err := s.audioRepo.OpenAudioFile(ctx, trackID, entity.QualityOriginal)
+	if err != nil {
+		return nil, err
+	}
+	defer closeContent(original)
+
+	pcm, err := s.decoder.DecodePCM(ctx, original)
+	if err != nil {
+		return nil, err
+	}
+	defer closeContent(pcm)
+
+	r := bufio.NewReader(pcm)
+	blocks := make([]uint16, 0)
+	sample := make([]byte, 2)
+
+	var peak uint16
+	for n := 0; ; n++ {
+		if _, err = io.ReadFull(r, sample); err != nil {
+			break
+		}
+		peak = max(peak, amplitude(int16(binary.LittleEndian.Uint16(sample))))
+
+		if n%blockSize == blockSize-1 {
+			blocks = append(blocks, peak)
+			peak = 0
+		}
+	}
+	if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if peak > 0 {
+		blocks = append(blocks, peak)
+	}
+
+	return blocks, nil
+}
+
+func amplitude(sample int16) uint16 {
+	if sample < 0 {
+		return uint16(-int32(sample))
+	}
+	return uint16(sample)
+}
+
+// downsample splits peaks into the given number of equal parts and keeps the peak of each,
+// a shorter input is stretched so that every point maps to the nearest peak.
+func downsample(peaks []uint8, points int) []uint8 {
+	res := make([]uint8, points)
+	if len(peaks) == 0 {
+		return res
+	}
+
+	for i := range res {
+		start := i * len(peaks) / points
+		end := max((i+1)*len(peaks)/points, start+1)
+		res[i] = slices.Max(peaks[start:end])
+	}
+
+	return res
+}
+
+func closeContent(content io.Closer) {
+	if err := content.Close(); err != nil {
+		slog.Error("failed to close audio file", "error", err)
+	}
+}
@@ -0,0 +1,167 @@
+package waveform_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/waveform"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackWaveformServiceSuite struct {
+	suite.Suite
+	service   *waveform.TrackWaveformService
+	audioRepo *mocks.AudioFileOpener
+	decoder   *mocks.PCMDecoder
+	repo      *mocks.WaveformRepository
+	ctx       context.Context
+	trackID   uuid.UUID
+}
+
+func TestTrackWaveformServiceSuite(t *testing.T) {
+	suite.Run(t, new(TrackWaveformServiceSuite))
+}
+
+func (s *TrackWaveformServiceSuite) SetupTest() {
+	s.audioRepo = mocks.NewAudioFileOpener(s.T())
+	s.decoder = mocks.NewPCMDecoder(s.T())
+	s.repo = mocks.NewWaveformRepository(s.T())
+	s.service = waveform.New(s.audioRepo, s.decoder, s.repo)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+}
+
+// Object Mother
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+// PCM returns samples whose amplitude rises linearly from silence to full scale,
+// alternating in sign.
+func PCM(samples int) io.ReadCloser {
+	data := make([]byte, 0, 2*samples)
+	for i := range samples {
+		sample := int16(i * 32767 / (samples - 1))
+		if i%2 == 1 {
+			sample = -sample
+		}
+		data = binary.LittleEndian.AppendUint16(data, uint16(sample))
+	}
+	return io.NopCloser(bytes.NewReader(data))
+}
+
+func (s *TrackWaveformServiceSuite) Waveform(points int, peaks []uint8) *entity.Waveform {
+	return &entity.Waveform{
+		TrackID: s.trackID,
+		Points:  points,
+		Peaks:   peaks,
+	}
+}
+
+func Ramp(points int) []uint8 {
+	peaks := make([]uint8, points)
+	for i := range peaks {
+		peaks[i] = uint8(i * 255 / (points - 1))
+	}
+	return peaks
+}
+
+// GenerateWaveform
+func (s *TrackWaveformServiceSuite) TestGenerateWaveform() {
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(&entity.AudioFile{TrackID: s.trackID}, content{bytes.NewReader(nil)}, nil)
+	s.decoder.On("DecodePCM", mock.Anything, mock.Anything).Return(PCM(64*8192), nil)
+
+	saved := make(map[int]*entity.Waveform)
+	s.repo.On("SaveWaveform", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		w := args.Get(1).(*entity.Waveform)
+		saved[w.Points] = w
+	}).Return(nil)
+
+	err := s.service.GenerateWaveform(s.ctx, s.trackID)
+	s.Require().NoError(err)
+	s.Require().Len(saved, len(entity.WaveformResolutions))
+
+	for _, points := range entity.WaveformResolutions {
+		w := saved[points]
+		s.Require().Len(w.Peaks, points)
+		s.Equal(s.trackID, w.TrackID)
+		s.Equal(uint8(255), w.Peaks[points-1])
+		s.Less(w.Peaks[0], uint8(2))
+		s.LessOrEqual(w.Peaks[points/2-1], w.Peaks[points/2])
+	}
+}
+
+func (s *TrackWaveformServiceSuite) TestGenerateWaveformSilence() {
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(&entity.AudioFile{TrackID: s.trackID}, content{bytes.NewReader(nil)}, nil)
+	s.decoder.On("DecodePCM", mock.Anything, mock.Anything).
+		Return(io.NopCloser(bytes.NewReader(make([]byte, 1000))), nil)
+	s.repo.On("SaveWaveform", mock.Anything, mock.MatchedBy(func(w *entity.Waveform) bool {
+		return len(w.Peaks) == w.Points && bytes.Count(w.Peaks, []byte{0}) == w.Points
+	})).Return(nil)
+
+	s.NoError(s.service.GenerateWaveform(s.ctx, s.trackID))
+}
+
+func (s *TrackWaveformServiceSuite) TestGenerateWaveformDecoderError() {
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(&entity.AudioFile{TrackID: s.trackID}, content{bytes.NewReader(nil)}, nil)
+	s.decoder.On("DecodePCM", mock.Anything, mock.Anything).Return(nil, errors.New("ffmpeg error"))
+
+	s.Error(s.service.GenerateWaveform(s.ctx, s.trackID))
+}
+
+func (s *TrackWaveformServiceSuite) TestGenerateWaveformNotFound() {
+	s.audioRepo.On("OpenAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).
+		Return(nil, nil, commonerr.ErrNotFound)
+
+	s.ErrorIs(s.service.GenerateWaveform(s.ctx, s.trackID), commonerr.ErrNotFound)
+}
+
+// GetWaveform
+func (s *TrackWaveformServiceSuite) TestGetWaveformStoredResolution() {
+	stored := s.Waveform(1024, Ramp(1024))
+	s.repo.On("GetWaveform", mock.Anything, s.trackID, 1024).Return(stored, nil)
+
+	res, err := s.service.GetWaveform(s.ctx, s.trackID, 1024)
+	s.NoError(err)
+	s.Equal(stored, res)
+}
+
+func (s *TrackWaveformServiceSuite) TestGetWaveformDownsamples() {
+	s.repo.On("GetWaveform", mock.Anything, s.trackID, 256).Return(s.Waveform(256, Ramp(256)), nil)
+
+	res, err := s.service.GetWaveform(s.ctx, s.trackID, 100)
+	s.Require().NoError(err)
+	s.Equal(100, res.Points)
+	s.Require().Len(res.Peaks, 100)
+	s.Equal(uint8(255), res.Peaks[99])
+}
+
+func (s *TrackWaveformServiceSuite) TestGetWaveformInvalidPoints() {
+	for _, points := range []int{0, -1, 100000} {
+		_, err := s.service.GetWaveform(s.ctx, s.trackID, points)
+		s.ErrorIs(err, waveform.ErrInvalidPoints)
+	}
+}
+
+func (s *TrackWaveformServiceSuite) TestGetWaveformNotFound() {
+	s.repo.On("GetWaveform", mock.Anything, s.trackID, 4096).Return(nil, commonerr.ErrNotFound)
+
+	_, err := s.service.GetWaveform(s.ctx, s.trackID, 2000)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
@@ -0,0 +1,288 @@
+package charts
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/usecases/content/aggregator"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	MaxLimit        = 100
+	WeeklyChartSize = 100
+
+	DefaultMaintenanceInterval = time.Hour
+	// the day chart is counted by the hour, older hourly counts are deleted
+	HourlyPlaysRetention = 48 * time.Hour
+
+	// the charts are served from the cache for CacheTTL, one per request or week
+	CacheTTL  = time.Minute
+	cacheSize = 256
+)
+
+var (
+	ErrInvalidLimit   = errors.New("invalid chart limit")
+	ErrWeekNotOver    = errors.New("the week is not over yet")
+	ErrEmptyChartWeek = errors.New("no plays in the week")
+)
+
+type ChartRepository interface {
+	// TopTracks returns the entries without positions, the most played first.
+	TopTracks(ctx context.Context, query *entity.ChartQuery) ([]*entity.ChartEntry, error)
+	// GetWeeklyChart returns commonerr.ErrNotFound if there's no snapshot of the week.
+	GetWeeklyChart(ctx context.Context, week time.Time) (*entity.WeeklyChart, error)
+	SaveWeeklyChart(ctx context.Context, chart *entity.WeeklyChart) error
+	DeleteHourlyPlays(ctx context.Context, before time.Time) (int64, error)
+}
+
+type ChartService struct {
+	repo         ChartRepository
+	aggregator   aggregator.ContentAggregator
+	now          func() time.Time
+	trackCharts  *expirable.LRU[entity.ChartRequest, []*entity.ChartEntryAggregated]
+	weeklyCharts *expirable.LRU[string, *entity.WeeklyChartAggregated]
+}
+
+type OptionFunc func(*ChartService)
+
+func WithClock(now func() time.Time) OptionFunc {
+	return func(s *ChartService) {
+		s.now = now
+	}
+}
+
+func New(repo ChartRepository, aggregator aggregator.ContentAggregator, opts ...OptionFunc) *ChartService {
+	s := &ChartService{
+		repo:         repo,
+		aggregator:   aggregator,
+		now:          time.Now,
+		trackCharts:  expirable.NewLRU[entity.ChartRequest, []*entity.ChartEntryAggregated](cacheSize, nil, CacheTTL),
+		weeklyCharts: expirable.NewLRU[string, *entity.WeeklyChartAggregated](cacheSize, nil, CacheTTL),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// GetTrackChart counts the plays of the last 24 hours by the hour, of the last 7 and 30 days by the day,
+// including the current hour or day.
+func (s *ChartService) GetTrackChart(ctx context.Context, req *entity.ChartRequest) (_ []*entity.ChartEntryAggregated, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetTrackChart, err)
+	}()
+
+	if req.Limit <= 0 || req.Limit > MaxLimit {
+		return nil, ErrInvalidLimit
+	}
+	if chart, ok := s.trackCharts.Get(*req); ok {
+		return chart, nil
+	}
+
+	now := s.now().UTC()
+	query := &entity.ChartQuery{Filters: req.Filters, Limit: req.Limit}
+	switch req.Period {
+	case entity.ChartDay:
+		query.To = now.Truncate(time.Hour).Add(time.Hour)
+		query.From = query.To.Add(-24 * time.Hour)
+		query.Hourly = true
+	case entity.ChartWeek, entity.ChartMonth:
+		days := 7
+		if req.Period == entity.ChartMonth {
+			days = 30
+		}
+		query.To = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		query.From = query.To.AddDate(0, 0, -days)
+	default:
+		return nil, entity.ErrUnknownChartPeriod
+	}
+
+	entries, err := s.repo.TopTracks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		entry.Position = i + 1
+	}
+
+	chart, err := s.aggregate(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	s.trackCharts.Add(*req, chart)
+
+	return chart, nil
+}
+
+func (s *ChartService) GetWeeklyChart(ctx context.Context, week time.Time) (_ *entity.WeeklyChartAggregated, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetWeeklyChart, err)
+	}()
+
+	week = entity.WeekStart(week)
+	key := week.Format(time.DateOnly)
+	if chart, ok := s.weeklyCharts.Get(key); ok {
+		return chart, nil
+	}
+
+	snapshot, err := s.repo.GetWeeklyChart(ctx, week)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.aggregate(ctx, snapshot.Entries)
+	if err != nil {
+		return nil, err
+	}
+	chart := &entity.WeeklyChartAggregated{Week: snapshot.Week, Entries: entries}
+	s.weeklyCharts.Add(key, chart)
+
+	return chart, nil
+}
+
+// aggregate loads the tracks of the entries, the tracks deleted meanwhile are left out.
+func (s *ChartService) aggregate(ctx context.Context, entries []*entity.ChartEntry) ([]*entity.ChartEntryAggregated, error) {
+	aggregated := make([]*entity.ChartEntryAggregated, 0, len(entries))
+	if len(entries) == 0 {
+		return aggregated, nil
+	}
+
+	trackIDs := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		trackIDs[i] = entry.TrackID
+	}
+
+	found, err := s.aggregator.GetExistingTracksByIDs(ctx, trackIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make(map[uuid.UUID]*entity.TrackMetaAggregated, len(found))
+	for _, track := range found {
+		tracks[track.ID] = track
+	}
+
+	for _, entry := range entries {
+		track, ok := tracks[entry.TrackID]
+		if !ok {
+			continue
+		}
+		aggregated = append(aggregated, &entity.ChartEntryAggregated{
+			Position:         entry.Position,
+			PreviousPosition: entry.PreviousPosition,
+			Plays:            entry.Plays,
+			Track:            track,
+		})
+	}
+
+	return aggregated, nil
+}
+
+// SnapshotWeeklyChart returns the saved snapshot if the week already has one.
+// The positions are compared with the snapshot of the week before, if any.
+func (s *ChartService) SnapshotWeeklyChart(ctx context.Context, week time.Time) (_ *entity.WeeklyChart, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrSnapshotWeeklyChart, err)
+	}()
+
+	week = entity.WeekStart(week)
+	if end := week.AddDate(0, 0, 7); end.After(s.now()) {
+		return nil, ErrWeekNotOver
+	}
+
+	chart, err := s.repo.GetWeeklyChart(ctx, week)
+	if err == nil || !errors.Is(err, commonerr.ErrNotFound) {
+		return chart, err
+	}
+
+	entries, err := s.repo.TopTracks(ctx, &entity.ChartQuery{
+		From:  week,
+		To:    week.AddDate(0, 0, 7),
+		Limit: WeeklyChartSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrEmptyChartWeek
+	}
+
+	previous, err := s.previousPositions(ctx, week)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		entry.Position = i + 1
+		if position, ok := previous[entry.TrackID]; ok {
+			entry.PreviousPosition = &position
+		}
+	}
+
+	chart = &entity.WeeklyChart{Week: week, Entries: entries}
+	if err = s.repo.SaveWeeklyChart(ctx, chart); err != nil {
+		return nil, err
+	}
+
+	return chart, nil
+}
+
+func (s *ChartService) previousPositions(ctx context.Context, week time.Time) (map[uuid.UUID]int, error) {
+	previous, err := s.repo.GetWeeklyChart(ctx, week.AddDate(0, 0, -7))
+	if errors.Is(err, commonerr.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[uuid.UUID]int, len(previous.Entries))
+	for _, entry := range previous.Entries {
+		positions[entry.TrackID] = entry.Position
+	}
+
+	return positions, nil
+}
+
+// RunMaintenance snapshots the last completed week and deletes the hourly plays
+// older than HourlyPlaysRetention on start and then every interval, until the context is done.
+// It runs where the listening events are processed, so one process does it.
+func (s *ChartService) RunMaintenance(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultMaintenanceInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		lastWeek := entity.WeekStart(s.now()).AddDate(0, 0, -7)
+		chart, err := s.SnapshotWeeklyChart(ctx, lastWeek)
+		switch {
+		case errors.Is(err, ErrEmptyChartWeek):
+		case err != nil:
+			slog.Error("failed to snapshot weekly chart", "week", lastWeek.Format(time.DateOnly), "err", err)
+		default:
+			slog.Debug("weekly chart snapshot", "week", lastWeek.Format(time.DateOnly), "tracks", len(chart.Entries))
+		}
+
+		if deleted, err := s.repo.DeleteHourlyPlays(ctx, s.now().Add(-HourlyPlaysRetention)); err != nil {
+			slog.Error("failed to delete hourly plays", "err", err)
+		} else {
+			slog.Debug("hourly plays deleted", "count", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package charts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/charts"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChartServiceSuite struct {
+	suite.Suite
+	service    *charts.ChartService
+	repo       *mocks.ChartRepository
+	aggregator *mocks.ContentAggregator
+	ctx        context.Context
+}
+
+func TestChartServiceSuite(t *testing.T) {
+	suite.Run(t, new(ChartServiceSuite))
+}
+
+func (s *ChartServiceSuite) SetupTest() {
+	s.repo = mocks.NewChartRepository(s.T())
+	s.aggregator = mocks.NewContentAggregator(s.T())
+	s.service = charts.New(s.repo, s.aggregator, charts.WithClock(Now))
+	s.ctx = context.Background()
+}
+
+// Object Mother
+
+// Now is a Wednesday, the current week starts on 2025-06-09.
+func Now() time.Time {
+	return time.Date(2025, 6, 11, 15, 20, 0, 0, time.UTC)
+}
+
+func LastWeek() time.Time {
+	return time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+}
+
+func Entries(trackIDs ...uuid.UUID) []*entity.ChartEntry {
+	entries := make([]*entity.ChartEntry, len(trackIDs))
+	for i, id := range trackIDs {
+		entries[i] = &entity.ChartEntry{TrackID: id, Plays: 100 - i}
+	}
+	return entries
+}
+
+func Tracks(trackIDs ...uuid.UUID) []*entity.TrackMetaAggregated {
+	tracks := make([]*entity.TrackMetaAggregated, len(trackIDs))
+	for i, id := range trackIDs {
+		tracks[i] = &entity.TrackMetaAggregated{ID: id}
+	}
+	return tracks
+}
+
+func Position(p int) *int {
+	return &p
+}
+
+// Tests
+func (s *ChartServiceSuite) TestGetTrackChartDay() {
+	filters := entity.Filters{GenreID: uuid.New(), Country: "SE"}
+	trackIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	s.repo.On("TopTracks", s.ctx, &entity.ChartQuery{
+		From:    time.Date(2025, 6, 10, 16, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 6, 11, 16, 0, 0, 0, time.UTC),
+		Hourly:  true,
+		Filters: filters,
+		Limit:   10,
+	}).Return(Entries(trackIDs...), nil)
+	s.aggregator.On("GetExistingTracksByIDs", s.ctx, trackIDs[0], trackIDs[1]).Return(Tracks(trackIDs...), nil)
+
+	entries, err := s.service.GetTrackChart(s.ctx, &entity.ChartRequest{Period: entity.ChartDay, Filters: filters, Limit: 10})
+
+	s.NoError(err)
+	s.Len(entries, 2)
+	s.Equal(1, entries[0].Position)
+	s.Equal(trackIDs[1], entries[1].Track.ID)
+	s.Equal(2, entries[1].Position)
+}
+
+func (s *ChartServiceSuite) TestGetTrackChartSkipsDeletedTracks() {
+	kept, deleted := uuid.New(), uuid.New()
+	s.repo.On("TopTracks", s.ctx, mock.Anything).Return(Entries(deleted, kept), nil)
+	s.aggregator.On("GetExistingTracksByIDs", s.ctx, deleted, kept).Return(Tracks(kept), nil)
+
+	entries, err := s.service.GetTrackChart(s.ctx, &entity.ChartRequest{Period: entity.ChartWeek, Limit: 10})
+
+	s.NoError(err)
+	s.Len(entries, 1)
+	s.Equal(kept, entries[0].Track.ID)
+	s.Equal(2, entries[0].Position)
+}
+
+func (s *ChartServiceSuite) TestGetTrackChartCached() {
+	trackID := uuid.New()
+	s.repo.On("TopTracks", s.ctx, mock.Anything).Return(Entries(trackID), nil).Once()
+	s.aggregator.On("GetExistingTracksByIDs", s.ctx, trackID).Return(Tracks(trackID), nil).Once()
+
+	req := &entity.ChartRequest{Period: entity.ChartWeek, Limit: 10}
+	first, err := s.service.GetTrackChart(s.ctx, req)
+	s.NoError(err)
+	second, err := s.service.GetTrackChart(s.ctx, &entity.ChartRequest{Period: entity.ChartWeek, Limit: 10})
+	s.NoError(err)
+
+	s.Equal(first, second)
+}
+
+func (s *ChartServiceSuite) TestGetTrackChartWeekAndMonth() {
+	for period, from := range map[entity.ChartPeriod]time.Time{
+		entity.ChartWeek:  time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC),
+		entity.ChartMonth: time.Date(2025, 5, 13, 0, 0, 0, 0, time.UTC),
+	} {
+		s.repo.On("TopTracks", s.ctx, &entity.ChartQuery{
+			From:  from,
+			To:    time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC),
+			Limit: 30,
+		}).Return(nil, nil).Once()
+
+		entries, err := s.service.GetTrackChart(s.ctx, &entity.ChartRequest{Period: period, Limit: 30})
+		s.NoError(err)
+		s.Empty(entries)
+	}
+	s.aggregator.AssertNotCalled(s.T(), "GetExistingTracksByIDs", mock.Anything, mock.Anything)
+}
+
+func (s *ChartServiceSuite) TestGetTrackChartInvalidRequest() {
+	_, err := s.service.GetTrackChart(s.ctx, &entity.ChartRequest{Period: entity.ChartWeek, Limit: charts.MaxLimit + 1})
+	s.ErrorIs(err, charts.ErrInvalidLimit)
+	s.ErrorIs(err, usecase.ErrGetTrackChart)
+
+	_, err = s.service.GetTrackChart(s.ctx, &entity.ChartRequest{Period: "year", Limit: 10})
+	s.ErrorIs(err, entity.ErrUnknownChartPeriod)
+}
+
+func (s *ChartServiceSuite) TestGetWeeklyChartUsesWeekStart() {
+	trackID := uuid.New()
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek()).Return(&entity.WeeklyChart{
+		Week:    LastWeek(),
+		Entries: []*entity.ChartEntry{{Position: 1, PreviousPosition: Position(4), TrackID: trackID, Plays: 10}},
+	}, nil).Once()
+	s.aggregator.On("GetExistingTracksByIDs", s.ctx, trackID).Return(Tracks(trackID), nil).Once()
+
+	got, err := s.service.GetWeeklyChart(s.ctx, LastWeek().AddDate(0, 0, 4))
+	s.NoError(err)
+	cached, err := s.service.GetWeeklyChart(s.ctx, LastWeek())
+	s.NoError(err)
+
+	s.Equal(&entity.WeeklyChartAggregated{
+		Week: LastWeek(),
+		Entries: []*entity.ChartEntryAggregated{
+			{Position: 1, PreviousPosition: Position(4), Plays: 10, Track: &entity.TrackMetaAggregated{ID: trackID}},
+		},
+	}, got)
+	s.Equal(got, cached)
+}
+
+func (s *ChartServiceSuite) TestGetWeeklyChartNotFound() {
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek()).Return(nil, commonerr.ErrNotFound)
+
+	_, err := s.service.GetWeeklyChart(s.ctx, LastWeek())
+
+	s.ErrorIs(err, commonerr.ErrNotFound)
+	s.ErrorIs(err, usecase.ErrGetWeeklyChart)
+}
+
+func (s *ChartServiceSuite) TestSnapshotWeeklyChart() {
+	stayed, climbed, entered := uuid.New(), uuid.New(), uuid.New()
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek()).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("TopTracks", s.ctx, &entity.ChartQuery{
+		From:  LastWeek(),
+		To:    LastWeek().AddDate(0, 0, 7),
+		Limit: charts.WeeklyChartSize,
+	}).Return(Entries(climbed, stayed, entered), nil)
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek().AddDate(0, 0, -7)).Return(&entity.WeeklyChart{
+		Week:    LastWeek().AddDate(0, 0, -7),
+		Entries: []*entity.ChartEntry{{Position: 1, TrackID: uuid.New()}, {Position: 2, TrackID: stayed}, {Position: 3, TrackID: climbed}},
+	}, nil)
+	s.repo.On("SaveWeeklyChart", s.ctx, mock.AnythingOfType("*entity.WeeklyChart")).Return(nil)
+
+	chart, err := s.service.SnapshotWeeklyChart(s.ctx, LastWeek())
+
+	s.NoError(err)
+	s.Equal(LastWeek(), chart.Week)
+	s.Equal([]*entity.ChartEntry{
+		{Position: 1, PreviousPosition: Position(3), TrackID: climbed, Plays: 100},
+		{Position: 2, PreviousPosition: Position(2), TrackID: stayed, Plays: 99},
+		{Position: 3, TrackID: entered, Plays: 98},
+	}, chart.Entries)
+}
+
+func (s *ChartServiceSuite) TestSnapshotWeeklyChartWithoutPreviousWeek() {
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek()).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("TopTracks", s.ctx, mock.Anything).Return(Entries(uuid.New()), nil)
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek().AddDate(0, 0, -7)).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("SaveWeeklyChart", s.ctx, mock.Anything).Return(nil)
+
+	chart, err := s.service.SnapshotWeeklyChart(s.ctx, LastWeek())
+
+	s.NoError(err)
+	s.Nil(chart.Entries[0].PreviousPosition)
+}
+
+func (s *ChartServiceSuite) TestSnapshotWeeklyChartExists() {
+	chart := &entity.WeeklyChart{Week: LastWeek(), Entries: Entries(uuid.New())}
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek()).Return(chart, nil)
+
+	got, err := s.service.SnapshotWeeklyChart(s.ctx, LastWeek())
+
+	s.NoError(err)
+	s.Equal(chart, got)
+	s.repo.AssertNotCalled(s.T(), "SaveWeeklyChart", mock.Anything, mock.Anything)
+}
+
+func (s *ChartServiceSuite) TestSnapshotWeeklyChartCurrentWeek() {
+	_, err := s.service.SnapshotWeeklyChart(s.ctx, Now())
+
+	s.ErrorIs(err, charts.ErrWeekNotOver)
+}
+
+func (s *ChartServiceSuite) TestSnapshotWeeklyChartEmptyWeek() {
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek()).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("TopTracks", s.ctx, mock.Anything).Return(nil, nil)
+
+	_, err := s.service.SnapshotWeeklyChart(s.ctx, LastWeek())
+
+	s.ErrorIs(err, charts.ErrEmptyChartWeek)
+}
+
+func (s *ChartServiceSuite) TestSnapshotWeeklyChartSaveError() {
+	saveErr := errors.New("db error")
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek()).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("TopTracks", s.ctx, mock.Anything).Return(Entries(uuid.New()), nil)
+	s.repo.On("GetWeeklyChart", s.ctx, LastWeek().AddDate(0, 0, -7)).Return(nil, commonerr.ErrNotFound)
+	s.repo.On("SaveWeeklyChart", s.ctx, mock.Anything).Return(saveErr)
+
+	_, err := s.service.SnapshotWeeklyChart(s.ctx, LastWeek())
+
+	s.ErrorIs(err, saveErr)
+	s.ErrorIs(err, usecase.ErrSnapshotWeeklyChart)
+}
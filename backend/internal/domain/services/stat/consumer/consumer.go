@@ -12,17 +12,22 @@ type ListeningStatService interface {
 	UpdateStat(ctx context.Context, event *entity.ListeningEvent) error
 }
 
+type ListeningHistoryRecorder interface {
+	RecordListening(ctx context.Context, event *entity.ListeningEvent) error
+}
+
 type EventBusSub interface {
 	Subscribe(ctx context.Context, handler func(ctx context.Context, event *entity.ListeningEvent) error) error
 }
 
 type ListeningEventConsumer struct {
-	bus  EventBusSub
-	stat ListeningStatService
+	bus     EventBusSub
+	stat    ListeningStatService
+	history ListeningHistoryRecorder
 }
 
-func New(bus EventBusSub, statService ListeningStatService) *ListeningEventConsumer {
-	return &ListeningEventConsumer{bus: bus, stat: statService}
+func New(bus EventBusSub, statService ListeningStatService, history ListeningHistoryRecorder) *ListeningEventConsumer {
+	return &ListeningEventConsumer{bus: bus, stat: statService, history: history}
 }
 
 func (c *ListeningEventConsumer) Start(ctx context.Context) (err error) {
@@ -42,5 +47,10 @@ func (c *ListeningEventConsumer) consumeListeningEvent(ctx context.Context, even
 		}
 	}()
 
-	return c.stat.UpdateStat(ctx, event)
+	// both are idempotent, so a redelivered event doesn't redo the part already done
+	if err = c.stat.UpdateStat(ctx, event); err != nil {
+		return err
+	}
+
+	return c.history.RecordListening(ctx, event)
 }
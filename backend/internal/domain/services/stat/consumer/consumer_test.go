@@ -43,10 +43,11 @@ func TestListeningEventConsumer_Start(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			bus := mocks.NewEventBusSub(t)
 			stat := mocks.NewListeningStatService(t)
+			history := mocks.NewListeningHistoryRecorder(t)
 
 			tt.setupMock(bus)
 
-			c := New(bus, stat)
+			c := New(bus, stat, history)
 			err := c.Start(context.Background())
 			if tt.expectErr {
 				assert.Error(t, err)
@@ -61,7 +62,7 @@ func TestListeningEventConsumer_consumeListeningEvent(t *testing.T) {
 	tests := []struct {
 		name      string
 		event     *entity.ListeningEvent
-		setupMock func(stat *mocks.ListeningStatService)
+		setupMock func(stat *mocks.ListeningStatService, history *mocks.ListeningHistoryRecorder)
 		expectErr bool
 	}{
 		{
@@ -74,8 +75,9 @@ func TestListeningEventConsumer_consumeListeningEvent(t *testing.T) {
 					{Start: 55, End: 141},
 				},
 			},
-			setupMock: func(stat *mocks.ListeningStatService) {
+			setupMock: func(stat *mocks.ListeningStatService, history *mocks.ListeningHistoryRecorder) {
 				stat.On("UpdateStat", mock.Anything, mock.Anything).Return(nil).Once()
+				history.On("RecordListening", mock.Anything, mock.Anything).Return(nil).Once()
 			},
 			expectErr: false,
 		},
@@ -88,22 +90,39 @@ func TestListeningEventConsumer_consumeListeningEvent(t *testing.T) {
 					{Start: 0, End: 60},
 				},
 			},
-			setupMock: func(stat *mocks.ListeningStatService) {
+			setupMock: func(stat *mocks.ListeningStatService, history *mocks.ListeningHistoryRecorder) {
 				stat.On("UpdateStat", mock.Anything, mock.Anything).
 					Return(errors.New("stat error")).Once()
 			},
 			expectErr: true,
 		},
+		{
+			name: "history error",
+			event: &entity.ListeningEvent{
+				TrackID: uuid.New(),
+				UserID:  uuid.New(),
+				Ranges: []*entity.Range{
+					{Start: 0, End: 60},
+				},
+			},
+			setupMock: func(stat *mocks.ListeningStatService, history *mocks.ListeningHistoryRecorder) {
+				stat.On("UpdateStat", mock.Anything, mock.Anything).Return(nil).Once()
+				history.On("RecordListening", mock.Anything, mock.Anything).
+					Return(errors.New("history error")).Once()
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			bus := mocks.NewEventBusSub(t)
 			stat := mocks.NewListeningStatService(t)
+			history := mocks.NewListeningHistoryRecorder(t)
 
-			tt.setupMock(stat)
+			tt.setupMock(stat, history)
 
-			c := New(bus, stat)
+			c := New(bus, stat, history)
 			err := c.consumeListeningEvent(context.Background(), tt.event)
 			if tt.expectErr {
 				assert.Error(t, err)
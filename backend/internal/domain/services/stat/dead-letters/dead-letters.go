@@ -0,0 +1,142 @@
+package deadletters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+var ErrUnknownDeadLetter = errors.New("unknown dead letter")
+
+type DeadLetterReader interface {
+	ListDeadLetters(ctx context.Context, limit int) ([]*entity.DeadLetter, error)
+}
+
+// ReplayLog records the replayed letters, so an event is not published twice.
+type ReplayLog interface {
+	GetReplayed(ctx context.Context, letters []*entity.DeadLetter) (map[string]time.Time, error)
+	MarkReplayed(ctx context.Context, letter *entity.DeadLetter) error
+}
+
+type EventBusPub interface {
+	Publish(ctx context.Context, event *entity.ListeningEvent) error
+}
+
+type DeadLetterService struct {
+	reader   DeadLetterReader
+	replayed ReplayLog
+	bus      EventBusPub
+}
+
+func New(reader DeadLetterReader, replayed ReplayLog, bus EventBusPub) *DeadLetterService {
+	return &DeadLetterService{reader: reader, replayed: replayed, bus: bus}
+}
+
+func (s *DeadLetterService) ListDeadLetters(ctx context.Context, claims *entity.Claims,
+	limit int) (_ []*entity.DeadLetter, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrListDeadLetters, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+
+	return s.listDeadLetters(ctx, limit)
+}
+
+// ReplayDeadLetters records every replayed letter and skips the ones replayed before.
+// A letter published but not recorded is skipped by the processor within the dedup
+// window if replayed again. The letters with an invalid payload are skipped.
+func (s *DeadLetterService) ReplayDeadLetters(ctx context.Context, claims *entity.Claims,
+	ids []string) (_ *entity.DeadLetterReplayReport, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrReplayDeadLetters, err)
+	}()
+
+	if claims == nil || claims.AccessLvl != entity.Admin {
+		return nil, commonerr.ErrForbidden
+	}
+
+	letters, err := s.listDeadLetters(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	if letters, err = selectDeadLetters(letters, ids); err != nil {
+		return nil, err
+	}
+
+	report := &entity.DeadLetterReplayReport{}
+	for _, letter := range letters {
+		switch {
+		case letter.ReplayedAt != nil:
+			report.AlreadyReplayed++
+			continue
+		case letter.Event == nil:
+			report.Skipped++
+			continue
+		}
+
+		if err := s.bus.Publish(ctx, letter.Event); err != nil {
+			return report, fmt.Errorf("replay %s: %w", letter.ID(), err)
+		}
+		if err := s.replayed.MarkReplayed(ctx, letter); err != nil {
+			return report, fmt.Errorf("replay %s: %w", letter.ID(), err)
+		}
+		report.Replayed++
+	}
+	slog.Info("dead letters replayed", "replayed", report.Replayed, "skipped", report.Skipped,
+		"already_replayed", report.AlreadyReplayed)
+
+	return report, nil
+}
+
+// listDeadLetters sets ReplayedAt of the letters replayed before.
+func (s *DeadLetterService) listDeadLetters(ctx context.Context, limit int) ([]*entity.DeadLetter, error) {
+	letters, err := s.reader.ListDeadLetters(ctx, limit)
+	if err != nil || len(letters) == 0 {
+		return letters, err
+	}
+
+	replayed, err := s.replayed.GetReplayed(ctx, letters)
+	if err != nil {
+		return nil, err
+	}
+	for _, letter := range letters {
+		if replayedAt, ok := replayed[letter.ID()]; ok {
+			letter.ReplayedAt = &replayedAt
+		}
+	}
+
+	return letters, nil
+}
+
+// selectDeadLetters keeps the letters with the given IDs, all of them if there are no IDs.
+func selectDeadLetters(letters []*entity.DeadLetter, ids []string) ([]*entity.DeadLetter, error) {
+	if len(ids) == 0 {
+		return letters, nil
+	}
+
+	byID := make(map[string]*entity.DeadLetter, len(letters))
+	for _, letter := range letters {
+		byID[letter.ID()] = letter
+	}
+
+	selected := make([]*entity.DeadLetter, 0, len(ids))
+	for _, id := range ids {
+		letter, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownDeadLetter, id)
+		}
+		selected = append(selected, letter)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,157 @@
+package deadletters_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	deadletters "github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/dead-letters"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeadLetterServiceSuite struct {
+	suite.Suite
+	service  *deadletters.DeadLetterService
+	reader   *mocks.DeadLetterReader
+	replayed *mocks.ReplayLog
+	bus      *mocks.EventBusPub
+	ctx      context.Context
+}
+
+func TestDeadLetterServiceSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterServiceSuite))
+}
+
+func (s *DeadLetterServiceSuite) SetupTest() {
+	s.reader = mocks.NewDeadLetterReader(s.T())
+	s.replayed = mocks.NewReplayLog(s.T())
+	s.bus = mocks.NewEventBusPub(s.T())
+	s.service = deadletters.New(s.reader, s.replayed, s.bus)
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func AdminClaims() *entity.Claims {
+	return &entity.Claims{UserID: uuid.New(), AccessLvl: entity.Admin}
+}
+
+func DeadLetter(offset int64) *entity.DeadLetter {
+	return &entity.DeadLetter{
+		Offset:   offset,
+		Event:    &entity.ListeningEvent{TrackID: uuid.New(), UserID: uuid.New()},
+		Error:    "segments not found",
+		Attempts: 4,
+	}
+}
+
+func PoisonLetter(offset int64) *entity.DeadLetter {
+	return &entity.DeadLetter{Offset: offset, Payload: []byte("{"), Error: "malformed event", Attempts: 1}
+}
+
+// Tests
+func (s *DeadLetterServiceSuite) TestListDeadLetters() {
+	letters := []*entity.DeadLetter{DeadLetter(0), PoisonLetter(1)}
+	s.reader.On("ListDeadLetters", s.ctx, 10).Return(letters, nil)
+	s.replayed.On("GetReplayed", s.ctx, letters).Return(map[string]time.Time{}, nil)
+
+	got, err := s.service.ListDeadLetters(s.ctx, AdminClaims(), 10)
+
+	s.Require().NoError(err)
+	s.Equal(letters, got)
+}
+
+func (s *DeadLetterServiceSuite) TestListDeadLettersMarksReplayed() {
+	replayedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	letters := []*entity.DeadLetter{DeadLetter(0), DeadLetter(1)}
+	s.reader.On("ListDeadLetters", s.ctx, 10).Return(letters, nil)
+	s.replayed.On("GetReplayed", s.ctx, letters).Return(map[string]time.Time{"0:1": replayedAt}, nil)
+
+	got, err := s.service.ListDeadLetters(s.ctx, AdminClaims(), 10)
+
+	s.Require().NoError(err)
+	s.Nil(got[0].ReplayedAt)
+	s.Require().NotNil(got[1].ReplayedAt)
+	s.Equal(replayedAt, *got[1].ReplayedAt)
+}
+
+func (s *DeadLetterServiceSuite) TestRequiresAdmin() {
+	user := &entity.Claims{UserID: uuid.New(), AccessLvl: entity.User}
+
+	_, err := s.service.ListDeadLetters(s.ctx, user, 10)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+
+	_, err = s.service.ReplayDeadLetters(s.ctx, user, nil)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
+
+func (s *DeadLetterServiceSuite) TestReplayAllSkipsPoison() {
+	first, poison, second := DeadLetter(0), PoisonLetter(1), DeadLetter(2)
+	s.reader.On("ListDeadLetters", s.ctx, 0).Return([]*entity.DeadLetter{first, poison, second}, nil)
+	s.replayed.On("GetReplayed", s.ctx, mock.Anything).Return(map[string]time.Time{}, nil)
+	s.bus.On("Publish", s.ctx, first.Event).Return(nil).Once()
+	s.bus.On("Publish", s.ctx, second.Event).Return(nil).Once()
+	s.replayed.On("MarkReplayed", s.ctx, first).Return(nil).Once()
+	s.replayed.On("MarkReplayed", s.ctx, second).Return(nil).Once()
+
+	report, err := s.service.ReplayDeadLetters(s.ctx, AdminClaims(), nil)
+
+	s.Require().NoError(err)
+	s.Equal(&entity.DeadLetterReplayReport{Replayed: 2, Skipped: 1}, report)
+}
+
+func (s *DeadLetterServiceSuite) TestReplaySelected() {
+	first, second := DeadLetter(0), DeadLetter(1)
+	s.reader.On("ListDeadLetters", s.ctx, 0).Return([]*entity.DeadLetter{first, second}, nil)
+	s.replayed.On("GetReplayed", s.ctx, mock.Anything).Return(map[string]time.Time{}, nil)
+	s.bus.On("Publish", s.ctx, second.Event).Return(nil).Once()
+	s.replayed.On("MarkReplayed", s.ctx, second).Return(nil).Once()
+
+	report, err := s.service.ReplayDeadLetters(s.ctx, AdminClaims(), []string{"0:1"})
+
+	s.Require().NoError(err)
+	s.Equal(1, report.Replayed)
+}
+
+func (s *DeadLetterServiceSuite) TestReplayUnknownID() {
+	s.reader.On("ListDeadLetters", s.ctx, 0).Return([]*entity.DeadLetter{DeadLetter(0)}, nil)
+	s.replayed.On("GetReplayed", s.ctx, mock.Anything).Return(map[string]time.Time{}, nil)
+
+	_, err := s.service.ReplayDeadLetters(s.ctx, AdminClaims(), []string{"0:0", "3:7"})
+
+	s.ErrorIs(err, deadletters.ErrUnknownDeadLetter)
+	s.ErrorIs(err, usecase.ErrReplayDeadLetters)
+	s.bus.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+}
+
+func (s *DeadLetterServiceSuite) TestReplayStopsOnPublishError() {
+	publishErr := errors.New("broker unavailable")
+	s.reader.On("ListDeadLetters", s.ctx, 0).Return([]*entity.DeadLetter{DeadLetter(0), DeadLetter(1)}, nil)
+	s.replayed.On("GetReplayed", s.ctx, mock.Anything).Return(map[string]time.Time{}, nil)
+	s.bus.On("Publish", s.ctx, mock.Anything).Return(publishErr).Once()
+
+	report, err := s.service.ReplayDeadLetters(s.ctx, AdminClaims(), nil)
+
+	s.ErrorIs(err, publishErr)
+	s.Equal(0, report.Replayed)
+	s.replayed.AssertNotCalled(s.T(), "MarkReplayed", mock.Anything, mock.Anything)
+}
+
+func (s *DeadLetterServiceSuite) TestReplaySkipsReplayedBefore() {
+	first, second := DeadLetter(0), DeadLetter(1)
+	s.reader.On("ListDeadLetters", s.ctx, 0).Return([]*entity.DeadLetter{first, second}, nil)
+	s.replayed.On("GetReplayed", s.ctx, mock.Anything).Return(map[string]time.Time{"0:0": time.Now()}, nil)
+	s.bus.On("Publish", s.ctx, second.Event).Return(nil).Once()
+	s.replayed.On("MarkReplayed", s.ctx, second).Return(nil).Once()
+
+	report, err := s.service.ReplayDeadLetters(s.ctx, AdminClaims(), nil)
+
+	s.Require().NoError(err)
+	s.Equal(&entity.DeadLetterReplayReport{Replayed: 1, AlreadyReplayed: 1}, report)
+}
@@ -0,0 +1,118 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
+)
+
+const MaxLimit = 100
+
+var ErrInvalidPagination = errors.New("invalid limit or offset")
+
+type ListeningHistoryRepository interface {
+	// AddEntry ignores an entry with the event ID already stored for the user.
+	AddEntry(ctx context.Context, entry *entity.ListeningHistoryEntry) error
+	GetEntries(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.ListeningHistoryEntry, error)
+	DeleteEntries(ctx context.Context, userID uuid.UUID) error
+	SetPaused(ctx context.Context, userID uuid.UUID, paused bool) error
+	IsPaused(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+type ListeningHistoryService struct {
+	repo ListeningHistoryRepository
+}
+
+func New(repo ListeningHistoryRepository) *ListeningHistoryService {
+	return &ListeningHistoryService{repo: repo}
+}
+
+func (s *ListeningHistoryService) RecordListening(ctx context.Context, event *entity.ListeningEvent) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrRecordListening, err)
+	}()
+
+	if event.UserID == uuid.Nil {
+		return nil
+	}
+
+	paused, err := s.repo.IsPaused(ctx, event.UserID)
+	if err != nil || paused {
+		return err
+	}
+
+	entry := &entity.ListeningHistoryEntry{
+		EventID:    event.EventID,
+		UserID:     event.UserID,
+		TrackID:    event.TrackID,
+		ListenedAt: event.ClampedListenedAt(time.Now()),
+		Seconds:    event.TotalSeconds(),
+	}
+	// events published before the event IDs were introduced can't be deduplicated
+	if entry.EventID == uuid.Nil {
+		entry.EventID = uuid.New()
+	}
+	if event.Context != nil && event.Context.Validate() == nil {
+		entry.Context = event.Context
+	}
+
+	return s.repo.AddEntry(ctx, entry)
+}
+
+func (s *ListeningHistoryService) GetHistory(ctx context.Context, claims *entity.Claims,
+	limit, offset int) (_ []*entity.ListeningHistoryEntry, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetListeningHistory, err)
+	}()
+
+	if claims == nil {
+		return nil, commonerr.ErrForbidden
+	}
+	if limit <= 0 || limit > MaxLimit || offset < 0 {
+		return nil, ErrInvalidPagination
+	}
+
+	return s.repo.GetEntries(ctx, claims.UserID, limit, offset)
+}
+
+func (s *ListeningHistoryService) ClearHistory(ctx context.Context, claims *entity.Claims) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrClearHistory, err)
+	}()
+
+	if claims == nil {
+		return commonerr.ErrForbidden
+	}
+
+	return s.repo.DeleteEntries(ctx, claims.UserID)
+}
+
+func (s *ListeningHistoryService) SetHistoryPaused(ctx context.Context, claims *entity.Claims, paused bool) (err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrPauseHistory, err)
+	}()
+
+	if claims == nil {
+		return commonerr.ErrForbidden
+	}
+
+	return s.repo.SetPaused(ctx, claims.UserID, paused)
+}
+
+func (s *ListeningHistoryService) IsHistoryPaused(ctx context.Context, claims *entity.Claims) (_ bool, err error) {
+	defer func() {
+		err = errwrap.WrapIfErr(usecase.ErrGetHistoryPaused, err)
+	}()
+
+	if claims == nil {
+		return false, commonerr.ErrForbidden
+	}
+
+	return s.repo.IsPaused(ctx, claims.UserID)
+}
@@ -0,0 +1,171 @@
+package history_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/history"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListeningHistoryServiceSuite struct {
+	suite.Suite
+	service *history.ListeningHistoryService
+	repo    *mocks.ListeningHistoryRepository
+	ctx     context.Context
+}
+
+func TestListeningHistoryServiceSuite(t *testing.T) {
+	suite.Run(t, new(ListeningHistoryServiceSuite))
+}
+
+func (s *ListeningHistoryServiceSuite) SetupTest() {
+	s.repo = mocks.NewListeningHistoryRepository(s.T())
+	s.service = history.New(s.repo)
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func UserClaims() *entity.Claims {
+	return &entity.Claims{UserID: uuid.New(), AccessLvl: entity.User}
+}
+
+func ListeningEvent(userID uuid.UUID) *entity.ListeningEvent {
+	return &entity.ListeningEvent{
+		EventID:    uuid.New(),
+		ListenedAt: time.Date(2025, 6, 1, 10, 30, 0, 0, time.UTC),
+		ReceivedAt: time.Date(2025, 6, 1, 10, 35, 0, 0, time.UTC),
+		TrackID:    uuid.New(),
+		UserID:     userID,
+		Ranges:     []*entity.Range{{Start: 0, End: 40}, {Start: 60, End: 80}},
+		Context:    &entity.ListeningContext{Type: entity.ListeningContextPlaylist, ID: uuid.New()},
+	}
+}
+
+// Tests
+func (s *ListeningHistoryServiceSuite) TestRecordListening() {
+	event := ListeningEvent(uuid.New())
+	s.repo.On("IsPaused", s.ctx, event.UserID).Return(false, nil)
+	s.repo.On("AddEntry", s.ctx, &entity.ListeningHistoryEntry{
+		EventID:    event.EventID,
+		UserID:     event.UserID,
+		TrackID:    event.TrackID,
+		ListenedAt: event.ListenedAt,
+		Seconds:    60,
+		Context:    event.Context,
+	}).Return(nil)
+
+	s.NoError(s.service.RecordListening(s.ctx, event))
+}
+
+func (s *ListeningHistoryServiceSuite) TestRecordListeningDropsInvalidContext() {
+	event := ListeningEvent(uuid.New())
+	event.Context.Type = "radio"
+	s.repo.On("IsPaused", s.ctx, event.UserID).Return(false, nil)
+	s.repo.On("AddEntry", s.ctx, mock.MatchedBy(func(entry *entity.ListeningHistoryEntry) bool {
+		return entry.Context == nil
+	})).Return(nil)
+
+	s.NoError(s.service.RecordListening(s.ctx, event))
+}
+
+func (s *ListeningHistoryServiceSuite) TestRecordListeningWithoutEventID() {
+	event := ListeningEvent(uuid.New())
+	event.EventID, event.ListenedAt = uuid.Nil, time.Time{}
+	s.repo.On("IsPaused", s.ctx, event.UserID).Return(false, nil)
+	s.repo.On("AddEntry", s.ctx, mock.MatchedBy(func(entry *entity.ListeningHistoryEntry) bool {
+		return entry.EventID != uuid.Nil && !entry.ListenedAt.IsZero()
+	})).Return(nil)
+
+	s.NoError(s.service.RecordListening(s.ctx, event))
+}
+
+func (s *ListeningHistoryServiceSuite) TestRecordListeningClampsFutureTime() {
+	event := ListeningEvent(uuid.New())
+	event.ListenedAt = event.ReceivedAt.Add(time.Hour)
+	s.repo.On("IsPaused", s.ctx, event.UserID).Return(false, nil)
+	s.repo.On("AddEntry", s.ctx, mock.MatchedBy(func(entry *entity.ListeningHistoryEntry) bool {
+		return entry.ListenedAt.Equal(event.ReceivedAt)
+	})).Return(nil)
+
+	s.NoError(s.service.RecordListening(s.ctx, event))
+}
+
+func (s *ListeningHistoryServiceSuite) TestRecordListeningSkipsAnonymous() {
+	s.NoError(s.service.RecordListening(s.ctx, ListeningEvent(uuid.Nil)))
+}
+
+func (s *ListeningHistoryServiceSuite) TestRecordListeningSkipsPaused() {
+	event := ListeningEvent(uuid.New())
+	s.repo.On("IsPaused", s.ctx, event.UserID).Return(true, nil)
+
+	s.NoError(s.service.RecordListening(s.ctx, event))
+	s.repo.AssertNotCalled(s.T(), "AddEntry", mock.Anything, mock.Anything)
+}
+
+func (s *ListeningHistoryServiceSuite) TestRecordListeningError() {
+	event := ListeningEvent(uuid.New())
+	dbErr := errors.New("db error")
+	s.repo.On("IsPaused", s.ctx, event.UserID).Return(false, dbErr)
+
+	err := s.service.RecordListening(s.ctx, event)
+
+	s.ErrorIs(err, dbErr)
+	s.ErrorIs(err, usecase.ErrRecordListening)
+}
+
+func (s *ListeningHistoryServiceSuite) TestGetHistory() {
+	claims := UserClaims()
+	entries := []*entity.ListeningHistoryEntry{{EventID: uuid.New(), UserID: claims.UserID, TrackID: uuid.New()}}
+	s.repo.On("GetEntries", s.ctx, claims.UserID, 20, 40).Return(entries, nil)
+
+	got, err := s.service.GetHistory(s.ctx, claims, 20, 40)
+
+	s.Require().NoError(err)
+	s.Equal(entries, got)
+}
+
+func (s *ListeningHistoryServiceSuite) TestGetHistoryInvalidPagination() {
+	for _, page := range [][2]int{{0, 0}, {history.MaxLimit + 1, 0}, {10, -1}} {
+		_, err := s.service.GetHistory(s.ctx, UserClaims(), page[0], page[1])
+		s.ErrorIs(err, history.ErrInvalidPagination)
+	}
+}
+
+func (s *ListeningHistoryServiceSuite) TestRequiresClaims() {
+	_, err := s.service.GetHistory(s.ctx, nil, 20, 0)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+
+	s.ErrorIs(s.service.ClearHistory(s.ctx, nil), commonerr.ErrForbidden)
+	s.ErrorIs(s.service.SetHistoryPaused(s.ctx, nil, true), commonerr.ErrForbidden)
+
+	_, err = s.service.IsHistoryPaused(s.ctx, nil)
+	s.ErrorIs(err, commonerr.ErrForbidden)
+}
+
+func (s *ListeningHistoryServiceSuite) TestClearHistory() {
+	claims := UserClaims()
+	s.repo.On("DeleteEntries", s.ctx, claims.UserID).Return(nil)
+
+	s.NoError(s.service.ClearHistory(s.ctx, claims))
+}
+
+func (s *ListeningHistoryServiceSuite) TestPauseHistory() {
+	claims := UserClaims()
+	s.repo.On("SetPaused", s.ctx, claims.UserID, true).Return(nil)
+	s.repo.On("IsPaused", s.ctx, claims.UserID).Return(true, nil)
+
+	s.Require().NoError(s.service.SetHistoryPaused(s.ctx, claims, true))
+	paused, err := s.service.IsHistoryPaused(s.ctx, claims)
+
+	s.NoError(err)
+	s.True(paused)
+}
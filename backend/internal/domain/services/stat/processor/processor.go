@@ -2,9 +2,13 @@ package processor
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
 	"github.com/hahaclassic/orpheon/backend/pkg/errwrap"
 )
@@ -12,11 +16,20 @@ import (
 const (
 	MinSeconds           = 30 // the minimum number of listening seconds to count
 	MinDiffForSmallTrack = 2  // the minimum difference between the total duration of the segments and the total duration of the listening event to count as a small track
+
+	DefaultDedupCleanupInterval = time.Hour
 )
 
+// ErrNoSegments is returned for a track without segments, e.g. an unknown one,
+// the event can't be counted and is not retried.
+var ErrNoSegments = fmt.Errorf("%w: track has no segments", commonerr.ErrNotFound)
+
 type ListeningStatService struct {
 	trackRepo   TrackStatRepository
 	segmentRepo SegmentStatRepository
+	dedupRepo   EventDedupRepository
+	playsRepo   PlayCountRepository
+	transactor  Transactor
 }
 
 type TrackStatRepository interface {
@@ -28,10 +41,37 @@ type SegmentStatRepository interface {
 	IncrementTotalStreams(ctx context.Context, trackID uuid.UUID, segmentsIdxs []int) error
 }
 
-func NewListeningStatService(trackRepo TrackStatRepository, segmentRepo SegmentStatRepository) *ListeningStatService {
-	return &ListeningStatService{trackRepo: trackRepo, segmentRepo: segmentRepo}
+// EventDedupRepository remembers the events already counted.
+type EventDedupRepository interface {
+	// Claim reports false if the event of the user has already been claimed.
+	Claim(ctx context.Context, userID, eventID uuid.UUID) (bool, error)
+	// DeleteExpired forgets the events older than the dedup window.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// PlayCountRepository counts the plays of the tracks by the hour and by the day for the charts.
+type PlayCountRepository interface {
+	IncrementPlayCounts(ctx context.Context, trackID uuid.UUID, at time.Time) error
 }
 
+// Transactor runs fn in one transaction, the repositories pick it up from the context.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+func NewListeningStatService(trackRepo TrackStatRepository, segmentRepo SegmentStatRepository,
+	dedupRepo EventDedupRepository, playsRepo PlayCountRepository, transactor Transactor) *ListeningStatService {
+	return &ListeningStatService{
+		trackRepo:   trackRepo,
+		segmentRepo: segmentRepo,
+		dedupRepo:   dedupRepo,
+		playsRepo:   playsRepo,
+		transactor:  transactor,
+	}
+}
+
+// UpdateStat claims the event and increments the counters in one transaction,
+// a failed event is counted in full when it is retried.
 func (s *ListeningStatService) UpdateStat(ctx context.Context, event *entity.ListeningEvent) (err error) {
 	defer func() {
 		if err != nil {
@@ -39,11 +79,33 @@ func (s *ListeningStatService) UpdateStat(ctx context.Context, event *entity.Lis
 		}
 	}()
 
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.updateStat(ctx, event)
+	})
+}
+
+func (s *ListeningStatService) updateStat(ctx context.Context, event *entity.ListeningEvent) error {
+	// events published before the event IDs were introduced can't be deduplicated
+	if event.EventID != uuid.Nil {
+		claimed, err := s.dedupRepo.Claim(ctx, event.UserID, event.EventID)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			slog.Debug("duplicate listening event skipped", "event_id", event.EventID, "track_id", event.TrackID)
+			return nil
+		}
+	}
+
 	segments, err := s.segmentRepo.GetSegments(ctx, event.TrackID)
 	if err != nil {
 		return err
 	}
 
+	if len(segments) == 0 || segments[0].Range.Len() <= 0 {
+		return ErrNoSegments
+	}
+
 	affectedSegIdx, totalDuration := s.proccessListeningEvent(segments, event)
 
 	if err = s.segmentRepo.IncrementTotalStreams(ctx, event.TrackID, affectedSegIdx); err != nil {
@@ -59,11 +121,41 @@ func (s *ListeningStatService) UpdateStat(ctx context.Context, event *entity.Lis
 		if err = s.trackRepo.IncrementTrackTotalStreams(ctx, event.TrackID); err != nil {
 			return err
 		}
+
+		// the time is set by the client, so it's kept close to the time the event was received
+		listenedAt := event.ClampedListenedAt(time.Now())
+		if err = s.playsRepo.IncrementPlayCounts(ctx, event.TrackID, listenedAt); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// RunDedupCleanup forgets the events older than the dedup window on start and then
+// every interval, until the context is done.
+func (s *ListeningStatService) RunDedupCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultDedupCleanupInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := s.dedupRepo.DeleteExpired(ctx); err != nil {
+			slog.Error("failed to delete expired listening events", "err", err)
+		} else {
+			slog.Debug("expired listening events deleted", "count", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (ListeningStatService) proccessListeningEvent(segments []*entity.Segment, event *entity.ListeningEvent) ([]int, int) {
 	totalDuration := 0
 	segLength := segments[0].Range.Len()
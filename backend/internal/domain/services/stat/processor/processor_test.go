@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/processor"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 	"github.com/hahaclassic/orpheon/backend/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -44,6 +46,12 @@ func (ListeningStatObjectMother) DefaultListeningEvent(trackID, userID uuid.UUID
 	}
 }
 
+func (m ListeningStatObjectMother) IdentifiedListeningEvent(trackID, userID uuid.UUID, start, end int) *entity.ListeningEvent {
+	event := m.DefaultListeningEvent(trackID, userID, start, end)
+	event.EventID = uuid.New()
+	return event
+}
+
 type ListeningStatServiceSuite struct {
 	suite.Suite
 
@@ -51,6 +59,9 @@ type ListeningStatServiceSuite struct {
 	service     *processor.ListeningStatService
 	trackRepo   *mocks.TrackStatRepository
 	segmentRepo *mocks.SegmentStatRepository
+	dedupRepo   *mocks.EventDedupRepository
+	playsRepo   *mocks.PlayCountRepository
+	transactor  *mocks.Transactor
 
 	objMother *ListeningStatObjectMother
 }
@@ -59,7 +70,13 @@ func (s *ListeningStatServiceSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.trackRepo = mocks.NewTrackStatRepository(s.T())
 	s.segmentRepo = mocks.NewSegmentStatRepository(s.T())
-	s.service = processor.NewListeningStatService(s.trackRepo, s.segmentRepo)
+	s.dedupRepo = mocks.NewEventDedupRepository(s.T())
+	s.playsRepo = mocks.NewPlayCountRepository(s.T())
+	s.transactor = mocks.NewTransactor(s.T())
+	s.transactor.On("WithinTransaction", s.ctx, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	s.service = processor.NewListeningStatService(s.trackRepo, s.segmentRepo, s.dedupRepo, s.playsRepo, s.transactor)
 	s.objMother = &ListeningStatObjectMother{}
 }
 
@@ -72,6 +89,7 @@ func (s *ListeningStatServiceSuite) TestUpdateStat_SuccessWithTrackIncrement() {
 	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(segments, nil)
 	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
 	s.trackRepo.On("IncrementTrackTotalStreams", s.ctx, trackID).Return(nil)
+	s.playsRepo.On("IncrementPlayCounts", s.ctx, trackID, mock.Anything).Return(nil)
 
 	err := s.service.UpdateStat(s.ctx, event)
 
@@ -92,6 +110,7 @@ func (s *ListeningStatServiceSuite) TestUpdateStat_SuccessWithoutTrackIncrement(
 	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(segments, nil)
 	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
 	s.trackRepo.On("IncrementTrackTotalStreams", s.ctx, trackID).Return(nil) // добавить этот мок
+	s.playsRepo.On("IncrementPlayCounts", s.ctx, trackID, mock.Anything).Return(nil)
 
 	err := s.service.UpdateStat(s.ctx, event)
 
@@ -112,6 +131,21 @@ func (s *ListeningStatServiceSuite) TestUpdateStat_GetSegmentsError() {
 	s.segmentRepo.AssertExpectations(s.T())
 }
 
+func (s *ListeningStatServiceSuite) TestUpdateStat_NoSegments() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.DefaultListeningEvent(trackID, userID, 0, 20)
+
+	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return([]*entity.Segment{}, nil)
+
+	err := s.service.UpdateStat(s.ctx, event)
+
+	s.ErrorIs(err, processor.ErrNoSegments)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+	s.segmentRepo.AssertNotCalled(s.T(), "IncrementTotalStreams", mock.Anything, mock.Anything, mock.Anything)
+	s.trackRepo.AssertNotCalled(s.T(), "IncrementTrackTotalStreams", mock.Anything, mock.Anything)
+}
+
 func (s *ListeningStatServiceSuite) TestUpdateStat_IncrementSegmentsError() {
 	trackID := s.objMother.DefaultTrackID()
 	userID := s.objMother.DefaultUserID()
@@ -143,3 +177,123 @@ func (s *ListeningStatServiceSuite) TestUpdateStat_IncrementTrackError() {
 	s.segmentRepo.AssertExpectations(s.T())
 	s.trackRepo.AssertExpectations(s.T())
 }
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_CountsNewEvent() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.IdentifiedListeningEvent(trackID, userID, 0, 35)
+
+	s.dedupRepo.On("Claim", s.ctx, userID, event.EventID).Return(true, nil)
+	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(s.objMother.DefaultSegments(trackID), nil)
+	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
+	s.trackRepo.On("IncrementTrackTotalStreams", s.ctx, trackID).Return(nil)
+	s.playsRepo.On("IncrementPlayCounts", s.ctx, trackID, mock.Anything).Return(nil)
+
+	err := s.service.UpdateStat(s.ctx, event)
+
+	s.NoError(err)
+}
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_SkipsDuplicateEvent() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.IdentifiedListeningEvent(trackID, userID, 0, 35)
+
+	s.dedupRepo.On("Claim", s.ctx, userID, event.EventID).Return(false, nil)
+
+	err := s.service.UpdateStat(s.ctx, event)
+
+	s.NoError(err)
+	s.segmentRepo.AssertNotCalled(s.T(), "GetSegments", mock.Anything, mock.Anything)
+	s.trackRepo.AssertNotCalled(s.T(), "IncrementTrackTotalStreams", mock.Anything, mock.Anything)
+}
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_FailsClaimedEventInTransaction() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.IdentifiedListeningEvent(trackID, userID, 0, 35)
+	dbErr := errors.New("db error")
+
+	s.dedupRepo.On("Claim", s.ctx, userID, event.EventID).Return(true, nil)
+	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(s.objMother.DefaultSegments(trackID), nil)
+	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
+	s.trackRepo.On("IncrementTrackTotalStreams", s.ctx, trackID).Return(nil)
+	s.playsRepo.On("IncrementPlayCounts", s.ctx, trackID, mock.Anything).Return(dbErr)
+
+	err := s.service.UpdateStat(s.ctx, event)
+
+	// the claim is rolled back with the increments, so the retried event is counted once
+	s.ErrorIs(err, dbErr)
+	s.transactor.AssertNumberOfCalls(s.T(), "WithinTransaction", 1)
+}
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_ClaimError() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.IdentifiedListeningEvent(trackID, userID, 0, 35)
+
+	s.dedupRepo.On("Claim", s.ctx, userID, event.EventID).Return(false, errors.New("redis error"))
+
+	err := s.service.UpdateStat(s.ctx, event)
+
+	s.Error(err)
+	s.segmentRepo.AssertNotCalled(s.T(), "GetSegments", mock.Anything, mock.Anything)
+}
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_CountsPlayAtListeningTime() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.DefaultListeningEvent(trackID, userID, 0, 35)
+	event.ListenedAt = time.Date(2025, 6, 1, 10, 30, 0, 0, time.UTC)
+	event.ReceivedAt = event.ListenedAt.Add(time.Hour)
+
+	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(s.objMother.DefaultSegments(trackID), nil)
+	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
+	s.trackRepo.On("IncrementTrackTotalStreams", s.ctx, trackID).Return(nil)
+	s.playsRepo.On("IncrementPlayCounts", s.ctx, trackID, event.ListenedAt).Return(nil)
+
+	s.NoError(s.service.UpdateStat(s.ctx, event))
+}
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_CountsFuturePlayNow() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.DefaultListeningEvent(trackID, userID, 0, 35)
+	event.ListenedAt = time.Now().Add(24 * time.Hour)
+
+	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(s.objMother.DefaultSegments(trackID), nil)
+	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
+	s.trackRepo.On("IncrementTrackTotalStreams", s.ctx, trackID).Return(nil)
+	s.playsRepo.On("IncrementPlayCounts", s.ctx, trackID, mock.MatchedBy(func(at time.Time) bool {
+		return !at.After(time.Now())
+	})).Return(nil)
+
+	s.NoError(s.service.UpdateStat(s.ctx, event))
+}
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_CountsHeldBackPlayWithinWindow() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.DefaultListeningEvent(trackID, userID, 0, 35)
+	event.ReceivedAt = time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	event.ListenedAt = event.ReceivedAt.AddDate(-1, 0, 0)
+
+	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(s.objMother.DefaultSegments(trackID), nil)
+	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
+	s.trackRepo.On("IncrementTrackTotalStreams", s.ctx, trackID).Return(nil)
+	s.playsRepo.On("IncrementPlayCounts", s.ctx, trackID, event.ReceivedAt.Add(-entity.MaxListenedAtAge)).Return(nil)
+
+	s.NoError(s.service.UpdateStat(s.ctx, event))
+}
+
+func (s *ListeningStatServiceSuite) TestUpdateStat_SkipsPlayCountsBelowMinimum() {
+	trackID := s.objMother.DefaultTrackID()
+	userID := s.objMother.DefaultUserID()
+	event := s.objMother.DefaultListeningEvent(trackID, userID, 0, 20)
+
+	s.segmentRepo.On("GetSegments", s.ctx, trackID).Return(s.objMother.DefaultSegments(trackID), nil)
+	s.segmentRepo.On("IncrementTotalStreams", s.ctx, trackID, mock.Anything).Return(nil)
+
+	s.NoError(s.service.UpdateStat(s.ctx, event))
+	s.playsRepo.AssertNotCalled(s.T(), "IncrementPlayCounts", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -3,6 +3,7 @@ package publisher
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	usecase "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
@@ -36,9 +37,11 @@ func (p *ListeningEventPublisher) PublishListeningEvent(ctx context.Context, eve
 		}
 	}()
 
-	if totalDuration(event) < MinTotalDuration {
+	if event.TotalSeconds() < MinTotalDuration {
 		return ErrShortListeningTime
 	}
+	// the listening time set by the client is counted relative to it
+	event.ReceivedAt = time.Now()
 
 	if err = p.bus.Publish(ctx, event); err != nil {
 		return err
@@ -46,12 +49,3 @@ func (p *ListeningEventPublisher) PublishListeningEvent(ctx context.Context, eve
 
 	return nil
 }
-
-func totalDuration(event *entity.ListeningEvent) int {
-	total := 0
-	for _, r := range event.Ranges {
-		total += r.End - r.Start
-	}
-
-	return total
-}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -88,3 +89,17 @@ func TestListeningEventPublisher_PublishListeningEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestListeningEventPublisher_SetsReceivedAt(t *testing.T) {
+	bus := mocks.NewEventBusPub(t)
+	bus.On("Publish", mock.Anything, mock.MatchedBy(func(event *entity.ListeningEvent) bool {
+		return !event.ReceivedAt.IsZero()
+	})).Return(nil).Once()
+
+	event := &entity.ListeningEvent{
+		TrackID:    uuid.New(),
+		ListenedAt: time.Now().Add(-time.Hour),
+		Ranges:     []*entity.Range{{Start: 0, End: 20}},
+	}
+	assert.NoError(t, New(bus).PublishListeningEvent(context.Background(), event))
+}
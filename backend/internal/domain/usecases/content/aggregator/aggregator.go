@@ -11,12 +11,15 @@ import (
 var (
 	ErrGetTracksAggregated      = errors.New("failed to get tracks aggregated")
 	ErrGetTracksAggregatedByIDs = errors.New("failed to get tracks aggregated by ids")
+	ErrGetExistingTracksByIDs   = errors.New("failed to get existing tracks aggregated by ids")
 	ErrGetAlbumsAggregated      = errors.New("failed to get albums aggregated")
 	ErrGetAlbumsAggregatedByIDs = errors.New("failed to get albums aggregated by ids")
 )
 
 type ContentAggregator interface {
 	GetTracksByIDs(ctx context.Context, trackIDs ...uuid.UUID) (_ []*entity.TrackMetaAggregated, err error)
+	// GetExistingTracksByIDs leaves out the tracks that are not found, e.g. deleted after their IDs were read.
+	GetExistingTracksByIDs(ctx context.Context, trackIDs ...uuid.UUID) (_ []*entity.TrackMetaAggregated, err error)
 	GetAlbumsByIDs(ctx context.Context, albumIDs ...uuid.UUID) (_ []*entity.AlbumMetaAggregated, err error)
 	GetAlbums(ctx context.Context, albums ...*entity.AlbumMeta) (_ []*entity.AlbumMetaAggregated, err error)
 	GetTracks(ctx context.Context, tracks ...*entity.TrackMeta) (_ []*entity.TrackMetaAggregated, err error)
@@ -15,7 +15,7 @@ var (
 )
 
 type AlbumCoverService interface {
-	GetCover(ctx context.Context, albumID uuid.UUID) (*entity.Cover, error)
+	GetCover(ctx context.Context, albumID uuid.UUID, size int) (*entity.Cover, error)
 	// Admin
 	UploadCover(ctx context.Context, claims *entity.Claims, cover *entity.Cover) error
 	DeleteCover(ctx context.Context, claims *entity.Claims, albumID uuid.UUID) error
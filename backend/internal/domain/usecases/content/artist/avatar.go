@@ -15,7 +15,7 @@ var (
 )
 
 type ArtistAvatarService interface {
-	GetCover(ctx context.Context, artistID uuid.UUID) (*entity.Cover, error)
+	GetCover(ctx context.Context, artistID uuid.UUID, size int) (*entity.Cover, error)
 	// Admin
 	UploadCover(ctx context.Context, claims *entity.Claims, cover *entity.Cover) error
 	DeleteCover(ctx context.Context, claims *entity.Claims, artistID uuid.UUID) error
@@ -15,7 +15,7 @@ var (
 )
 
 type PlaylistCoverService interface {
-	GetCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) (*entity.Cover, error)
+	GetCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID, size int) (*entity.Cover, error)
 	UploadCover(ctx context.Context, claims *entity.Claims, cover *entity.Cover) error
 	DeleteCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) error
 }
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var ErrMigrateStorage = errors.New("failed to migrate storage")
+
+type StorageMigrationService interface {
+	// MigrateStorage copies the stored content of one backend to another. Items already
+	// in the sink are skipped, so an interrupted migration is resumed by running it again.
+	MigrateStorage(ctx context.Context, claims *entity.Claims, opts *entity.StorageMigrationOptions) (*entity.StorageMigrationReport, error)
+}
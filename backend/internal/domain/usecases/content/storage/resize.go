@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var ErrResizeCovers = errors.New("failed to resize covers")
+
+type CoverResizeService interface {
+	// ResizeCovers stores the covers uploaded before resizing in every one of entity.CoverSizes.
+	// Covers already resized are skipped, so an interrupted backfill is resumed by running it again.
+	ResizeCovers(ctx context.Context, claims *entity.Claims) (*entity.CoverResizeReport, error)
+}
@@ -0,0 +1,21 @@
+package stream
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrIssueStreamToken  = errors.New("issue stream token error")
+	ErrVerifyStreamToken = errors.New("verify stream token error")
+)
+
+type StreamTokenService interface {
+	IssueToken(ctx context.Context, claims *entity.Claims, resource entity.StreamResource,
+		resourceID uuid.UUID, quality entity.AudioQuality) (*entity.StreamToken, error)
+	VerifyToken(ctx context.Context, claims *entity.Claims, token string, resource entity.StreamResource,
+		resourceID uuid.UUID, quality entity.AudioQuality) (*entity.StreamGrant, error)
+}
@@ -3,20 +3,22 @@ package track
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 )
 
 var (
-	ErrGetAudioChunk   = errors.New("failed to get audio chunk")
+	ErrGetAudioFile    = errors.New("failed to get audio file")
 	ErrUploadAudioFile = errors.New("failed to upload audio file")
 	ErrDeleteAudioFile = errors.New("failed to delete audio file")
 )
 
 type AudioFileService interface {
-	GetAudioChunk(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error)
+	// GetAudioFile opens the stored file for reading, the caller must close the content.
+	GetAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)
 	// Admin
-	UploadAudioFile(ctx context.Context, claims *entity.Claims, chunk *entity.AudioChunk) error
+	UploadAudioFile(ctx context.Context, claims *entity.Claims, file *entity.AudioFile, content io.Reader) error
 	DeleteAudioFile(ctx context.Context, claims *entity.Claims, trackID uuid.UUID) error
 }
@@ -0,0 +1,23 @@
+package track
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrGetHLSRenditions    = errors.New("failed to get hls renditions")
+	ErrGetHLSMediaPlaylist = errors.New("failed to get hls media playlist")
+	ErrGetHLSSegment       = errors.New("failed to get hls segment")
+)
+
+type HLSService interface {
+	GetRenditions(ctx context.Context, trackID uuid.UUID) ([]*entity.HLSRendition, error)
+	GetMediaPlaylist(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.HLSMediaPlaylist, error)
+	// GetSegment opens the byte range of the segment for reading, the caller must close the content.
+	GetSegment(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality, segmentIdx int) (*entity.HLSSegment, io.ReadSeekCloser, error)
+}
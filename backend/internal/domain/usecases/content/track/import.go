@@ -0,0 +1,20 @@
+package track
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrImportTrack = errors.New("failed to import track")
+)
+
+type TrackImportService interface {
+	// Admin
+	// ImportTrack creates or matches the track, its album, artist and genre
+	// from the tags of the file and uploads the audio.
+	ImportTrack(ctx context.Context, claims *entity.Claims, params *entity.TrackImportParams, content io.Reader) (*entity.TrackImportReport, error)
+}
@@ -0,0 +1,20 @@
+package track
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAnalyzeLoudness     = errors.New("failed to analyze loudness")
+	ErrUpdateAlbumLoudness = errors.New("failed to update album loudness")
+)
+
+type TrackLoudnessService interface {
+	// AnalyzeLoudness measures the stored original and updates the loudness of the track and of its album.
+	AnalyzeLoudness(ctx context.Context, trackID uuid.UUID) error
+	// UpdateAlbumLoudness recomputes the album loudness from the analyzed tracks left in the album.
+	UpdateAlbumLoudness(ctx context.Context, albumID uuid.UUID) error
+}
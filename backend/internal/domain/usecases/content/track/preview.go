@@ -0,0 +1,18 @@
+package track
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var ErrGetPreview = errors.New("failed to get preview")
+
+type TrackPreviewService interface {
+	// GetPreview returns an MP3 clip of the given length around the most listened part of the track.
+	GetPreview(ctx context.Context, trackID uuid.UUID, length time.Duration) (*entity.PreviewClip, io.ReadCloser, error)
+}
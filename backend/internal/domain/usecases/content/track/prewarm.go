@@ -0,0 +1,15 @@
+package track
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var ErrPrewarmAudioCache = errors.New("failed to prewarm audio cache")
+
+type AudioCachePrewarmService interface {
+	// PrewarmAudioCache copies the audio of the most streamed tracks to the local cache.
+	PrewarmAudioCache(ctx context.Context, claims *entity.Claims, tracks int) (*entity.AudioCacheStats, error)
+}
@@ -0,0 +1,16 @@
+package track
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var ErrScrubAudio = errors.New("failed to scrub audio storage")
+
+type AudioScrubService interface {
+	// ScrubAudio re-hashes every stored blob and checks the blobs against the files of tracks.
+	// With quarantine set, broken and orphaned blobs are moved aside and unlinked from their tracks.
+	ScrubAudio(ctx context.Context, claims *entity.Claims, quarantine bool) (*entity.BlobScrubReport, error)
+}
@@ -0,0 +1,22 @@
+package track
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrGenerateSeekTables = errors.New("failed to generate seek tables")
+	ErrResolveSeekOffset  = errors.New("failed to resolve seek offset")
+)
+
+type TrackSeekService interface {
+	// GenerateSeekTables indexes the frames of every stored MP3 quality of the track.
+	GenerateSeekTables(ctx context.Context, trackID uuid.UUID) error
+	// ResolveOffset returns the byte offset of the frame playing at the given time.
+	ResolveOffset(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality, at time.Duration) (int64, error)
+}
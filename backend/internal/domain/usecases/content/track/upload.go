@@ -0,0 +1,26 @@
+package track
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrCreateAudioUpload   = errors.New("failed to create audio upload")
+	ErrGetAudioUpload      = errors.New("failed to get audio upload")
+	ErrUploadAudioChunk    = errors.New("failed to upload audio chunk")
+	ErrCompleteAudioUpload = errors.New("failed to complete audio upload")
+	ErrAbortAudioUpload    = errors.New("failed to abort audio upload")
+)
+
+type AudioUploadService interface {
+	// Admin
+	CreateUpload(ctx context.Context, claims *entity.Claims, trackID uuid.UUID, size int64) (*entity.AudioUpload, error)
+	GetUpload(ctx context.Context, claims *entity.Claims, trackID uuid.UUID, uploadID uuid.UUID) (*entity.AudioUpload, error)
+	UploadChunk(ctx context.Context, claims *entity.Claims, uploadID uuid.UUID, chunk *entity.AudioChunk) (*entity.AudioUpload, error)
+	CompleteUpload(ctx context.Context, claims *entity.Claims, trackID uuid.UUID, uploadID uuid.UUID) error
+	AbortUpload(ctx context.Context, claims *entity.Claims, trackID uuid.UUID, uploadID uuid.UUID) error
+}
@@ -0,0 +1,20 @@
+package track
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrGetWaveform      = errors.New("failed to get waveform")
+	ErrGenerateWaveform = errors.New("failed to generate waveform")
+)
+
+type TrackWaveformService interface {
+	GetWaveform(ctx context.Context, trackID uuid.UUID, points int) (*entity.Waveform, error)
+	// GenerateWaveform computes the peaks of the stored original at every resolution.
+	GenerateWaveform(ctx context.Context, trackID uuid.UUID) error
+}
@@ -0,0 +1,24 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrGetTrackChart       = errors.New("failed to get track chart")
+	ErrGetWeeklyChart      = errors.New("failed to get weekly chart")
+	ErrSnapshotWeeklyChart = errors.New("failed to snapshot weekly chart")
+)
+
+type ChartService interface {
+	// GetTrackChart returns the most played tracks of the period, filtered by genre and artist country.
+	GetTrackChart(ctx context.Context, req *entity.ChartRequest) ([]*entity.ChartEntryAggregated, error)
+	// GetWeeklyChart returns the snapshot of the week containing the given time.
+	GetWeeklyChart(ctx context.Context, week time.Time) (*entity.WeeklyChartAggregated, error)
+	// SnapshotWeeklyChart saves the chart of a completed week, once.
+	SnapshotWeeklyChart(ctx context.Context, week time.Time) (*entity.WeeklyChart, error)
+}
@@ -0,0 +1,21 @@
+package stats
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrListDeadLetters   = errors.New("failed to list dead letters")
+	ErrReplayDeadLetters = errors.New("failed to replay dead letters")
+)
+
+type DeadLetterService interface {
+	// ListDeadLetters returns at most limit dead letters, all of them if limit is not positive.
+	ListDeadLetters(ctx context.Context, claims *entity.Claims, limit int) ([]*entity.DeadLetter, error)
+	// ReplayDeadLetters publishes the events of the dead letters with the given IDs again,
+	// of all dead letters if no IDs are given. The letters replayed before are skipped.
+	ReplayDeadLetters(ctx context.Context, claims *entity.Claims, ids []string) (*entity.DeadLetterReplayReport, error)
+}
@@ -0,0 +1,26 @@
+package stats
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+)
+
+var (
+	ErrRecordListening     = errors.New("failed to record listening")
+	ErrGetListeningHistory = errors.New("failed to get listening history")
+	ErrClearHistory        = errors.New("failed to clear listening history")
+	ErrPauseHistory        = errors.New("failed to pause listening history")
+	ErrGetHistoryPaused    = errors.New("failed to get listening history pause")
+)
+
+type ListeningHistoryService interface {
+	// RecordListening skips the events of anonymous users and of users who paused their history.
+	RecordListening(ctx context.Context, event *entity.ListeningEvent) error
+	// GetHistory returns the recently played tracks, the latest first.
+	GetHistory(ctx context.Context, claims *entity.Claims, limit, offset int) ([]*entity.ListeningHistoryEntry, error)
+	ClearHistory(ctx context.Context, claims *entity.Claims) error
+	SetHistoryPaused(ctx context.Context, claims *entity.Claims, paused bool) error
+	IsHistoryPaused(ctx context.Context, claims *entity.Claims) (bool, error)
+}
@@ -12,5 +12,5 @@ var (
 )
 
 type ListeningEventPublisher interface {
-	SendListeningEvent(ctx context.Context, event *entity.ListeningEvent) error
+	PublishListeningEvent(ctx context.Context, event *entity.ListeningEvent) error
 }
@@ -19,7 +19,8 @@ func NewAlbumTrackRepository(pool *pgxpool.Pool) *AlbumTrackRepository {
 func (r *AlbumTrackRepository) GetAllTracks(ctx context.Context, albumID uuid.UUID) ([]*entity.TrackMeta, error) {
 	query := `
 		SELECT t.id, t.name, t.duration, t.explicit, t.license_id, t.album_id,
-			   t.track_number, t.total_streams, t.genre_id
+			   t.track_number, t.total_streams, t.genre_id, t.format, t.bitrate, t.sample_rate,
+			   t.loudness, t.true_peak, t.album_loudness, t.album_true_peak
 		FROM tracks t WHERE t.album_id = $1 ORDER BY t.track_number ASC
 	`
 	rows, err := r.pool.Query(ctx, query, albumID)
@@ -31,11 +32,15 @@ func (r *AlbumTrackRepository) GetAllTracks(ctx context.Context, albumID uuid.UU
 	tracks := make([]*entity.TrackMeta, 0)
 	for rows.Next() {
 		var track entity.TrackMeta
+		var loudness, truePeak, albumLoudness, albumTruePeak *float64
 		if err := rows.Scan(&track.ID, &track.Name, &track.Duration,
 			&track.Explicit, &track.LicenseID, &track.AlbumID,
-			&track.TrackNumber, &track.TotalStreams, &track.GenreID); err != nil {
+			&track.TrackNumber, &track.TotalStreams, &track.GenreID, &track.Format, &track.Bitrate, &track.SampleRate,
+			&loudness, &truePeak, &albumLoudness, &albumTruePeak); err != nil {
 			return nil, err
 		}
+		track.Loudness = entity.NewLoudness(loudness, truePeak)
+		track.AlbumLoudness = entity.NewLoudness(albumLoudness, albumTruePeak)
 		tracks = append(tracks, &track)
 	}
 
@@ -79,7 +79,8 @@ func (r *ArtistAssignRepository) GetArtistAlbums(ctx context.Context, artistID u
 
 func (r *ArtistAssignRepository) GetArtistTracks(ctx context.Context, artistID uuid.UUID) ([]*entity.TrackMeta, error) {
 	query := `
-		SELECT t.id, t.name, t.album_id, t.duration, t.explicit, t.license_id, t.genre_id, t.total_streams, t.track_number
+		SELECT t.id, t.name, t.album_id, t.duration, t.explicit, t.license_id, t.genre_id, t.total_streams, t.track_number, t.format, t.bitrate, t.sample_rate,
+			t.loudness, t.true_peak, t.album_loudness, t.album_true_peak
 		FROM tracks t
 		JOIN artist_tracks at ON t.id = at.track_id
 		WHERE at.artist_id = $1 ORDER BY t.total_streams DESC
@@ -93,11 +94,15 @@ func (r *ArtistAssignRepository) GetArtistTracks(ctx context.Context, artistID u
 	var tracks []*entity.TrackMeta
 	for rows.Next() {
 		var track entity.TrackMeta
+		var loudness, truePeak, albumLoudness, albumTruePeak *float64
 		err := rows.Scan(&track.ID, &track.Name, &track.AlbumID, &track.Duration, &track.Explicit,
-			&track.LicenseID, &track.GenreID, &track.TotalStreams, &track.TrackNumber)
+			&track.LicenseID, &track.GenreID, &track.TotalStreams, &track.TrackNumber, &track.Format, &track.Bitrate, &track.SampleRate,
+			&loudness, &truePeak, &albumLoudness, &albumTruePeak)
 		if err != nil {
 			return nil, fmt.Errorf("get artist tracks: %w", err)
 		}
+		track.Loudness = entity.NewLoudness(loudness, truePeak)
+		track.AlbumLoudness = entity.NewLoudness(albumLoudness, albumTruePeak)
 		tracks = append(tracks, &track)
 	}
 
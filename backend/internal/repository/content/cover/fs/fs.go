@@ -0,0 +1,244 @@
+package cover_fs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+// extensions keep the content type of a cover in its file name.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// CoverRepository keeps the covers of one kind of object, e.g. the album covers
+// or the artist avatars, in its own directory. Every size of a cover is in a file
+// named <object ID>_<size> with the extension of its content type, covers stored
+// before resizing are named by the object ID alone. Files stored without
+// an extension have their content type detected when read.
+type CoverRepository struct {
+	dir string
+}
+
+func NewCoverRepository(dir string) (*CoverRepository, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create covers directory: %w", err)
+	}
+
+	return &CoverRepository{dir: dir}, nil
+}
+
+func (r *CoverRepository) path(objectID uuid.UUID, size int) string {
+	if size == 0 {
+		return filepath.Join(r.dir, objectID.String())
+	}
+	return filepath.Join(r.dir, fmt.Sprintf("%s_%d", objectID, size))
+}
+
+// paths returns every file name the cover may be stored under, the name without
+// an extension is the last.
+func (r *CoverRepository) paths(objectID uuid.UUID, size int) []string {
+	base := r.path(objectID, size)
+	paths := make([]string, 0, len(extensions)+1)
+	for _, ext := range extensions {
+		paths = append(paths, base+ext)
+	}
+	slices.Sort(paths)
+
+	return append(paths, base)
+}
+
+// SaveCover writes the cover to a temporary file first, so readers never see a partially written one.
+func (r *CoverRepository) SaveCover(ctx context.Context, cover *entity.Cover) error {
+	return r.SaveCovers(ctx, []*entity.Cover{cover})
+}
+
+// SaveCovers writes every size before renaming any of them in place,
+// so a failed write leaves the previous cover whole.
+func (r *CoverRepository) SaveCovers(ctx context.Context, covers []*entity.Cover) error {
+	tmps := make([]string, 0, len(covers))
+	defer func() {
+		for _, tmp := range tmps {
+			if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+				slog.Error("failed to remove temporary file", "error", err)
+			}
+		}
+	}()
+
+	for _, cover := range covers {
+		tmp, err := r.writeTemp(cover)
+		if err != nil {
+			return err
+		}
+		tmps = append(tmps, tmp)
+	}
+
+	for i, cover := range covers {
+		if err := r.install(tmps[i], cover); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *CoverRepository) writeTemp(cover *entity.Cover) (string, error) {
+	tmp, err := os.CreateTemp(r.dir, ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("create temporary file: %w", err)
+	}
+
+	if _, err := tmp.Write(cover.Data); err != nil {
+		_ = tmp.Close()
+		return tmp.Name(), fmt.Errorf("write cover: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return tmp.Name(), fmt.Errorf("write cover: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// install renames the written cover in place and removes the files
+// of the same size stored with another content type.
+func (r *CoverRepository) install(tmp string, cover *entity.Cover) error {
+	contentType := cover.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(cover.Data)
+	}
+	path := r.path(cover.ObjectID, cover.Size) + extensions[contentType]
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("save cover: %w", err)
+	}
+
+	for _, stale := range r.paths(cover.ObjectID, cover.Size) {
+		if stale == path {
+			continue
+		}
+		if err := os.Remove(stale); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove replaced cover: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetCover falls back to the cover stored before resizing if the size is missing.
+// The SHA-256 of the cover is used as the ETag.
+func (r *CoverRepository) GetCover(ctx context.Context, objectID uuid.UUID, size int) (*entity.Cover, error) {
+	cover, err := r.readCover(objectID, size)
+	if err != nil && size != 0 && os.IsNotExist(err) {
+		cover, err = r.readCover(objectID, 0)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %v", commonerr.ErrNotFound, err)
+		}
+		return nil, err
+	}
+
+	return cover, nil
+}
+
+func (r *CoverRepository) readCover(objectID uuid.UUID, size int) (*entity.Cover, error) {
+	f, err := r.open(objectID, size)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			slog.Error("failed to close cover file", "error", err)
+		}
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat cover: %w", err)
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read cover: %w", err)
+	}
+
+	contentType := http.DetectContentType(data)
+	for stored, ext := range extensions {
+		if filepath.Ext(f.Name()) == ext {
+			contentType = stored
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	return &entity.Cover{
+		ObjectID:    objectID,
+		Size:        size,
+		ContentType: contentType,
+		Data:        data,
+		ETag:        strconv.Quote(hex.EncodeToString(sum[:])),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// open opens the first of the cover's files found.
+func (r *CoverRepository) open(objectID uuid.UUID, size int) (f *os.File, err error) {
+	for _, path := range r.paths(objectID, size) {
+		if f, err = os.Open(path); !os.IsNotExist(err) {
+			return f, err
+		}
+	}
+	return nil, err
+}
+
+// DeleteCover removes every size of the cover.
+func (r *CoverRepository) DeleteCover(ctx context.Context, objectID uuid.UUID) error {
+	for _, size := range append([]int{0}, entity.CoverSizes...) {
+		for _, path := range r.paths(objectID, size) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("delete cover: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ListCovers returns the IDs of the objects having a cover, files with other names are skipped.
+func (r *CoverRepository) ListCovers(ctx context.Context) ([]uuid.UUID, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("list covers: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(entries))
+	seen := make(map[uuid.UUID]struct{}, len(entries))
+	for _, e := range entries {
+		name, _, _ := strings.Cut(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())), "_")
+		id, err := uuid.Parse(name)
+		if err != nil || e.IsDir() {
+			continue
+		}
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,141 @@
+package cover_fs_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	cover_fs "github.com/hahaclassic/orpheon/backend/internal/repository/content/cover/fs"
+	"github.com/stretchr/testify/suite"
+)
+
+type CoverRepositorySuite struct {
+	suite.Suite
+	dir  string
+	repo *cover_fs.CoverRepository
+	ctx  context.Context
+}
+
+func TestCoverRepositorySuite(t *testing.T) {
+	suite.Run(t, new(CoverRepositorySuite))
+}
+
+func (s *CoverRepositorySuite) SetupTest() {
+	s.dir = filepath.Join(s.T().TempDir(), "album-covers")
+
+	var err error
+	s.repo, err = cover_fs.NewCoverRepository(s.dir)
+	s.Require().NoError(err)
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func (s *CoverRepositorySuite) Save(objectID uuid.UUID, size int, data string) {
+	s.Require().NoError(s.repo.SaveCover(s.ctx, &entity.Cover{ObjectID: objectID, Size: size, Data: []byte(data)}))
+}
+
+// Tests
+func (s *CoverRepositorySuite) TestNewCreatesDirectory() {
+	info, err := os.Stat(s.dir)
+	s.Require().NoError(err)
+	s.True(info.IsDir())
+}
+
+func (s *CoverRepositorySuite) TestSaveAndGetCover() {
+	id := uuid.New()
+	s.Save(id, 300, "small")
+	s.Save(id, 640, "large")
+
+	cover, err := s.repo.GetCover(s.ctx, id, 300)
+
+	s.Require().NoError(err)
+	s.Equal(id, cover.ObjectID)
+	s.Equal(300, cover.Size)
+	s.Equal([]byte("small"), cover.Data)
+	s.NotEmpty(cover.ETag)
+	s.False(cover.ModTime.IsZero())
+}
+
+func (s *CoverRepositorySuite) TestSaveReplacesCover() {
+	id := uuid.New()
+	s.Save(id, 300, "old")
+	old, err := s.repo.GetCover(s.ctx, id, 300)
+	s.Require().NoError(err)
+
+	s.Save(id, 300, "new")
+	cover, err := s.repo.GetCover(s.ctx, id, 300)
+
+	s.Require().NoError(err)
+	s.Equal([]byte("new"), cover.Data)
+	s.NotEqual(old.ETag, cover.ETag)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(s.dir)
+	s.Require().NoError(err)
+	s.Len(entries, 1)
+}
+
+func (s *CoverRepositorySuite) TestGetCoverFallsBackToUnresized() {
+	id := uuid.New()
+	s.Save(id, 0, "original")
+
+	cover, err := s.repo.GetCover(s.ctx, id, 300)
+
+	s.Require().NoError(err)
+	s.Equal(0, cover.Size)
+	s.Equal([]byte("original"), cover.Data)
+}
+
+func (s *CoverRepositorySuite) TestGetCoverNotFound() {
+	_, err := s.repo.GetCover(s.ctx, uuid.New(), 300)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+func (s *CoverRepositorySuite) TestDeleteCoverRemovesEverySize() {
+	id, other := uuid.New(), uuid.New()
+	s.Save(id, 0, "original")
+	for _, size := range entity.CoverSizes {
+		s.Save(id, size, "sized")
+	}
+	s.Save(other, 300, "other")
+
+	s.Require().NoError(s.repo.DeleteCover(s.ctx, id))
+
+	for _, size := range append([]int{0}, entity.CoverSizes...) {
+		_, err := s.repo.GetCover(s.ctx, id, size)
+		s.ErrorIs(err, commonerr.ErrNotFound)
+	}
+	_, err := s.repo.GetCover(s.ctx, other, 300)
+	s.NoError(err)
+
+	// deleting a missing cover is not an error
+	s.NoError(s.repo.DeleteCover(s.ctx, id))
+}
+
+func (s *CoverRepositorySuite) TestListCovers() {
+	a, b := uuid.New(), uuid.New()
+	s.Save(a, 0, "original")
+	s.Save(b, 300, "small")
+	s.Save(b, 640, "large")
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "README"), []byte("not a cover"), 0644))
+	s.Require().NoError(os.Mkdir(filepath.Join(s.dir, uuid.NewString()), 0755))
+
+	ids, err := s.repo.ListCovers(s.ctx)
+
+	s.Require().NoError(err)
+	s.ElementsMatch([]uuid.UUID{a, b}, ids)
+}
+
+func (s *CoverRepositorySuite) TestDirectoriesAreSeparate() {
+	avatars, err := cover_fs.NewCoverRepository(filepath.Join(filepath.Dir(s.dir), "artist-avatars"))
+	s.Require().NoError(err)
+	id := uuid.New()
+	s.Save(id, 300, "album cover")
+
+	_, err = avatars.GetCover(s.ctx, id, 300)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
@@ -0,0 +1,189 @@
+package cover_minio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/minio/minio-go/v7"
+)
+
+// stagingPrefix keeps the sizes of an upload until all of them are written.
+const stagingPrefix = ".staging/"
+
+// CoverRepository keeps the covers of one kind of object under its own prefix of the bucket,
+// every size of a cover as <prefix><object ID>_<size>. Covers stored before resizing
+// are named by the object ID alone.
+type CoverRepository struct {
+	client     *minio.Client
+	bucketName string
+	prefix     string
+}
+
+func NewCoverRepository(ctx context.Context, client *minio.Client, bucketName, prefix string) (*CoverRepository, error) {
+	exists, err := client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return nil, fmt.Errorf("check bucket: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("create bucket: %w", err)
+		}
+	}
+
+	return &CoverRepository{
+		client:     client,
+		bucketName: bucketName,
+		prefix:     prefix,
+	}, nil
+}
+
+func (r *CoverRepository) objectName(objectID uuid.UUID, size int) string {
+	if size == 0 {
+		return r.prefix + objectID.String()
+	}
+	return fmt.Sprintf("%s%s_%d", r.prefix, objectID, size)
+}
+
+func (r *CoverRepository) SaveCover(ctx context.Context, cover *entity.Cover) error {
+	return r.putObject(ctx, r.objectName(cover.ObjectID, cover.Size), cover)
+}
+
+// SaveCovers uploads every size to a staging name first and copies them in place
+// once all are uploaded, so a failed upload leaves the previous cover whole.
+func (r *CoverRepository) SaveCovers(ctx context.Context, covers []*entity.Cover) error {
+	staging := r.prefix + stagingPrefix + uuid.NewString() + "/"
+	defer func() {
+		for _, cover := range covers {
+			name := staging + strconv.Itoa(cover.Size)
+			if err := r.client.RemoveObject(ctx, r.bucketName, name, minio.RemoveObjectOptions{}); err != nil {
+				slog.Error("failed to remove staged cover", "name", name, "error", err)
+			}
+		}
+	}()
+
+	for _, cover := range covers {
+		if err := r.putObject(ctx, staging+strconv.Itoa(cover.Size), cover); err != nil {
+			return err
+		}
+	}
+
+	for _, cover := range covers {
+		_, err := r.client.CopyObject(ctx,
+			minio.CopyDestOptions{Bucket: r.bucketName, Object: r.objectName(cover.ObjectID, cover.Size)},
+			minio.CopySrcOptions{Bucket: r.bucketName, Object: staging + strconv.Itoa(cover.Size)},
+		)
+		if err != nil {
+			return fmt.Errorf("save cover: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *CoverRepository) putObject(ctx context.Context, name string, cover *entity.Cover) error {
+	contentType := cover.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(cover.Data)
+	}
+
+	_, err := r.client.PutObject(ctx, r.bucketName, name,
+		bytes.NewReader(cover.Data),
+		int64(len(cover.Data)),
+		minio.PutObjectOptions{ContentType: contentType},
+	)
+	if err != nil {
+		return fmt.Errorf("upload cover: %w", err)
+	}
+	return nil
+}
+
+// GetCover falls back to the cover stored before resizing if the size is missing.
+func (r *CoverRepository) GetCover(ctx context.Context, objectID uuid.UUID, size int) (*entity.Cover, error) {
+	cover, err := r.getObject(ctx, objectID, size)
+	if errors.Is(err, commonerr.ErrNotFound) && size != 0 {
+		return r.getObject(ctx, objectID, 0)
+	}
+	return cover, err
+}
+
+func (r *CoverRepository) getObject(ctx context.Context, objectID uuid.UUID, size int) (*entity.Cover, error) {
+	obj, err := r.client.GetObject(ctx, r.bucketName, r.objectName(objectID, size), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get cover object: %w", err)
+	}
+	defer func() {
+		if err := obj.Close(); err != nil {
+			slog.Error("failed to close cover object", "error", err)
+		}
+	}()
+
+	info, err := obj.Stat()
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: %v", commonerr.ErrNotFound, err)
+		}
+		return nil, fmt.Errorf("stat cover object: %w", err)
+	}
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, fmt.Errorf("read cover data: %w", err)
+	}
+
+	// covers uploaded before the content type was kept were stored as octet-stream
+	contentType := info.ContentType
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(data)
+	}
+
+	return &entity.Cover{
+		ObjectID:    objectID,
+		Size:        size,
+		ContentType: contentType,
+		Data:        data,
+		ETag:        strconv.Quote(info.ETag),
+		ModTime:     info.LastModified,
+	}, nil
+}
+
+// DeleteCover removes every size of the cover.
+func (r *CoverRepository) DeleteCover(ctx context.Context, objectID uuid.UUID) error {
+	for _, size := range append([]int{0}, entity.CoverSizes...) {
+		err := r.client.RemoveObject(ctx, r.bucketName, r.objectName(objectID, size), minio.RemoveObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("delete cover: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListCovers returns the IDs of the objects having a cover, other names are skipped.
+func (r *CoverRepository) ListCovers(ctx context.Context) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	seen := make(map[uuid.UUID]struct{})
+
+	for obj := range r.client.ListObjects(ctx, r.bucketName, minio.ListObjectsOptions{Prefix: r.prefix}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("list covers: %w", obj.Err)
+		}
+		name, _, _ := strings.Cut(strings.TrimPrefix(obj.Key, r.prefix), "_")
+		if id, err := uuid.Parse(name); err == nil {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
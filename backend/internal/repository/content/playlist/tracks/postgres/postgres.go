@@ -77,7 +77,8 @@ func (r *PlaylistTracksRepository) GetAllPlaylistTracks(ctx context.Context, pla
 	const query = `
 		SELECT 
 			t.id, t.genre_id, t.name, t.duration, t.explicit,
-			t.license_id, t.album_id, t.track_number, t.total_streams
+			t.license_id, t.album_id, t.track_number, t.total_streams, t.format, t.bitrate, t.sample_rate,
+			t.loudness, t.true_peak, t.album_loudness, t.album_true_peak
 		FROM playlist_tracks pt
 		JOIN tracks t ON pt.track_id = t.id
 		WHERE pt.playlist_id = $1
@@ -93,6 +94,7 @@ func (r *PlaylistTracksRepository) GetAllPlaylistTracks(ctx context.Context, pla
 	var tracks []*entity.TrackMeta
 	for rows.Next() {
 		var track entity.TrackMeta
+		var loudness, truePeak, albumLoudness, albumTruePeak *float64
 		if err := rows.Scan(
 			&track.ID,
 			&track.GenreID,
@@ -103,9 +105,18 @@ func (r *PlaylistTracksRepository) GetAllPlaylistTracks(ctx context.Context, pla
 			&track.AlbumID,
 			&track.TrackNumber,
 			&track.TotalStreams,
+			&track.Format,
+			&track.Bitrate,
+			&track.SampleRate,
+			&loudness,
+			&truePeak,
+			&albumLoudness,
+			&albumTruePeak,
 		); err != nil {
 			return nil, fmt.Errorf("scan track: %w", err)
 		}
+		track.Loudness = entity.NewLoudness(loudness, truePeak)
+		track.AlbumLoudness = entity.NewLoudness(albumLoudness, albumTruePeak)
 		tracks = append(tracks, &track)
 	}
 
@@ -19,7 +19,8 @@ func NewSearchRepository(db *pgxpool.Pool) *SearchRepository {
 
 func (r *SearchRepository) SearchTracks(ctx context.Context, req *entity.SearchRequest) ([]*entity.TrackMeta, error) {
 	query := `
-		SELECT t.id, t.genre_id, t.name, t.duration, t.explicit, t.license_id, t.album_id, t.track_number, t.total_streams
+		SELECT t.id, t.genre_id, t.name, t.duration, t.explicit, t.license_id, t.album_id, t.track_number, t.total_streams, t.format, t.bitrate, t.sample_rate,
+			t.loudness, t.true_peak, t.album_loudness, t.album_true_peak
 		FROM tracks t
 		LEFT JOIN artist_tracks at ON t.id = at.track_id
 		LEFT JOIN artists ar ON at.artist_id = ar.id
@@ -56,10 +57,14 @@ func (r *SearchRepository) SearchTracks(ctx context.Context, req *entity.SearchR
 	var tracks []*entity.TrackMeta
 	for rows.Next() {
 		var track entity.TrackMeta
-		err := rows.Scan(&track.ID, &track.GenreID, &track.Name, &track.Duration, &track.Explicit, &track.LicenseID, &track.AlbumID, &track.TrackNumber, &track.TotalStreams)
+		var loudness, truePeak, albumLoudness, albumTruePeak *float64
+		err := rows.Scan(&track.ID, &track.GenreID, &track.Name, &track.Duration, &track.Explicit, &track.LicenseID, &track.AlbumID, &track.TrackNumber, &track.TotalStreams, &track.Format, &track.Bitrate, &track.SampleRate,
+			&loudness, &truePeak, &albumLoudness, &albumTruePeak)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan track: %w", err)
 		}
+		track.Loudness = entity.NewLoudness(loudness, truePeak)
+		track.AlbumLoudness = entity.NewLoudness(albumLoudness, albumTruePeak)
 		tracks = append(tracks, &track)
 	}
 
@@ -0,0 +1,229 @@
+package audio_cas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+var ErrBlobReleased = errors.New("blob was released while the file was saved, upload it again")
+
+type BlobStore interface {
+	// WriteBlob stores the content under its SHA-256, content that is already stored is kept as is.
+	WriteBlob(ctx context.Context, content io.Reader, size int64) (*entity.AudioBlob, error)
+	OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error)
+	DeleteBlob(ctx context.Context, hash string) error
+	DeleteWaveforms(ctx context.Context, trackID uuid.UUID) error
+}
+
+type AudioFileIndex interface {
+	GetAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, error)
+	SaveAudioFile(ctx context.Context, file *entity.AudioFile, blobStored func(ctx context.Context) error) (string, error)
+	AddAudioFile(ctx context.Context, file *entity.AudioFile, blobStored func(ctx context.Context) error) (bool, error)
+	DeleteAudioFiles(ctx context.Context, trackID uuid.UUID) ([]string, error)
+	ReleaseBlob(ctx context.Context, hash string, deleteBlob func(ctx context.Context) error) error
+}
+
+// LegacyStorage keeps the files stored per track before the blobs.
+type LegacyStorage interface {
+	OpenLegacyFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadCloser, error)
+	ListLegacyFiles(ctx context.Context) ([]*entity.AudioFile, error)
+	DeleteLegacyFiles(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) error
+}
+
+// AudioFileRepository stores audio files as content addressed blobs, so
+// identical uploads are kept once. Files of tracks are mapped to blobs by the index.
+type AudioFileRepository struct {
+	blobs  BlobStore
+	index  AudioFileIndex
+	legacy LegacyStorage
+}
+
+type OptionFunc func(*AudioFileRepository)
+
+// WithLegacyFiles moves the files stored before the blobs into blobs when they are first opened.
+func WithLegacyFiles(legacy LegacyStorage) OptionFunc {
+	return func(r *AudioFileRepository) {
+		r.legacy = legacy
+	}
+}
+
+func New(blobs BlobStore, index AudioFileIndex, opts ...OptionFunc) *AudioFileRepository {
+	r := &AudioFileRepository{
+		blobs: blobs,
+		index: index,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *AudioFileRepository) UploadAudioFile(ctx context.Context, file *entity.AudioFile, content io.Reader) error {
+	blob, err := r.blobs.WriteBlob(ctx, content, file.Size)
+	if err != nil {
+		return err
+	}
+
+	stored := *file
+	stored.Hash = blob.Hash
+	stored.Size = blob.Size
+
+	previous, err := r.index.SaveAudioFile(ctx, &stored, r.blobStored(blob.Hash))
+	if err != nil {
+		return err
+	}
+	if previous != "" && previous != blob.Hash {
+		r.releaseBlob(ctx, previous)
+	}
+
+	// the legacy file would be adopted again once the new one is deleted
+	if r.legacy != nil {
+		if err := r.legacy.DeleteLegacyFiles(ctx, file.TrackID, file.Quality); err != nil {
+			slog.Error("failed to delete legacy file", "track_id", file.TrackID, "quality", file.Quality, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// OpenAudioFile uses the hash as the ETag, it changes only with the content.
+func (r *AudioFileRepository) OpenAudioFile(ctx context.Context, trackID uuid.UUID,
+	quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error) {
+	file, err := r.index.GetAudioFile(ctx, trackID, quality)
+	if errors.Is(err, commonerr.ErrNotFound) && r.legacy != nil {
+		file, err = r.adoptLegacyFile(ctx, trackID, quality)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blob, content, err := r.blobs.OpenBlob(ctx, file.Hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("blob of %s audio file of track %s: %w", quality, trackID, err)
+	}
+
+	file.Size = blob.Size
+	file.ETag = strconv.Quote(file.Hash)
+
+	return file, content, nil
+}
+
+func (r *AudioFileRepository) DeleteFile(ctx context.Context, trackID uuid.UUID) error {
+	hashes, err := r.index.DeleteAudioFiles(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		r.releaseBlob(ctx, hash)
+	}
+
+	if r.legacy != nil {
+		for _, quality := range entity.AudioQualities {
+			if err := r.legacy.DeleteLegacyFiles(ctx, trackID, quality); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.blobs.DeleteWaveforms(ctx, trackID)
+}
+
+// AdoptLegacyFiles moves every file stored before the blobs into a blob
+// and returns the number of files moved.
+func (r *AudioFileRepository) AdoptLegacyFiles(ctx context.Context) (int, error) {
+	if r.legacy == nil {
+		return 0, nil
+	}
+
+	files, err := r.legacy.ListLegacyFiles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	adopted := 0
+	for _, file := range files {
+		if _, err := r.adoptLegacyFile(ctx, file.TrackID, file.Quality); err != nil {
+			return adopted, err
+		}
+		adopted++
+	}
+
+	return adopted, nil
+}
+
+// adoptLegacyFile moves the legacy file into a blob, unless the track got a new file meanwhile.
+func (r *AudioFileRepository) adoptLegacyFile(ctx context.Context, trackID uuid.UUID,
+	quality entity.AudioQuality) (*entity.AudioFile, error) {
+	file, content, err := r.legacy.OpenLegacyFile(ctx, trackID, quality)
+	if errors.Is(err, commonerr.ErrNotFound) {
+		// moved by a concurrent adoption, if there was a file at all
+		return r.index.GetAudioFile(ctx, trackID, quality)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := content.Close(); err != nil {
+			slog.Error("failed to close legacy file", "error", err)
+		}
+	}()
+
+	blob, err := r.blobs.WriteBlob(ctx, content, file.Size)
+	if err != nil {
+		return nil, err
+	}
+	file.Hash = blob.Hash
+	file.Size = blob.Size
+
+	added, err := r.index.AddAudioFile(ctx, file, r.blobStored(blob.Hash))
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		r.releaseBlob(ctx, blob.Hash)
+	}
+
+	if err := r.legacy.DeleteLegacyFiles(ctx, trackID, quality); err != nil {
+		slog.Error("failed to delete legacy file", "track_id", trackID, "quality", quality, "error", err)
+	}
+	slog.Info("legacy audio file moved into a blob", "track_id", trackID, "quality", quality, "hash", blob.Hash)
+
+	return r.index.GetAudioFile(ctx, trackID, quality)
+}
+
+// blobStored reports whether the blob is still there once it is locked, a blob
+// found by WriteBlob may have been released before the file pointed to it.
+func (r *AudioFileRepository) blobStored(hash string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, content, err := r.blobs.OpenBlob(ctx, hash)
+		if errors.Is(err, commonerr.ErrNotFound) {
+			return ErrBlobReleased
+		}
+		if err != nil {
+			return err
+		}
+
+		return content.Close()
+	}
+}
+
+// releaseBlob deletes the blob once no file points to it. Failures only leave
+// an orphaned blob behind, which the scrub reports, so they are logged.
+func (r *AudioFileRepository) releaseBlob(ctx context.Context, hash string) {
+	err := r.index.ReleaseBlob(ctx, hash, func(ctx context.Context) error {
+		return r.blobs.DeleteBlob(ctx, hash)
+	})
+	if err != nil {
+		slog.Error("failed to release blob", "hash", hash, "error", err)
+	}
+}
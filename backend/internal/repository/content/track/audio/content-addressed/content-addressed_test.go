@@ -0,0 +1,244 @@
+package audio_cas_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	audio_cas "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/content-addressed"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AudioFileRepositorySuite struct {
+	suite.Suite
+	repo    *audio_cas.AudioFileRepository
+	blobs   *mocks.BlobStore
+	index   *mocks.AudioFileIndex
+	legacy  *mocks.LegacyStorage
+	ctx     context.Context
+	trackID uuid.UUID
+}
+
+func TestAudioFileRepositorySuite(t *testing.T) {
+	suite.Run(t, new(AudioFileRepositorySuite))
+}
+
+func (s *AudioFileRepositorySuite) SetupTest() {
+	s.blobs = mocks.NewBlobStore(s.T())
+	s.index = mocks.NewAudioFileIndex(s.T())
+	s.legacy = mocks.NewLegacyStorage(s.T())
+	s.repo = audio_cas.New(s.blobs, s.index)
+	s.ctx = context.Background()
+	s.trackID = uuid.New()
+}
+
+// Object Mother
+const (
+	hashA = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	hashB = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+type content struct {
+	*bytes.Reader
+}
+
+func (content) Close() error {
+	return nil
+}
+
+func (s *AudioFileRepositorySuite) File() *entity.AudioFile {
+	return &entity.AudioFile{
+		TrackID: s.trackID,
+		Quality: entity.QualityOriginal,
+		Format:  entity.FormatMP3,
+		Size:    -1,
+	}
+}
+
+func (s *AudioFileRepositorySuite) expectWrite(hash string, previous string) {
+	s.blobs.On("WriteBlob", mock.Anything, mock.Anything, int64(-1)).Return(&entity.AudioBlob{Hash: hash, Size: 5}, nil)
+	s.index.On("SaveAudioFile", mock.Anything, mock.MatchedBy(func(file *entity.AudioFile) bool {
+		return file.Hash == hash && file.Size == 5 && file.TrackID == s.trackID
+	}), mock.Anything).Return(previous, nil)
+}
+
+// expectRelease deletes the blob only when no file points to it.
+func (s *AudioFileRepositorySuite) expectRelease(hash string, referenced bool) {
+	s.index.On("ReleaseBlob", mock.Anything, hash, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		if !referenced {
+			s.NoError(args.Get(2).(func(context.Context) error)(s.ctx))
+		}
+	})
+}
+
+// UploadAudioFile
+func (s *AudioFileRepositorySuite) TestUploadAudioFile() {
+	s.expectWrite(hashA, "")
+
+	err := s.repo.UploadAudioFile(s.ctx, s.File(), bytes.NewReader([]byte("audio")))
+	s.NoError(err)
+}
+
+func (s *AudioFileRepositorySuite) TestUploadAudioFileReleasesPreviousBlob() {
+	s.expectWrite(hashA, hashB)
+	s.expectRelease(hashB, false)
+	s.blobs.On("DeleteBlob", mock.Anything, hashB).Return(nil)
+
+	err := s.repo.UploadAudioFile(s.ctx, s.File(), bytes.NewReader([]byte("audio")))
+	s.NoError(err)
+}
+
+func (s *AudioFileRepositorySuite) TestUploadAudioFileKeepsSharedBlob() {
+	s.expectWrite(hashA, hashB)
+	s.expectRelease(hashB, true)
+
+	err := s.repo.UploadAudioFile(s.ctx, s.File(), bytes.NewReader([]byte("audio")))
+	s.NoError(err)
+	s.blobs.AssertNotCalled(s.T(), "DeleteBlob", mock.Anything, mock.Anything)
+}
+
+func (s *AudioFileRepositorySuite) TestUploadAudioFileSameContent() {
+	s.expectWrite(hashA, hashA)
+
+	err := s.repo.UploadAudioFile(s.ctx, s.File(), bytes.NewReader([]byte("audio")))
+	s.NoError(err)
+	s.index.AssertNotCalled(s.T(), "ReleaseBlob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AudioFileRepositorySuite) TestUploadAudioFileBlobReleased() {
+	s.blobs.On("WriteBlob", mock.Anything, mock.Anything, int64(-1)).Return(&entity.AudioBlob{Hash: hashA, Size: 5}, nil)
+	s.blobs.On("OpenBlob", mock.Anything, hashA).Return(nil, nil, commonerr.ErrNotFound)
+	s.index.On("SaveAudioFile", mock.Anything, mock.Anything, mock.Anything).Return("", audio_cas.ErrBlobReleased).
+		Run(func(args mock.Arguments) {
+			s.ErrorIs(args.Get(2).(func(context.Context) error)(s.ctx), audio_cas.ErrBlobReleased)
+		})
+
+	err := s.repo.UploadAudioFile(s.ctx, s.File(), bytes.NewReader([]byte("audio")))
+	s.ErrorIs(err, audio_cas.ErrBlobReleased)
+}
+
+func (s *AudioFileRepositorySuite) TestUploadAudioFileWriteError() {
+	s.blobs.On("WriteBlob", mock.Anything, mock.Anything, int64(-1)).Return(nil, errors.New("disk full"))
+
+	err := s.repo.UploadAudioFile(s.ctx, s.File(), bytes.NewReader([]byte("audio")))
+	s.Error(err)
+}
+
+// OpenAudioFile
+func (s *AudioFileRepositorySuite) TestOpenAudioFile() {
+	file := s.File()
+	file.Hash = hashA
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(file, nil)
+	s.blobs.On("OpenBlob", mock.Anything, hashA).Return(&entity.AudioBlob{Hash: hashA, Size: 5}, content{bytes.NewReader([]byte("audio"))}, nil)
+
+	res, data, err := s.repo.OpenAudioFile(s.ctx, s.trackID, entity.QualityOriginal)
+	s.Require().NoError(err)
+	s.NotNil(data)
+	s.Equal(int64(5), res.Size)
+	s.Equal(`"`+hashA+`"`, res.ETag)
+	s.Equal(entity.FormatMP3, res.Format)
+}
+
+func (s *AudioFileRepositorySuite) TestOpenAudioFileNotFound() {
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityHigh).Return(nil, commonerr.ErrNotFound)
+
+	_, _, err := s.repo.OpenAudioFile(s.ctx, s.trackID, entity.QualityHigh)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+func (s *AudioFileRepositorySuite) TestOpenAudioFileMissingBlob() {
+	file := s.File()
+	file.Hash = hashA
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(file, nil)
+	s.blobs.On("OpenBlob", mock.Anything, hashA).Return(nil, nil, commonerr.ErrNotFound)
+
+	_, _, err := s.repo.OpenAudioFile(s.ctx, s.trackID, entity.QualityOriginal)
+	s.ErrorIs(err, commonerr.ErrNotFound)
+}
+
+func (s *AudioFileRepositorySuite) TestOpenAudioFileAdoptsLegacyFile() {
+	s.repo = audio_cas.New(s.blobs, s.index, audio_cas.WithLegacyFiles(s.legacy))
+	legacy := s.File()
+	legacy.Size = 5
+	adopted := s.File()
+	adopted.Hash = hashA
+
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil, commonerr.ErrNotFound).Once()
+	s.legacy.On("OpenLegacyFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(legacy, content{bytes.NewReader([]byte("audio"))}, nil)
+	s.blobs.On("WriteBlob", mock.Anything, mock.Anything, int64(5)).Return(&entity.AudioBlob{Hash: hashA, Size: 5}, nil)
+	s.index.On("AddAudioFile", mock.Anything, mock.MatchedBy(func(file *entity.AudioFile) bool {
+		return file.Hash == hashA && file.Format == entity.FormatMP3
+	}), mock.Anything).Return(true, nil)
+	s.legacy.On("DeleteLegacyFiles", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil)
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(adopted, nil)
+	s.blobs.On("OpenBlob", mock.Anything, hashA).Return(&entity.AudioBlob{Hash: hashA, Size: 5}, content{bytes.NewReader([]byte("audio"))}, nil)
+
+	res, _, err := s.repo.OpenAudioFile(s.ctx, s.trackID, entity.QualityOriginal)
+	s.Require().NoError(err)
+	s.Equal(hashA, res.Hash)
+}
+
+func (s *AudioFileRepositorySuite) TestOpenAudioFileLegacyAdoptedConcurrently() {
+	s.repo = audio_cas.New(s.blobs, s.index, audio_cas.WithLegacyFiles(s.legacy))
+	file := s.File()
+	file.Hash = hashB
+
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil, commonerr.ErrNotFound).Once()
+	s.legacy.On("OpenLegacyFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil, nil, commonerr.ErrNotFound)
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(file, nil)
+	s.blobs.On("OpenBlob", mock.Anything, hashB).Return(&entity.AudioBlob{Hash: hashB, Size: 5}, content{bytes.NewReader([]byte("audio"))}, nil)
+
+	res, _, err := s.repo.OpenAudioFile(s.ctx, s.trackID, entity.QualityOriginal)
+	s.Require().NoError(err)
+	s.Equal(hashB, res.Hash)
+	s.blobs.AssertNotCalled(s.T(), "WriteBlob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AudioFileRepositorySuite) TestAdoptLegacyFilesKeepsNewerUpload() {
+	s.repo = audio_cas.New(s.blobs, s.index, audio_cas.WithLegacyFiles(s.legacy))
+	legacy := s.File()
+	legacy.Size = 5
+
+	s.legacy.On("ListLegacyFiles", mock.Anything).Return([]*entity.AudioFile{legacy}, nil)
+	s.legacy.On("OpenLegacyFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(legacy, content{bytes.NewReader([]byte("audio"))}, nil)
+	s.blobs.On("WriteBlob", mock.Anything, mock.Anything, int64(5)).Return(&entity.AudioBlob{Hash: hashA, Size: 5}, nil)
+	s.index.On("AddAudioFile", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	s.expectRelease(hashA, false)
+	s.blobs.On("DeleteBlob", mock.Anything, hashA).Return(nil)
+	s.legacy.On("DeleteLegacyFiles", mock.Anything, s.trackID, entity.QualityOriginal).Return(nil)
+	s.index.On("GetAudioFile", mock.Anything, s.trackID, entity.QualityOriginal).Return(&entity.AudioFile{Hash: hashB}, nil)
+
+	adopted, err := s.repo.AdoptLegacyFiles(s.ctx)
+	s.NoError(err)
+	s.Equal(1, adopted)
+}
+
+// DeleteFile
+func (s *AudioFileRepositorySuite) TestDeleteFile() {
+	s.index.On("DeleteAudioFiles", mock.Anything, s.trackID).Return([]string{hashA, hashB}, nil)
+	s.expectRelease(hashA, false)
+	s.expectRelease(hashB, true)
+	s.blobs.On("DeleteBlob", mock.Anything, hashA).Return(nil)
+	s.blobs.On("DeleteWaveforms", mock.Anything, s.trackID).Return(nil)
+
+	err := s.repo.DeleteFile(s.ctx, s.trackID)
+	s.NoError(err)
+	s.blobs.AssertNotCalled(s.T(), "DeleteBlob", mock.Anything, hashB)
+}
+
+func (s *AudioFileRepositorySuite) TestDeleteFileDeletesLegacyFiles() {
+	s.repo = audio_cas.New(s.blobs, s.index, audio_cas.WithLegacyFiles(s.legacy))
+	s.index.On("DeleteAudioFiles", mock.Anything, s.trackID).Return(nil, nil)
+	s.legacy.On("DeleteLegacyFiles", mock.Anything, s.trackID, mock.Anything).Return(nil)
+	s.blobs.On("DeleteWaveforms", mock.Anything, s.trackID).Return(nil)
+
+	err := s.repo.DeleteFile(s.ctx, s.trackID)
+	s.NoError(err)
+	s.legacy.AssertNumberOfCalls(s.T(), "DeleteLegacyFiles", len(entity.AudioQualities))
+}
@@ -2,108 +2,167 @@ package audio_fs
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
-	"math"
 	"os"
 	"path/filepath"
 
-	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 )
 
-type AudioFileRepository struct {
+const (
+	blobsDir      = "blobs"
+	quarantineDir = "quarantine"
+)
+
+// AudioStorage keeps audio content as blobs named by their SHA-256, along with
+// upload sessions and waveforms.
+type AudioStorage struct {
 	baseDir string
 }
 
-func NewAudioFileRepository(baseDir string) (*AudioFileRepository, error) {
+func NewAudioStorage(baseDir string) (*AudioStorage, error) {
 	if err := os.MkdirAll(baseDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create base directory: %w", err)
 	}
-	return &AudioFileRepository{
+	return &AudioStorage{
 		baseDir: baseDir,
 	}, nil
 }
 
-func (r *AudioFileRepository) filePath(trackID uuid.UUID) string {
-	return filepath.Join(r.baseDir, fmt.Sprintf("%s.mp3", trackID.String()))
+// Blobs are spread over subdirectories by the first byte of the hash,
+// so no directory grows too large.
+func (r *AudioStorage) blobPath(hash string) string {
+	return filepath.Join(r.baseDir, blobsDir, hash[:2], hash)
 }
 
-func (r *AudioFileRepository) UploadAudioFile(ctx context.Context, chunk *entity.AudioChunk) error {
-	path := r.filePath(chunk.TrackID)
-
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+// WriteBlob writes the content to a temporary file first, so readers never
+// see a partially written blob. Content that is already stored is not replaced.
+func (r *AudioStorage) WriteBlob(ctx context.Context, content io.Reader, size int64) (*entity.AudioBlob, error) {
+	tmp, err := os.CreateTemp(r.baseDir, ".upload-*")
 	if err != nil {
-		return fmt.Errorf("failed to open file for writing: %w", err)
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
 	}
-	defer f.Close()
+	defer func() {
+		if err := os.Remove(tmp.Name()); err != nil && !os.IsNotExist(err) {
+			slog.Error("failed to remove temporary file", "error", err)
+		}
+	}()
 
-	if _, err := f.Seek(chunk.Start, io.SeekStart); err != nil {
-		return fmt.Errorf("failed to seek: %w", err)
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), content); err != nil {
+		_ = tmp.Close()
+		return nil, fmt.Errorf("failed to write file: %w", err)
 	}
 
-	if _, err := f.Write(chunk.Data); err != nil {
-		return fmt.Errorf("failed to write chunk: %w", err)
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write file: %w", err)
 	}
 
-	return nil
+	path := r.blobPath(hex.EncodeToString(hash.Sum(nil)))
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create blob directory: %w", err)
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			return nil, fmt.Errorf("failed to save file: %w", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return blob(path, info), nil
 }
 
-func (r *AudioFileRepository) GetAudioChunk(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error) {
-	path := r.filePath(chunk.TrackID)
+func (r *AudioStorage) OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error) {
+	if !entity.IsBlobHash(hash) {
+		return nil, nil, fmt.Errorf("%w: blob %q", commonerr.ErrNotFound, hash)
+	}
 
-	f, err := os.Open(path)
+	f, err := os.Open(r.blobPath(hash))
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file for reading: %w", err)
-	}
-	defer func() {
-		if err := f.Close(); err != nil {
-			slog.Error("failed to close file", "error", err)
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("%w: %v", commonerr.ErrNotFound, err)
 		}
-	}()
+		return nil, nil, fmt.Errorf("failed to open file for reading: %w", err)
+	}
 
-	if _, err := f.Seek(chunk.Start, io.SeekStart); err != nil {
-		return nil, fmt.Errorf("failed to seek: %w", err)
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("failed to stat file: %w", err)
 	}
 
-	size := chunk.End - chunk.Start
-	if size <= 0 {
-		return nil, fmt.Errorf("invalid chunk range: start=%d, end=%d", chunk.Start, chunk.End)
+	return blob(f.Name(), info), f, nil
+}
+
+func (r *AudioStorage) DeleteBlob(ctx context.Context, hash string) error {
+	if !entity.IsBlobHash(hash) {
+		return nil
+	}
+
+	if err := os.Remove(r.blobPath(hash)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
 	}
 
-	var data []byte
-	if chunk.End == math.MaxInt64 {
-		data, err = io.ReadAll(f)
+	return nil
+}
+
+// ListBlobs returns the hashes of all stored blobs, files with other names are skipped.
+func (r *AudioStorage) ListBlobs(ctx context.Context) ([]string, error) {
+	hashes := make([]string, 0)
+
+	err := filepath.WalkDir(filepath.Join(r.baseDir, blobsDir), func(path string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to read chunk: %w", err)
+			return err
 		}
-	} else {
-		data = make([]byte, size)
-		limitReader := io.LimitReader(f, size)
-		_, err = limitReader.Read(data)
-		if err != nil && err != io.ErrUnexpectedEOF {
-			return nil, fmt.Errorf("failed to read chunk: %w", err)
+		if !d.IsDir() && entity.IsBlobHash(d.Name()) {
+			hashes = append(hashes, d.Name())
 		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
 	}
 
-	return &entity.AudioChunk{
-		Data:    data,
-		TrackID: chunk.TrackID,
-		Start:   chunk.Start,
-		End:     chunk.Start + int64(len(data)),
-	}, nil
+	return hashes, nil
 }
 
-func (r *AudioFileRepository) DeleteFile(ctx context.Context, trackID uuid.UUID) error {
-	path := r.filePath(trackID)
+// QuarantineBlob moves the blob aside for inspection, it is no longer served.
+func (r *AudioStorage) QuarantineBlob(ctx context.Context, hash string) error {
+	if !entity.IsBlobHash(hash) {
+		return fmt.Errorf("%w: blob %q", commonerr.ErrNotFound, hash)
+	}
+
+	if err := os.MkdirAll(filepath.Join(r.baseDir, quarantineDir), 0755); err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
 
-	if err := os.Remove(path); err != nil {
+	if err := os.Rename(r.blobPath(hash), filepath.Join(r.baseDir, quarantineDir, hash)); err != nil {
 		if os.IsNotExist(err) {
-			return nil
+			return fmt.Errorf("%w: %v", commonerr.ErrNotFound, err)
 		}
-		return fmt.Errorf("failed to delete file: %w", err)
+		return fmt.Errorf("failed to quarantine blob: %w", err)
 	}
 
 	return nil
 }
+
+func blob(path string, info os.FileInfo) *entity.AudioBlob {
+	return &entity.AudioBlob{
+		Hash:    filepath.Base(path),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+}
@@ -0,0 +1,101 @@
+package audio_fs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+// Files stored before the blobs are kept in the base directory as <trackID>[_quality].<ext>,
+// until they are moved into blobs.
+func (r *AudioStorage) legacyPath(trackID uuid.UUID, quality entity.AudioQuality, format entity.AudioFormat) string {
+	return filepath.Join(r.baseDir, entity.LegacyAudioName(trackID, quality)+format.Extension())
+}
+
+// OpenLegacyFile looks the file up among all supported formats, since the
+// extension of an original depends on what was uploaded.
+func (r *AudioStorage) OpenLegacyFile(ctx context.Context, trackID uuid.UUID,
+	quality entity.AudioQuality) (*entity.AudioFile, io.ReadCloser, error) {
+	for _, format := range entity.AudioFormats {
+		f, err := os.Open(r.legacyPath(trackID, quality, format))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open legacy file: %w", err)
+		}
+
+		info, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, fmt.Errorf("failed to stat legacy file: %w", err)
+		}
+
+		return &entity.AudioFile{
+			TrackID: trackID,
+			Quality: quality,
+			Format:  format,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		}, f, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: legacy %s audio file of track %s", commonerr.ErrNotFound, quality, trackID)
+}
+
+func (r *AudioStorage) ListLegacyFiles(ctx context.Context) ([]*entity.AudioFile, error) {
+	entries, err := os.ReadDir(r.baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legacy files: %w", err)
+	}
+
+	files := make([]*entity.AudioFile, 0)
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		format := entity.AudioFormat(strings.TrimPrefix(ext, "."))
+		if e.IsDir() || !slices.Contains(entity.AudioFormats, format) {
+			continue
+		}
+
+		trackID, quality, ok := entity.ParseLegacyAudioName(strings.TrimSuffix(e.Name(), ext))
+		if !ok {
+			continue
+		}
+
+		info, err := e.Info()
+		if os.IsNotExist(err) {
+			continue // moved into a blob meanwhile
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat legacy file: %w", err)
+		}
+
+		files = append(files, &entity.AudioFile{
+			TrackID: trackID,
+			Quality: quality,
+			Format:  format,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	return files, nil
+}
+
+func (r *AudioStorage) DeleteLegacyFiles(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) error {
+	for _, format := range entity.AudioFormats {
+		if err := os.Remove(r.legacyPath(trackID, quality, format)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete legacy file: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package audio_fs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+const uploadsDir = "uploads"
+
+// Every upload session is kept as a pair of files: <id>.json with the session
+// itself and <id>.part with the bytes received so far.
+func (r *AudioStorage) uploadPath(uploadID uuid.UUID, ext string) string {
+	return filepath.Join(r.baseDir, uploadsDir, uploadID.String()+ext)
+}
+
+func (r *AudioStorage) CreateUpload(ctx context.Context, upload *entity.AudioUpload) error {
+	if err := os.MkdirAll(filepath.Join(r.baseDir, uploadsDir), 0755); err != nil {
+		return fmt.Errorf("failed to create uploads directory: %w", err)
+	}
+
+	meta, err := json.Marshal(upload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload: %w", err)
+	}
+
+	if err := os.WriteFile(r.uploadPath(upload.ID, ".part"), nil, 0644); err != nil {
+		return fmt.Errorf("failed to create upload file: %w", err)
+	}
+
+	if err := os.WriteFile(r.uploadPath(upload.ID, ".json"), meta, 0644); err != nil {
+		return fmt.Errorf("failed to save upload: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AudioStorage) GetUpload(ctx context.Context, uploadID uuid.UUID) (*entity.AudioUpload, error) {
+	meta, err := os.ReadFile(r.uploadPath(uploadID, ".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: upload %s", commonerr.ErrNotFound, uploadID)
+		}
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	var upload entity.AudioUpload
+	if err := json.Unmarshal(meta, &upload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal upload: %w", err)
+	}
+
+	info, err := os.Stat(r.uploadPath(uploadID, ".part"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat upload file: %w", err)
+	}
+	upload.Offset = info.Size()
+
+	return &upload, nil
+}
+
+func (r *AudioStorage) WriteUploadChunk(ctx context.Context, upload *entity.AudioUpload, chunk *entity.AudioChunk) error {
+	f, err := os.OpenFile(r.uploadPath(upload.ID, ".part"), os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(chunk.Start, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek: %w", err)
+	}
+
+	if _, err := f.Write(chunk.Data); err != nil {
+		return fmt.Errorf("failed to write chunk: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AudioStorage) CompleteUpload(ctx context.Context, upload *entity.AudioUpload) (io.ReadCloser, error) {
+	f, err := os.Open(r.uploadPath(upload.ID, ".part"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload file: %w", err)
+	}
+
+	return f, nil
+}
+
+func (r *AudioStorage) DeleteUpload(ctx context.Context, uploadID uuid.UUID) error {
+	for _, ext := range []string{".json", ".part"} {
+		if err := os.Remove(r.uploadPath(uploadID, ext)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete upload: %w", err)
+		}
+	}
+
+	return nil
+}
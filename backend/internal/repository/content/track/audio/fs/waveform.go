@@ -0,0 +1,64 @@
+package audio_fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+// Waveforms are stored next to the audio as raw peaks, one byte per point.
+func (r *AudioStorage) waveformPath(trackID uuid.UUID, points int) string {
+	return filepath.Join(r.baseDir, fmt.Sprintf("%s_waveform_%d.peaks", trackID, points))
+}
+
+func (r *AudioStorage) SaveWaveform(ctx context.Context, waveform *entity.Waveform) error {
+	tmp, err := os.CreateTemp(r.baseDir, ".waveform-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(waveform.Peaks); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write waveform: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write waveform: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), r.waveformPath(waveform.TrackID, waveform.Points)); err != nil {
+		return fmt.Errorf("failed to save waveform: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AudioStorage) GetWaveform(ctx context.Context, trackID uuid.UUID, points int) (*entity.Waveform, error) {
+	peaks, err := os.ReadFile(r.waveformPath(trackID, points))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: waveform of track %s", commonerr.ErrNotFound, trackID)
+		}
+		return nil, fmt.Errorf("failed to read waveform: %w", err)
+	}
+
+	return &entity.Waveform{
+		TrackID: trackID,
+		Points:  points,
+		Peaks:   peaks,
+	}, nil
+}
+
+func (r *AudioStorage) DeleteWaveforms(ctx context.Context, trackID uuid.UUID) error {
+	for _, points := range entity.WaveformResolutions {
+		if err := os.Remove(r.waveformPath(trackID, points)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete waveform: %w", err)
+		}
+	}
+	return nil
+}
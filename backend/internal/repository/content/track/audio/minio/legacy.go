@@ -0,0 +1,81 @@
+package audio_minio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/minio/minio-go/v7"
+)
+
+// Objects stored before the blobs are named <trackID>[_quality] at the top of the bucket,
+// until they are moved into blobs. Their format is kept in the content type.
+func (r *AudioStorage) OpenLegacyFile(ctx context.Context, trackID uuid.UUID,
+	quality entity.AudioQuality) (*entity.AudioFile, io.ReadCloser, error) {
+	obj, err := r.minioClient.GetObject(ctx, r.bucketName, entity.LegacyAudioName(trackID, quality), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get legacy file: %w", err)
+	}
+
+	info, err := obj.Stat()
+	if err != nil {
+		if err := obj.Close(); err != nil {
+			slog.Error("failed to close object", "error", err)
+		}
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil, fmt.Errorf("%w: legacy %s audio file of track %s", commonerr.ErrNotFound, quality, trackID)
+		}
+		return nil, nil, fmt.Errorf("failed to stat legacy file: %w", err)
+	}
+
+	format, err := entity.ParseAudioFormatMIME(info.ContentType)
+	if err != nil {
+		format = entity.FormatMP3 // objects uploaded before format detection
+	}
+
+	return &entity.AudioFile{
+		TrackID: trackID,
+		Quality: quality,
+		Format:  format,
+		Size:    info.Size,
+		ModTime: info.LastModified,
+	}, obj, nil
+}
+
+func (r *AudioStorage) ListLegacyFiles(ctx context.Context) ([]*entity.AudioFile, error) {
+	files := make([]*entity.AudioFile, 0)
+
+	// not recursive, so blobs, uploads and the other prefixes are left out
+	for obj := range r.minioClient.ListObjects(ctx, r.bucketName, minio.ListObjectsOptions{}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list legacy files: %w", obj.Err)
+		}
+
+		trackID, quality, ok := entity.ParseLegacyAudioName(obj.Key)
+		if !ok {
+			continue
+		}
+
+		files = append(files, &entity.AudioFile{
+			TrackID: trackID,
+			Quality: quality,
+			Size:    obj.Size,
+			ModTime: obj.LastModified,
+		})
+	}
+
+	return files, nil
+}
+
+func (r *AudioStorage) DeleteLegacyFiles(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) error {
+	err := r.minioClient.RemoveObject(ctx, r.bucketName, entity.LegacyAudioName(trackID, quality), minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete legacy file: %w", err)
+	}
+
+	return nil
+}
@@ -1,24 +1,34 @@
 package audio_minio
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log/slog"
-	"math"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
 	"github.com/minio/minio-go/v7"
 )
 
-type AudioFileRepository struct {
+const (
+	blobsPrefix      = "blobs/"
+	quarantinePrefix = "quarantine/"
+	tmpPrefix        = "tmp/"
+)
+
+// AudioStorage keeps audio content as blobs named by their SHA-256, along with
+// upload sessions and waveforms.
+type AudioStorage struct {
 	minioClient *minio.Client
 	bucketName  string
 }
 
-func NewAudioFileRepository(ctx context.Context, client *minio.Client, bucketName string) (*AudioFileRepository, error) {
+func NewAudioStorage(ctx context.Context, client *minio.Client, bucketName string) (*AudioStorage, error) {
 	exists, err := client.BucketExists(ctx, bucketName)
 	if err != nil {
 		return nil, fmt.Errorf("check bucket: %w", err)
@@ -29,66 +39,129 @@ func NewAudioFileRepository(ctx context.Context, client *minio.Client, bucketNam
 		}
 	}
 
-	return &AudioFileRepository{
+	return &AudioStorage{
 		minioClient: client,
 		bucketName:  bucketName,
 	}, nil
 }
 
-func (r *AudioFileRepository) UploadAudioFile(ctx context.Context, chunk *entity.AudioChunk) error {
-	objectName := chunk.TrackID.String()
+func blobObjectName(hash string) string {
+	return blobsPrefix + hash
+}
 
-	_, err := r.minioClient.PutObject(ctx, r.bucketName, objectName,
-		bytes.NewReader(chunk.Data), int64(len(chunk.Data)),
-		minio.PutObjectOptions{ContentType: "audio/mpeg"})
+// WriteBlob uploads the content under a temporary name while hashing it, then
+// copies it to its blob on the server side. Content that is already stored is not copied.
+func (r *AudioStorage) WriteBlob(ctx context.Context, content io.Reader, size int64) (*entity.AudioBlob, error) {
+	tmpName := tmpPrefix + uuid.NewString()
+	defer func() {
+		if err := r.minioClient.RemoveObject(ctx, r.bucketName, tmpName, minio.RemoveObjectOptions{}); err != nil {
+			slog.Error("failed to remove temporary object", "error", err)
+		}
+	}()
+
+	hash := sha256.New()
+	_, err := r.minioClient.PutObject(ctx, r.bucketName, tmpName, io.TeeReader(content, hash), size,
+		minio.PutObjectOptions{ContentType: "application/octet-stream"})
 	if err != nil {
-		return fmt.Errorf("failed to upload audio file: %w", err)
+		return nil, fmt.Errorf("failed to upload audio file: %w", err)
 	}
 
-	return nil
-}
+	name := blobObjectName(hex.EncodeToString(hash.Sum(nil)))
+	info, err := r.minioClient.StatObject(ctx, r.bucketName, name, minio.StatObjectOptions{})
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		_, err = r.minioClient.CopyObject(ctx,
+			minio.CopyDestOptions{Bucket: r.bucketName, Object: name},
+			minio.CopySrcOptions{Bucket: r.bucketName, Object: tmpName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save audio file: %w", err)
+		}
+		info, err = r.minioClient.StatObject(ctx, r.bucketName, name, minio.StatObjectOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat audio file: %w", err)
+	}
 
-func (r *AudioFileRepository) GetAudioChunk(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error) {
-	objectName := chunk.TrackID.String()
+	return blob(info), nil
+}
 
-	opts := minio.GetObjectOptions{}
-	if err := opts.SetRange(chunk.Start, chunk.End-1); err != nil {
-		return nil, fmt.Errorf("invalid range: %w", err)
+// OpenBlob returns the object itself as the content, so it is streamed
+// from MinIO on demand and seeking issues ranged requests.
+func (r *AudioStorage) OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error) {
+	if !entity.IsBlobHash(hash) {
+		return nil, nil, fmt.Errorf("%w: blob %q", commonerr.ErrNotFound, hash)
 	}
 
-	obj, err := r.minioClient.GetObject(ctx, r.bucketName, objectName, opts)
+	obj, err := r.minioClient.GetObject(ctx, r.bucketName, blobObjectName(hash), minio.GetObjectOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get audio chunk: %w", err)
+		return nil, nil, fmt.Errorf("failed to get audio file: %w", err)
 	}
-	defer func() {
-		err := obj.Close()
-		if err != nil {
-			slog.Error("err", "object close error", err)
+
+	info, err := obj.Stat()
+	if err != nil {
+		if err := obj.Close(); err != nil {
+			slog.Error("failed to close object", "error", err)
 		}
-	}()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil, fmt.Errorf("%w: %v", commonerr.ErrNotFound, err)
+		}
+		return nil, nil, fmt.Errorf("failed to stat audio file: %w", err)
+	}
 
-	data, err := io.ReadAll(obj)
+	return blob(info), obj, nil
+}
+
+func (r *AudioStorage) DeleteBlob(ctx context.Context, hash string) error {
+	if !entity.IsBlobHash(hash) {
+		return nil
+	}
+
+	err := r.minioClient.RemoveObject(ctx, r.bucketName, blobObjectName(hash), minio.RemoveObjectOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read chunk: %w", err)
+		return fmt.Errorf("failed to delete file: %w", err)
 	}
 
-	if chunk.End == math.MaxInt64 {
-		chunk.End = int64(len(data))
+	return nil
+}
+
+// ListBlobs returns the hashes of all stored blobs, objects with other names are skipped.
+func (r *AudioStorage) ListBlobs(ctx context.Context) ([]string, error) {
+	hashes := make([]string, 0)
+
+	for obj := range r.minioClient.ListObjects(ctx, r.bucketName, minio.ListObjectsOptions{Prefix: blobsPrefix}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list blobs: %w", obj.Err)
+		}
+		if hash := strings.TrimPrefix(obj.Key, blobsPrefix); entity.IsBlobHash(hash) {
+			hashes = append(hashes, hash)
+		}
 	}
 
-	return &entity.AudioChunk{
-		Data:    data,
-		TrackID: chunk.TrackID,
-		Start:   chunk.Start,
-		End:     chunk.End,
-	}, nil
+	return hashes, nil
 }
 
-func (r *AudioFileRepository) DeleteFile(ctx context.Context, trackID uuid.UUID) error {
-	objectName := trackID.String()
-	err := r.minioClient.RemoveObject(ctx, r.bucketName, objectName, minio.RemoveObjectOptions{})
+// QuarantineBlob moves the blob aside for inspection, it is no longer served.
+func (r *AudioStorage) QuarantineBlob(ctx context.Context, hash string) error {
+	if !entity.IsBlobHash(hash) {
+		return fmt.Errorf("%w: blob %q", commonerr.ErrNotFound, hash)
+	}
+
+	_, err := r.minioClient.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: r.bucketName, Object: quarantinePrefix + hash},
+		minio.CopySrcOptions{Bucket: r.bucketName, Object: blobObjectName(hash)})
 	if err != nil {
-		return fmt.Errorf("failed to delete file: %w", err)
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return fmt.Errorf("%w: %v", commonerr.ErrNotFound, err)
+		}
+		return fmt.Errorf("failed to quarantine blob: %w", err)
+	}
+
+	return r.DeleteBlob(ctx, hash)
+}
+
+func blob(info minio.ObjectInfo) *entity.AudioBlob {
+	return &entity.AudioBlob{
+		Hash:    strings.TrimPrefix(info.Key, blobsPrefix),
+		Size:    info.Size,
+		ModTime: info.LastModified,
 	}
-	return nil
 }
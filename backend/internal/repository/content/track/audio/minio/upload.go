@@ -0,0 +1,212 @@
+package audio_minio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/minio/minio-go/v7"
+)
+
+const uploadsPrefix = "uploads/"
+
+// uploadMeta is stored next to the multipart upload, since S3 does not keep
+// custom metadata of uploads that are still in progress.
+type uploadMeta struct {
+	entity.AudioUpload
+	MultipartID string `json:"multipart_id"`
+}
+
+func uploadObjectName(uploadID uuid.UUID) string {
+	return uploadsPrefix + uploadID.String()
+}
+
+func uploadMetaObjectName(uploadID uuid.UUID) string {
+	return uploadsPrefix + uploadID.String() + ".json"
+}
+
+func (r *AudioStorage) core() minio.Core {
+	return minio.Core{Client: r.minioClient}
+}
+
+func (r *AudioStorage) CreateUpload(ctx context.Context, upload *entity.AudioUpload) error {
+	multipartID, err := r.core().NewMultipartUpload(ctx, r.bucketName, uploadObjectName(upload.ID),
+		minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		return fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+
+	meta, err := json.Marshal(&uploadMeta{AudioUpload: *upload, MultipartID: multipartID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload: %w", err)
+	}
+
+	_, err = r.minioClient.PutObject(ctx, r.bucketName, uploadMetaObjectName(upload.ID),
+		bytes.NewReader(meta), int64(len(meta)),
+		minio.PutObjectOptions{ContentType: "application/json"})
+	if err != nil {
+		return fmt.Errorf("failed to save upload: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AudioStorage) GetUpload(ctx context.Context, uploadID uuid.UUID) (*entity.AudioUpload, error) {
+	meta, err := r.getUploadMeta(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := r.listUploadParts(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := meta.AudioUpload
+	upload.Offset = 0
+	for _, part := range parts {
+		upload.Offset += part.Size
+	}
+
+	return &upload, nil
+}
+
+func (r *AudioStorage) WriteUploadChunk(ctx context.Context, upload *entity.AudioUpload, chunk *entity.AudioChunk) error {
+	meta, err := r.getUploadMeta(ctx, upload.ID)
+	if err != nil {
+		return err
+	}
+
+	parts, err := r.listUploadParts(ctx, meta)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.core().PutObjectPart(ctx, r.bucketName, uploadObjectName(upload.ID), meta.MultipartID,
+		len(parts)+1, bytes.NewReader(chunk.Data), int64(len(chunk.Data)), minio.PutObjectPartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to upload part: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AudioStorage) CompleteUpload(ctx context.Context, upload *entity.AudioUpload) (io.ReadCloser, error) {
+	meta, err := r.getUploadMeta(ctx, upload.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.minioClient.StatObject(ctx, r.bucketName, uploadObjectName(upload.ID), minio.StatObjectOptions{}); err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+			return nil, fmt.Errorf("failed to stat upload: %w", err)
+		}
+
+		// not assembled yet, a retried completion skips this step
+		if err := r.completeMultipartUpload(ctx, meta); err != nil {
+			return nil, err
+		}
+	}
+
+	obj, err := r.minioClient.GetObject(ctx, r.bucketName, uploadObjectName(upload.ID), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+
+	return obj, nil
+}
+
+func (r *AudioStorage) DeleteUpload(ctx context.Context, uploadID uuid.UUID) error {
+	meta, err := r.getUploadMeta(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+
+	err = r.core().AbortMultipartUpload(ctx, r.bucketName, uploadObjectName(uploadID), meta.MultipartID)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchUpload" {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+
+	for _, objectName := range []string{uploadObjectName(uploadID), uploadMetaObjectName(uploadID)} {
+		if err := r.minioClient.RemoveObject(ctx, r.bucketName, objectName, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("failed to delete upload: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *AudioStorage) getUploadMeta(ctx context.Context, uploadID uuid.UUID) (*uploadMeta, error) {
+	obj, err := r.minioClient.GetObject(ctx, r.bucketName, uploadMetaObjectName(uploadID), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+	defer func() {
+		if err := obj.Close(); err != nil {
+			slog.Error("failed to close object", "error", err)
+		}
+	}()
+
+	var meta uploadMeta
+	if err := json.NewDecoder(obj).Decode(&meta); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: upload %s", commonerr.ErrNotFound, uploadID)
+		}
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	return &meta, nil
+}
+
+// listUploadParts returns the parts received so far. A multipart upload that has
+// already been assembled is reported as a single part of the whole object.
+func (r *AudioStorage) listUploadParts(ctx context.Context, meta *uploadMeta) ([]minio.ObjectPart, error) {
+	parts := make([]minio.ObjectPart, 0)
+
+	marker := 0
+	for {
+		result, err := r.core().ListObjectParts(ctx, r.bucketName, uploadObjectName(meta.ID), meta.MultipartID, marker, 0)
+		if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+			info, statErr := r.minioClient.StatObject(ctx, r.bucketName, uploadObjectName(meta.ID), minio.StatObjectOptions{})
+			if statErr != nil {
+				return nil, fmt.Errorf("failed to list upload parts: %w", err)
+			}
+			return []minio.ObjectPart{{PartNumber: 1, Size: info.Size, ETag: info.ETag}}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list upload parts: %w", err)
+		}
+
+		parts = append(parts, result.ObjectParts...)
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+func (r *AudioStorage) completeMultipartUpload(ctx context.Context, meta *uploadMeta) error {
+	parts, err := r.listUploadParts(ctx, meta)
+	if err != nil {
+		return err
+	}
+
+	completeParts := make([]minio.CompletePart, len(parts))
+	for i, part := range parts {
+		completeParts[i] = minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag}
+	}
+
+	_, err = r.core().CompleteMultipartUpload(ctx, r.bucketName, uploadObjectName(meta.ID), meta.MultipartID,
+		completeParts, minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+
+	return nil
+}
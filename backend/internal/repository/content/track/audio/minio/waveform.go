@@ -0,0 +1,66 @@
+package audio_minio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/minio/minio-go/v7"
+)
+
+// Waveforms are stored next to the audio as raw peaks, one byte per point.
+func waveformObjectName(trackID uuid.UUID, points int) string {
+	return fmt.Sprintf("%s_waveform_%d", trackID, points)
+}
+
+func (r *AudioStorage) SaveWaveform(ctx context.Context, waveform *entity.Waveform) error {
+	_, err := r.minioClient.PutObject(ctx, r.bucketName, waveformObjectName(waveform.TrackID, waveform.Points),
+		bytes.NewReader(waveform.Peaks), int64(len(waveform.Peaks)),
+		minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		return fmt.Errorf("failed to upload waveform: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AudioStorage) GetWaveform(ctx context.Context, trackID uuid.UUID, points int) (*entity.Waveform, error) {
+	obj, err := r.minioClient.GetObject(ctx, r.bucketName, waveformObjectName(trackID, points), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waveform: %w", err)
+	}
+	defer func() {
+		if err := obj.Close(); err != nil {
+			slog.Error("failed to close object", "error", err)
+		}
+	}()
+
+	peaks, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: %v", commonerr.ErrNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to read waveform: %w", err)
+	}
+
+	return &entity.Waveform{
+		TrackID: trackID,
+		Points:  points,
+		Peaks:   peaks,
+	}, nil
+}
+
+func (r *AudioStorage) DeleteWaveforms(ctx context.Context, trackID uuid.UUID) error {
+	for _, points := range entity.WaveformResolutions {
+		err := r.minioClient.RemoveObject(ctx, r.bucketName, waveformObjectName(trackID, points), minio.RemoveObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to delete waveform: %w", err)
+		}
+	}
+	return nil
+}
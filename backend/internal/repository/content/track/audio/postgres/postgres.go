@@ -0,0 +1,333 @@
+package audio_postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AudioFileIndex maps the files of tracks to the blobs holding their content.
+type AudioFileIndex struct {
+	pool *pgxpool.Pool
+}
+
+func NewAudioFileIndex(pool *pgxpool.Pool) *AudioFileIndex {
+	return &AudioFileIndex{pool: pool}
+}
+
+func (r *AudioFileIndex) GetAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, error) {
+	query := `
+		SELECT hash, format, size, created_at
+		FROM track_audio_files
+		WHERE track_id = $1 AND quality = $2
+	`
+
+	file := &entity.AudioFile{
+		TrackID: trackID,
+		Quality: quality,
+	}
+	err := r.pool.QueryRow(ctx, query, trackID, quality).Scan(&file.Hash, &file.Format, &file.Size, &file.ModTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s audio file of track %s", commonerr.ErrNotFound, quality, trackID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio file: %w", err)
+	}
+
+	return file, nil
+}
+
+// SaveAudioFile points the file to its blob and returns the hash it pointed to
+// before, empty if the file is new. blobStored is checked under the lock of the blob,
+// so the blob can't be released between the check and the save.
+func (r *AudioFileIndex) SaveAudioFile(ctx context.Context, file *entity.AudioFile,
+	blobStored func(ctx context.Context) error) (string, error) {
+	query := `
+		WITH previous AS (
+			SELECT hash FROM track_audio_files
+			WHERE track_id = $1 AND quality = $2
+		)
+		INSERT INTO track_audio_files (track_id, quality, hash, format, size)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (track_id, quality) DO UPDATE
+		SET hash = EXCLUDED.hash,
+			format = EXCLUDED.format,
+			size = EXCLUDED.size,
+			created_at = NOW()
+		RETURNING (SELECT hash FROM previous), created_at
+	`
+
+	var previous *string
+	err := r.withBlobLock(ctx, file.Hash, func(tx pgx.Tx) error {
+		if err := blobStored(ctx); err != nil {
+			return err
+		}
+		return tx.QueryRow(ctx, query, file.TrackID, file.Quality, file.Hash, file.Format, file.Size).
+			Scan(&previous, &file.ModTime)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save audio file: %w", err)
+	}
+
+	if previous == nil {
+		return "", nil
+	}
+	return *previous, nil
+}
+
+// AddAudioFile is SaveAudioFile keeping the file if the track already has one,
+// false if it was kept.
+func (r *AudioFileIndex) AddAudioFile(ctx context.Context, file *entity.AudioFile,
+	blobStored func(ctx context.Context) error) (bool, error) {
+	query := `
+		INSERT INTO track_audio_files (track_id, quality, hash, format, size)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (track_id, quality) DO NOTHING
+		RETURNING created_at
+	`
+
+	added := false
+	err := r.withBlobLock(ctx, file.Hash, func(tx pgx.Tx) error {
+		if err := blobStored(ctx); err != nil {
+			return err
+		}
+		err := tx.QueryRow(ctx, query, file.TrackID, file.Quality, file.Hash, file.Format, file.Size).Scan(&file.ModTime)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		added = err == nil
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to add audio file: %w", err)
+	}
+
+	return added, nil
+}
+
+// ReleaseBlob calls deleteBlob if no file points to the blob and deletes the seek
+// table of the blob with it. No file can be pointed to the blob until deleteBlob returns.
+func (r *AudioFileIndex) ReleaseBlob(ctx context.Context, hash string, deleteBlob func(ctx context.Context) error) error {
+	if _, err := r.removeUnreferenced(ctx, hash, deleteBlob); err != nil {
+		return fmt.Errorf("failed to release blob: %w", err)
+	}
+
+	return nil
+}
+
+// QuarantineOrphanedBlob calls quarantine if still no file points to the blob and
+// deletes the seek table of the blob with it, false if a file was pointed to it meanwhile.
+// The orphan is only known to the scrub, an upload deduplicated onto it does not change the blob.
+func (r *AudioFileIndex) QuarantineOrphanedBlob(ctx context.Context, hash string,
+	quarantine func(ctx context.Context) error) (bool, error) {
+	removed, err := r.removeUnreferenced(ctx, hash, quarantine)
+	if err != nil {
+		return false, fmt.Errorf("failed to quarantine blob: %w", err)
+	}
+
+	return removed, nil
+}
+
+// removeUnreferenced calls remove under the lock of the blob unless a file points to it.
+func (r *AudioFileIndex) removeUnreferenced(ctx context.Context, hash string,
+	remove func(ctx context.Context) error) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM track_audio_files WHERE hash = $1)`
+
+	removed := false
+	err := r.withBlobLock(ctx, hash, func(tx pgx.Tx) error {
+		var referenced bool
+		if err := tx.QueryRow(ctx, query, hash).Scan(&referenced); err != nil {
+			return err
+		}
+		if referenced {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM audio_seek_tables WHERE hash = $1`, hash); err != nil {
+			return err
+		}
+		if err := remove(ctx); err != nil {
+			return err
+		}
+		removed = true
+		return nil
+	})
+
+	return removed, err
+}
+
+// withBlobLock runs fn in a transaction holding the advisory lock of the blob,
+// which serializes the references to the blob with its deletion.
+func (r *AudioFileIndex) withBlobLock(ctx context.Context, hash string, fn func(tx pgx.Tx) error) (err error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				slog.Error("failed to rollback transaction", "err", rbErr)
+			}
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, hash); err != nil {
+		return fmt.Errorf("lock blob: %w", err)
+	}
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteAudioFiles deletes all files of the track and returns the hashes they pointed to.
+func (r *AudioFileIndex) DeleteAudioFiles(ctx context.Context, trackID uuid.UUID) ([]string, error) {
+	query := `
+		DELETE FROM track_audio_files
+		WHERE track_id = $1
+		RETURNING hash
+	`
+
+	rows, err := r.pool.Query(ctx, query, trackID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete audio files: %w", err)
+	}
+
+	hashes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete audio files: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// ListReferencedBlobs returns the hashes of all blobs referenced by tracks.
+func (r *AudioFileIndex) ListReferencedBlobs(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT DISTINCT hash FROM track_audio_files`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+
+	hashes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+
+	return hashes, nil
+}
+
+func (r *AudioFileIndex) GetBlobTracks(ctx context.Context, hash string) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `SELECT DISTINCT track_id FROM track_audio_files WHERE hash = $1`, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob tracks: %w", err)
+	}
+
+	trackIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob tracks: %w", err)
+	}
+
+	return trackIDs, nil
+}
+
+// DeleteBlobReferences deletes all files pointing to the blob, so the tracks
+// report them as missing instead of serving broken content. The seek table of
+// the blob is deleted with them.
+func (r *AudioFileIndex) DeleteBlobReferences(ctx context.Context, hash string) error {
+	query := `
+		WITH deleted_files AS (
+			DELETE FROM track_audio_files WHERE hash = $1
+		)
+		DELETE FROM audio_seek_tables WHERE hash = $1
+	`
+
+	if _, err := r.pool.Exec(ctx, query, hash); err != nil {
+		return fmt.Errorf("failed to delete blob references: %w", err)
+	}
+
+	return nil
+}
+
+// CountBlobStreams sums the streams of the tracks using each of the blobs,
+// blobs no track refers to are left out.
+func (r *AudioFileIndex) CountBlobStreams(ctx context.Context, hashes []string) (map[string]int64, error) {
+	query := `
+		SELECT f.hash, SUM(t.total_streams)
+		FROM track_audio_files f
+		JOIN tracks t ON t.id = f.track_id
+		WHERE f.hash = ANY($1)
+		GROUP BY f.hash
+	`
+
+	rows, err := r.pool.Query(ctx, query, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blob streams: %w", err)
+	}
+	defer rows.Close()
+
+	streams := make(map[string]int64, len(hashes))
+	for rows.Next() {
+		var (
+			hash  string
+			count int64
+		)
+		if err := rows.Scan(&hash, &count); err != nil {
+			return nil, fmt.Errorf("failed to count blob streams: %w", err)
+		}
+		streams[hash] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count blob streams: %w", err)
+	}
+
+	return streams, nil
+}
+
+// ListPopularBlobs returns the blobs of the most streamed tracks, the most streamed first.
+func (r *AudioFileIndex) ListPopularBlobs(ctx context.Context, tracks int) ([]string, error) {
+	query := `
+		SELECT f.hash
+		FROM track_audio_files f
+		JOIN (
+			SELECT id, total_streams FROM tracks
+			ORDER BY total_streams DESC
+			LIMIT $1
+		) top ON top.id = f.track_id
+		GROUP BY f.hash
+		ORDER BY MAX(top.total_streams) DESC, f.hash
+	`
+
+	rows, err := r.pool.Query(ctx, query, tracks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list popular blobs: %w", err)
+	}
+
+	hashes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list popular blobs: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// ListAudioTracks returns the tracks having at least one audio file.
+func (r *AudioFileIndex) ListAudioTracks(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `SELECT DISTINCT track_id FROM track_audio_files`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audio tracks: %w", err)
+	}
+
+	trackIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audio tracks: %w", err)
+	}
+
+	return trackIDs, nil
+}
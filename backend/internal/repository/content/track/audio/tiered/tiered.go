@@ -0,0 +1,556 @@
+package audio_tiered
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+)
+
+const (
+	// EvictLRU evicts the least recently served blobs first.
+	EvictLRU = "lru"
+	// EvictStreams evicts the blobs of the least streamed tracks first, ties go by LRU.
+	EvictStreams = "streams"
+
+	fillTimeout = 10 * time.Minute
+)
+
+var (
+	ErrUnknownEviction = errors.New("unknown cache eviction policy")
+	ErrInvalidCapacity = errors.New("invalid cache capacity")
+	ErrCorruptedOrigin = errors.New("origin content does not match its hash")
+)
+
+// Origin is the storage of record, every write and delete goes there.
+type Origin interface {
+	WriteBlob(ctx context.Context, content io.Reader, size int64) (*entity.AudioBlob, error)
+	OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error)
+	DeleteBlob(ctx context.Context, hash string) error
+	DeleteWaveforms(ctx context.Context, trackID uuid.UUID) error
+}
+
+type Cache interface {
+	WriteBlob(ctx context.Context, content io.Reader, size int64) (*entity.AudioBlob, error)
+	OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error)
+	DeleteBlob(ctx context.Context, hash string) error
+	ListBlobs(ctx context.Context) ([]string, error)
+}
+
+type BlobPopularity interface {
+	CountBlobStreams(ctx context.Context, hashes []string) (map[string]int64, error)
+}
+
+type Config struct {
+	Capacity int64  // bytes
+	Eviction string // EvictLRU if empty
+}
+
+type entry struct {
+	size       int64
+	lastAccess time.Time
+	cachedAt   time.Time
+}
+
+// fillState is marked evicted when the blob is deleted while it is copied,
+// so the copy is discarded instead of cached.
+type fillState struct {
+	evicted bool
+}
+
+// BlobStore serves blobs from a size bounded local cache in front of the origin.
+// Blobs never change under their hash, so cached copies are never stale. Missed
+// blobs are served from the origin while they are copied to the cache in the background.
+type BlobStore struct {
+	origin     Origin
+	cache      Cache
+	popularity BlobPopularity
+	capacity   int64
+	eviction   string
+
+	mu       sync.Mutex
+	entries  map[string]*entry
+	used     int64 // includes the space reserved for fills in progress
+	reserved int64
+	filling  map[string]*fillState
+	fills    sync.WaitGroup
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	filled    atomic.Int64
+	evictions atomic.Int64
+}
+
+// New picks up the blobs already in the cache and evicts them down to the capacity.
+// The popularity is only needed by EvictStreams.
+func New(ctx context.Context, origin Origin, cache Cache, popularity BlobPopularity, conf Config) (*BlobStore, error) {
+	if conf.Capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	if conf.Eviction == "" {
+		conf.Eviction = EvictLRU
+	}
+	if conf.Eviction != EvictLRU && (conf.Eviction != EvictStreams || popularity == nil) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEviction, conf.Eviction)
+	}
+
+	s := &BlobStore{
+		origin:     origin,
+		cache:      cache,
+		popularity: popularity,
+		capacity:   conf.Capacity,
+		eviction:   conf.Eviction,
+		entries:    make(map[string]*entry),
+		filling:    make(map[string]*fillState),
+	}
+
+	if err := s.Resync(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load audio cache: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *BlobStore) WriteBlob(ctx context.Context, content io.Reader, size int64) (*entity.AudioBlob, error) {
+	return s.origin.WriteBlob(ctx, content, size)
+}
+
+func (s *BlobStore) OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error) {
+	if blob, content, ok := s.openCached(ctx, hash); ok {
+		s.hits.Add(1)
+		return blob, content, nil
+	}
+	s.misses.Add(1)
+
+	blob, content, err := s.origin.OpenBlob(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.startFill(hash, blob.Size)
+
+	return blob, content, nil
+}
+
+// DeleteBlob deletes the blob from the origin first, so it is never served
+// from the cache after it is gone.
+func (s *BlobStore) DeleteBlob(ctx context.Context, hash string) error {
+	if err := s.origin.DeleteBlob(ctx, hash); err != nil {
+		return err
+	}
+
+	if err := s.EvictBlob(ctx, hash); err != nil {
+		slog.Error("failed to delete cached audio blob", "hash", hash, "error", err)
+	}
+
+	return nil
+}
+
+// EvictBlob drops the cached copy of a blob that is gone from the origin or
+// quarantined, a copy in progress is discarded once written.
+func (s *BlobStore) EvictBlob(ctx context.Context, hash string) error {
+	s.mu.Lock()
+	if e, ok := s.entries[hash]; ok {
+		delete(s.entries, hash)
+		s.used -= e.size
+	}
+	if state, ok := s.filling[hash]; ok {
+		state.evicted = true
+	}
+	s.mu.Unlock()
+
+	if err := s.cache.DeleteBlob(ctx, hash); err != nil && !errors.Is(err, commonerr.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// Resync recomputes the usage from the blobs in the cache, which is also filled
+// by the other processes sharing it, such as the prewarm command, and evicts
+// the blobs over the capacity.
+func (s *BlobStore) Resync(ctx context.Context) error {
+	listedAt := time.Now()
+	hashes, err := s.cache.ListBlobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	listed := make(map[string]bool, len(hashes))
+	var unknown []string
+	s.mu.Lock()
+	for _, hash := range hashes {
+		listed[hash] = true
+		if _, ok := s.entries[hash]; !ok {
+			unknown = append(unknown, hash)
+		}
+	}
+	s.mu.Unlock()
+
+	found := make(map[string]*entity.AudioBlob, len(unknown))
+	for _, hash := range unknown {
+		blob, content, err := s.cache.OpenBlob(ctx, hash)
+		if err != nil {
+			if !errors.Is(err, commonerr.ErrNotFound) {
+				slog.Error("failed to load cached audio blob", "hash", hash, "error", err)
+			}
+			continue
+		}
+		closeContent(content)
+		found[hash] = blob
+	}
+
+	s.mu.Lock()
+	// the entries cached after the listing are kept, the fills in progress are reserved
+	for hash, e := range s.entries {
+		if !listed[hash] && e.cachedAt.Before(listedAt) {
+			delete(s.entries, hash)
+		}
+	}
+	for hash, blob := range found {
+		_, known := s.entries[hash]
+		_, filling := s.filling[hash]
+		if !known && !filling {
+			s.entries[hash] = &entry{size: blob.Size, lastAccess: blob.ModTime, cachedAt: listedAt}
+		}
+	}
+	s.used = s.reserved
+	for _, e := range s.entries {
+		s.used += e.size
+	}
+	s.mu.Unlock()
+
+	s.makeRoom(ctx, 0)
+
+	return nil
+}
+
+// RunResync resyncs the usage every interval until the context is done.
+func (s *BlobStore) RunResync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Resync(ctx); err != nil {
+				slog.Error("failed to resync audio cache", "error", err)
+			}
+		}
+	}
+}
+
+func (s *BlobStore) DeleteWaveforms(ctx context.Context, trackID uuid.UUID) error {
+	return s.origin.DeleteWaveforms(ctx, trackID)
+}
+
+// Prewarm copies the blobs to the cache in the given order and stops before
+// a blob would evict one cached by this call. It returns how many blobs were copied.
+func (s *BlobStore) Prewarm(ctx context.Context, hashes []string) (int, error) {
+	var (
+		warmed  int
+		planned int64
+	)
+
+	for _, hash := range hashes {
+		if err := ctx.Err(); err != nil {
+			return warmed, err
+		}
+
+		s.mu.Lock()
+		e, cached := s.entries[hash]
+		if cached {
+			e.lastAccess = time.Now()
+		}
+		s.mu.Unlock()
+
+		if cached {
+			planned += e.size
+			continue
+		}
+
+		blob, content, err := s.origin.OpenBlob(ctx, hash)
+		if err != nil {
+			return warmed, err
+		}
+		closeContent(content)
+
+		if planned+blob.Size > s.capacity {
+			break
+		}
+		planned += blob.Size
+
+		if !s.claimFill(hash) {
+			continue
+		}
+		if err := s.fill(ctx, hash, blob.Size); err != nil {
+			return warmed, err
+		}
+		warmed++
+	}
+
+	return warmed, nil
+}
+
+func (s *BlobStore) Stats() entity.AudioCacheStats {
+	s.mu.Lock()
+	blobs, used := len(s.entries), s.used
+	s.mu.Unlock()
+
+	return entity.AudioCacheStats{
+		Hits:      s.hits.Load(),
+		Misses:    s.misses.Load(),
+		Fills:     s.filled.Load(),
+		Evictions: s.evictions.Load(),
+		Blobs:     blobs,
+		Used:      used,
+		Capacity:  s.capacity,
+	}
+}
+
+// ReportStats logs the counters every interval until the context is done.
+func (s *BlobStore) ReportStats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := s.Stats()
+			slog.Info("audio cache stats",
+				"hits", stats.Hits,
+				"misses", stats.Misses,
+				"hit_ratio", stats.HitRatio(),
+				"fills", stats.Fills,
+				"evictions", stats.Evictions,
+				"blobs", stats.Blobs,
+				"used", stats.Used,
+				"capacity", stats.Capacity,
+			)
+		}
+	}
+}
+
+// Wait blocks until the background fills are done.
+func (s *BlobStore) Wait() {
+	s.fills.Wait()
+}
+
+// openCached also serves blobs cached by other processes sharing the cache,
+// such as the prewarm command.
+func (s *BlobStore) openCached(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, bool) {
+	s.mu.Lock()
+	e, known := s.entries[hash]
+	if known {
+		e.lastAccess = time.Now()
+	}
+	s.mu.Unlock()
+
+	blob, content, err := s.cache.OpenBlob(ctx, hash)
+	if err != nil {
+		if known {
+			s.forget(hash)
+		}
+		if known || !errors.Is(err, commonerr.ErrNotFound) {
+			slog.Error("failed to open cached audio blob", "hash", hash, "error", err)
+		}
+		return nil, nil, false
+	}
+
+	if !known {
+		s.mu.Lock()
+		_, cached := s.entries[hash]
+		_, filling := s.filling[hash]
+		if !cached && !filling {
+			s.entries[hash] = &entry{size: blob.Size, lastAccess: time.Now(), cachedAt: time.Now()}
+			s.used += blob.Size
+		}
+		s.mu.Unlock()
+	}
+
+	return blob, content, true
+}
+
+func (s *BlobStore) forget(hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[hash]; ok {
+		delete(s.entries, hash)
+		s.used -= e.size
+	}
+}
+
+func (s *BlobStore) startFill(hash string, size int64) {
+	if size > s.capacity || !s.claimFill(hash) {
+		return
+	}
+
+	s.fills.Add(1)
+	go func() {
+		defer s.fills.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), fillTimeout)
+		defer cancel()
+
+		if err := s.fill(ctx, hash, size); err != nil {
+			slog.Error("failed to cache audio blob", "hash", hash, "error", err)
+		}
+	}()
+}
+
+// claimFill makes sure a blob is copied to the cache once at a time.
+func (s *BlobStore) claimFill(hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[hash]; ok {
+		return false
+	}
+	if _, ok := s.filling[hash]; ok {
+		return false
+	}
+	s.filling[hash] = &fillState{}
+	return true
+}
+
+func (s *BlobStore) fill(ctx context.Context, hash string, size int64) (err error) {
+	s.makeRoom(ctx, size)
+
+	stored := false
+	defer func() {
+		s.mu.Lock()
+		delete(s.filling, hash)
+		s.reserved -= size
+		if !stored {
+			s.used -= size
+		}
+		s.mu.Unlock()
+	}()
+
+	_, content, err := s.origin.OpenBlob(ctx, hash)
+	if err != nil {
+		return err
+	}
+	defer closeContent(content)
+
+	blob, err := s.cache.WriteBlob(ctx, content, size)
+	if err != nil {
+		return err
+	}
+	if blob.Hash != hash {
+		if err := s.cache.DeleteBlob(ctx, blob.Hash); err != nil {
+			slog.Error("failed to delete cached audio blob", "hash", blob.Hash, "error", err)
+		}
+		return fmt.Errorf("%w: %s", ErrCorruptedOrigin, hash)
+	}
+
+	s.mu.Lock()
+	evicted := s.filling[hash].evicted
+	if !evicted {
+		s.entries[hash] = &entry{size: size, lastAccess: time.Now(), cachedAt: time.Now()}
+		stored = true
+	}
+	s.mu.Unlock()
+
+	if evicted {
+		if err := s.cache.DeleteBlob(ctx, hash); err != nil {
+			slog.Error("failed to delete cached audio blob", "hash", hash, "error", err)
+		}
+		return nil
+	}
+	s.filled.Add(1)
+
+	return nil
+}
+
+// makeRoom evicts blobs until the size fits and reserves it. The streams are
+// counted before locking, on failure the eviction falls back to LRU.
+func (s *BlobStore) makeRoom(ctx context.Context, size int64) {
+	s.mu.Lock()
+	full := s.used+size > s.capacity
+	s.mu.Unlock()
+
+	var streams map[string]int64
+	if full && s.eviction == EvictStreams {
+		var err error
+		if streams, err = s.popularity.CountBlobStreams(ctx, s.cachedHashes()); err != nil {
+			slog.Error("failed to count audio blob streams, evicting by lru", "error", err)
+		}
+	}
+
+	s.mu.Lock()
+	victims := s.selectVictims(size, streams)
+	s.used += size
+	s.reserved += size
+	s.mu.Unlock()
+
+	for _, hash := range victims {
+		if err := s.cache.DeleteBlob(ctx, hash); err != nil {
+			slog.Error("failed to evict audio blob", "hash", hash, "error", err)
+			continue
+		}
+		s.evictions.Add(1)
+	}
+}
+
+// selectVictims must be called with the lock held.
+func (s *BlobStore) selectVictims(size int64, streams map[string]int64) []string {
+	if s.used+size <= s.capacity {
+		return nil
+	}
+
+	candidates := make([]string, 0, len(s.entries))
+	for hash := range s.entries {
+		candidates = append(candidates, hash)
+	}
+	slices.SortFunc(candidates, func(a, b string) int {
+		if c := cmp.Compare(streams[a], streams[b]); c != 0 {
+			return c
+		}
+		return s.entries[a].lastAccess.Compare(s.entries[b].lastAccess)
+	})
+
+	var victims []string
+	for _, hash := range candidates {
+		if s.used+size <= s.capacity {
+			break
+		}
+		s.used -= s.entries[hash].size
+		delete(s.entries, hash)
+		victims = append(victims, hash)
+	}
+
+	return victims
+}
+
+func (s *BlobStore) cachedHashes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashes := make([]string, 0, len(s.entries))
+	for hash := range s.entries {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func closeContent(content io.Closer) {
+	if err := content.Close(); err != nil {
+		slog.Error("failed to close audio blob", "error", err)
+	}
+}
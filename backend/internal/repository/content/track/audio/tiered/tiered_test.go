@@ -0,0 +1,344 @@
+package audio_tiered_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	audio_fs "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/fs"
+	audio_tiered "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/tiered"
+	"github.com/hahaclassic/orpheon/backend/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BlobStoreSuite struct {
+	suite.Suite
+	origin     *audio_fs.AudioStorage
+	cache      *audio_fs.AudioStorage
+	popularity *mocks.BlobPopularity
+	ctx        context.Context
+}
+
+func TestBlobStoreSuite(t *testing.T) {
+	suite.Run(t, new(BlobStoreSuite))
+}
+
+func (s *BlobStoreSuite) SetupTest() {
+	var err error
+	s.origin, err = audio_fs.NewAudioStorage(s.T().TempDir())
+	s.Require().NoError(err)
+	s.cache, err = audio_fs.NewAudioStorage(s.T().TempDir())
+	s.Require().NoError(err)
+	s.popularity = mocks.NewBlobPopularity(s.T())
+	s.ctx = context.Background()
+}
+
+// Object Mother
+func (s *BlobStoreSuite) Store(capacity int64, eviction string) *audio_tiered.BlobStore {
+	store, err := audio_tiered.New(s.ctx, s.origin, s.cache, s.popularity, audio_tiered.Config{
+		Capacity: capacity,
+		Eviction: eviction,
+	})
+	s.Require().NoError(err)
+	return store
+}
+
+// Upload stores 100 bytes filled with b in the origin.
+func (s *BlobStoreSuite) Upload(b byte) string {
+	blob, err := s.origin.WriteBlob(s.ctx, bytes.NewReader(bytes.Repeat([]byte{b}, 100)), 100)
+	s.Require().NoError(err)
+	return blob.Hash
+}
+
+func (s *BlobStoreSuite) Read(store *audio_tiered.BlobStore, hash string) []byte {
+	_, content, err := store.OpenBlob(s.ctx, hash)
+	s.Require().NoError(err)
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *BlobStoreSuite) Cached(hash string) bool {
+	_, content, err := s.cache.OpenBlob(s.ctx, hash)
+	if err != nil {
+		return false
+	}
+	content.Close()
+	return true
+}
+
+// Tests
+func (s *BlobStoreSuite) TestNewValidatesConfig() {
+	_, err := audio_tiered.New(s.ctx, s.origin, s.cache, nil, audio_tiered.Config{Capacity: 0})
+	s.ErrorIs(err, audio_tiered.ErrInvalidCapacity)
+
+	_, err = audio_tiered.New(s.ctx, s.origin, s.cache, nil, audio_tiered.Config{Capacity: 1, Eviction: "random"})
+	s.ErrorIs(err, audio_tiered.ErrUnknownEviction)
+
+	_, err = audio_tiered.New(s.ctx, s.origin, s.cache, nil, audio_tiered.Config{Capacity: 1, Eviction: audio_tiered.EvictStreams})
+	s.ErrorIs(err, audio_tiered.ErrUnknownEviction)
+}
+
+func (s *BlobStoreSuite) TestMissFillsCache() {
+	store := s.Store(1000, audio_tiered.EvictLRU)
+	hash := s.Upload('a')
+
+	s.Equal(bytes.Repeat([]byte{'a'}, 100), s.Read(store, hash))
+	store.Wait()
+	s.True(s.Cached(hash))
+
+	s.Equal(bytes.Repeat([]byte{'a'}, 100), s.Read(store, hash))
+
+	stats := store.Stats()
+	s.Equal(int64(1), stats.Hits)
+	s.Equal(int64(1), stats.Misses)
+	s.Equal(int64(1), stats.Fills)
+	s.Equal(1, stats.Blobs)
+	s.Equal(int64(100), stats.Used)
+	s.Equal(0.5, stats.HitRatio())
+}
+
+func (s *BlobStoreSuite) TestEvictBlobWhenOriginLost() {
+	store := s.Store(1000, audio_tiered.EvictLRU)
+	hash := s.Upload('a')
+	s.Read(store, hash)
+	store.Wait()
+
+	s.Require().NoError(s.origin.DeleteBlob(s.ctx, hash))
+	s.Require().NoError(store.EvictBlob(s.ctx, hash))
+
+	_, _, err := store.OpenBlob(s.ctx, hash)
+	s.Error(err)
+	s.False(s.Cached(hash))
+	s.Equal(int64(0), store.Stats().Hits)
+}
+
+func (s *BlobStoreSuite) TestSkipsBlobsLargerThanCapacity() {
+	store := s.Store(50, audio_tiered.EvictLRU)
+	hash := s.Upload('a')
+
+	s.Read(store, hash)
+	store.Wait()
+
+	s.False(s.Cached(hash))
+	s.Equal(int64(0), store.Stats().Used)
+}
+
+func (s *BlobStoreSuite) TestEvictsLeastRecentlyUsed() {
+	store := s.Store(200, audio_tiered.EvictLRU)
+	a, b, c := s.Upload('a'), s.Upload('b'), s.Upload('c')
+
+	s.Read(store, a)
+	store.Wait()
+	s.Read(store, b)
+	store.Wait()
+	s.Read(store, a)
+	s.Read(store, c)
+	store.Wait()
+
+	s.True(s.Cached(a))
+	s.False(s.Cached(b))
+	s.True(s.Cached(c))
+
+	stats := store.Stats()
+	s.Equal(int64(1), stats.Evictions)
+	s.Equal(int64(200), stats.Used)
+}
+
+func (s *BlobStoreSuite) TestEvictsLeastStreamed() {
+	store := s.Store(200, audio_tiered.EvictStreams)
+	a, b, c := s.Upload('a'), s.Upload('b'), s.Upload('c')
+
+	s.popularity.EXPECT().CountBlobStreams(mock.Anything, mock.Anything).
+		Return(map[string]int64{a: 5, b: 50}, nil)
+
+	s.Read(store, a)
+	store.Wait()
+	s.Read(store, b)
+	store.Wait()
+	s.Read(store, a)
+	s.Read(store, c)
+	store.Wait()
+
+	s.False(s.Cached(a))
+	s.True(s.Cached(b))
+	s.True(s.Cached(c))
+}
+
+func (s *BlobStoreSuite) TestEvictsByLRUWhenStreamsUnavailable() {
+	store := s.Store(200, audio_tiered.EvictStreams)
+	a, b, c := s.Upload('a'), s.Upload('b'), s.Upload('c')
+
+	s.popularity.EXPECT().CountBlobStreams(mock.Anything, mock.Anything).
+		Return(nil, errors.New("db error"))
+
+	s.Read(store, a)
+	store.Wait()
+	s.Read(store, b)
+	store.Wait()
+	s.Read(store, c)
+	store.Wait()
+
+	s.False(s.Cached(a))
+	s.True(s.Cached(b))
+	s.True(s.Cached(c))
+}
+
+func (s *BlobStoreSuite) TestNewLoadsAndTrimsCache() {
+	s.Upload('a')
+	for _, b := range []byte{'a', 'b', 'c'} {
+		_, err := s.cache.WriteBlob(s.ctx, bytes.NewReader(bytes.Repeat([]byte{b}, 100)), 100)
+		s.Require().NoError(err)
+	}
+
+	store := s.Store(250, audio_tiered.EvictLRU)
+
+	stats := store.Stats()
+	s.Equal(2, stats.Blobs)
+	s.Equal(int64(200), stats.Used)
+	s.Equal(int64(1), stats.Evictions)
+}
+
+func (s *BlobStoreSuite) TestServesBlobsCachedByOthers() {
+	store := s.Store(1000, audio_tiered.EvictLRU)
+	hash := s.Upload('a')
+	_, err := s.cache.WriteBlob(s.ctx, bytes.NewReader(bytes.Repeat([]byte{'a'}, 100)), 100)
+	s.Require().NoError(err)
+
+	s.Read(store, hash)
+
+	stats := store.Stats()
+	s.Equal(int64(1), stats.Hits)
+	s.Equal(1, stats.Blobs)
+}
+
+func (s *BlobStoreSuite) TestDeleteBlob() {
+	store := s.Store(1000, audio_tiered.EvictLRU)
+	hash := s.Upload('a')
+	s.Read(store, hash)
+	store.Wait()
+
+	s.Require().NoError(store.DeleteBlob(s.ctx, hash))
+
+	s.False(s.Cached(hash))
+	_, _, err := store.OpenBlob(s.ctx, hash)
+	s.Error(err)
+	s.Equal(0, store.Stats().Blobs)
+}
+
+func (s *BlobStoreSuite) TestDeleteBlobDuringFill() {
+	origin := &gatedOrigin{
+		AudioStorage: s.origin,
+		filling:      make(chan struct{}),
+		gate:         make(chan struct{}),
+	}
+	store, err := audio_tiered.New(s.ctx, origin, s.cache, s.popularity, audio_tiered.Config{Capacity: 1000})
+	s.Require().NoError(err)
+	hash := s.Upload('a')
+
+	_, content, err := store.OpenBlob(s.ctx, hash)
+	s.Require().NoError(err)
+	content.Close()
+	<-origin.filling
+
+	s.Require().NoError(store.DeleteBlob(s.ctx, hash))
+	close(origin.gate)
+	store.Wait()
+
+	s.False(s.Cached(hash))
+	stats := store.Stats()
+	s.Equal(0, stats.Blobs)
+	s.Equal(int64(0), stats.Used)
+}
+
+func (s *BlobStoreSuite) TestResyncPicksUpBlobsCachedByOthers() {
+	store := s.Store(250, audio_tiered.EvictLRU)
+	for _, b := range []byte{'a', 'b', 'c'} {
+		_, err := s.cache.WriteBlob(s.ctx, bytes.NewReader(bytes.Repeat([]byte{b}, 100)), 100)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(store.Resync(s.ctx))
+
+	stats := store.Stats()
+	s.Equal(2, stats.Blobs)
+	s.Equal(int64(200), stats.Used)
+	s.Equal(int64(1), stats.Evictions)
+}
+
+func (s *BlobStoreSuite) TestResyncDropsBlobsDeletedByOthers() {
+	store := s.Store(1000, audio_tiered.EvictLRU)
+	hash := s.Upload('a')
+	s.Read(store, hash)
+	store.Wait()
+
+	s.Require().NoError(s.cache.DeleteBlob(s.ctx, hash))
+	s.Require().NoError(store.Resync(s.ctx))
+
+	stats := store.Stats()
+	s.Equal(0, stats.Blobs)
+	s.Equal(int64(0), stats.Used)
+}
+
+func (s *BlobStoreSuite) TestPrewarmStopsAtCapacity() {
+	store := s.Store(250, audio_tiered.EvictLRU)
+	a, b, c := s.Upload('a'), s.Upload('b'), s.Upload('c')
+
+	warmed, err := store.Prewarm(s.ctx, []string{a, b, c})
+	s.Require().NoError(err)
+
+	s.Equal(2, warmed)
+	s.True(s.Cached(a))
+	s.True(s.Cached(b))
+	s.False(s.Cached(c))
+	s.Equal(int64(0), store.Stats().Evictions)
+}
+
+func (s *BlobStoreSuite) TestPrewarmKeepsCachedBlobs() {
+	store := s.Store(250, audio_tiered.EvictLRU)
+	a, b := s.Upload('a'), s.Upload('b')
+	s.Read(store, a)
+	store.Wait()
+
+	warmed, err := store.Prewarm(s.ctx, []string{a, b})
+	s.Require().NoError(err)
+
+	s.Equal(1, warmed)
+	s.True(s.Cached(a))
+	s.True(s.Cached(b))
+}
+
+// gatedOrigin holds the copy to the cache, the second open of a blob is the fill.
+type gatedOrigin struct {
+	*audio_fs.AudioStorage
+	opens   atomic.Int32
+	filling chan struct{}
+	gate    chan struct{}
+}
+
+func (o *gatedOrigin) OpenBlob(ctx context.Context, hash string) (*entity.AudioBlob, io.ReadSeekCloser, error) {
+	blob, content, err := o.AudioStorage.OpenBlob(ctx, hash)
+	if err != nil || o.opens.Add(1) != 2 {
+		return blob, content, err
+	}
+
+	close(o.filling)
+	return blob, &gatedContent{ReadSeekCloser: content, gate: o.gate}, nil
+}
+
+type gatedContent struct {
+	io.ReadSeekCloser
+	gate chan struct{}
+}
+
+func (c *gatedContent) Read(p []byte) (int, error) {
+	<-c.gate
+	return c.ReadSeekCloser.Read(p)
+}
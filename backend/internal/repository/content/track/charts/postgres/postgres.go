@@ -0,0 +1,193 @@
+package charts_postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ChartRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewChartRepository(pool *pgxpool.Pool) *ChartRepository {
+	return &ChartRepository{pool: pool}
+}
+
+// IncrementPlayCounts counts a play in the hourly and the daily buckets of the time in UTC.
+// It joins the transaction of the context, the other stat increments are written in.
+func (r *ChartRepository) IncrementPlayCounts(ctx context.Context, trackID uuid.UUID, at time.Time) error {
+	const query = `
+		WITH hourly AS (
+			INSERT INTO track_plays_hourly (track_id, hour, plays)
+			VALUES ($1, $2, 1)
+			ON CONFLICT (track_id, hour) DO UPDATE SET plays = track_plays_hourly.plays + 1
+		)
+		INSERT INTO track_plays_daily (track_id, day, plays)
+		VALUES ($1, $3, 1)
+		ON CONFLICT (track_id, day) DO UPDATE SET plays = track_plays_daily.plays + 1
+	`
+
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	if _, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, trackID, at.Truncate(time.Hour), day); err != nil {
+		return fmt.Errorf("increment play counts: %w", err)
+	}
+
+	return nil
+}
+
+// TopTracks filters by the country of any of the track's artists.
+func (r *ChartRepository) TopTracks(ctx context.Context, q *entity.ChartQuery) ([]*entity.ChartEntry, error) {
+	table, column := "track_plays_daily", "day"
+	if q.Hourly {
+		table, column = "track_plays_hourly", "hour"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.track_id, SUM(p.plays) AS plays
+		FROM %s p
+		JOIN tracks t ON t.id = p.track_id
+		WHERE p.%s >= $1 AND p.%s < $2
+	`, table, column, column)
+	args := []any{q.From, q.To}
+	argIdx := 3
+
+	if q.Filters.GenreID != uuid.Nil {
+		query += fmt.Sprintf(" AND t.genre_id = $%d", argIdx)
+		args = append(args, q.Filters.GenreID)
+		argIdx++
+	}
+	if q.Filters.Country != "" {
+		query += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM artist_tracks at
+			JOIN artists ar ON ar.id = at.artist_id
+			WHERE at.track_id = p.track_id AND ar.country = $%d)`, argIdx)
+		args = append(args, q.Filters.Country)
+		argIdx++
+	}
+
+	query += fmt.Sprintf(" GROUP BY p.track_id HAVING SUM(p.plays) > 0 ORDER BY plays DESC, p.track_id LIMIT $%d", argIdx)
+	args = append(args, q.Limit)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get top tracks: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*entity.ChartEntry, 0, q.Limit)
+	for rows.Next() {
+		var entry entity.ChartEntry
+		if err := rows.Scan(&entry.TrackID, &entry.Plays); err != nil {
+			return nil, fmt.Errorf("scan chart entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *ChartRepository) GetWeeklyChart(ctx context.Context, week time.Time) (*entity.WeeklyChart, error) {
+	const query = `
+		SELECT position, previous_position, track_id, plays
+		FROM weekly_chart_snapshots
+		WHERE week = $1
+		ORDER BY position
+	`
+
+	rows, err := r.pool.Query(ctx, query, week)
+	if err != nil {
+		return nil, fmt.Errorf("get weekly chart: %w", err)
+	}
+	defer rows.Close()
+
+	chart := &entity.WeeklyChart{Week: week}
+	for rows.Next() {
+		var entry entity.ChartEntry
+		if err := rows.Scan(&entry.Position, &entry.PreviousPosition, &entry.TrackID, &entry.Plays); err != nil {
+			return nil, fmt.Errorf("scan chart entry: %w", err)
+		}
+		chart.Entries = append(chart.Entries, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+	if len(chart.Entries) == 0 {
+		return nil, fmt.Errorf("%w: weekly chart of %s", commonerr.ErrNotFound, week.Format(time.DateOnly))
+	}
+
+	return chart, nil
+}
+
+// SaveWeeklyChart keeps the snapshot saved first if another one of the week is saved
+// concurrently. The week is locked, so the snapshots are never mixed.
+func (r *ChartRepository) SaveWeeklyChart(ctx context.Context, chart *entity.WeeklyChart) (err error) {
+	const (
+		lockQuery = `SELECT pg_advisory_xact_lock(hashtextextended('weekly_chart_snapshots:' || $1::date, 0))`
+		query     = `
+			INSERT INTO weekly_chart_snapshots (week, position, track_id, plays, previous_position)
+			SELECT $1, e.position, e.track_id, e.plays, e.previous_position
+			FROM UNNEST($2::int[], $3::uuid[], $4::int[], $5::int[]) AS e(position, track_id, plays, previous_position)
+			WHERE NOT EXISTS (SELECT 1 FROM weekly_chart_snapshots WHERE week = $1)
+		`
+	)
+
+	positions := make([]int, len(chart.Entries))
+	trackIDs := make([]uuid.UUID, len(chart.Entries))
+	plays := make([]int, len(chart.Entries))
+	previous := make([]*int, len(chart.Entries))
+	for i, entry := range chart.Entries {
+		positions[i], trackIDs[i], plays[i], previous[i] = entry.Position, entry.TrackID, entry.Plays, entry.PreviousPosition
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				slog.Error("failed to rollback transaction", "err", rbErr)
+			}
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, lockQuery, chart.Week); err != nil {
+		return fmt.Errorf("lock weekly chart: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, chart.Week, positions, trackIDs, plays, previous); err != nil {
+		return fmt.Errorf("save weekly chart: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteHourlyPlays deletes the hourly play counts before the given time and returns
+// their number. The daily ones are kept for the weekly and monthly charts.
+func (r *ChartRepository) DeleteHourlyPlays(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM track_plays_hourly WHERE hour < $1`
+
+	tag, err := r.pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete hourly plays: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,76 @@
+package dead_letters_postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReplayedDeadLetterRepository records the dead letters replayed by the admin,
+// the dead-letter topic itself is append-only.
+type ReplayedDeadLetterRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReplayedDeadLetterRepository(pool *pgxpool.Pool) *ReplayedDeadLetterRepository {
+	return &ReplayedDeadLetterRepository{pool: pool}
+}
+
+// GetReplayed returns when the given letters were replayed by their IDs,
+// the letters never replayed are left out.
+func (r *ReplayedDeadLetterRepository) GetReplayed(ctx context.Context,
+	letters []*entity.DeadLetter) (map[string]time.Time, error) {
+	const query = `
+		SELECT r.partition, r."offset", r.replayed_at
+		FROM replayed_dead_letters r
+		JOIN UNNEST($1::text[], $2::int[], $3::bigint[]) AS l(topic, partition, "offset")
+			ON r.topic = l.topic AND r.partition = l.partition AND r."offset" = l."offset"
+	`
+
+	topics := make([]string, len(letters))
+	partitions := make([]int, len(letters))
+	offsets := make([]int64, len(letters))
+	for i, letter := range letters {
+		topics[i], partitions[i], offsets[i] = letter.Topic, letter.Partition, letter.Offset
+	}
+
+	rows, err := r.pool.Query(ctx, query, topics, partitions, offsets)
+	if err != nil {
+		return nil, fmt.Errorf("get replayed dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	replayed := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			letter     entity.DeadLetter
+			replayedAt time.Time
+		)
+		if err := rows.Scan(&letter.Partition, &letter.Offset, &replayedAt); err != nil {
+			return nil, fmt.Errorf("scan replayed dead letter: %w", err)
+		}
+		replayed[letter.ID()] = replayedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get replayed dead letters: %w", err)
+	}
+
+	return replayed, nil
+}
+
+func (r *ReplayedDeadLetterRepository) MarkReplayed(ctx context.Context, letter *entity.DeadLetter) error {
+	const query = `
+		INSERT INTO replayed_dead_letters (topic, partition, "offset")
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := r.pool.Exec(ctx, query, letter.Topic, letter.Partition, letter.Offset); err != nil {
+		return fmt.Errorf("mark dead letter replayed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package history_postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ListeningHistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewListeningHistoryRepository(pool *pgxpool.Pool) *ListeningHistoryRepository {
+	return &ListeningHistoryRepository{pool: pool}
+}
+
+func (r *ListeningHistoryRepository) AddEntry(ctx context.Context, entry *entity.ListeningHistoryEntry) error {
+	const query = `
+		INSERT INTO listening_history (user_id, event_id, track_id, listened_at, seconds, context_type, context_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING
+	`
+
+	var (
+		contextType *string
+		contextID   *uuid.UUID
+	)
+	if entry.Context != nil {
+		t := string(entry.Context.Type)
+		contextType, contextID = &t, &entry.Context.ID
+	}
+
+	_, err := r.pool.Exec(ctx, query, entry.UserID, entry.EventID, entry.TrackID,
+		entry.ListenedAt, entry.Seconds, contextType, contextID)
+	if err != nil {
+		return fmt.Errorf("add history entry: %w", err)
+	}
+	return nil
+}
+
+func (r *ListeningHistoryRepository) GetEntries(ctx context.Context, userID uuid.UUID,
+	limit, offset int) ([]*entity.ListeningHistoryEntry, error) {
+	const query = `
+		SELECT event_id, user_id, track_id, listened_at, seconds, context_type, context_id
+		FROM listening_history
+		WHERE user_id = $1
+		ORDER BY listened_at DESC, event_id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("get history entries: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]*entity.ListeningHistoryEntry, 0, limit)
+	for rows.Next() {
+		var (
+			entry       entity.ListeningHistoryEntry
+			contextType *string
+			contextID   *uuid.UUID
+		)
+		if err := rows.Scan(&entry.EventID, &entry.UserID, &entry.TrackID, &entry.ListenedAt,
+			&entry.Seconds, &contextType, &contextID); err != nil {
+			return nil, fmt.Errorf("scan history entry: %w", err)
+		}
+		if contextType != nil && contextID != nil {
+			entry.Context = &entity.ListeningContext{Type: entity.ListeningContextType(*contextType), ID: *contextID}
+		}
+		result = append(result, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *ListeningHistoryRepository) DeleteEntries(ctx context.Context, userID uuid.UUID) error {
+	const query = `
+		DELETE FROM listening_history
+		WHERE user_id = $1
+	`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("delete history entries: %w", err)
+	}
+	return nil
+}
+
+func (r *ListeningHistoryRepository) SetPaused(ctx context.Context, userID uuid.UUID, paused bool) error {
+	query := `
+		DELETE FROM listening_history_pauses
+		WHERE user_id = $1
+	`
+	if paused {
+		query = `
+			INSERT INTO listening_history_pauses (user_id)
+			VALUES ($1)
+			ON CONFLICT DO NOTHING
+		`
+	}
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("set history paused: %w", err)
+	}
+	return nil
+}
+
+func (r *ListeningHistoryRepository) IsPaused(ctx context.Context, userID uuid.UUID) (bool, error) {
+	const query = `
+		SELECT EXISTS (SELECT 1 FROM listening_history_pauses WHERE user_id = $1)
+	`
+
+	var paused bool
+	if err := r.pool.QueryRow(ctx, query, userID).Scan(&paused); err != nil {
+		return false, fmt.Errorf("get history paused: %w", err)
+	}
+	return paused, nil
+}
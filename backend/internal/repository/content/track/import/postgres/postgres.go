@@ -0,0 +1,167 @@
+package import_postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ImportRepository looks up the records matched by the track import by their names,
+// case-insensitively. An exact match is preferred over one differing in case.
+type ImportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportRepository(pool *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{pool: pool}
+}
+
+func (r *ImportRepository) GetGenreByTitle(ctx context.Context, title string) (*entity.Genre, error) {
+	const query = `
+		SELECT id, title
+		FROM genres
+		WHERE LOWER(title) = LOWER($1)
+		ORDER BY title = $1 DESC
+		LIMIT 1
+	`
+
+	var genre entity.Genre
+	err := r.pool.QueryRow(ctx, query, title).Scan(&genre.ID, &genre.Title)
+	if err != nil {
+		return nil, notFound(err, "genre", title)
+	}
+
+	return &genre, nil
+}
+
+func (r *ImportRepository) GetArtistByName(ctx context.Context, name string) (*entity.ArtistMeta, error) {
+	const query = `
+		SELECT id, name, description, country
+		FROM artists
+		WHERE LOWER(name) = LOWER($1)
+		ORDER BY name = $1 DESC
+		LIMIT 1
+	`
+
+	var artist entity.ArtistMeta
+	err := r.pool.QueryRow(ctx, query, name).Scan(&artist.ID, &artist.Name, &artist.Description, &artist.Country)
+	if err != nil {
+		return nil, notFound(err, "artist", name)
+	}
+
+	return &artist, nil
+}
+
+// GetArtistAlbum looks the album up among the albums of the artist.
+func (r *ImportRepository) GetArtistAlbum(ctx context.Context, artistID uuid.UUID, title string) (*entity.AlbumMeta, error) {
+	const query = `
+		SELECT a.id, a.title, a.label, a.license_id, a.release_date
+		FROM albums a
+		JOIN artist_albums aa ON aa.album_id = a.id
+		WHERE aa.artist_id = $1 AND LOWER(a.title) = LOWER($2)
+		ORDER BY a.title = $2 DESC
+		LIMIT 1
+	`
+
+	var album entity.AlbumMeta
+	err := r.pool.QueryRow(ctx, query, artistID, title).
+		Scan(&album.ID, &album.Title, &album.Label, &album.LicenseID, &album.ReleaseDate)
+	if err != nil {
+		return nil, notFound(err, "album", title)
+	}
+
+	return &album, nil
+}
+
+// GetAlbumTrack looks the track up among the tracks of the album.
+func (r *ImportRepository) GetAlbumTrack(ctx context.Context, albumID uuid.UUID, name string) (*entity.TrackMeta, error) {
+	const query = `
+		SELECT id, genre_id, name, album_id, track_number
+		FROM tracks
+		WHERE album_id = $1 AND LOWER(name) = LOWER($2)
+		ORDER BY name = $2 DESC
+		LIMIT 1
+	`
+
+	var track entity.TrackMeta
+	err := r.pool.QueryRow(ctx, query, albumID, name).
+		Scan(&track.ID, &track.GenreID, &track.Name, &track.AlbumID, &track.TrackNumber)
+	if err != nil {
+		return nil, notFound(err, "track", name)
+	}
+
+	return &track, nil
+}
+
+// NextTrackNumber returns the number following the last track of the album.
+func (r *ImportRepository) NextTrackNumber(ctx context.Context, albumID uuid.UUID) (int, error) {
+	const query = `SELECT COALESCE(MAX(track_number), 0) + 1 FROM tracks WHERE album_id = $1`
+
+	var number int
+	if err := r.pool.QueryRow(ctx, query, albumID).Scan(&number); err != nil {
+		return 0, fmt.Errorf("get next track number: %w", err)
+	}
+
+	return number, nil
+}
+
+// DeleteAlbumIfUnused deletes the album unless it has tracks, e.g. of a concurrent
+// import matching it, and reports whether it was deleted.
+func (r *ImportRepository) DeleteAlbumIfUnused(ctx context.Context, albumID uuid.UUID) (bool, error) {
+	const query = `
+		DELETE FROM albums a
+		WHERE a.id = $1
+			AND NOT EXISTS (SELECT 1 FROM tracks t WHERE t.album_id = a.id)
+	`
+
+	tag, err := r.pool.Exec(ctx, query, albumID)
+	if err != nil {
+		return false, fmt.Errorf("delete unused album: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *ImportRepository) DeleteArtistIfUnused(ctx context.Context, artistID uuid.UUID) error {
+	const query = `
+		DELETE FROM artists a
+		WHERE a.id = $1
+			AND NOT EXISTS (SELECT 1 FROM artist_tracks at WHERE at.artist_id = a.id)
+			AND NOT EXISTS (SELECT 1 FROM artist_albums aa WHERE aa.artist_id = a.id)
+	`
+
+	if _, err := r.pool.Exec(ctx, query, artistID); err != nil {
+		return fmt.Errorf("delete unused artist: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImportRepository) DeleteGenreIfUnused(ctx context.Context, genreID uuid.UUID) error {
+	const query = `
+		DELETE FROM genres g
+		WHERE g.id = $1
+			AND NOT EXISTS (SELECT 1 FROM tracks t WHERE t.genre_id = g.id)
+			AND NOT EXISTS (SELECT 1 FROM album_genres ag WHERE ag.genre_id = g.id)
+	`
+
+	if _, err := r.pool.Exec(ctx, query, genreID); err != nil {
+		return fmt.Errorf("delete unused genre: %w", err)
+	}
+
+	return nil
+}
+
+func notFound(err error, object, name string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s %q", commonerr.ErrNotFound, object, name)
+	}
+
+	return fmt.Errorf("get %s: %w", object, err)
+}
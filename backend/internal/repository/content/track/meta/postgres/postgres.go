@@ -2,12 +2,16 @@ package track_meta_postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/postgres"
 )
 
 type TrackMetaRepository struct {
@@ -20,7 +24,8 @@ func NewTrackMetaRepository(pool *pgxpool.Pool) *TrackMetaRepository {
 
 func (r *TrackMetaRepository) GetByID(ctx context.Context, trackID uuid.UUID) (*entity.TrackMeta, error) {
 	query := `
-		SELECT id, genre_id, name, duration, explicit, license_id, album_id, track_number, total_streams
+		SELECT id, genre_id, name, duration, explicit, license_id, album_id, track_number, total_streams, format, bitrate, sample_rate,
+			loudness, true_peak, album_loudness, album_true_peak
 		FROM tracks
 		WHERE id = $1
 	`
@@ -28,6 +33,7 @@ func (r *TrackMetaRepository) GetByID(ctx context.Context, trackID uuid.UUID) (*
 	row := r.pool.QueryRow(ctx, query, trackID)
 
 	var track entity.TrackMeta
+	var loudness, truePeak, albumLoudness, albumTruePeak *float64
 	err := row.Scan(
 		&track.ID,
 		&track.GenreID,
@@ -38,10 +44,22 @@ func (r *TrackMetaRepository) GetByID(ctx context.Context, trackID uuid.UUID) (*
 		&track.AlbumID,
 		&track.TrackNumber,
 		&track.TotalStreams,
+		&track.Format,
+		&track.Bitrate,
+		&track.SampleRate,
+		&loudness,
+		&truePeak,
+		&albumLoudness,
+		&albumTruePeak,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: track %s", commonerr.ErrNotFound, trackID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get track: %w", err)
 	}
+	track.Loudness = entity.NewLoudness(loudness, truePeak)
+	track.AlbumLoudness = entity.NewLoudness(albumLoudness, albumTruePeak)
 
 	return &track, nil
 }
@@ -130,6 +148,21 @@ func (r *TrackMetaRepository) Update(ctx context.Context, track *entity.TrackMet
 	return nil
 }
 
+func (r *TrackMetaRepository) UpdateAudioInfo(ctx context.Context, trackID uuid.UUID, info *entity.AudioInfo) error {
+	query := `
+		UPDATE tracks
+		SET format = $2, duration = $3, bitrate = $4, sample_rate = $5
+		WHERE id = $1
+	`
+
+	_, err := r.pool.Exec(ctx, query, trackID, info.Format, info.Seconds(), info.Bitrate, info.SampleRate)
+	if err != nil {
+		return fmt.Errorf("failed to update track audio info: %w", err)
+	}
+
+	return nil
+}
+
 func (r *TrackMetaRepository) Delete(ctx context.Context, trackID uuid.UUID) error {
 	query := `
 		WITH deleted_track AS (
@@ -158,10 +191,45 @@ func (r *TrackMetaRepository) IncrementTrackTotalStreams(ctx context.Context, tr
 		WHERE id = $1
 	`
 
-	_, err := r.pool.Exec(ctx, query, trackID)
+	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, trackID)
 	if err != nil {
 		return fmt.Errorf("failed to increment track total streams: %w", err)
 	}
 
 	return nil
 }
+
+func (r *TrackMetaRepository) UpdateLoudness(ctx context.Context, trackID uuid.UUID, loudness *entity.Loudness) error {
+	query := `
+		UPDATE tracks
+		SET loudness = $2, true_peak = $3
+		WHERE id = $1
+	`
+
+	_, err := r.pool.Exec(ctx, query, trackID, loudness.Integrated, loudness.TruePeak)
+	if err != nil {
+		return fmt.Errorf("failed to update track loudness: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TrackMetaRepository) UpdateAlbumLoudness(ctx context.Context, albumID uuid.UUID, loudness *entity.Loudness) error {
+	query := `
+		UPDATE tracks
+		SET album_loudness = $2, album_true_peak = $3
+		WHERE album_id = $1
+	`
+
+	var integrated, truePeak *float64
+	if loudness != nil {
+		integrated, truePeak = &loudness.Integrated, &loudness.TruePeak
+	}
+
+	_, err := r.pool.Exec(ctx, query, albumID, integrated, truePeak)
+	if err != nil {
+		return fmt.Errorf("failed to update album loudness: %w", err)
+	}
+
+	return nil
+}
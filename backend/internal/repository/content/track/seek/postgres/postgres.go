@@ -0,0 +1,60 @@
+package seek_postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	commonerr "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SeekTableRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSeekTableRepository(pool *pgxpool.Pool) *SeekTableRepository {
+	return &SeekTableRepository{pool: pool}
+}
+
+func (r *SeekTableRepository) GetSeekTable(ctx context.Context, hash string) (*entity.SeekTable, error) {
+	query := `
+		SELECT interval_ms, offsets
+		FROM audio_seek_tables
+		WHERE hash = $1
+	`
+
+	var intervalMs int64
+	table := &entity.SeekTable{Hash: hash}
+	err := r.pool.QueryRow(ctx, query, hash).Scan(&intervalMs, &table.Offsets)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: seek table of blob %s", commonerr.ErrNotFound, hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seek table: %w", err)
+	}
+	table.Interval = time.Duration(intervalMs) * time.Millisecond
+
+	return table, nil
+}
+
+// SaveSeekTable keeps the stored table if there is one, the same content always yields the same table.
+// A table of a blob no file points to anymore is not saved, it is deleted with the blob.
+func (r *SeekTableRepository) SaveSeekTable(ctx context.Context, table *entity.SeekTable) error {
+	query := `
+		INSERT INTO audio_seek_tables (hash, interval_ms, offsets)
+		SELECT $1, $2, $3
+		WHERE EXISTS (SELECT 1 FROM track_audio_files WHERE hash = $1)
+		ON CONFLICT (hash) DO NOTHING
+	`
+
+	_, err := r.pool.Exec(ctx, query, table.Hash, table.Interval.Milliseconds(), table.Offsets)
+	if err != nil {
+		return fmt.Errorf("failed to save seek table: %w", err)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/postgres"
 	"github.com/jackc/pgx"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -21,7 +22,7 @@ func NewTrackSegmentRepository(pool *pgxpool.Pool) *TrackSegmentRepository {
 }
 
 func (r *TrackSegmentRepository) IncrementTotalStreams(ctx context.Context, trackID uuid.UUID, segmentsIdxs []int) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := postgres.Conn(ctx, r.pool).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
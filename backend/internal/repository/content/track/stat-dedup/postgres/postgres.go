@@ -0,0 +1,64 @@
+package stat_dedup_postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const DefaultWindow = 24 * time.Hour
+
+// ListeningEventDedupRepository remembers the processed events for the dedup window,
+// a repeated event is recognized only within it.
+type ListeningEventDedupRepository struct {
+	pool   *pgxpool.Pool
+	window time.Duration
+}
+
+func NewListeningEventDedupRepository(pool *pgxpool.Pool, window time.Duration) *ListeningEventDedupRepository {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+
+	return &ListeningEventDedupRepository{
+		pool:   pool,
+		window: window,
+	}
+}
+
+// Claim is meant to run in the transaction of the stat increments, so a failed
+// event is rolled back with them. An event older than the window is claimed again.
+func (r *ListeningEventDedupRepository) Claim(ctx context.Context, userID, eventID uuid.UUID) (bool, error) {
+	const query = `
+		INSERT INTO processed_listening_events (user_id, event_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, event_id) DO UPDATE SET processed_at = NOW()
+		WHERE processed_listening_events.processed_at < NOW() - $3::interval
+	`
+
+	tag, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, userID, eventID, r.window)
+	if err != nil {
+		return false, fmt.Errorf("claim listening event: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// DeleteExpired deletes the events older than the window and returns their number.
+func (r *ListeningEventDedupRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	const query = `
+		DELETE FROM processed_listening_events
+		WHERE processed_at < NOW() - $1::interval
+	`
+
+	tag, err := r.pool.Exec(ctx, query, r.window)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired listening events: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
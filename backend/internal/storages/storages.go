@@ -0,0 +1,167 @@
+// Package storages opens the audio and cover storages selected by the config,
+// so the API, the CLI and the workers agree on the defaults.
+package storages
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/hahaclassic/orpheon/backend/internal/config"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	migration_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/storage/migration"
+	scrub_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/scrub"
+	upload_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/upload"
+	waveform_service "github.com/hahaclassic/orpheon/backend/internal/domain/services/content/track/waveform"
+	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/minio"
+	cover_fs "github.com/hahaclassic/orpheon/backend/internal/repository/content/cover/fs"
+	cover_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/cover/minio"
+	audio_cas "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/content-addressed"
+	audio_fs "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/fs"
+	audio_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/minio"
+	audio_tiered "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/tiered"
+	minio_go "github.com/minio/minio-go/v7"
+)
+
+const (
+	FS    = "fs"
+	MinIO = "minio" // used when the type is not set
+)
+
+// the directories of the covers in COVER_STORAGE_BASE_PATH
+const (
+	playlistCoversDir = "playlist-covers"
+	albumCoversDir    = "album-covers"
+	artistAvatarsDir  = "artist-avatars"
+)
+
+// the key prefixes of the covers in their MinIO buckets
+const (
+	playlistCoversPrefix = "playlist_covers/"
+	albumCoversPrefix    = "album-covers/"
+	artistAvatarsPrefix  = ""
+)
+
+type AudioStorage interface {
+	audio_cas.BlobStore
+	audio_cas.LegacyStorage
+	upload_service.UploadStorage
+	waveform_service.WaveformRepository
+	scrub_service.QuarantineStorage
+	migration_service.BlobStorage
+}
+
+type CoverStorage interface {
+	migration_service.CoverStorage
+	SaveCovers(ctx context.Context, covers []*entity.Cover) error
+	DeleteCover(ctx context.Context, objectID uuid.UUID) error
+}
+
+type CoverStorages struct {
+	Playlist CoverStorage
+	Album    CoverStorage
+	Avatar   CoverStorage
+}
+
+// UsesMinIO reports whether any of the configured storages is kept in MinIO.
+func UsesMinIO(conf *config.Config) bool {
+	return conf.AudioStorage.Type != FS || conf.CoverStorage.Type != FS
+}
+
+// connectMinIO connects on demand, MinIO is left out of deployments keeping
+// everything on the filesystem.
+func connectMinIO(conf *config.Config, client *minio_go.Client) (*minio_go.Client, error) {
+	if client != nil {
+		return client, nil
+	}
+
+	return minio.NewMinioClient(conf.MinIO)
+}
+
+// NewAudioStorage opens the audio storage of the given type, AUDIO_STORAGE_TYPE
+// is passed by everything but the storage migration.
+func NewAudioStorage(ctx context.Context, conf *config.Config, minioClient *minio_go.Client,
+	storageType string) (AudioStorage, error) {
+	var (
+		storage AudioStorage
+		err     error
+	)
+
+	switch storageType {
+	case FS:
+		storage, err = audio_fs.NewAudioStorage(conf.AudioStorage.BasePath)
+	case MinIO, "":
+		if minioClient, err = connectMinIO(conf, minioClient); err != nil {
+			return nil, err
+		}
+		storage, err = audio_minio.NewAudioStorage(ctx, minioClient, conf.MinIO.BucketAudio)
+	default:
+		return nil, fmt.Errorf("unknown audio storage type %q", storageType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio file repository: %w", err)
+	}
+
+	return storage, nil
+}
+
+// NewAudioCache puts the local cache in front of the origin, it returns nil
+// if the cache is not configured.
+func NewAudioCache(ctx context.Context, conf *config.Config, origin audio_tiered.Origin,
+	popularity audio_tiered.BlobPopularity) (*audio_tiered.BlobStore, error) {
+	if conf.AudioStorage.CacheDir == "" || conf.AudioStorage.CacheSizeMB <= 0 {
+		return nil, nil
+	}
+
+	cache, err := audio_fs.NewAudioStorage(conf.AudioStorage.CacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio cache: %w", err)
+	}
+
+	return audio_tiered.New(ctx, origin, cache, popularity, audio_tiered.Config{
+		Capacity: conf.AudioStorage.CacheSizeMB << 20,
+		Eviction: conf.AudioStorage.CacheEviction,
+	})
+}
+
+// NewCoverStorages opens the cover storages of the given type, COVER_STORAGE_TYPE
+// is passed by everything but the storage migration.
+func NewCoverStorages(ctx context.Context, conf *config.Config, minioClient *minio_go.Client,
+	storageType string) (*CoverStorages, error) {
+	var (
+		covers CoverStorages
+		err    error
+	)
+
+	switch storageType {
+	case FS:
+		base := conf.CoverStorage.BasePath
+		if covers.Playlist, err = cover_fs.NewCoverRepository(filepath.Join(base, playlistCoversDir)); err != nil {
+			return nil, err
+		}
+		if covers.Album, err = cover_fs.NewCoverRepository(filepath.Join(base, albumCoversDir)); err != nil {
+			return nil, err
+		}
+		if covers.Avatar, err = cover_fs.NewCoverRepository(filepath.Join(base, artistAvatarsDir)); err != nil {
+			return nil, err
+		}
+	case MinIO, "":
+		if minioClient, err = connectMinIO(conf, minioClient); err != nil {
+			return nil, err
+		}
+		if covers.Playlist, err = cover_minio.NewCoverRepository(ctx, minioClient, conf.MinIO.BucketPlaylist, playlistCoversPrefix); err != nil {
+			return nil, err
+		}
+		if covers.Album, err = cover_minio.NewCoverRepository(ctx, minioClient, conf.MinIO.BucketAlbum, albumCoversPrefix); err != nil {
+			return nil, err
+		}
+		if covers.Avatar, err = cover_minio.NewCoverRepository(ctx, minioClient, conf.MinIO.BucketArtistAvatar, artistAvatarsPrefix); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown cover storage type %q", storageType)
+	}
+
+	return &covers, nil
+}
@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	inmemory "github.com/hahaclassic/orpheon/backend/internal/adapters/event-bus/in-memory"
+	"github.com/hahaclassic/orpheon/backend/internal/adapters/event-bus/kafka"
+	"github.com/hahaclassic/orpheon/backend/internal/config"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/entity"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/charts"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/consumer"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/history"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/processor"
+	"github.com/hahaclassic/orpheon/backend/internal/domain/services/stat/publisher"
+	stats "github.com/hahaclassic/orpheon/backend/internal/domain/usecases/stat"
+	"github.com/hahaclassic/orpheon/backend/internal/infrastructure/postgres"
+	charts_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/charts/postgres"
+	history_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/history/postgres"
+	track_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/meta/postgres"
+	segment_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/segment/postgres"
+	stat_dedup_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/stat-dedup/postgres"
+)
+
+const (
+	MemoryBus = "memory"
+	KafkaBus  = "kafka"
+)
+
+// a failed consumer is restarted with a doubling delay
+const (
+	consumerRestartDelay    = time.Second
+	maxConsumerRestartDelay = time.Minute
+)
+
+type ListeningEventBus interface {
+	publisher.EventBusPub
+	consumer.EventBusSub
+}
+
+// NewEventBus returns the bus configured by EVENT_BUS_TYPE, the in-memory one by default.
+func NewEventBus(conf config.EventBusConfig) (ListeningEventBus, error) {
+	switch conf.Type {
+	case MemoryBus, "":
+		return inmemory.NewInMemoryEventBus[*entity.ListeningEvent](conf.QueueSize), nil
+	case KafkaBus:
+		if len(conf.KafkaBrokers) == 0 {
+			return nil, errors.New("no kafka brokers configured")
+		}
+		return NewKafkaEventBus(conf), nil
+	default:
+		return nil, fmt.Errorf("unknown event bus type %q", conf.Type)
+	}
+}
+
+func NewKafkaEventBus(conf config.EventBusConfig) *kafka.KafkaEventBus {
+	return kafka.NewKafkaEventBus(conf.KafkaBrokers, conf.KafkaGroupID,
+		kafka.WithRetries(conf.KafkaMaxRetries, conf.KafkaRetryBackoff, conf.KafkaRetryMaxDelay),
+		kafka.WithDeadLetterTopic(conf.KafkaDeadLetterTopic),
+		kafka.WithPermanentErrors(postgres.IsPermanentError),
+	)
+}
+
+// RunConsumers starts the consumer in the given number of goroutines and waits
+// for all of them to stop, either with the context or once the bus is closed.
+func RunConsumers(ctx context.Context, c stats.ListeningEventConsumer, workers int) {
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runConsumer(ctx, c)
+		}()
+	}
+	wg.Wait()
+}
+
+// runConsumer restarts the consumer until it stops without an error. The delay
+// starts over once the consumer has run longer than the longest delay.
+func runConsumer(ctx context.Context, c stats.ListeningEventConsumer) {
+	delay := consumerRestartDelay
+	for {
+		started := time.Now()
+		err := c.Start(ctx)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) > maxConsumerRestartDelay {
+			delay = consumerRestartDelay
+		}
+		slog.Error("listening event consumer failed, restarting", "err", err, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxConsumerRestartDelay)
+	}
+}
+
+// Run processes the listening events published to Kafka by the API.
+// The process exits with status 1 if the worker is misconfigured.
+func Run(conf *config.Config) {
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
+	if conf.EventBus.Type != KafkaBus {
+		slog.Error("stats worker requires EVENT_BUS_TYPE=kafka, the memory bus is consumed by the API itself",
+			"type", conf.EventBus.Type)
+		exitCode = 1
+		return
+	}
+
+	if len(conf.EventBus.KafkaBrokers) == 0 {
+		slog.Error("no kafka brokers configured")
+		exitCode = 1
+		return
+	}
+
+	bus := NewKafkaEventBus(conf.EventBus)
+	defer func() {
+		if err := bus.Close(); err != nil {
+			slog.Error("failed to close event bus", "err", err)
+		}
+	}()
+
+	pgxpool := postgres.NewPostgresPool(conf.Postgres)
+	defer pgxpool.Close()
+
+	chartRepo := charts_postgres.NewChartRepository(pgxpool)
+	listeningStatService := processor.NewListeningStatService(
+		track_meta_postgres.NewTrackMetaRepository(pgxpool),
+		segment_postgres.NewTrackSegmentRepository(pgxpool),
+		stat_dedup_postgres.NewListeningEventDedupRepository(pgxpool, conf.EventBus.DedupWindow),
+		chartRepo,
+		postgres.NewTransactor(pgxpool),
+	)
+	// the worker only snapshots the weekly charts, which never loads the tracks
+	chartService := charts.New(chartRepo, nil)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("starting stats worker", "workers", max(conf.EventBus.Workers, 1))
+	listeningHistoryService := history.New(history_postgres.NewListeningHistoryRepository(pgxpool))
+
+	go listeningStatService.RunDedupCleanup(ctx, processor.DefaultDedupCleanupInterval)
+	go chartService.RunMaintenance(ctx, conf.Charts.MaintenanceInterval)
+	RunConsumers(ctx, consumer.New(bus, listeningStatService, listeningHistoryService), conf.EventBus.Workers)
+	slog.Info("stats worker exited")
+}
@@ -5,11 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
-
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // AlbumCoverRepository is an autogenerated mock type for the AlbumCoverRepository type
@@ -72,9 +70,9 @@ func (_c *AlbumCoverRepository_DeleteCover_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
-// GetCover provides a mock function with given fields: ctx, albumID
-func (_m *AlbumCoverRepository) GetCover(ctx context.Context, albumID uuid.UUID) (*entity.Cover, error) {
-	ret := _m.Called(ctx, albumID)
+// GetCover provides a mock function with given fields: ctx, albumID, size
+func (_m *AlbumCoverRepository) GetCover(ctx context.Context, albumID uuid.UUID, size int) (*entity.Cover, error) {
+	ret := _m.Called(ctx, albumID, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCover")
@@ -82,19 +80,19 @@ func (_m *AlbumCoverRepository) GetCover(ctx context.Context, albumID uuid.UUID)
 
 	var r0 *entity.Cover
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Cover, error)); ok {
-		return rf(ctx, albumID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (*entity.Cover, error)); ok {
+		return rf(ctx, albumID, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Cover); ok {
-		r0 = rf(ctx, albumID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) *entity.Cover); ok {
+		r0 = rf(ctx, albumID, size)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Cover)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, albumID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, albumID, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,13 +108,14 @@ type AlbumCoverRepository_GetCover_Call struct {
 // GetCover is a helper method to define mock.On call
 //   - ctx context.Context
 //   - albumID uuid.UUID
-func (_e *AlbumCoverRepository_Expecter) GetCover(ctx interface{}, albumID interface{}) *AlbumCoverRepository_GetCover_Call {
-	return &AlbumCoverRepository_GetCover_Call{Call: _e.mock.On("GetCover", ctx, albumID)}
+//   - size int
+func (_e *AlbumCoverRepository_Expecter) GetCover(ctx interface{}, albumID interface{}, size interface{}) *AlbumCoverRepository_GetCover_Call {
+	return &AlbumCoverRepository_GetCover_Call{Call: _e.mock.On("GetCover", ctx, albumID, size)}
 }
 
-func (_c *AlbumCoverRepository_GetCover_Call) Run(run func(ctx context.Context, albumID uuid.UUID)) *AlbumCoverRepository_GetCover_Call {
+func (_c *AlbumCoverRepository_GetCover_Call) Run(run func(ctx context.Context, albumID uuid.UUID, size int)) *AlbumCoverRepository_GetCover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
 	})
 	return _c
 }
@@ -126,22 +125,22 @@ func (_c *AlbumCoverRepository_GetCover_Call) Return(_a0 *entity.Cover, _a1 erro
 	return _c
 }
 
-func (_c *AlbumCoverRepository_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Cover, error)) *AlbumCoverRepository_GetCover_Call {
+func (_c *AlbumCoverRepository_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) (*entity.Cover, error)) *AlbumCoverRepository_GetCover_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveCover provides a mock function with given fields: ctx, _a1
-func (_m *AlbumCoverRepository) SaveCover(ctx context.Context, _a1 *entity.Cover) error {
-	ret := _m.Called(ctx, _a1)
+// SaveCovers provides a mock function with given fields: ctx, covers
+func (_m *AlbumCoverRepository) SaveCovers(ctx context.Context, covers []*entity.Cover) error {
+	ret := _m.Called(ctx, covers)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveCover")
+		panic("no return value specified for SaveCovers")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Cover) error); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, []*entity.Cover) error); ok {
+		r0 = rf(ctx, covers)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -149,31 +148,31 @@ func (_m *AlbumCoverRepository) SaveCover(ctx context.Context, _a1 *entity.Cover
 	return r0
 }
 
-// AlbumCoverRepository_SaveCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCover'
-type AlbumCoverRepository_SaveCover_Call struct {
+// AlbumCoverRepository_SaveCovers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCovers'
+type AlbumCoverRepository_SaveCovers_Call struct {
 	*mock.Call
 }
 
-// SaveCover is a helper method to define mock.On call
+// SaveCovers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - _a1 *entity.Cover
-func (_e *AlbumCoverRepository_Expecter) SaveCover(ctx interface{}, _a1 interface{}) *AlbumCoverRepository_SaveCover_Call {
-	return &AlbumCoverRepository_SaveCover_Call{Call: _e.mock.On("SaveCover", ctx, _a1)}
+//   - covers []*entity.Cover
+func (_e *AlbumCoverRepository_Expecter) SaveCovers(ctx interface{}, covers interface{}) *AlbumCoverRepository_SaveCovers_Call {
+	return &AlbumCoverRepository_SaveCovers_Call{Call: _e.mock.On("SaveCovers", ctx, covers)}
 }
 
-func (_c *AlbumCoverRepository_SaveCover_Call) Run(run func(ctx context.Context, _a1 *entity.Cover)) *AlbumCoverRepository_SaveCover_Call {
+func (_c *AlbumCoverRepository_SaveCovers_Call) Run(run func(ctx context.Context, covers []*entity.Cover)) *AlbumCoverRepository_SaveCovers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.Cover))
+		run(args[0].(context.Context), args[1].([]*entity.Cover))
 	})
 	return _c
 }
 
-func (_c *AlbumCoverRepository_SaveCover_Call) Return(_a0 error) *AlbumCoverRepository_SaveCover_Call {
+func (_c *AlbumCoverRepository_SaveCovers_Call) Return(_a0 error) *AlbumCoverRepository_SaveCovers_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *AlbumCoverRepository_SaveCover_Call) RunAndReturn(run func(context.Context, *entity.Cover) error) *AlbumCoverRepository_SaveCover_Call {
+func (_c *AlbumCoverRepository_SaveCovers_Call) RunAndReturn(run func(context.Context, []*entity.Cover) error) *AlbumCoverRepository_SaveCovers_Call {
 	_c.Call.Return(run)
 	return _c
 }
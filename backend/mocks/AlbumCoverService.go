@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // AlbumCoverService is an autogenerated mock type for the AlbumCoverService type
@@ -72,9 +71,9 @@ func (_c *AlbumCoverService_DeleteCover_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
-// GetCover provides a mock function with given fields: ctx, albumID
-func (_m *AlbumCoverService) GetCover(ctx context.Context, albumID uuid.UUID) (*entity.Cover, error) {
-	ret := _m.Called(ctx, albumID)
+// GetCover provides a mock function with given fields: ctx, albumID, size
+func (_m *AlbumCoverService) GetCover(ctx context.Context, albumID uuid.UUID, size int) (*entity.Cover, error) {
+	ret := _m.Called(ctx, albumID, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCover")
@@ -82,19 +81,19 @@ func (_m *AlbumCoverService) GetCover(ctx context.Context, albumID uuid.UUID) (*
 
 	var r0 *entity.Cover
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Cover, error)); ok {
-		return rf(ctx, albumID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (*entity.Cover, error)); ok {
+		return rf(ctx, albumID, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Cover); ok {
-		r0 = rf(ctx, albumID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) *entity.Cover); ok {
+		r0 = rf(ctx, albumID, size)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Cover)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, albumID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, albumID, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,13 +109,14 @@ type AlbumCoverService_GetCover_Call struct {
 // GetCover is a helper method to define mock.On call
 //   - ctx context.Context
 //   - albumID uuid.UUID
-func (_e *AlbumCoverService_Expecter) GetCover(ctx interface{}, albumID interface{}) *AlbumCoverService_GetCover_Call {
-	return &AlbumCoverService_GetCover_Call{Call: _e.mock.On("GetCover", ctx, albumID)}
+//   - size int
+func (_e *AlbumCoverService_Expecter) GetCover(ctx interface{}, albumID interface{}, size interface{}) *AlbumCoverService_GetCover_Call {
+	return &AlbumCoverService_GetCover_Call{Call: _e.mock.On("GetCover", ctx, albumID, size)}
 }
 
-func (_c *AlbumCoverService_GetCover_Call) Run(run func(ctx context.Context, albumID uuid.UUID)) *AlbumCoverService_GetCover_Call {
+func (_c *AlbumCoverService_GetCover_Call) Run(run func(ctx context.Context, albumID uuid.UUID, size int)) *AlbumCoverService_GetCover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
 	})
 	return _c
 }
@@ -126,7 +126,7 @@ func (_c *AlbumCoverService_GetCover_Call) Return(_a0 *entity.Cover, _a1 error)
 	return _c
 }
 
-func (_c *AlbumCoverService_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Cover, error)) *AlbumCoverService_GetCover_Call {
+func (_c *AlbumCoverService_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) (*entity.Cover, error)) *AlbumCoverService_GetCover_Call {
 	_c.Call.Return(run)
 	return _c
 }
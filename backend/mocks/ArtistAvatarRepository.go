@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // ArtistAvatarRepository is an autogenerated mock type for the ArtistAvatarRepository type
@@ -71,9 +70,9 @@ func (_c *ArtistAvatarRepository_DeleteCover_Call) RunAndReturn(run func(context
 	return _c
 }
 
-// GetCover provides a mock function with given fields: ctx, artistID
-func (_m *ArtistAvatarRepository) GetCover(ctx context.Context, artistID uuid.UUID) (*entity.Cover, error) {
-	ret := _m.Called(ctx, artistID)
+// GetCover provides a mock function with given fields: ctx, artistID, size
+func (_m *ArtistAvatarRepository) GetCover(ctx context.Context, artistID uuid.UUID, size int) (*entity.Cover, error) {
+	ret := _m.Called(ctx, artistID, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCover")
@@ -81,19 +80,19 @@ func (_m *ArtistAvatarRepository) GetCover(ctx context.Context, artistID uuid.UU
 
 	var r0 *entity.Cover
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Cover, error)); ok {
-		return rf(ctx, artistID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (*entity.Cover, error)); ok {
+		return rf(ctx, artistID, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Cover); ok {
-		r0 = rf(ctx, artistID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) *entity.Cover); ok {
+		r0 = rf(ctx, artistID, size)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Cover)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, artistID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, artistID, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -109,13 +108,14 @@ type ArtistAvatarRepository_GetCover_Call struct {
 // GetCover is a helper method to define mock.On call
 //   - ctx context.Context
 //   - artistID uuid.UUID
-func (_e *ArtistAvatarRepository_Expecter) GetCover(ctx interface{}, artistID interface{}) *ArtistAvatarRepository_GetCover_Call {
-	return &ArtistAvatarRepository_GetCover_Call{Call: _e.mock.On("GetCover", ctx, artistID)}
+//   - size int
+func (_e *ArtistAvatarRepository_Expecter) GetCover(ctx interface{}, artistID interface{}, size interface{}) *ArtistAvatarRepository_GetCover_Call {
+	return &ArtistAvatarRepository_GetCover_Call{Call: _e.mock.On("GetCover", ctx, artistID, size)}
 }
 
-func (_c *ArtistAvatarRepository_GetCover_Call) Run(run func(ctx context.Context, artistID uuid.UUID)) *ArtistAvatarRepository_GetCover_Call {
+func (_c *ArtistAvatarRepository_GetCover_Call) Run(run func(ctx context.Context, artistID uuid.UUID, size int)) *ArtistAvatarRepository_GetCover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
 	})
 	return _c
 }
@@ -125,22 +125,22 @@ func (_c *ArtistAvatarRepository_GetCover_Call) Return(_a0 *entity.Cover, _a1 er
 	return _c
 }
 
-func (_c *ArtistAvatarRepository_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Cover, error)) *ArtistAvatarRepository_GetCover_Call {
+func (_c *ArtistAvatarRepository_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) (*entity.Cover, error)) *ArtistAvatarRepository_GetCover_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveCover provides a mock function with given fields: ctx, cover
-func (_m *ArtistAvatarRepository) SaveCover(ctx context.Context, cover *entity.Cover) error {
-	ret := _m.Called(ctx, cover)
+// SaveCovers provides a mock function with given fields: ctx, covers
+func (_m *ArtistAvatarRepository) SaveCovers(ctx context.Context, covers []*entity.Cover) error {
+	ret := _m.Called(ctx, covers)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveCover")
+		panic("no return value specified for SaveCovers")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Cover) error); ok {
-		r0 = rf(ctx, cover)
+	if rf, ok := ret.Get(0).(func(context.Context, []*entity.Cover) error); ok {
+		r0 = rf(ctx, covers)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -148,31 +148,31 @@ func (_m *ArtistAvatarRepository) SaveCover(ctx context.Context, cover *entity.C
 	return r0
 }
 
-// ArtistAvatarRepository_SaveCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCover'
-type ArtistAvatarRepository_SaveCover_Call struct {
+// ArtistAvatarRepository_SaveCovers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCovers'
+type ArtistAvatarRepository_SaveCovers_Call struct {
 	*mock.Call
 }
 
-// SaveCover is a helper method to define mock.On call
+// SaveCovers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - cover *entity.Cover
-func (_e *ArtistAvatarRepository_Expecter) SaveCover(ctx interface{}, cover interface{}) *ArtistAvatarRepository_SaveCover_Call {
-	return &ArtistAvatarRepository_SaveCover_Call{Call: _e.mock.On("SaveCover", ctx, cover)}
+//   - covers []*entity.Cover
+func (_e *ArtistAvatarRepository_Expecter) SaveCovers(ctx interface{}, covers interface{}) *ArtistAvatarRepository_SaveCovers_Call {
+	return &ArtistAvatarRepository_SaveCovers_Call{Call: _e.mock.On("SaveCovers", ctx, covers)}
 }
 
-func (_c *ArtistAvatarRepository_SaveCover_Call) Run(run func(ctx context.Context, cover *entity.Cover)) *ArtistAvatarRepository_SaveCover_Call {
+func (_c *ArtistAvatarRepository_SaveCovers_Call) Run(run func(ctx context.Context, covers []*entity.Cover)) *ArtistAvatarRepository_SaveCovers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.Cover))
+		run(args[0].(context.Context), args[1].([]*entity.Cover))
 	})
 	return _c
 }
 
-func (_c *ArtistAvatarRepository_SaveCover_Call) Return(_a0 error) *ArtistAvatarRepository_SaveCover_Call {
+func (_c *ArtistAvatarRepository_SaveCovers_Call) Return(_a0 error) *ArtistAvatarRepository_SaveCovers_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ArtistAvatarRepository_SaveCover_Call) RunAndReturn(run func(context.Context, *entity.Cover) error) *ArtistAvatarRepository_SaveCover_Call {
+func (_c *ArtistAvatarRepository_SaveCovers_Call) RunAndReturn(run func(context.Context, []*entity.Cover) error) *ArtistAvatarRepository_SaveCovers_Call {
 	_c.Call.Return(run)
 	return _c
 }
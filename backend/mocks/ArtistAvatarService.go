@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // ArtistAvatarService is an autogenerated mock type for the ArtistAvatarService type
@@ -72,9 +71,9 @@ func (_c *ArtistAvatarService_DeleteCover_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
-// GetCover provides a mock function with given fields: ctx, artistID
-func (_m *ArtistAvatarService) GetCover(ctx context.Context, artistID uuid.UUID) (*entity.Cover, error) {
-	ret := _m.Called(ctx, artistID)
+// GetCover provides a mock function with given fields: ctx, artistID, size
+func (_m *ArtistAvatarService) GetCover(ctx context.Context, artistID uuid.UUID, size int) (*entity.Cover, error) {
+	ret := _m.Called(ctx, artistID, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCover")
@@ -82,19 +81,19 @@ func (_m *ArtistAvatarService) GetCover(ctx context.Context, artistID uuid.UUID)
 
 	var r0 *entity.Cover
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Cover, error)); ok {
-		return rf(ctx, artistID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (*entity.Cover, error)); ok {
+		return rf(ctx, artistID, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Cover); ok {
-		r0 = rf(ctx, artistID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) *entity.Cover); ok {
+		r0 = rf(ctx, artistID, size)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Cover)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, artistID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, artistID, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,13 +109,14 @@ type ArtistAvatarService_GetCover_Call struct {
 // GetCover is a helper method to define mock.On call
 //   - ctx context.Context
 //   - artistID uuid.UUID
-func (_e *ArtistAvatarService_Expecter) GetCover(ctx interface{}, artistID interface{}) *ArtistAvatarService_GetCover_Call {
-	return &ArtistAvatarService_GetCover_Call{Call: _e.mock.On("GetCover", ctx, artistID)}
+//   - size int
+func (_e *ArtistAvatarService_Expecter) GetCover(ctx interface{}, artistID interface{}, size interface{}) *ArtistAvatarService_GetCover_Call {
+	return &ArtistAvatarService_GetCover_Call{Call: _e.mock.On("GetCover", ctx, artistID, size)}
 }
 
-func (_c *ArtistAvatarService_GetCover_Call) Run(run func(ctx context.Context, artistID uuid.UUID)) *ArtistAvatarService_GetCover_Call {
+func (_c *ArtistAvatarService_GetCover_Call) Run(run func(ctx context.Context, artistID uuid.UUID, size int)) *ArtistAvatarService_GetCover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
 	})
 	return _c
 }
@@ -126,7 +126,7 @@ func (_c *ArtistAvatarService_GetCover_Call) Return(_a0 *entity.Cover, _a1 error
 	return _c
 }
 
-func (_c *ArtistAvatarService_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Cover, error)) *ArtistAvatarService_GetCover_Call {
+func (_c *ArtistAvatarService_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) (*entity.Cover, error)) *ArtistAvatarService_GetCover_Call {
 	_c.Call.Return(run)
 	return _c
 }
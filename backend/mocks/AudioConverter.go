@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	mock "github.com/stretchr/testify/mock"
@@ -22,29 +23,29 @@ func (_m *AudioConverter) EXPECT() *AudioConverter_Expecter {
 	return &AudioConverter_Expecter{mock: &_m.Mock}
 }
 
-// ChangeBitrate provides a mock function with given fields: ctx, chunk
-func (_m *AudioConverter) ChangeBitrate(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error) {
-	ret := _m.Called(ctx, chunk)
+// ChangeBitrate provides a mock function with given fields: ctx, src, quality
+func (_m *AudioConverter) ChangeBitrate(ctx context.Context, src io.Reader, quality entity.AudioQuality) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, src, quality)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChangeBitrate")
 	}
 
-	var r0 *entity.AudioChunk
+	var r0 io.ReadCloser
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioChunk) (*entity.AudioChunk, error)); ok {
-		return rf(ctx, chunk)
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, entity.AudioQuality) (io.ReadCloser, error)); ok {
+		return rf(ctx, src, quality)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioChunk) *entity.AudioChunk); ok {
-		r0 = rf(ctx, chunk)
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, entity.AudioQuality) io.ReadCloser); ok {
+		r0 = rf(ctx, src, quality)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.AudioChunk)
+			r0 = ret.Get(0).(io.ReadCloser)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *entity.AudioChunk) error); ok {
-		r1 = rf(ctx, chunk)
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, entity.AudioQuality) error); ok {
+		r1 = rf(ctx, src, quality)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,24 +60,25 @@ type AudioConverter_ChangeBitrate_Call struct {
 
 // ChangeBitrate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chunk *entity.AudioChunk
-func (_e *AudioConverter_Expecter) ChangeBitrate(ctx interface{}, chunk interface{}) *AudioConverter_ChangeBitrate_Call {
-	return &AudioConverter_ChangeBitrate_Call{Call: _e.mock.On("ChangeBitrate", ctx, chunk)}
+//   - src io.Reader
+//   - quality entity.AudioQuality
+func (_e *AudioConverter_Expecter) ChangeBitrate(ctx interface{}, src interface{}, quality interface{}) *AudioConverter_ChangeBitrate_Call {
+	return &AudioConverter_ChangeBitrate_Call{Call: _e.mock.On("ChangeBitrate", ctx, src, quality)}
 }
 
-func (_c *AudioConverter_ChangeBitrate_Call) Run(run func(ctx context.Context, chunk *entity.AudioChunk)) *AudioConverter_ChangeBitrate_Call {
+func (_c *AudioConverter_ChangeBitrate_Call) Run(run func(ctx context.Context, src io.Reader, quality entity.AudioQuality)) *AudioConverter_ChangeBitrate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.AudioChunk))
+		run(args[0].(context.Context), args[1].(io.Reader), args[2].(entity.AudioQuality))
 	})
 	return _c
 }
 
-func (_c *AudioConverter_ChangeBitrate_Call) Return(_a0 *entity.AudioChunk, _a1 error) *AudioConverter_ChangeBitrate_Call {
+func (_c *AudioConverter_ChangeBitrate_Call) Return(_a0 io.ReadCloser, _a1 error) *AudioConverter_ChangeBitrate_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AudioConverter_ChangeBitrate_Call) RunAndReturn(run func(context.Context, *entity.AudioChunk) (*entity.AudioChunk, error)) *AudioConverter_ChangeBitrate_Call {
+func (_c *AudioConverter_ChangeBitrate_Call) RunAndReturn(run func(context.Context, io.Reader, entity.AudioQuality) (io.ReadCloser, error)) *AudioConverter_ChangeBitrate_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,11 +4,11 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
+	uuid "github.com/google/uuid"
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // AudioFileRepository is an autogenerated mock type for the AudioFileRepository type
@@ -71,76 +71,86 @@ func (_c *AudioFileRepository_DeleteFile_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
-// GetAudioChunk provides a mock function with given fields: ctx, chunk
-func (_m *AudioFileRepository) GetAudioChunk(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error) {
-	ret := _m.Called(ctx, chunk)
+// OpenAudioFile provides a mock function with given fields: ctx, trackID, quality
+func (_m *AudioFileRepository) OpenAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error) {
+	ret := _m.Called(ctx, trackID, quality)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAudioChunk")
+		panic("no return value specified for OpenAudioFile")
 	}
 
-	var r0 *entity.AudioChunk
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioChunk) (*entity.AudioChunk, error)); ok {
-		return rf(ctx, chunk)
+	var r0 *entity.AudioFile
+	var r1 io.ReadSeekCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)); ok {
+		return rf(ctx, trackID, quality)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioChunk) *entity.AudioChunk); ok {
-		r0 = rf(ctx, chunk)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AudioQuality) *entity.AudioFile); ok {
+		r0 = rf(ctx, trackID, quality)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.AudioChunk)
+			r0 = ret.Get(0).(*entity.AudioFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.AudioQuality) io.ReadSeekCloser); ok {
+		r1 = rf(ctx, trackID, quality)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadSeekCloser)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *entity.AudioChunk) error); ok {
-		r1 = rf(ctx, chunk)
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.AudioQuality) error); ok {
+		r2 = rf(ctx, trackID, quality)
 	} else {
-		r1 = ret.Error(1)
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
-// AudioFileRepository_GetAudioChunk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAudioChunk'
-type AudioFileRepository_GetAudioChunk_Call struct {
+// AudioFileRepository_OpenAudioFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenAudioFile'
+type AudioFileRepository_OpenAudioFile_Call struct {
 	*mock.Call
 }
 
-// GetAudioChunk is a helper method to define mock.On call
+// OpenAudioFile is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chunk *entity.AudioChunk
-func (_e *AudioFileRepository_Expecter) GetAudioChunk(ctx interface{}, chunk interface{}) *AudioFileRepository_GetAudioChunk_Call {
-	return &AudioFileRepository_GetAudioChunk_Call{Call: _e.mock.On("GetAudioChunk", ctx, chunk)}
+//   - trackID uuid.UUID
+//   - quality entity.AudioQuality
+func (_e *AudioFileRepository_Expecter) OpenAudioFile(ctx interface{}, trackID interface{}, quality interface{}) *AudioFileRepository_OpenAudioFile_Call {
+	return &AudioFileRepository_OpenAudioFile_Call{Call: _e.mock.On("OpenAudioFile", ctx, trackID, quality)}
 }
 
-func (_c *AudioFileRepository_GetAudioChunk_Call) Run(run func(ctx context.Context, chunk *entity.AudioChunk)) *AudioFileRepository_GetAudioChunk_Call {
+func (_c *AudioFileRepository_OpenAudioFile_Call) Run(run func(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality)) *AudioFileRepository_OpenAudioFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.AudioChunk))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.AudioQuality))
 	})
 	return _c
 }
 
-func (_c *AudioFileRepository_GetAudioChunk_Call) Return(_a0 *entity.AudioChunk, _a1 error) *AudioFileRepository_GetAudioChunk_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *AudioFileRepository_OpenAudioFile_Call) Return(_a0 *entity.AudioFile, _a1 io.ReadSeekCloser, _a2 error) *AudioFileRepository_OpenAudioFile_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *AudioFileRepository_GetAudioChunk_Call) RunAndReturn(run func(context.Context, *entity.AudioChunk) (*entity.AudioChunk, error)) *AudioFileRepository_GetAudioChunk_Call {
+func (_c *AudioFileRepository_OpenAudioFile_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)) *AudioFileRepository_OpenAudioFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UploadAudioFile provides a mock function with given fields: ctx, chunk
-func (_m *AudioFileRepository) UploadAudioFile(ctx context.Context, chunk *entity.AudioChunk) error {
-	ret := _m.Called(ctx, chunk)
+// UploadAudioFile provides a mock function with given fields: ctx, file, content
+func (_m *AudioFileRepository) UploadAudioFile(ctx context.Context, file *entity.AudioFile, content io.Reader) error {
+	ret := _m.Called(ctx, file, content)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UploadAudioFile")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioChunk) error); ok {
-		r0 = rf(ctx, chunk)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioFile, io.Reader) error); ok {
+		r0 = rf(ctx, file, content)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -155,14 +165,15 @@ type AudioFileRepository_UploadAudioFile_Call struct {
 
 // UploadAudioFile is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chunk *entity.AudioChunk
-func (_e *AudioFileRepository_Expecter) UploadAudioFile(ctx interface{}, chunk interface{}) *AudioFileRepository_UploadAudioFile_Call {
-	return &AudioFileRepository_UploadAudioFile_Call{Call: _e.mock.On("UploadAudioFile", ctx, chunk)}
+//   - file *entity.AudioFile
+//   - content io.Reader
+func (_e *AudioFileRepository_Expecter) UploadAudioFile(ctx interface{}, file interface{}, content interface{}) *AudioFileRepository_UploadAudioFile_Call {
+	return &AudioFileRepository_UploadAudioFile_Call{Call: _e.mock.On("UploadAudioFile", ctx, file, content)}
 }
 
-func (_c *AudioFileRepository_UploadAudioFile_Call) Run(run func(ctx context.Context, chunk *entity.AudioChunk)) *AudioFileRepository_UploadAudioFile_Call {
+func (_c *AudioFileRepository_UploadAudioFile_Call) Run(run func(ctx context.Context, file *entity.AudioFile, content io.Reader)) *AudioFileRepository_UploadAudioFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.AudioChunk))
+		run(args[0].(context.Context), args[1].(*entity.AudioFile), args[2].(io.Reader))
 	})
 	return _c
 }
@@ -172,7 +183,7 @@ func (_c *AudioFileRepository_UploadAudioFile_Call) Return(_a0 error) *AudioFile
 	return _c
 }
 
-func (_c *AudioFileRepository_UploadAudioFile_Call) RunAndReturn(run func(context.Context, *entity.AudioChunk) error) *AudioFileRepository_UploadAudioFile_Call {
+func (_c *AudioFileRepository_UploadAudioFile_Call) RunAndReturn(run func(context.Context, *entity.AudioFile, io.Reader) error) *AudioFileRepository_UploadAudioFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
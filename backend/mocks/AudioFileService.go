@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
 	mock "github.com/stretchr/testify/mock"
@@ -72,76 +73,86 @@ func (_c *AudioFileService_DeleteAudioFile_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
-// GetAudioChunk provides a mock function with given fields: ctx, chunk
-func (_m *AudioFileService) GetAudioChunk(ctx context.Context, chunk *entity.AudioChunk) (*entity.AudioChunk, error) {
-	ret := _m.Called(ctx, chunk)
+// GetAudioFile provides a mock function with given fields: ctx, trackID, quality
+func (_m *AudioFileService) GetAudioFile(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error) {
+	ret := _m.Called(ctx, trackID, quality)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAudioChunk")
+		panic("no return value specified for GetAudioFile")
 	}
 
-	var r0 *entity.AudioChunk
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioChunk) (*entity.AudioChunk, error)); ok {
-		return rf(ctx, chunk)
+	var r0 *entity.AudioFile
+	var r1 io.ReadSeekCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)); ok {
+		return rf(ctx, trackID, quality)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.AudioChunk) *entity.AudioChunk); ok {
-		r0 = rf(ctx, chunk)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AudioQuality) *entity.AudioFile); ok {
+		r0 = rf(ctx, trackID, quality)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.AudioChunk)
+			r0 = ret.Get(0).(*entity.AudioFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *entity.AudioChunk) error); ok {
-		r1 = rf(ctx, chunk)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.AudioQuality) io.ReadSeekCloser); ok {
+		r1 = rf(ctx, trackID, quality)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadSeekCloser)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.AudioQuality) error); ok {
+		r2 = rf(ctx, trackID, quality)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// AudioFileService_GetAudioChunk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAudioChunk'
-type AudioFileService_GetAudioChunk_Call struct {
+// AudioFileService_GetAudioFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAudioFile'
+type AudioFileService_GetAudioFile_Call struct {
 	*mock.Call
 }
 
-// GetAudioChunk is a helper method to define mock.On call
+// GetAudioFile is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chunk *entity.AudioChunk
-func (_e *AudioFileService_Expecter) GetAudioChunk(ctx interface{}, chunk interface{}) *AudioFileService_GetAudioChunk_Call {
-	return &AudioFileService_GetAudioChunk_Call{Call: _e.mock.On("GetAudioChunk", ctx, chunk)}
+//   - trackID uuid.UUID
+//   - quality entity.AudioQuality
+func (_e *AudioFileService_Expecter) GetAudioFile(ctx interface{}, trackID interface{}, quality interface{}) *AudioFileService_GetAudioFile_Call {
+	return &AudioFileService_GetAudioFile_Call{Call: _e.mock.On("GetAudioFile", ctx, trackID, quality)}
 }
 
-func (_c *AudioFileService_GetAudioChunk_Call) Run(run func(ctx context.Context, chunk *entity.AudioChunk)) *AudioFileService_GetAudioChunk_Call {
+func (_c *AudioFileService_GetAudioFile_Call) Run(run func(ctx context.Context, trackID uuid.UUID, quality entity.AudioQuality)) *AudioFileService_GetAudioFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.AudioChunk))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.AudioQuality))
 	})
 	return _c
 }
 
-func (_c *AudioFileService_GetAudioChunk_Call) Return(_a0 *entity.AudioChunk, _a1 error) *AudioFileService_GetAudioChunk_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *AudioFileService_GetAudioFile_Call) Return(_a0 *entity.AudioFile, _a1 io.ReadSeekCloser, _a2 error) *AudioFileService_GetAudioFile_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *AudioFileService_GetAudioChunk_Call) RunAndReturn(run func(context.Context, *entity.AudioChunk) (*entity.AudioChunk, error)) *AudioFileService_GetAudioChunk_Call {
+func (_c *AudioFileService_GetAudioFile_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.AudioQuality) (*entity.AudioFile, io.ReadSeekCloser, error)) *AudioFileService_GetAudioFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UploadAudioFile provides a mock function with given fields: ctx, claims, chunk
-func (_m *AudioFileService) UploadAudioFile(ctx context.Context, claims *entity.Claims, chunk *entity.AudioChunk) error {
-	ret := _m.Called(ctx, claims, chunk)
+// UploadAudioFile provides a mock function with given fields: ctx, claims, file, content
+func (_m *AudioFileService) UploadAudioFile(ctx context.Context, claims *entity.Claims, file *entity.AudioFile, content io.Reader) error {
+	ret := _m.Called(ctx, claims, file, content)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UploadAudioFile")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Claims, *entity.AudioChunk) error); ok {
-		r0 = rf(ctx, claims, chunk)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Claims, *entity.AudioFile, io.Reader) error); ok {
+		r0 = rf(ctx, claims, file, content)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -157,14 +168,15 @@ type AudioFileService_UploadAudioFile_Call struct {
 // UploadAudioFile is a helper method to define mock.On call
 //   - ctx context.Context
 //   - claims *entity.Claims
-//   - chunk *entity.AudioChunk
-func (_e *AudioFileService_Expecter) UploadAudioFile(ctx interface{}, claims interface{}, chunk interface{}) *AudioFileService_UploadAudioFile_Call {
-	return &AudioFileService_UploadAudioFile_Call{Call: _e.mock.On("UploadAudioFile", ctx, claims, chunk)}
+//   - file *entity.AudioFile
+//   - content io.Reader
+func (_e *AudioFileService_Expecter) UploadAudioFile(ctx interface{}, claims interface{}, file interface{}, content interface{}) *AudioFileService_UploadAudioFile_Call {
+	return &AudioFileService_UploadAudioFile_Call{Call: _e.mock.On("UploadAudioFile", ctx, claims, file, content)}
 }
 
-func (_c *AudioFileService_UploadAudioFile_Call) Run(run func(ctx context.Context, claims *entity.Claims, chunk *entity.AudioChunk)) *AudioFileService_UploadAudioFile_Call {
+func (_c *AudioFileService_UploadAudioFile_Call) Run(run func(ctx context.Context, claims *entity.Claims, file *entity.AudioFile, content io.Reader)) *AudioFileService_UploadAudioFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.Claims), args[2].(*entity.AudioChunk))
+		run(args[0].(context.Context), args[1].(*entity.Claims), args[2].(*entity.AudioFile), args[3].(io.Reader))
 	})
 	return _c
 }
@@ -174,7 +186,7 @@ func (_c *AudioFileService_UploadAudioFile_Call) Return(_a0 error) *AudioFileSer
 	return _c
 }
 
-func (_c *AudioFileService_UploadAudioFile_Call) RunAndReturn(run func(context.Context, *entity.Claims, *entity.AudioChunk) error) *AudioFileService_UploadAudioFile_Call {
+func (_c *AudioFileService_UploadAudioFile_Call) RunAndReturn(run func(context.Context, *entity.Claims, *entity.AudioFile, io.Reader) error) *AudioFileService_UploadAudioFile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -170,6 +170,79 @@ func (_c *ContentAggregator_GetAlbumsByIDs_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// GetExistingTracksByIDs provides a mock function with given fields: ctx, trackIDs
+func (_m *ContentAggregator) GetExistingTracksByIDs(ctx context.Context, trackIDs ...uuid.UUID) ([]*entity.TrackMetaAggregated, error) {
+	_va := make([]interface{}, len(trackIDs))
+	for _i := range trackIDs {
+		_va[_i] = trackIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExistingTracksByIDs")
+	}
+
+	var r0 []*entity.TrackMetaAggregated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...uuid.UUID) ([]*entity.TrackMetaAggregated, error)); ok {
+		return rf(ctx, trackIDs...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...uuid.UUID) []*entity.TrackMetaAggregated); ok {
+		r0 = rf(ctx, trackIDs...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.TrackMetaAggregated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...uuid.UUID) error); ok {
+		r1 = rf(ctx, trackIDs...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentAggregator_GetExistingTracksByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExistingTracksByIDs'
+type ContentAggregator_GetExistingTracksByIDs_Call struct {
+	*mock.Call
+}
+
+// GetExistingTracksByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - trackIDs ...uuid.UUID
+func (_e *ContentAggregator_Expecter) GetExistingTracksByIDs(ctx interface{}, trackIDs ...interface{}) *ContentAggregator_GetExistingTracksByIDs_Call {
+	return &ContentAggregator_GetExistingTracksByIDs_Call{Call: _e.mock.On("GetExistingTracksByIDs",
+		append([]interface{}{ctx}, trackIDs...)...)}
+}
+
+func (_c *ContentAggregator_GetExistingTracksByIDs_Call) Run(run func(ctx context.Context, trackIDs ...uuid.UUID)) *ContentAggregator_GetExistingTracksByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]uuid.UUID, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(uuid.UUID)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ContentAggregator_GetExistingTracksByIDs_Call) Return(_a0 []*entity.TrackMetaAggregated, err error) *ContentAggregator_GetExistingTracksByIDs_Call {
+	_c.Call.Return(_a0, err)
+	return _c
+}
+
+func (_c *ContentAggregator_GetExistingTracksByIDs_Call) RunAndReturn(run func(context.Context, ...uuid.UUID) ([]*entity.TrackMetaAggregated, error)) *ContentAggregator_GetExistingTracksByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTracks provides a mock function with given fields: ctx, tracks
 func (_m *ContentAggregator) GetTracks(ctx context.Context, tracks ...*entity.TrackMeta) ([]*entity.TrackMetaAggregated, error) {
 	_va := make([]interface{}, len(tracks))
@@ -22,12 +22,12 @@ func (_m *ListeningEventPublisher) EXPECT() *ListeningEventPublisher_Expecter {
 	return &ListeningEventPublisher_Expecter{mock: &_m.Mock}
 }
 
-// SendListeningEvent provides a mock function with given fields: ctx, event
-func (_m *ListeningEventPublisher) SendListeningEvent(ctx context.Context, event *entity.ListeningEvent) error {
+// PublishListeningEvent provides a mock function with given fields: ctx, event
+func (_m *ListeningEventPublisher) PublishListeningEvent(ctx context.Context, event *entity.ListeningEvent) error {
 	ret := _m.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendListeningEvent")
+		panic("no return value specified for PublishListeningEvent")
 	}
 
 	var r0 error
@@ -40,31 +40,31 @@ func (_m *ListeningEventPublisher) SendListeningEvent(ctx context.Context, event
 	return r0
 }
 
-// ListeningEventPublisher_SendListeningEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendListeningEvent'
-type ListeningEventPublisher_SendListeningEvent_Call struct {
+// ListeningEventPublisher_PublishListeningEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishListeningEvent'
+type ListeningEventPublisher_PublishListeningEvent_Call struct {
 	*mock.Call
 }
 
-// SendListeningEvent is a helper method to define mock.On call
+// PublishListeningEvent is a helper method to define mock.On call
 //   - ctx context.Context
 //   - event *entity.ListeningEvent
-func (_e *ListeningEventPublisher_Expecter) SendListeningEvent(ctx interface{}, event interface{}) *ListeningEventPublisher_SendListeningEvent_Call {
-	return &ListeningEventPublisher_SendListeningEvent_Call{Call: _e.mock.On("SendListeningEvent", ctx, event)}
+func (_e *ListeningEventPublisher_Expecter) PublishListeningEvent(ctx interface{}, event interface{}) *ListeningEventPublisher_PublishListeningEvent_Call {
+	return &ListeningEventPublisher_PublishListeningEvent_Call{Call: _e.mock.On("PublishListeningEvent", ctx, event)}
 }
 
-func (_c *ListeningEventPublisher_SendListeningEvent_Call) Run(run func(ctx context.Context, event *entity.ListeningEvent)) *ListeningEventPublisher_SendListeningEvent_Call {
+func (_c *ListeningEventPublisher_PublishListeningEvent_Call) Run(run func(ctx context.Context, event *entity.ListeningEvent)) *ListeningEventPublisher_PublishListeningEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(*entity.ListeningEvent))
 	})
 	return _c
 }
 
-func (_c *ListeningEventPublisher_SendListeningEvent_Call) Return(_a0 error) *ListeningEventPublisher_SendListeningEvent_Call {
+func (_c *ListeningEventPublisher_PublishListeningEvent_Call) Return(_a0 error) *ListeningEventPublisher_PublishListeningEvent_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ListeningEventPublisher_SendListeningEvent_Call) RunAndReturn(run func(context.Context, *entity.ListeningEvent) error) *ListeningEventPublisher_SendListeningEvent_Call {
+func (_c *ListeningEventPublisher_PublishListeningEvent_Call) RunAndReturn(run func(context.Context, *entity.ListeningEvent) error) *ListeningEventPublisher_PublishListeningEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
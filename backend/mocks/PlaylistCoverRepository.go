@@ -5,11 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	entity "github.com/hahaclassic/orpheon/backend/internal/domain/entity"
-
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // PlaylistCoverRepository is an autogenerated mock type for the PlaylistCoverRepository type
@@ -72,9 +70,9 @@ func (_c *PlaylistCoverRepository_DeleteCover_Call) RunAndReturn(run func(contex
 	return _c
 }
 
-// GetCover provides a mock function with given fields: ctx, playlistID
-func (_m *PlaylistCoverRepository) GetCover(ctx context.Context, playlistID uuid.UUID) (*entity.Cover, error) {
-	ret := _m.Called(ctx, playlistID)
+// GetCover provides a mock function with given fields: ctx, playlistID, size
+func (_m *PlaylistCoverRepository) GetCover(ctx context.Context, playlistID uuid.UUID, size int) (*entity.Cover, error) {
+	ret := _m.Called(ctx, playlistID, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCover")
@@ -82,19 +80,19 @@ func (_m *PlaylistCoverRepository) GetCover(ctx context.Context, playlistID uuid
 
 	var r0 *entity.Cover
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Cover, error)); ok {
-		return rf(ctx, playlistID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (*entity.Cover, error)); ok {
+		return rf(ctx, playlistID, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Cover); ok {
-		r0 = rf(ctx, playlistID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) *entity.Cover); ok {
+		r0 = rf(ctx, playlistID, size)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Cover)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, playlistID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, playlistID, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,13 +108,14 @@ type PlaylistCoverRepository_GetCover_Call struct {
 // GetCover is a helper method to define mock.On call
 //   - ctx context.Context
 //   - playlistID uuid.UUID
-func (_e *PlaylistCoverRepository_Expecter) GetCover(ctx interface{}, playlistID interface{}) *PlaylistCoverRepository_GetCover_Call {
-	return &PlaylistCoverRepository_GetCover_Call{Call: _e.mock.On("GetCover", ctx, playlistID)}
+//   - size int
+func (_e *PlaylistCoverRepository_Expecter) GetCover(ctx interface{}, playlistID interface{}, size interface{}) *PlaylistCoverRepository_GetCover_Call {
+	return &PlaylistCoverRepository_GetCover_Call{Call: _e.mock.On("GetCover", ctx, playlistID, size)}
 }
 
-func (_c *PlaylistCoverRepository_GetCover_Call) Run(run func(ctx context.Context, playlistID uuid.UUID)) *PlaylistCoverRepository_GetCover_Call {
+func (_c *PlaylistCoverRepository_GetCover_Call) Run(run func(ctx context.Context, playlistID uuid.UUID, size int)) *PlaylistCoverRepository_GetCover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
 	})
 	return _c
 }
@@ -126,22 +125,22 @@ func (_c *PlaylistCoverRepository_GetCover_Call) Return(_a0 *entity.Cover, _a1 e
 	return _c
 }
 
-func (_c *PlaylistCoverRepository_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Cover, error)) *PlaylistCoverRepository_GetCover_Call {
+func (_c *PlaylistCoverRepository_GetCover_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) (*entity.Cover, error)) *PlaylistCoverRepository_GetCover_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveCover provides a mock function with given fields: ctx, _a1
-func (_m *PlaylistCoverRepository) SaveCover(ctx context.Context, _a1 *entity.Cover) error {
-	ret := _m.Called(ctx, _a1)
+// SaveCovers provides a mock function with given fields: ctx, covers
+func (_m *PlaylistCoverRepository) SaveCovers(ctx context.Context, covers []*entity.Cover) error {
+	ret := _m.Called(ctx, covers)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveCover")
+		panic("no return value specified for SaveCovers")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Cover) error); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, []*entity.Cover) error); ok {
+		r0 = rf(ctx, covers)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -149,31 +148,31 @@ func (_m *PlaylistCoverRepository) SaveCover(ctx context.Context, _a1 *entity.Co
 	return r0
 }
 
-// PlaylistCoverRepository_SaveCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCover'
-type PlaylistCoverRepository_SaveCover_Call struct {
+// PlaylistCoverRepository_SaveCovers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCovers'
+type PlaylistCoverRepository_SaveCovers_Call struct {
 	*mock.Call
 }
 
-// SaveCover is a helper method to define mock.On call
+// SaveCovers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - _a1 *entity.Cover
-func (_e *PlaylistCoverRepository_Expecter) SaveCover(ctx interface{}, _a1 interface{}) *PlaylistCoverRepository_SaveCover_Call {
-	return &PlaylistCoverRepository_SaveCover_Call{Call: _e.mock.On("SaveCover", ctx, _a1)}
+//   - covers []*entity.Cover
+func (_e *PlaylistCoverRepository_Expecter) SaveCovers(ctx interface{}, covers interface{}) *PlaylistCoverRepository_SaveCovers_Call {
+	return &PlaylistCoverRepository_SaveCovers_Call{Call: _e.mock.On("SaveCovers", ctx, covers)}
 }
 
-func (_c *PlaylistCoverRepository_SaveCover_Call) Run(run func(ctx context.Context, _a1 *entity.Cover)) *PlaylistCoverRepository_SaveCover_Call {
+func (_c *PlaylistCoverRepository_SaveCovers_Call) Run(run func(ctx context.Context, covers []*entity.Cover)) *PlaylistCoverRepository_SaveCovers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.Cover))
+		run(args[0].(context.Context), args[1].([]*entity.Cover))
 	})
 	return _c
 }
 
-func (_c *PlaylistCoverRepository_SaveCover_Call) Return(_a0 error) *PlaylistCoverRepository_SaveCover_Call {
+func (_c *PlaylistCoverRepository_SaveCovers_Call) Return(_a0 error) *PlaylistCoverRepository_SaveCovers_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *PlaylistCoverRepository_SaveCover_Call) RunAndReturn(run func(context.Context, *entity.Cover) error) *PlaylistCoverRepository_SaveCover_Call {
+func (_c *PlaylistCoverRepository_SaveCovers_Call) RunAndReturn(run func(context.Context, []*entity.Cover) error) *PlaylistCoverRepository_SaveCovers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -72,9 +72,9 @@ func (_c *PlaylistCoverService_DeleteCover_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
-// GetCover provides a mock function with given fields: ctx, claims, playlistID
-func (_m *PlaylistCoverService) GetCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID) (*entity.Cover, error) {
-	ret := _m.Called(ctx, claims, playlistID)
+// GetCover provides a mock function with given fields: ctx, claims, playlistID, size
+func (_m *PlaylistCoverService) GetCover(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID, size int) (*entity.Cover, error) {
+	ret := _m.Called(ctx, claims, playlistID, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCover")
@@ -82,19 +82,19 @@ func (_m *PlaylistCoverService) GetCover(ctx context.Context, claims *entity.Cla
 
 	var r0 *entity.Cover
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Claims, uuid.UUID) (*entity.Cover, error)); ok {
-		return rf(ctx, claims, playlistID)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Claims, uuid.UUID, int) (*entity.Cover, error)); ok {
+		return rf(ctx, claims, playlistID, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Claims, uuid.UUID) *entity.Cover); ok {
-		r0 = rf(ctx, claims, playlistID)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Claims, uuid.UUID, int) *entity.Cover); ok {
+		r0 = rf(ctx, claims, playlistID, size)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Cover)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *entity.Claims, uuid.UUID) error); ok {
-		r1 = rf(ctx, claims, playlistID)
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Claims, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, claims, playlistID, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,13 +111,14 @@ type PlaylistCoverService_GetCover_Call struct {
 //   - ctx context.Context
 //   - claims *entity.Claims
 //   - playlistID uuid.UUID
-func (_e *PlaylistCoverService_Expecter) GetCover(ctx interface{}, claims interface{}, playlistID interface{}) *PlaylistCoverService_GetCover_Call {
-	return &PlaylistCoverService_GetCover_Call{Call: _e.mock.On("GetCover", ctx, claims, playlistID)}
+//   - size int
+func (_e *PlaylistCoverService_Expecter) GetCover(ctx interface{}, claims interface{}, playlistID interface{}, size interface{}) *PlaylistCoverService_GetCover_Call {
+	return &PlaylistCoverService_GetCover_Call{Call: _e.mock.On("GetCover", ctx, claims, playlistID, size)}
 }
 
-func (_c *PlaylistCoverService_GetCover_Call) Run(run func(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID)) *PlaylistCoverService_GetCover_Call {
+func (_c *PlaylistCoverService_GetCover_Call) Run(run func(ctx context.Context, claims *entity.Claims, playlistID uuid.UUID, size int)) *PlaylistCoverService_GetCover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.Claims), args[2].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(*entity.Claims), args[2].(uuid.UUID), args[3].(int))
 	})
 	return _c
 }
@@ -127,7 +128,7 @@ func (_c *PlaylistCoverService_GetCover_Call) Return(_a0 *entity.Cover, _a1 erro
 	return _c
 }
 
-func (_c *PlaylistCoverService_GetCover_Call) RunAndReturn(run func(context.Context, *entity.Claims, uuid.UUID) (*entity.Cover, error)) *PlaylistCoverService_GetCover_Call {
+func (_c *PlaylistCoverService_GetCover_Call) RunAndReturn(run func(context.Context, *entity.Claims, uuid.UUID, int) (*entity.Cover, error)) *PlaylistCoverService_GetCover_Call {
 	_c.Call.Return(run)
 	return _c
 }
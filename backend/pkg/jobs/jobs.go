@@ -0,0 +1,113 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+const (
+	DefaultWorkers   = 2
+	DefaultQueueSize = 100
+)
+
+var ErrClosed = errors.New("job queue is closed")
+
+type job struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Queue runs jobs in the background on a fixed number of workers. The jobs
+// outlive the requests enqueuing them, they get the context of the queue.
+type Queue struct {
+	jobs   chan job
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+}
+
+func New(workers, size int) *Queue {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		jobs:   make(chan job, size),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	q.wg.Add(workers)
+	for range workers {
+		go q.work()
+	}
+
+	return q
+}
+
+// Enqueue waits for a free place in the queue until ctx is done.
+// A failed job is logged under its name.
+func (q *Queue) Enqueue(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	select {
+	case q.jobs <- job{name: name, fn: fn}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting jobs and waits for the queued ones to finish.
+// When ctx is done first, the running jobs are canceled.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+
+	for j := range q.jobs {
+		if q.ctx.Err() != nil {
+			slog.Error("background job dropped", "job", j.name, "error", q.ctx.Err())
+			continue
+		}
+		if err := j.fn(q.ctx); err != nil {
+			slog.Error("background job failed", "job", j.name, "error", err)
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package integration_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
 	"math/rand/v2"
 	"runtime/debug"
 	"testing"
@@ -12,7 +16,9 @@ import (
 	album_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/album/meta/postgres"
 	genre_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/genre/meta/postgres"
 	license_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/license/postgres"
+	audio_cas "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/content-addressed"
 	audio_minio "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/minio"
+	audio_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/audio/postgres"
 	track_meta_postgres "github.com/hahaclassic/orpheon/backend/internal/repository/content/track/meta/postgres"
 	"github.com/stretchr/testify/require"
 )
@@ -45,8 +51,9 @@ func TestTrackUploadFlow(t *testing.T) {
 	licenseRepo := license_postgres.NewLicenseRepository(pgxPool)
 	albumRepo := album_meta_postgres.NewAlbumRepository(pgxPool)
 	trackRepo := track_meta_postgres.NewTrackMetaRepository(pgxPool)
-	audioRepo, err := audio_minio.NewAudioFileRepository(ctx, minioClient, minioAudioBucketName)
+	audioStorage, err := audio_minio.NewAudioStorage(ctx, minioClient, minioAudioBucketName)
 	require.NoError(t, err)
+	audioRepo := audio_cas.New(audioStorage, audio_postgres.NewAudioFileIndex(pgxPool))
 
 	require.NoError(t, runMigrationsUp(pgxPool))
 	defer func() {
@@ -88,6 +95,7 @@ func TestTrackUploadFlow(t *testing.T) {
 		Duration:    120,
 		LicenseID:   license.ID,
 		GenreID:     genre.ID,
+		Format:      entity.FormatMP3,
 	}
 	require.NoError(t, trackRepo.Create(ctx, track))
 
@@ -96,12 +104,12 @@ func TestTrackUploadFlow(t *testing.T) {
 	for i := range audioBytes {
 		audioBytes[i] = byte(rand.N(256))
 	}
-	require.NoError(t, audioRepo.UploadAudioFile(ctx, &entity.AudioChunk{
+	require.NoError(t, audioRepo.UploadAudioFile(ctx, &entity.AudioFile{
 		TrackID: track.ID,
-		Start:   0,
-		End:     int64(len(audioBytes) - 1),
-		Data:    audioBytes,
-	}))
+		Quality: entity.QualityOriginal,
+		Format:  entity.FormatMP3,
+		Size:    int64(len(audioBytes)),
+	}, bytes.NewReader(audioBytes)))
 
 	// 6.
 	trackMeta, err := trackRepo.GetByID(ctx, track.ID)
@@ -109,12 +117,17 @@ func TestTrackUploadFlow(t *testing.T) {
 	require.Equal(t, *track, *trackMeta)
 
 	// 7. Получение аудио чанка
-	chunk, err := audioRepo.GetAudioChunk(ctx, &entity.AudioChunk{
-		TrackID: track.ID,
-		Start:   1000000,
-		End:     1010240, // 10kb
-	})
+	file, content, err := audioRepo.OpenAudioFile(ctx, track.ID, entity.QualityOriginal)
+	require.NoError(t, err)
+	defer content.Close()
+	require.Equal(t, int64(len(audioBytes)), file.Size)
+	require.Equal(t, fmt.Sprintf("%x", sha256.Sum256(audioBytes)), file.Hash)
+
+	_, err = content.Seek(1000000, io.SeekStart)
+	require.NoError(t, err)
 
+	chunk := make([]byte, 10240) // 10kb
+	_, err = io.ReadFull(content, chunk)
 	require.NoError(t, err)
-	require.Equal(t, audioBytes[1000000:1010240], chunk.Data)
+	require.Equal(t, audioBytes[1000000:1010240], chunk)
 }
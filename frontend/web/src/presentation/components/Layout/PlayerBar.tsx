@@ -20,6 +20,10 @@ import { apiService } from '../../services/api';
 import { useAuthContext } from '../../contexts/AuthContext';
 import type { Playlist } from '../../types';
 
+// Попытки отправки статистики прослушивания, задержка растет с каждой попыткой
+const STATS_SEND_ATTEMPTS = 3;
+const STATS_RETRY_DELAY_MS = 2000;
+
 // Моковые данные для графика
 const mockSegments = [
   { idx: 0, totalStreams: 50, range: [0, 10] },
@@ -310,16 +314,28 @@ const PlayerBar = () => {
     const allRanges = lastRange ? [...listenedRanges, lastRange] : listenedRanges;
 
     if (currentTrack && allRanges.length > 0) {
-      try {
-        await apiService.post(`/tracks/${currentTrack.id}/stats`, {
-          track_id: currentTrack.id,
-          ranges: allRanges.map(([start, end]) => ({
-            start: Math.floor(start),
-            end: Math.floor(end),
-          })),
-        });
-      } catch (err) {
-        console.error('Failed to send listening stats:', err);
+      // event_id создается один раз на прослушивание, повторные попытки отправки не учитываются дважды
+      const event = {
+        event_id: crypto.randomUUID(),
+        listened_at: new Date().toISOString(),
+        track_id: currentTrack.id,
+        ranges: allRanges.map(([start, end]) => ({
+          start: Math.floor(start),
+          end: Math.floor(end),
+        })),
+      };
+
+      for (let attempt = 1; ; attempt++) {
+        try {
+          await apiService.post(`/tracks/${currentTrack.id}/stats`, event);
+          return;
+        } catch (err) {
+          if (attempt >= STATS_SEND_ATTEMPTS) {
+            console.error('Failed to send listening stats:', err);
+            return;
+          }
+          await new Promise(resolve => setTimeout(resolve, attempt * STATS_RETRY_DELAY_MS));
+        }
       }
     }
   }, [currentTrack, listenedRanges]);